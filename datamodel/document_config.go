@@ -93,6 +93,38 @@ type DocumentConfiguration struct {
 	// lookup only occurs when this value is true.
 	AllowRemoteReferences bool
 
+	// RemoteCacheDirectory is a directory used to persist remote documents between runs. Cached documents are
+	// revalidated using their ETag and Last-Modified headers, and honor Cache-Control. Only used when
+	// AllowRemoteReferences is true. When a RemoteURLHandler is set, documents are fetched with it, but as the
+	// handler only receives a URL, stale documents are fetched again in full rather than revalidated.
+	RemoteCacheDirectory string
+
+	// RemoteVendorDirectory is a directory of pre-seeded remote documents laid out as <host>/<path>. Vendored
+	// documents are served when offline, or when a remote document cannot be fetched.
+	RemoteVendorDirectory string
+
+	// OfflineRemoteReferences serves remote documents only from the RemoteCacheDirectory and the
+	// RemoteVendorDirectory, no network requests are made. A document that has not been cached or vendored fails
+	// to load with an error.
+	OfflineRemoteReferences bool
+
+	// RemotePolicy restricts the hosts, addresses and schemes remote references may be fetched from, the redirects
+	// followed, and adds per-host headers to requests. Only used when AllowRemoteReferences is true. The policy is
+	// enforced at every connection, so it cannot be set with a RemoteURLHandler (building the document fails with
+	// index.ErrRemotePolicyHandler). Violations are returned as *index.RemotePolicyError. Set it when indexing
+	// untrusted documents.
+	RemotePolicy *RemotePolicy
+
+	// ResourceBudget sets hard limits on the files, bytes, reference depth, YAML nodes, alias expansions and time
+	// used to index the document and everything it references. Exceeding a limit aborts indexing with an
+	// *index.ResourceBudgetError. Set it when indexing untrusted documents.
+	ResourceBudget *ResourceBudget
+
+	// ReferenceCatalog maps canonical URLs of remote references to local files, directories or in-memory documents.
+	// Mapped documents are served from the catalog before any remote fetch, even when AllowRemoteReferences is
+	// false, and references keep reporting their canonical URL.
+	ReferenceCatalog *ReferenceCatalog
+
 	// AvoidIndexBuild will avoid building the index. This is disabled by default, only use if you are sure you don't need it.
 	// This is useful for developers building out models that should be indexed later on.
 	AvoidIndexBuild bool
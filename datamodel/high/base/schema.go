@@ -89,6 +89,10 @@ type Schema struct {
 	// 3.1+ only, JSON Schema 2020-12 dynamic reference for recursive schema resolution
 	DynamicRef string `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
 
+	// DynamicRefTarget is the schema DynamicRef resolved to within the dynamic scope this schema was reached
+	// through. It is never rendered, the $dynamicRef keyword is preserved as-is.
+	DynamicRefTarget *SchemaProxy `json:"-" yaml:"-"`
+
 	// 3.1+ only, JSON Schema 2020-12 $comment - explanatory notes without affecting validation
 	Comment string `json:"$comment,omitempty" yaml:"$comment,omitempty"`
 
@@ -347,6 +351,9 @@ func NewSchema(schema *base.Schema) *Schema {
 	if !schema.DynamicRef.IsEmpty() {
 		s.DynamicRef = schema.DynamicRef.Value
 	}
+	if !schema.DynamicRefTarget.IsEmpty() {
+		s.DynamicRefTarget = NewSchemaProxy(&schema.DynamicRefTarget)
+	}
 	if !schema.Comment.IsEmpty() {
 		s.Comment = schema.Comment.Value
 	}
@@ -2416,3 +2416,46 @@ description: A binary image encoded as string`
 	assert.Equal(t, "image/png", highSch.ContentMediaType)
 	assert.Equal(t, "string", highSch.Type[0])
 }
+
+func TestNewSchema_DynamicRefTarget(t *testing.T) {
+	yml := `openapi: "3.1.0"
+components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: "#node"`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	treeNode := idx.GetSchemaAnchor(idx.GetSpecAbsolutePath(), "node").SchemaNode
+
+	sp := new(lowbase.SchemaProxy)
+	err := sp.Build(context.Background(), nil, treeNode, idx)
+	assert.NoError(t, err)
+
+	lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: treeNode,
+	}
+
+	tree := NewSchemaProxy(&lowproxy).Schema()
+	require.NotNil(t, tree)
+
+	items := tree.Properties.GetOrZero("children").Schema().Items.A.Schema()
+	require.NotNil(t, items)
+	assert.Equal(t, "#node", items.DynamicRef)
+	require.NotNil(t, items.DynamicRefTarget)
+	assert.Equal(t, []string{"object"}, items.DynamicRefTarget.Schema().Type)
+
+	// the target is not part of the rendered schema.
+	rend, _ := items.Render()
+	assert.Equal(t, `$dynamicRef: "#node"`, strings.TrimSpace(string(rend)))
+}
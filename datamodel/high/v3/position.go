@@ -0,0 +1,156 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Position is what is under a line and column of a file in a document, along with the high-level models of the
+// OpenAPI objects enclosing it. It is built for editor features, such as hovers and go to definition.
+type Position struct {
+	*index.PositionLookup
+
+	// Models are the high-level models of PositionLookup.Objects, in the same order. A model is one of *Document,
+	// *PathItem, *Operation, *Parameter, *RequestBody, *Response, *MediaType, *Header, *Encoding, *base.Schema
+	// (for schemas and properties), *base.Example, *Link, *Callback or *SecurityScheme. An object that is a $ref
+	// is built from the object it refers to. The model of a document is nil, unless it is this document.
+	Models []any
+}
+
+// Model returns the high-level model of the innermost OpenAPI object enclosing the position, or nil if there is
+// none.
+func (p *Position) Model() any {
+	if len(p.Models) == 0 {
+		return nil
+	}
+	return p.Models[len(p.Models)-1]
+}
+
+// LocatePosition returns what is under a line and column of a file in the document. The file is either the root
+// document, or any file indexed by the rolodex; a relative file is resolved against the BasePath of the document
+// configuration. Lines and columns are 1-based.
+//
+// An error is returned if the file is not part of the document, or if one of the enclosing objects cannot be
+// built, in which case the Position is still returned with the models that could be built.
+func (d *Document) LocatePosition(file string, line, column int) (*Position, error) {
+	rolodex := d.Rolodex
+	if rolodex == nil && d.Index != nil {
+		rolodex = d.Index.GetRolodex()
+	}
+	var lookup *index.PositionLookup
+	switch {
+	case rolodex != nil:
+		var err error
+		if lookup, err = rolodex.LookupPosition(file, line, column); err != nil {
+			return nil, err
+		}
+	case d.Index != nil:
+		if file != "" && file != d.Index.GetSpecAbsolutePath() {
+			return nil, fmt.Errorf("unable to locate position, file '%s' is not part of the document", file)
+		}
+		lookup = d.Index.LookupPosition(line, column)
+	}
+	if lookup == nil {
+		return nil, errors.New("unable to locate position, the document has not been indexed")
+	}
+
+	p := &Position{PositionLookup: lookup, Models: make([]any, 0, len(lookup.Objects))}
+	var errs []error
+	for _, o := range lookup.Objects {
+		if o.Kind == index.PositionDocument && lookup.Index == d.Index {
+			p.Models = append(p.Models, d)
+			continue
+		}
+		model, err := buildPositionModel(o, lookup.Index)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		p.Models = append(p.Models, model)
+	}
+	return p, errors.Join(errs...)
+}
+
+// buildPositionModel builds the high-level model of an OpenAPI object from its node.
+func buildPositionModel(o *index.PositionObject, idx *index.SpecIndex) (any, error) {
+	switch o.Kind {
+	case index.PositionPathItem:
+		return buildPositionLowModel(o, idx, NewPathItem)
+	case index.PositionOperation:
+		return buildPositionLowModel(o, idx, NewOperation)
+	case index.PositionParameter:
+		return buildPositionLowModel(o, idx, NewParameter)
+	case index.PositionRequestBody:
+		return buildPositionLowModel(o, idx, NewRequestBody)
+	case index.PositionResponse:
+		return buildPositionLowModel(o, idx, NewResponse)
+	case index.PositionMediaType:
+		return buildPositionLowModel(o, idx, NewMediaType)
+	case index.PositionHeader:
+		return buildPositionLowModel(o, idx, NewHeader)
+	case index.PositionEncoding:
+		return buildPositionLowModel(o, idx, NewEncoding)
+	case index.PositionExample:
+		return buildPositionLowModel(o, idx, base.NewExample)
+	case index.PositionLink:
+		return buildPositionLowModel(o, idx, NewLink)
+	case index.PositionCallback:
+		return buildPositionLowModel(o, idx, NewCallback)
+	case index.PositionSecurityScheme:
+		return buildPositionLowModel(o, idx, NewSecurityScheme)
+	case index.PositionSchema, index.PositionProperty:
+		var proxy lowbase.SchemaProxy
+		if err := proxy.Build(context.Background(), o.KeyNode, o.Node, idx); err != nil {
+			return nil, err
+		}
+		schema := base.NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{
+			Value:     &proxy,
+			KeyNode:   o.KeyNode,
+			ValueNode: o.Node,
+		})
+		built, err := schema.BuildSchema()
+		if err != nil {
+			return nil, err
+		}
+		return built, nil
+	}
+	return nil, nil
+}
+
+// buildPositionLowModel builds a low-level model from the node of an OpenAPI object, following it first if it is a
+// $ref, and wraps it in a high-level one.
+func buildPositionLowModel[L any, H any, PL interface {
+	*L
+	Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error
+}](o *index.PositionObject, idx *index.SpecIndex, newHigh func(*L) *H,
+) (any, error) {
+	ctx, node := context.Background(), o.Node
+	if isRef, _, ref := utils.IsNodeRefValue(node); isRef {
+		resolved, resolvedIdx, err, resolvedCtx := lowmodel.LocateRefEnd(ctx, node, idx, 0)
+		if resolved == nil {
+			return nil, fmt.Errorf("unable to build %s, reference cannot be found: %s", o.Kind, ref)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ctx, node, idx = resolvedCtx, resolved, resolvedIdx
+	}
+	var model L
+	if err := lowmodel.BuildModel(node, &model); err != nil {
+		return nil, err
+	}
+	if err := PL(&model).Build(ctx, o.KeyNode, node, idx); err != nil {
+		return nil, err
+	}
+	return newHigh(&model), nil
+}
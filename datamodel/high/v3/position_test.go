@@ -0,0 +1,99 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/testify/assert"
+)
+
+func buildPositionDocument(t *testing.T, files map[string]string) (*Document, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	info, err := datamodel.ExtractSpecInfo([]byte(files["openapi.yaml"]))
+	assert.NoError(t, err)
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		BasePath:            dir,
+		SpecFilePath:        filepath.Join(dir, "openapi.yaml"),
+	})
+	assert.NoError(t, err)
+	return NewDocument(lowDoc), dir
+}
+
+func TestDocument_LocatePosition(t *testing.T) {
+	d, dir := buildPositionDocument(t, map[string]string{
+		"openapi.yaml": `openapi: 3.1.0
+info:
+  title: position
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: 'params.yaml#/Limit'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'pet.json'
+`,
+		"params.yaml": `Limit:
+  name: limit
+  in: query
+  schema:
+    type: integer
+`,
+		"pet.json": `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "description": "the name"}
+  }
+}`,
+	})
+
+	// the operationId, within the operation.
+	p, err := d.LocatePosition("openapi.yaml", 8, 21)
+	assert.NoError(t, err)
+	assert.Equal(t, "/paths/~1pets/get/operationId", p.Pointer)
+	assert.Len(t, p.Models, 3)
+	assert.Same(t, d, p.Models[0])
+	assert.Equal(t, "listPets", p.Model().(*Operation).OperationId)
+
+	// on a $ref, the parameter is built from its target, and the target location is known.
+	p, err = d.LocatePosition("openapi.yaml", 10, 20)
+	assert.NoError(t, err)
+	param := p.Model().(*Parameter)
+	assert.Equal(t, "limit", param.Name)
+	assert.Equal(t, "query", param.In)
+	assert.Equal(t, filepath.Join(dir, "params.yaml"), p.Target.File)
+	assert.Equal(t, "/Limit", p.Target.Pointer)
+	assert.Equal(t, 2, p.Target.Line)
+
+	// a property of a schema held by a JSON file.
+	p, err = d.LocatePosition(filepath.Join(dir, "pet.json"), 4, 34)
+	assert.NoError(t, err)
+	assert.Equal(t, "/properties/name/description", p.Pointer)
+	assert.Equal(t, []index.PositionObjectKind{index.PositionSchema, index.PositionProperty},
+		[]index.PositionObjectKind{p.Objects[0].Kind, p.Objects[1].Kind})
+	property := p.Model().(*base.Schema)
+	assert.Equal(t, "the name", property.Description)
+	assert.Equal(t, []string{"string"}, property.Type)
+
+	_, err = d.LocatePosition("missing.yaml", 1, 1)
+	assert.Error(t, err)
+}
@@ -99,6 +99,10 @@ type Schema struct {
 	DynamicAnchor         low.NodeReference[string]
 	DynamicRef            low.NodeReference[string]
 
+	// DynamicRefTarget is the schema the $dynamicRef resolved to, using the dynamic scope the schema was
+	// reached through. Empty when there is no $dynamicRef, or when it cannot be resolved.
+	DynamicRefTarget low.NodeReference[*SchemaProxy]
+
 	// Compatible with all versions
 	Title                low.NodeReference[string]
 	MultipleOf           low.NodeReference[float64]
@@ -240,6 +240,14 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		s.DynamicRef = low.NodeReference[string]{
 			Value: dynamicRefNode.Value, KeyNode: dynamicRefLabel, ValueNode: dynamicRefNode,
 		}
+		// an unresolvable $dynamicRef is not fatal, the schema is still valid without its target.
+		if target, targetIdx, lErr, targetCtx := low.LocateDynamicRefNodeWithContext(ctx, root, idx); target != nil && lErr == nil {
+			s.DynamicRefTarget = low.NodeReference[*SchemaProxy]{
+				Value:     buildSchemaProxy(targetCtx, targetIdx, dynamicRefLabel, target, nil, nil, nil, "").Value,
+				KeyNode:   dynamicRefLabel,
+				ValueNode: dynamicRefNode,
+			}
+		}
 	}
 
 	_, commentLabel, commentNode := utils.FindKeyNodeFullTop(CommentLabel, root.Content)
@@ -396,6 +396,52 @@ func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, *index.Sp
 	return r, i, e
 }
 
+// LocateDynamicRefNodeWithContext resolves the $dynamicRef declared in root per JSON Schema 2020-12. The
+// schema resources carried in the context $id scope form the dynamic scope, so the same $dynamicRef can
+// resolve to different schemas depending on how it was reached. Returns a nil node and no error if root
+// does not declare a $dynamicRef.
+func LocateDynamicRefNodeWithContext(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, *index.SpecIndex, error, context.Context) {
+	if root == nil || idx == nil {
+		return nil, idx, nil, ctx
+	}
+	_, _, dynamicRefNode := utils.FindKeyNodeFullTop("$dynamicRef", root.Content)
+	if dynamicRefNode == nil || !utils.IsNodeStringValue(dynamicRefNode) {
+		return nil, idx, nil, ctx
+	}
+	if dynamicRefNode.Value == "" {
+		return nil, idx, fmt.Errorf("dynamic reference at line %d, column %d is empty, it cannot be resolved",
+			dynamicRefNode.Line, dynamicRefNode.Column), ctx
+	}
+
+	base := idx.GetSpecAbsolutePath()
+	if p, ok := ctx.Value(index.CurrentPathKey).(string); ok && p != "" {
+		base = p
+	}
+	var dynamicScope []string
+	if scope := index.GetSchemaIdScope(ctx); scope != nil {
+		if scope.BaseUri != "" {
+			base = scope.BaseUri
+		}
+		dynamicScope = scope.DynamicScope()
+	}
+
+	found := idx.ResolveDynamicRef(dynamicRefNode.Value, base, dynamicScope)
+	if found == nil || found.Node == nil {
+		return nil, idx, fmt.Errorf("dynamic reference '%s' at line %d, column %d was not found",
+			dynamicRefNode.Value, dynamicRefNode.Line, dynamicRefNode.Column), ctx
+	}
+
+	foundIdx := idx
+	if found.Index != nil {
+		foundIdx = found.Index
+	}
+	foundCtx := ctx
+	if found.RemoteLocation != "" {
+		foundCtx = context.WithValue(foundCtx, index.CurrentPathKey, found.RemoteLocation)
+	}
+	return utils.NodeAlias(found.Node), foundIdx, nil, applyResolvedSchemaIdScope(foundCtx, found, foundIdx)
+}
+
 // ExtractObjectRaw will extract a typed Buildable[N] object from a root yaml.Node. The 'raw' aspect is
 // that there is no NodeReference wrapper around the result returned, just the raw object.
 func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yaml.Node, idx *index.SpecIndex) (T, error, bool, string) {
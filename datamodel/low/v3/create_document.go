@@ -152,6 +152,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	idxConfig.UseSchemaQuickHash = config.UseSchemaQuickHash
 	idxConfig.ExcludeExtensionRefs = config.ExcludeExtensionRefs
 	idxConfig.SkipMetadataCollection = config.SkipMetadataCollection
+	idxConfig.ResourceBudget = config.ResourceBudget
+	idxConfig.ReferenceCatalog = config.ReferenceCatalog
 	idxConfig.IgnoreArrayCircularReferences = config.IgnoreArrayCircularReferences
 	idxConfig.IgnorePolymorphicCircularReferences = config.IgnorePolymorphicCircularReferences
 	idxConfig.AllowUnknownExtensionContentDetection = config.AllowUnknownExtensionContentDetection
@@ -253,9 +255,24 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 
 		// create a remote filesystem
 		remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
+		if config.RemotePolicy != nil {
+			if config.RemoteURLHandler != nil {
+				return nil, index.ErrRemotePolicyHandler
+			}
+			if policyErr := remoteFS.SetRemotePolicy(config.RemotePolicy); policyErr != nil {
+				return nil, policyErr
+			}
+		}
 		if config.RemoteURLHandler != nil {
 			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
 		}
+		remoteCache, cacheErr := index.NewRemoteCacheWithDocumentConfig(config)
+		if cacheErr != nil {
+			return nil, cacheErr
+		}
+		if remoteCache != nil {
+			remoteFS.SetRemoteCache(remoteCache)
+		}
 		idxConfig.AllowRemoteLookup = true
 
 		// add to the rolodex
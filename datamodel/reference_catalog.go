@@ -0,0 +1,24 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+// ReferenceCatalog maps canonical URLs of remote references to local copies, like an XML catalog or a JSON Schema
+// $id registry. A mapped document is read from the catalog before any remote fetch, even when remote references
+// are not allowed, and is indexed under its canonical URL, so references still report the original URL.
+//
+// Fragments are ignored when matching. Documents take precedence over URLs, and URLs over Prefixes. When several
+// prefixes match, the longest wins. Relative references inside a mapped document resolve against its canonical URL,
+// so they are looked up in the catalog too.
+type ReferenceCatalog struct {
+	// Documents maps exact URLs to in-memory document bytes.
+	Documents map[string][]byte
+
+	// URLs maps exact URLs to local files.
+	URLs map[string]string
+
+	// Prefixes maps URL prefixes to local directories. The remainder of a matching URL is resolved against the
+	// directory, for example https://schemas.example.com/common/ mapped to vendor/common serves
+	// https://schemas.example.com/common/v1/money.yaml from vendor/common/v1/money.yaml.
+	Prefixes map[string]string
+}
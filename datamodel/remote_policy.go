@@ -0,0 +1,43 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import "net/http"
+
+// RemotePolicy restricts the network requests made to fetch remote references, for indexing untrusted documents
+// without exposing internal services (server-side request forgery).
+//
+// Hosts are matched case-insensitively, without the port. A pattern is an exact host name or IP address
+// (api.example.com), or a wildcard matching any subdomain (*.example.com, which does not match example.com).
+//
+// Addresses are checked after DNS resolution, at every connection, so a host name resolving to a blocked address is
+// refused even if it changes between lookups. Loopback, private, link-local (including cloud metadata addresses such
+// as 169.254.169.254), carrier-grade NAT, multicast and unspecified addresses are blocked, unless
+// AllowPrivateNetworks is set or the address is in one of the AllowedNetworks.
+type RemotePolicy struct {
+	// AllowedSchemes are the URL schemes remote references may use. Defaults to http and https.
+	AllowedSchemes []string
+
+	// AllowedHosts are the host patterns remote references may point at. When empty, any host not denied is allowed.
+	AllowedHosts []string
+
+	// DeniedHosts are host patterns remote references may never point at, even if allowed.
+	DeniedHosts []string
+
+	// AllowPrivateNetworks disables the blocking of loopback, private and link-local addresses.
+	AllowPrivateNetworks bool
+
+	// AllowedNetworks are CIDR ranges (10.20.0.0/16) that are allowed even though they are private, for
+	// example an internal registry.
+	AllowedNetworks []string
+
+	// MaxRedirects is the number of redirects followed when fetching a remote reference. Every redirect is checked
+	// against the policy again. Zero follows no redirects.
+	MaxRedirects int
+
+	// HostHeaders are headers added to every request made to a host pattern, for example the Authorization header
+	// of an authenticated registry. Headers are only sent to matching hosts, and never follow a redirect to
+	// another host.
+	HostHeaders map[string]http.Header
+}
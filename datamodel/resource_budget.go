@@ -0,0 +1,38 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import "time"
+
+// ResourceBudget sets hard limits on the resources used to index a document and everything it references, for
+// indexing untrusted documents without exhausting memory or time (thousands of files, huge remote payloads, deep
+// reference chains or YAML alias bombs).
+//
+// A zero limit is unlimited. Budgets are shared by every document indexed by a rolodex, including the root document.
+// The first budget exceeded aborts indexing: no more documents are opened, indexed or resolved, and the rolodex
+// reports an error naming the limit.
+type ResourceBudget struct {
+	// MaxFiles is the maximum number of documents indexed, local or remote, including the root document.
+	MaxFiles int
+
+	// MaxBytes is the maximum number of bytes read across all documents. Remote documents are never read past the
+	// remaining budget.
+	MaxBytes int64
+
+	// MaxReferenceDepth is the maximum number of references followed in a single chain ($ref to a $ref to a $ref).
+	MaxReferenceDepth int
+
+	// MaxYAMLNodes is the maximum number of YAML nodes parsed across all documents. It is checked after each document
+	// is parsed, so a single document can parse past it; use MaxBytes to bound the size of a single parse.
+	MaxYAMLNodes int
+
+	// MaxAliasExpansions is the maximum number of YAML aliases expanded when walking all documents, counting every
+	// alias reached through another alias. It is checked after each document is parsed, before it is indexed.
+	MaxAliasExpansions int
+
+	// MaxIndexingTime is the maximum wall-clock time spent indexing, from the moment the rolodex starts indexing. It
+	// is checked when a document is opened or parsed and when a reference is followed, not while a single document
+	// is being read or parsed.
+	MaxIndexingTime time.Duration
+}
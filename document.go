@@ -298,6 +298,9 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], error) {
 
 	var docErr error
 	lowDoc, docErr = v2low.CreateDocumentFromConfig(d.info, d.config)
+	if lowDoc == nil {
+		return nil, docErr
+	}
 	d.rolodex = lowDoc.Rolodex
 
 	if docErr != nil {
@@ -344,6 +347,9 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], error) {
 
 	var docErr error
 	lowDoc, docErr = v3low.CreateDocumentFromConfig(d.info, d.config)
+	if lowDoc == nil {
+		return nil, docErr
+	}
 	d.rolodex = lowDoc.Rolodex
 
 	if docErr != nil {
@@ -3,14 +3,18 @@
 package libopenapi
 
 import (
+	"archive/zip"
 	"bytes"
 	stdContext "context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -21,6 +25,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/pb33f/libopenapi/what-changed/model"
@@ -2557,3 +2562,260 @@ components:
 		assert.True(t, doc.GetRolodex().GetRootIndex().GetConfig().AllowRemoteLookup)
 	})
 }
+
+func TestNewDocument_FromArchive(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string]string{
+		"pets/openapi.yaml": `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pet.yaml'
+`,
+		"pets/schemas/pet.yaml": `type: object
+properties:
+  owner:
+    $ref: '../../shared/owner.yaml'
+`,
+		"shared/owner.yaml": `type: object
+properties:
+  name:
+    type: string
+`,
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	archiveFS, err := index.NewArchiveFS(buf.Bytes(), &index.ArchiveFSConfig{BaseDirectory: t.TempDir()})
+	require.NoError(t, err)
+	doc, err := NewDocumentWithConfiguration(archiveFS.GetRootDocumentBytes(), archiveFS.DocumentConfiguration(nil))
+	require.NoError(t, err)
+	model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	schema := model.Model.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200").
+		Content.GetOrZero("application/json").Schema.Schema()
+	require.NotNil(t, schema)
+	owner := schema.Properties.GetOrZero("owner").Schema()
+	require.NotNil(t, owner)
+	assert.Equal(t, []string{"string"}, owner.Properties.GetOrZero("name").Schema().Type)
+}
+
+func TestNewDocument_OfflineRemoteReferences(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'https://schemas.example.com/pet.yaml'
+`)
+	vendor := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(vendor, "schemas.example.com"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(vendor, "schemas.example.com", "pet.yaml"), []byte(`type: object
+properties:
+  name:
+    type: string
+`), 0o644))
+
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowRemoteReferences = true
+	config.RemoteVendorDirectory = vendor
+	config.OfflineRemoteReferences = true
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	pet := model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	require.NotNil(t, pet)
+	assert.Equal(t, []string{"string"}, pet.Properties.GetOrZero("name").Schema().Type)
+
+	config.RemoteVendorDirectory = t.TempDir()
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot resolve reference `https://schemas.example.com/pet.yaml`")
+}
+
+func TestNewDocument_RemotePolicy(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'http://169.254.169.254/latest/pet.yaml'
+`)
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowRemoteReferences = true
+	config.RemotePolicy = &datamodel.RemotePolicy{}
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.Error(t, err)
+
+	var policyErr *index.RemotePolicyError
+	for _, caught := range doc.GetRolodex().GetCaughtErrors() {
+		var indexingErr *index.IndexingError
+		if errors.As(caught, &indexingErr) && errors.As(indexingErr.Err, &policyErr) {
+			break
+		}
+	}
+	require.NotNil(t, policyErr)
+	assert.Equal(t, index.RemotePolicyBlockedAddress, policyErr.Rule)
+	assert.Equal(t, "169.254.169.254", policyErr.Address)
+
+	config.RemotePolicy = &datamodel.RemotePolicy{AllowedNetworks: []string{"not a network"}}
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	assert.ErrorContains(t, err, "invalid allowed network 'not a network'")
+
+	// a policy cannot be enforced by a custom handler.
+	config.RemotePolicy = &datamodel.RemotePolicy{}
+	config.RemoteURLHandler = func(url string) (*http.Response, error) {
+		t.Fatal("remote handler should not be called")
+		return nil, nil
+	}
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	assert.ErrorIs(t, err, index.ErrRemotePolicyHandler)
+}
+
+func TestNewDocument_ResourceBudget(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+x-lol: &lol [lol, lol, lol, lol, lol]
+x-lols: &lols [*lol, *lol, *lol, *lol, *lol]
+x-bomb: [*lols, *lols, *lols, *lols, *lols]
+`)
+	config := datamodel.NewDocumentConfiguration()
+	config.ResourceBudget = &datamodel.ResourceBudget{MaxAliasExpansions: 20}
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.Error(t, err)
+
+	var budgetErr *index.ResourceBudgetError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, index.ResourceLimitAliasExpansions, budgetErr.Limit)
+	assert.Equal(t, int64(35), budgetErr.Used)
+
+	config.ResourceBudget = &datamodel.ResourceBudget{MaxAliasExpansions: 35}
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	assert.NoError(t, err)
+}
+
+func TestNewDocument_ReferenceCatalog(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Price:
+      $ref: 'https://schemas.example.com/common/v1/money.yaml#/Money'
+`)
+	config := datamodel.NewDocumentConfiguration()
+	config.ReferenceCatalog = &datamodel.ReferenceCatalog{
+		Documents: map[string][]byte{
+			"https://schemas.example.com/common/v1/money.yaml": []byte(`Money:
+  type: object
+  properties:
+    amount:
+      type: number`),
+		},
+	}
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	price := model.Model.Components.Schemas.GetOrZero("Price")
+	require.NotNil(t, price)
+	assert.Equal(t, "https://schemas.example.com/common/v1/money.yaml#/Money", price.GetReference())
+	assert.Equal(t, "object", price.Schema().Type[0])
+	assert.Contains(t, model.Index.GetRolodex().GetCatalogFiles(), "https://schemas.example.com/common/v1/money.yaml")
+}
+
+func TestNewDocument_GitFS_CompareRevisions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		out, err := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test",
+			"-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"},
+			args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(version, nameType string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "openapi.yaml"), []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: `+version+`
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "pet.yaml"),
+			[]byte("type: object\nproperties:\n  name:\n    type: "+nameType), 0o644))
+		git("add", "-A")
+		git("commit", "-q", "-m", version)
+		git("tag", "v"+version)
+	}
+	git("init", "-q")
+	commit("1.0.0", "string")
+	commit("2.0.0", "integer")
+
+	load := func(revision string) Document {
+		gitFS, err := index.NewGitFS(&index.GitFSConfig{Repository: repo, Revision: revision})
+		require.NoError(t, err)
+		spec, err := gitFS.ReadFile("openapi.yaml")
+		require.NoError(t, err)
+		config := datamodel.NewDocumentConfiguration()
+		config.BasePath = repo
+		config.SpecFilePath = "openapi.yaml"
+		config.LocalFS = gitFS
+		doc, err := NewDocumentWithConfiguration(spec, config)
+		require.NoError(t, err)
+		return doc
+	}
+	nameType := func(doc Document) string {
+		model, err := doc.BuildV3Model()
+		require.NoError(t, err)
+		pet := model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+		require.NotNil(t, pet)
+		return pet.Properties.GetOrZero("name").Schema().Type[0]
+	}
+	original, updated := load("v1.0.0"), load("v2.0.0")
+	assert.Equal(t, "string", nameType(original))
+	assert.Equal(t, "integer", nameType(updated))
+
+	changes, err := CompareDocuments(original, updated)
+	require.NoError(t, err)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.InfoChanges.TotalChanges())
+}
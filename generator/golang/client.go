@@ -0,0 +1,638 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"strconv"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ClientFileName is the conventional file name for generated client source.
+const ClientFileName = "client.go"
+
+// GeneratedClient contains a typed HTTP client generated from OpenAPI
+// operations together with the models it uses.
+type GeneratedClient struct {
+	PackageName string
+	// Models contains the component models and the models for inline operation
+	// schemas, rendered with RenderSchemas.
+	Models *GeneratedFile
+	// Client contains the client source for the same package as Models.
+	Client *GeneratedSourceFile
+	// Operations lists the generated client methods in document order.
+	Operations []*GeneratedOperation
+	// Diagnostics reports operation shapes that required a notable decision.
+	// Model diagnostics are reported on Models.
+	Diagnostics []Diagnostic
+}
+
+// GeneratedOperation describes one generated operation method.
+type GeneratedOperation struct {
+	Name        string
+	Method      string
+	Path        string
+	OperationID string
+	ParamsType  string
+	ResultType  string
+
+	// RequestType and ServerResultType are only set by RenderServer.
+	RequestType      string
+	ServerResultType string
+}
+
+// operationDecls are the exported client and server declarations. Operation
+// types that collide with them are renamed, so a client and a server generated
+// into one package agree on every type name.
+var operationDecls = []string{
+	"Client", "ClientOption", "HTTPRequestDoer", "NewClient", "RequestEditorFn", "WithHTTPClient", "WithRequestEditorFn",
+	"HandlerOption", "NewHandler", "RequestError", "ServerInterface", "UnimplementedServer", "WithErrorHandler",
+}
+
+const clientCoreSource = `// HTTPRequestDoer performs HTTP requests. *http.Client satisfies it.
+type HTTPRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RequestEditorFn edits a request before it is sent.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Client calls the API operations.
+type Client struct {
+	// Server is the base URL that operation paths are appended to.
+	Server string
+	// Client performs requests. NewClient defaults it to http.DefaultClient.
+	Client HTTPRequestDoer
+	// RequestEditors run on every request before per-call editors.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client) error
+
+// NewClient creates a Client for the server base URL.
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	c := &Client{Server: strings.TrimSuffix(server, "/")}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.Client == nil {
+		c.Client = http.DefaultClient
+	}
+	return c, nil
+}
+
+// WithHTTPClient sets the HTTPRequestDoer used to send requests.
+func WithHTTPClient(doer HTTPRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn adds a RequestEditorFn that runs on every request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, editors []RequestEditorFn) (*http.Response, error) {
+	for _, editor := range c.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	for _, editor := range editors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+`
+
+// RenderClient renders a typed HTTP client for the operations in doc.
+func RenderClient(doc *v3high.Document, opts ...Option) (*GeneratedClient, error) {
+	return NewGenerator(opts...).RenderClient(doc)
+}
+
+// RenderClient renders a typed HTTP client for the operations in doc using this
+// generator. The client has one method per operation, a params struct per
+// operation with parameters, and a response struct with one typed field per
+// documented JSON response.
+func (g *Generator) RenderClient(doc *v3high.Document) (*GeneratedClient, error) {
+	if err := validatePackageName(g.packageName); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, wrapPath(ErrNilDocument, "")
+	}
+	r := g.run()
+	ops, synthesized := r.collectOperations(doc)
+	models := operationModels(doc, synthesized)
+	file, err := g.RenderSchemas(models)
+	if err != nil {
+		return nil, err
+	}
+	r.imports = make(map[string]struct{})
+	r.resolveOperationTypes(ops, models, file, synthesized)
+	r.resolveOperationTypeNames(ops, file)
+	source, err := r.renderClientSource(ops)
+	if err != nil {
+		return nil, err
+	}
+	return &GeneratedClient{
+		PackageName: g.packageName,
+		Models:      file,
+		Client:      &GeneratedSourceFile{Name: ClientFileName, Source: source},
+		Operations:  generatedOperations(ops),
+		Diagnostics: append([]Diagnostic(nil), r.diagnostics...),
+	}, nil
+}
+
+// resolveOperationTypeNames names the params and response types of each
+// operation without colliding with models or fixed declarations. The returned
+// registry lets server rendering name its own types after the client types.
+func (g *Generator) resolveOperationTypeNames(ops []*operationIR, file *GeneratedFile) *nameRegistry {
+	names := newNameRegistry()
+	for _, typ := range file.Types {
+		names.resolve(typ.Name, typ.Name)
+	}
+	for _, name := range g.componentTypeNames {
+		names.resolve(name, name)
+	}
+	for _, name := range operationDecls {
+		names.resolve(name, name)
+	}
+	if g.validationMethods {
+		for _, name := range validationDecls {
+			names.resolve(name, name)
+		}
+	}
+	for _, op := range ops {
+		if len(op.Params) > 0 {
+			op.ParamsType = g.resolveOperationTypeName(names, op, "Params")
+		}
+		op.ResultType = g.resolveOperationTypeName(names, op, "Response")
+	}
+	return names
+}
+
+func (g *Generator) resolveOperationTypeName(names *nameRegistry, op *operationIR, suffix string) string {
+	name, collision := names.resolve(op.Method+" "+op.Path+" "+suffix, op.Name+suffix)
+	if collision {
+		g.addDiagnostic(DiagnosticTypeNameCollision, op.Method+" "+op.Path, "type name collision resolved as "+name)
+	}
+	return name
+}
+
+func generatedOperations(ops []*operationIR) []*GeneratedOperation {
+	out := make([]*GeneratedOperation, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, &GeneratedOperation{
+			Name:             op.Name,
+			Method:           op.Method,
+			Path:             op.Path,
+			OperationID:      op.OperationID,
+			ParamsType:       op.ParamsType,
+			ResultType:       op.ResultType,
+			RequestType:      op.RequestType,
+			ServerResultType: op.ServerResultType,
+		})
+	}
+	return out
+}
+
+// operationHelpers returns the runtime helpers needed by ops, in a stable
+// order.
+func operationHelpers(ops []*operationIR, responses bool) []runtimeHelper {
+	var params, path, query, header, jsonParams, jsonResponses bool
+	for _, op := range ops {
+		for _, param := range op.Params {
+			params = true
+			switch param.In {
+			case paramInPath:
+				path = true
+			case paramInQuery:
+				query = true
+			default:
+				header = true
+			}
+			jsonParams = jsonParams || param.JSON
+		}
+		for _, response := range op.Responses {
+			jsonResponses = jsonResponses || response.schema != nil
+		}
+	}
+	var helpers []runtimeHelper
+	for _, helper := range []struct {
+		used   bool
+		helper runtimeHelper
+	}{
+		{params, paramValueHelper},
+		{path, pathParamHelper},
+		{query, queryParamHelper},
+		{header, headerParamHelper},
+		{jsonParams, jsonParamHelper},
+		{responses && jsonResponses, jsonResponseHelper},
+	} {
+		if helper.used {
+			helpers = append(helpers, helper.helper)
+		}
+	}
+	return helpers
+}
+
+func (g *Generator) renderClientSource(ops []*operationIR) ([]byte, error) {
+	g.addImport("context")
+	g.addImport("net/http")
+	g.addImport("strings")
+	var decls []string
+	decls = append(decls, clientCoreSource)
+	for _, op := range ops {
+		if op.ParamsType != "" {
+			decls = append(decls, renderParamsDecl(op))
+		}
+		decls = append(decls, g.renderClientResponseDecl(op))
+		decls = append(decls, g.renderClientMethod(op))
+	}
+	for _, helper := range operationHelpers(ops, true) {
+		for _, path := range helper.imports {
+			g.addImport(path)
+		}
+		decls = append(decls, helper.source)
+	}
+	return g.renderSourceFile(decls)
+}
+
+// renderSourceFile writes the file header, package clause, and imports ahead
+// of decls and formats the result.
+func (g *Generator) renderSourceFile(decls []string) ([]byte, error) {
+	var b strings.Builder
+	if g.generatedComment {
+		b.WriteString("// Code generated by libopenapi generator/golang. DO NOT EDIT.\n")
+	}
+	if g.headerComment != "" {
+		writeLineCommentBlock(&b, g.headerComment)
+	}
+	if g.generatedComment || g.headerComment != "" {
+		b.WriteByte('\n')
+	}
+	b.WriteString("package ")
+	b.WriteString(g.packageName)
+	b.WriteString("\n\n")
+	g.writeImports(&b)
+	for _, decl := range decls {
+		b.WriteString(decl)
+		b.WriteByte('\n')
+	}
+	return formatSource([]byte(b.String()))
+}
+
+func renderParamsDecl(op *operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, op.ParamsType+" contains the parameters of "+op.Name)
+	b.WriteString("type ")
+	b.WriteString(op.ParamsType)
+	b.WriteString(" struct {\n")
+	for _, param := range op.Params {
+		if param.Description != "" {
+			writeComment(&b, param.Field, param.Description)
+		} else {
+			writeLineComment(&b, param.Field+" is the "+param.Name+" "+param.In+" parameter")
+		}
+		b.WriteByte('\t')
+		b.WriteString(param.Field)
+		b.WriteByte(' ')
+		b.WriteString(param.Type)
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func (g *Generator) renderClientResponseDecl(op *operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, op.ResultType+" is the response of "+op.Name)
+	b.WriteString("type ")
+	b.WriteString(op.ResultType)
+	b.WriteString(" struct {\n")
+	b.WriteString("\t// HTTPResponse is the raw response. Its body has been read into Body.\n")
+	b.WriteString("\tHTTPResponse *http.Response\n")
+	b.WriteString("\tBody         []byte\n")
+	for _, response := range op.Responses {
+		if response.Field == "" {
+			continue
+		}
+		text := response.Field + " is the decoded " + strings.ToLower(response.Code) + " response"
+		if description := strings.TrimSpace(strings.Split(response.Description, "\n")[0]); description != "" {
+			text += ": " + description
+		}
+		writeLineComment(&b, text)
+		b.WriteByte('\t')
+		b.WriteString(response.Field)
+		b.WriteByte(' ')
+		b.WriteString(optionalType(response.Type))
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n\n")
+	writeLineComment(&b, "StatusCode returns the HTTP status code of the response")
+	b.WriteString("func (r *")
+	b.WriteString(op.ResultType)
+	b.WriteString(") StatusCode() int {\n\tif r == nil || r.HTTPResponse == nil {\n\t\treturn 0\n\t}\n\treturn r.HTTPResponse.StatusCode\n}\n")
+	return b.String()
+}
+
+func (g *Generator) renderClientMethod(op *operationIR) string {
+	var b strings.Builder
+	writeOperationComment(&b, op)
+	b.WriteString("func (c *Client) ")
+	b.WriteString(op.Name)
+	b.WriteString("(ctx context.Context")
+	if op.ParamsType != "" {
+		b.WriteString(", params ")
+		b.WriteString(op.ParamsType)
+	}
+	if op.Body != nil {
+		if op.Body.JSON {
+			b.WriteString(", body ")
+			if op.Body.Required {
+				b.WriteString(op.Body.Type)
+			} else {
+				b.WriteString(optionalType(op.Body.Type))
+			}
+		} else {
+			b.WriteString(", contentType string, body io.Reader")
+			g.addImport("io")
+		}
+	}
+	b.WriteString(", reqEditors ...RequestEditorFn) (*")
+	b.WriteString(op.ResultType)
+	b.WriteString(", error) {\n")
+
+	g.writeClientPath(&b, op)
+	g.writeClientQuery(&b, op)
+	g.writeClientBody(&b, op)
+	b.WriteString("\treq, err := http.NewRequestWithContext(ctx, ")
+	b.WriteString(strconv.Quote(op.Method))
+	b.WriteString(", target, reqBody)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	if op.Body != nil {
+		b.WriteString("\treq.Header.Set(\"Content-Type\", ")
+		if op.Body.JSON {
+			b.WriteString(strconv.Quote(op.Body.ContentType))
+		} else {
+			b.WriteString("contentType")
+		}
+		b.WriteString(")\n")
+	}
+	writeClientHeaders(&b, op)
+	b.WriteString("\trsp, err := c.do(ctx, req, reqEditors)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	b.WriteString("\tdefer rsp.Body.Close()\n\tdata, err := io.ReadAll(rsp.Body)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	g.addImport("io")
+	b.WriteString("\tresponse := &")
+	b.WriteString(op.ResultType)
+	b.WriteString("{HTTPResponse: rsp, Body: data}\n")
+	g.writeClientDecode(&b, op)
+	b.WriteString("\treturn response, nil\n}\n")
+	return b.String()
+}
+
+func writeOperationComment(b *strings.Builder, op *operationIR) {
+	text := op.Summary
+	if text == "" {
+		text = op.Description
+	}
+	if text == "" {
+		text = "calls " + op.Method + " " + op.Path
+	}
+	writeComment(b, op.Name, text)
+	if op.Deprecated {
+		b.WriteString("//\n")
+		writeLineComment(b, "Deprecated: the "+op.Name+" operation is deprecated")
+	}
+}
+
+func (g *Generator) writeClientPath(b *strings.Builder, op *operationIR) {
+	params := make(map[string]*paramIR)
+	for _, param := range op.Params {
+		if param.In == paramInPath {
+			params[param.Name] = param
+		}
+	}
+	var parts []string
+	var literal strings.Builder
+	flush := func() {
+		if literal.Len() > 0 {
+			parts = append(parts, strconv.Quote(literal.String()))
+			literal.Reset()
+		}
+	}
+	values := 0
+	for _, segment := range splitPathTemplate(op.Path) {
+		param := params[segment.value]
+		if !segment.param || param == nil {
+			if segment.param {
+				literal.WriteString("{" + segment.value + "}")
+			} else {
+				literal.WriteString(segment.value)
+			}
+			continue
+		}
+		flush()
+		values++
+		variable := "pathValue" + intString(values)
+		value := "params." + param.Field
+		if param.JSON {
+			b.WriteString("\t" + variable + ", err := jsonParam(" + value + ")\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+			b.WriteString("\t" + variable + ", err = pathParam(")
+			value = variable
+		} else {
+			b.WriteString("\t" + variable + ", err := pathParam(")
+		}
+		b.WriteString(strconv.Quote(param.Style) + ", " + strconv.FormatBool(param.Explode) + ", " + strconv.Quote(param.Name) + ", " + value + ")\n")
+		b.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		parts = append(parts, variable)
+	}
+	flush()
+	if len(parts) == 0 {
+		parts = append(parts, `""`)
+	}
+	b.WriteString("\ttarget := c.Server + ")
+	b.WriteString(strings.Join(parts, " + "))
+	b.WriteByte('\n')
+}
+
+func (g *Generator) writeClientQuery(b *strings.Builder, op *operationIR) {
+	var query []*paramIR
+	for _, param := range op.Params {
+		if param.In == paramInQuery {
+			query = append(query, param)
+		}
+	}
+	if len(query) == 0 {
+		return
+	}
+	g.addImport("net/url")
+	b.WriteString("\tquery := url.Values{}\n")
+	for _, param := range query {
+		indent, closing := openParamScope(b, param, param.JSON)
+		value := writeJSONParam(b, indent, param)
+		b.WriteString(indent + "if err := addQueryParam(query, " + strconv.Quote(param.Style) + ", " + strconv.FormatBool(param.Explode) + ", " + strconv.Quote(param.Name) + ", " + value + "); err != nil {\n")
+		b.WriteString(indent + "\treturn nil, err\n" + indent + "}\n")
+		b.WriteString(closing)
+	}
+	b.WriteString("\tif len(query) > 0 {\n\t\ttarget += \"?\" + query.Encode()\n\t}\n")
+}
+
+func (g *Generator) writeClientBody(b *strings.Builder, op *operationIR) {
+	g.addImport("io")
+	b.WriteString("\tvar reqBody io.Reader\n")
+	if op.Body == nil {
+		return
+	}
+	if !op.Body.JSON {
+		b.WriteString("\treqBody = body\n")
+		return
+	}
+	g.addImport("bytes")
+	g.addImport("encoding/json")
+	indent := "\t"
+	optional := !op.Body.Required
+	if optional {
+		b.WriteString("\tif body != nil {\n")
+		indent = "\t\t"
+	}
+	b.WriteString(indent + "encoded, err := json.Marshal(body)\n")
+	b.WriteString(indent + "if err != nil {\n" + indent + "\treturn nil, err\n" + indent + "}\n")
+	b.WriteString(indent + "reqBody = bytes.NewReader(encoded)\n")
+	if optional {
+		b.WriteString("\t}\n")
+	}
+}
+
+func writeClientHeaders(b *strings.Builder, op *operationIR) {
+	for _, param := range op.Params {
+		if param.In != paramInHeader && param.In != paramInCookie {
+			continue
+		}
+		indent, closing := openParamScope(b, param, true)
+		value := writeJSONParam(b, indent, param)
+		b.WriteString(indent + "value, err := headerParam(" + strconv.FormatBool(param.Explode) + ", " + value + ")\n")
+		b.WriteString(indent + "if err != nil {\n" + indent + "\treturn nil, err\n" + indent + "}\n")
+		if param.In == paramInHeader {
+			b.WriteString(indent + "req.Header.Set(" + strconv.Quote(param.Name) + ", value)\n")
+		} else {
+			b.WriteString(indent + "req.AddCookie(&http.Cookie{Name: " + strconv.Quote(param.Name) + ", Value: value})\n")
+		}
+		b.WriteString(closing)
+	}
+}
+
+// openParamScope opens the block that serializes one parameter. Optional
+// parameters are guarded by a nil check; required parameters get a bare block
+// when the serialization declares local variables.
+func openParamScope(b *strings.Builder, param *paramIR, locals bool) (string, string) {
+	if check := optionalCheck(param); check != "" {
+		b.WriteString("\tif " + check + " {\n")
+		return "\t\t", "\t}\n"
+	}
+	if locals {
+		b.WriteString("\t{\n")
+		return "\t\t", "\t}\n"
+	}
+	return "\t", ""
+}
+
+// writeJSONParam encodes a JSON content parameter into a local and returns the
+// expression holding the parameter value.
+func writeJSONParam(b *strings.Builder, indent string, param *paramIR) string {
+	value := "params." + param.Field
+	if !param.JSON {
+		return value
+	}
+	b.WriteString(indent + "encoded, err := jsonParam(" + value + ")\n")
+	b.WriteString(indent + "if err != nil {\n" + indent + "\treturn nil, err\n" + indent + "}\n")
+	return "encoded"
+}
+
+func (g *Generator) writeClientDecode(b *strings.Builder, op *operationIR) {
+	var cases []string
+	var ranges []string
+	var fallback string
+	decodes := false
+	for _, response := range op.Responses {
+		var body string
+		if response.Field != "" {
+			decodes = true
+			g.addImport("encoding/json")
+			body = "\t\tvar dest " + response.Type + "\n" +
+				"\t\tif err := json.Unmarshal(data, &dest); err != nil {\n\t\t\treturn response, err\n\t\t}\n" +
+				"\t\tresponse." + response.Field + " = " + optionalValue(response.Type, "dest") + "\n"
+		}
+		switch condition := statusCondition(response.Code); {
+		case response.Code == "Default":
+			fallback = "\tdefault:\n" + body
+		case condition == "":
+			continue
+		case strings.Contains(condition, "/"):
+			ranges = append(ranges, "\tcase "+condition+":\n"+body)
+		default:
+			cases = append(cases, "\tcase "+condition+":\n"+body)
+		}
+	}
+	if !decodes {
+		return
+	}
+	b.WriteString("\tif !decodesJSON(rsp, data) {\n\t\treturn response, nil\n\t}\n\tswitch {\n")
+	for _, c := range append(cases, ranges...) {
+		b.WriteString(c)
+	}
+	b.WriteString(fallback)
+	b.WriteString("\t}\n")
+}
+
+// statusCondition returns the Go condition matching a response code, or an
+// empty string for codes that are not valid statuses.
+func statusCondition(code string) string {
+	if len(code) == 3 && strings.HasSuffix(code, "XX") && code[0] >= '1' && code[0] <= '5' {
+		return "rsp.StatusCode/100 == " + code[:1]
+	}
+	if status, err := strconv.Atoi(code); err == nil && status >= 100 && status <= 599 {
+		return "rsp.StatusCode == " + code
+	}
+	return ""
+}
+
+// optionalCheck returns the condition guarding an optional parameter, or an
+// empty string when the parameter is always sent.
+func optionalCheck(param *paramIR) string {
+	if param.Required || !nilableType(param.Type) {
+		return ""
+	}
+	return "params." + param.Field + " != nil"
+}
+
+// optionalType returns the pointer form of typ unless typ is already nilable.
+func optionalType(typ string) string {
+	if nilableType(typ) {
+		return typ
+	}
+	return "*" + typ
+}
+
+func optionalValue(typ, value string) string {
+	if nilableType(typ) {
+		return value
+	}
+	return "&" + value
+}
+
+func nilableType(typ string) bool {
+	return typ == "any" || typ == "io.Reader" || strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[")
+}
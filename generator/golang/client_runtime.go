@@ -0,0 +1,237 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+// runtimeHelper is unexported Go source emitted into generated client and
+// server files, so generated code has no runtime dependency on libopenapi.
+type runtimeHelper struct {
+	imports []string
+	source  string
+}
+
+var paramValueHelper = runtimeHelper{
+	imports: []string{"encoding", "encoding/json", "reflect", "sort", "strconv", "strings"},
+	source: `type paramKind int
+
+const (
+	paramPrimitive paramKind = iota
+	paramArray
+	paramObject
+)
+
+// paramValue flattens a parameter value into its serialized parts. Arrays
+// return their elements and objects return alternating keys and values sorted
+// by key.
+func paramValue(value any) ([]string, paramKind, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, paramPrimitive, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, paramPrimitive, nil
+	}
+	if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := paramScalar(v)
+		return []string{text}, paramPrimitive, err
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			part, err := paramScalar(v.Index(i))
+			if err != nil {
+				return nil, paramArray, err
+			}
+			parts = append(parts, part)
+		}
+		return parts, paramArray, nil
+	case reflect.Map, reflect.Struct:
+		encoded, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, paramObject, err
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(encoded, &fields); err != nil {
+			return nil, paramObject, err
+		}
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys)*2)
+		for _, key := range keys {
+			part, err := paramScalar(reflect.ValueOf(fields[key]))
+			if err != nil {
+				return nil, paramObject, err
+			}
+			parts = append(parts, key, part)
+		}
+		return parts, paramObject, nil
+	default:
+		part, err := paramScalar(v)
+		return []string{part}, paramPrimitive, err
+	}
+}
+
+func paramScalar(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", nil
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	default:
+		encoded, err := json.Marshal(v.Interface())
+		return string(encoded), err
+	}
+}
+
+// simpleParam joins parameter parts with the simple style. Exploded objects
+// render as key=value pairs.
+func simpleParam(parts []string, kind paramKind, explode bool, separator string) string {
+	if kind == paramObject && explode {
+		pairs := make([]string, 0, len(parts)/2)
+		for i := 0; i+1 < len(parts); i += 2 {
+			pairs = append(pairs, parts[i]+"="+parts[i+1])
+		}
+		return strings.Join(pairs, separator)
+	}
+	if kind == paramArray && explode {
+		return strings.Join(parts, separator)
+	}
+	return strings.Join(parts, ",")
+}
+`,
+}
+
+var pathParamHelper = runtimeHelper{
+	imports: []string{"net/url", "strings"},
+	source: `// pathParam serializes a path parameter with the simple, label, or matrix
+// style.
+func pathParam(style string, explode bool, name string, value any) (string, error) {
+	parts, kind, err := paramValue(value)
+	if err != nil {
+		return "", err
+	}
+	// percent-encode everything but unreserved characters, so values cannot
+	// introduce the delimiters used by the styles below.
+	for i := range parts {
+		parts[i] = strings.ReplaceAll(url.QueryEscape(parts[i]), "+", "%20")
+	}
+	switch style {
+	case "label":
+		return "." + simpleParam(parts, kind, explode, "."), nil
+	case "matrix":
+		switch {
+		case kind == paramObject && explode:
+			return ";" + simpleParam(parts, kind, explode, ";"), nil
+		case kind == paramArray && explode:
+			return ";" + name + "=" + strings.Join(parts, ";"+name+"="), nil
+		default:
+			return ";" + name + "=" + strings.Join(parts, ","), nil
+		}
+	default:
+		return simpleParam(parts, kind, explode, ","), nil
+	}
+}
+`,
+}
+
+var queryParamHelper = runtimeHelper{
+	imports: []string{"net/url", "strings"},
+	source: `// addQueryParam serializes a query parameter with the form, spaceDelimited,
+// pipeDelimited, or deepObject style.
+func addQueryParam(query url.Values, style string, explode bool, name string, value any) error {
+	parts, kind, err := paramValue(value)
+	if err != nil {
+		return err
+	}
+	switch {
+	case kind == paramObject && style == "deepObject":
+		for i := 0; i+1 < len(parts); i += 2 {
+			query.Add(name+"["+parts[i]+"]", parts[i+1])
+		}
+	case kind == paramObject && explode:
+		for i := 0; i+1 < len(parts); i += 2 {
+			query.Add(parts[i], parts[i+1])
+		}
+	case kind == paramArray && explode:
+		for _, part := range parts {
+			query.Add(name, part)
+		}
+	default:
+		separator := ","
+		if kind == paramArray && style == "spaceDelimited" {
+			separator = " "
+		} else if kind == paramArray && style == "pipeDelimited" {
+			separator = "|"
+		}
+		query.Add(name, strings.Join(parts, separator))
+	}
+	return nil
+}
+`,
+}
+
+var headerParamHelper = runtimeHelper{
+	source: `// headerParam serializes a header or cookie parameter with the simple style.
+func headerParam(explode bool, value any) (string, error) {
+	parts, kind, err := paramValue(value)
+	if err != nil {
+		return "", err
+	}
+	return simpleParam(parts, kind, explode, ","), nil
+}
+`,
+}
+
+var jsonParamHelper = runtimeHelper{
+	imports: []string{"encoding/json"},
+	source: `// jsonParam serializes a parameter declared with JSON content.
+func jsonParam(value any) (string, error) {
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+`,
+}
+
+var jsonResponseHelper = runtimeHelper{
+	imports: []string{"net/http", "strings"},
+	source: `// decodesJSON reports whether a response body should be decoded as JSON.
+// Responses without a Content-Type are decoded.
+func decodesJSON(rsp *http.Response, body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+	contentType := rsp.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+`,
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+func TestBookingsClientGolden(t *testing.T) {
+	client := renderBookingsClient(t)
+	assertGolden(t, "testdata/bookings_client_models.golden.go", client.Models.Source)
+	assertGolden(t, "testdata/bookings_client.golden.go", client.Client.Source)
+}
+
+func TestRenderClientOperations(t *testing.T) {
+	client := renderBookingsClient(t)
+	if client.PackageName != "models" || client.Client.Name != ClientFileName {
+		t.Fatalf("unexpected client file: %q %q", client.PackageName, client.Client.Name)
+	}
+	var got []string
+	for _, op := range client.Operations {
+		got = append(got, op.Method+" "+op.Path+" "+op.Name+" "+op.ParamsType+" "+op.ResultType)
+	}
+	want := []string{
+		"GET /stations GetStations GetStationsParams GetStationsResponse",
+		"GET /bookings/{bookingId} GetBooking GetBookingParams GetBookingResponse",
+		"DELETE /bookings/{bookingId} DeleteBooking DeleteBookingParams DeleteBookingResponse",
+		"POST /bookings CreateBooking  CreateBookingResponse",
+		"PUT /bookings/{bookingId}/ticket{format} PutBookingsBookingIDTicketFormat PutBookingsBookingIDTicketFormatParams PutBookingsBookingIDTicketFormatResponse",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected operations:\n%s", strings.Join(got, "\n"))
+	}
+	assertDiagnostic(t, client.Diagnostics, DiagnosticMissingOperationID, "PUT /bookings/{bookingId}/ticket{format}")
+	assertDiagnostic(t, client.Diagnostics, DiagnosticRawRequestBody, "PUT /bookings/{bookingId}/ticket{format}")
+}
+
+func TestRenderClientCompilesAndCallsServer(t *testing.T) {
+	client := renderBookingsClient(t)
+	assertParsesCompilesAndTestsWithFiles(t, map[string][]byte{
+		"models.go": client.Models.Source,
+		"client.go": client.Client.Source,
+	}, `package models
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedClient(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		cookie, _ := r.Cookie("session")
+		session := ""
+		if cookie != nil {
+			session = cookie.Value
+		}
+		got = append(got, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery+" "+r.Header.Get("X-Request-Id")+" "+session+" "+r.Header.Get("Authorization")+" "+r.Header.Get("Content-Type")+" "+string(body))
+		switch {
+		case r.URL.Path == "/stations" && r.URL.Query().Get("country") == "XX":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`+"`"+`{"title":"bad country","status":400}`+"`"+`))
+		case r.URL.Path == "/stations":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`+"`"+`{"data":[{"id":"s1","name":"Berlin"}]}`+"`"+`))
+		case r.URL.Path == "/bookings/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/bookings/garbled":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Trace", "t-1")
+			_, _ = w.Write([]byte(`+"`"+`{"id":`+"`"+`))
+		case r.URL.Path == "/bookings/broken":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTeapot)
+			_, _ = w.Write([]byte(`+"`"+`{"title":"teapot"}`+"`"+`))
+		case r.Method == http.MethodPost:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer token")
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	page, name, requestID, session := 2, "Ber lin", "req-1", "abc"
+	stations, err := client.GetStations(ctx, GetStationsParams{
+		Page:        &page,
+		Country:     "DE",
+		Coordinates: []float64{52.5, 13.4},
+		Filter:      &GetStationsFilter{Name: &name},
+		XRequestID:  &requestID,
+		Session:     &session,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stations.StatusCode() != 200 || stations.JSON200 == nil || stations.JSON200.Data[0].Name != "Berlin" || stations.JSON4XX != nil {
+		t.Fatalf("unexpected stations response: %#v", stations)
+	}
+	problem, err := client.GetStations(ctx, GetStationsParams{Country: "XX"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problem.JSON4XX == nil || *problem.JSON4XX.Title != "bad country" || problem.JSON200 != nil {
+		t.Fatalf("unexpected problem response: %#v", problem)
+	}
+	missing, err := client.GetBooking(ctx, GetBookingParams{BookingID: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing.StatusCode() != 404 || missing.JSON200 != nil || missing.JSONDefault != nil {
+		t.Fatalf("unexpected missing response: %#v", missing)
+	}
+	broken, err := client.GetBooking(ctx, GetBookingParams{BookingID: "broken"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if broken.JSONDefault == nil || *broken.JSONDefault.Title != "teapot" {
+		t.Fatalf("unexpected default response: %#v", broken)
+	}
+	garbled, err := client.GetBooking(ctx, GetBookingParams{BookingID: "garbled"})
+	if err == nil || garbled == nil || garbled.StatusCode() != 200 || garbled.HTTPResponse.Header.Get("X-Trace") != "t-1" || string(garbled.Body) != `+"`"+`{"id":`+"`"+` {
+		t.Fatalf("unexpected garbled response: %#v %v", garbled, err)
+	}
+	passenger := "Ada"
+	created, err := client.CreateBooking(ctx, Booking{PassengerName: &passenger}, func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer override")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.JSON201 == nil || *created.JSON201.PassengerName != "Ada" {
+		t.Fatalf("unexpected created response: %#v", created)
+	}
+	if _, err := client.PutBookingsBookingIDTicketFormat(ctx, PutBookingsBookingIDTicketFormatParams{BookingID: "b 1,2;x=y", Format: "pdf"}, "application/pdf", strings.NewReader("%PDF")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /stations?coordinates=52.5%2C13.4&country=DE&filter%5Bname%5D=Ber+lin&page=2 req-1 abc Bearer token  ",
+		"GET /stations?country=XX   Bearer token  ",
+		"GET /bookings/missing?   Bearer token  ",
+		"GET /bookings/broken?   Bearer token  ",
+		"GET /bookings/garbled?   Bearer token  ",
+		"POST /bookings?   Bearer override application/json {\"passenger_name\":\"Ada\"}",
+		"PUT /bookings/b%201%2C2%3Bx%3Dy/ticket.pdf?   Bearer token application/pdf %PDF",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(got, "\n"))
+	}
+}
+`)
+}
+
+func TestRenderClientTypeNameCollision(t *testing.T) {
+	model := mustBuildV3(t, `openapi: 3.1.0
+info:
+  title: Collisions
+  version: 1.0.0
+paths:
+  /things:
+    get:
+      operationId: listThings
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    ListThingsResponse:
+      type: object
+      properties:
+        id:
+          type: string
+`)
+	client, err := RenderClient(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.Operations[0].ResultType; got != "ListThingsResponse__2" {
+		t.Fatalf("unexpected result type %q", got)
+	}
+	assertDiagnostic(t, client.Diagnostics, DiagnosticTypeNameCollision, "GET /things")
+	assertContains(t, string(client.Client.Source), "func (c *Client) ListThings(ctx context.Context, reqEditors ...RequestEditorFn) (*ListThingsResponse__2, error)")
+}
+
+func TestRenderClientUndeclaredPathParameter(t *testing.T) {
+	model := mustBuildV3(t, `openapi: 3.1.0
+info:
+  title: Undeclared
+  version: 1.0.0
+paths:
+  /things/{id}:
+    get:
+      responses:
+        '200':
+          description: OK
+`)
+	client, err := RenderClient(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDiagnostic(t, client.Diagnostics, DiagnosticUndeclaredPathParameter, "GET /things/{id}")
+	assertContains(t, string(client.Client.Source), `target := c.Server + "/things/{id}"`)
+}
+
+func TestRenderClientErrors(t *testing.T) {
+	if _, err := RenderClient(nil); !errors.Is(err, ErrNilDocument) {
+		t.Fatalf("expected ErrNilDocument, got %v", err)
+	}
+	if _, err := RenderClient(&v3high.Document{}, WithPackageName("not-valid")); !errors.Is(err, ErrInvalidPackageName) {
+		t.Fatalf("expected ErrInvalidPackageName, got %v", err)
+	}
+}
+
+func TestStatusCondition(t *testing.T) {
+	for code, want := range map[string]string{
+		"200":     "rsp.StatusCode == 200",
+		"4XX":     "rsp.StatusCode/100 == 4",
+		"6XX":     "",
+		"99":      "",
+		"Default": "",
+	} {
+		if got := statusCondition(code); got != want {
+			t.Fatalf("statusCondition(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func renderBookingsClient(t *testing.T, opts ...Option) *GeneratedClient {
+	t.Helper()
+	spec, err := os.ReadFile("testdata/bookings-client.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := RenderClient(mustBuildV3(t, string(spec)), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func mustBuildV3(t *testing.T, spec string) *v3high.Document {
+	t.Helper()
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &model.Model
+}
+
+func assertDiagnostic(t *testing.T, diagnostics []Diagnostic, code, path string) {
+	t.Helper()
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Code == code && diagnostic.Path == path {
+			return
+		}
+	}
+	t.Fatalf("missing diagnostic %s at %s in %#v", code, path, diagnostics)
+}
@@ -1,18 +1,31 @@
 // Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
 // SPDX-License-Identifier: MIT
 
-// Package golang generates Go model types from OpenAPI schemas and generates
-// OpenAPI schemas from Go runtime types.
+// Package golang generates Go model types, typed HTTP clients, and server
+// adapters from OpenAPI documents and generates OpenAPI schemas from Go runtime
+// types.
 //
-// The package is intentionally library-only. It does not provide a CLI,
-// generated client or server code, a validation runtime, or runtime helper
-// package. Callers provide libopenapi schema models or Go reflection types and
-// receive generated Go source or OpenAPI schema proxies.
+// The package is intentionally library-only. It does not provide a CLI, a
+// validation runtime, or runtime helper package.
+// Callers provide libopenapi documents, schema models, or Go reflection types
+// and receive generated Go source or OpenAPI schema proxies.
 //
 // OpenAPI to Go model generation starts with RenderSchema for a single schema
 // or Generator.RenderSchemas for component maps. The generated source is
 // gofmt-formatted and diagnostics report schema shapes that do not map directly
-// to plain Go model fields.
+// to plain Go model fields. Generator.SchemaIRs stops before rendering and
+// returns the neutral SchemaIR values, which generator/typescript renders as
+// TypeScript and generator/protobuf renders as proto3.
+//
+// RenderClient renders the operations of a v3 document as a typed client in
+// the same package as the models. Each operation becomes a Client method with a
+// params struct, a typed request body, and a response struct with one decoded
+// field per documented JSON status. Parameter serialization helpers are emitted
+// into the client file, so generated clients only import the standard library.
+// RenderServer renders the same operations as a ServerInterface and a net/http
+// handler that binds parameters and bodies and writes typed results. Client and
+// server share the models and their type names, so both can be generated into
+// one package.
 //
 // Go to OpenAPI generation starts with SchemaFromType for a single schema or
 // Generator.SchemasFromTypes for a reusable component graph. Package-level
@@ -24,6 +37,10 @@
 // OpenAPI schema models without adding methods to the scalar type, and
 // WithFieldSchema/WithFieldSchemaByJSONName map individual struct fields to
 // exact schema models while keeping the surrounding type reflected normally.
+// OperationBuilder goes one step further and builds a complete v3 document from
+// registered routes, turning tagged params struct fields into parameters and
+// body and per-status response types into JSON content that shares one
+// component graph.
 // Reflected nullable values use JSON Schema 2020-12 native nullability rather
 // than OpenAPI 3.0 nullable: direct schemas use type arrays that include
 // "null", and nullable component references use anyOf wrappers.
@@ -43,6 +60,16 @@
 // required const discriminator, render as typed union wrappers. Ambiguous oneOf
 // and anyOf schemas render as json.RawMessage wrappers so the generated model
 // remains dependency-free and does not embed validation behavior.
+// WithUnionVariantDecoding also decodes those wrappers into a typed Value, by
+// discriminator when one is present or else by the first variant that strictly
+// decodes, and gives raw and typed unions an As accessor per variant.
+//
+// WithValidationMethods adds a Validate method to every generated model. It
+// checks required fields and the schema's string, numeric, enum, array, and
+// object constraints, recurses into nested models and unions, and aggregates
+// violations as ValidationErrors with JSON pointer paths into the instance.
+// The error types and helpers are emitted into the models file, so validated
+// models still only import the standard library.
 //
 // Schema-valued additionalProperties can round-trip unknown JSON object fields
 // through generated marshal/unmarshal methods. WithAdditionalPropertiesMethods
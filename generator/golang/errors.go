@@ -9,11 +9,13 @@ import (
 )
 
 var (
+	ErrNilDocument        = errors.New("nil document")
 	ErrNilSchema          = errors.New("nil schema")
 	ErrNilType            = errors.New("nil type")
 	ErrUnsupportedType    = errors.New("unsupported type")
 	ErrUnsupportedMapKey  = errors.New("unsupported map key")
 	ErrInvalidPackageName = errors.New("invalid package name")
+	ErrInvalidOperation   = errors.New("invalid operation")
 )
 
 func wrapPath(err error, path string) error {
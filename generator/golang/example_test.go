@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/pb33f/libopenapi"
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/orderedmap"
 )
@@ -60,3 +61,153 @@ func ExampleGenerator_SchemasFromTypes() {
 	// #/components/schemas/ExampleCustomer
 	// true true
 }
+
+type ExampleGetCustomerParams struct {
+	ID string `path:"id"`
+}
+
+func ExampleOperationBuilder() {
+	built, err := NewOperationBuilder().
+		Add("GET", "/customers/{id}", Operation{
+			OperationID: "getCustomer",
+			Params:      ExampleGetCustomerParams{},
+			Responses:   map[string]any{"200": ExampleCustomer{}, "404": nil},
+		}).
+		Build(&highbase.Info{Title: "Customers", Version: "1.0.0"})
+	if err != nil {
+		panic(err)
+	}
+
+	op := built.Document.Paths.PathItems.GetOrZero("/customers/{id}").Get
+	schema := op.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema
+
+	fmt.Println(op.Parameters[0].In, op.Parameters[0].Name)
+	fmt.Println(schema.GetReference())
+	fmt.Println(built.Document.Components.Schemas.Len())
+
+	// Output:
+	// path id
+	// #/components/schemas/ExampleCustomer
+	// 2
+}
+
+func ExampleRenderClient() {
+	doc, err := libopenapi.NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: A pet.
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`))
+	if err != nil {
+		panic(err)
+	}
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		panic(err)
+	}
+	client, err := RenderClient(&model.Model)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(client.Client.Name)
+	fmt.Println(strings.Contains(string(client.Client.Source), "func (c *Client) GetPet(ctx context.Context, params GetPetParams, reqEditors ...RequestEditorFn) (*GetPetResponse, error)"))
+	fmt.Println(strings.Contains(string(client.Client.Source), "JSON200 *Pet"))
+
+	// Output:
+	// client.go
+	// true
+	// true
+}
+
+func ExampleRenderServer() {
+	doc, err := libopenapi.NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: A pet.
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+`))
+	if err != nil {
+		panic(err)
+	}
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		panic(err)
+	}
+	server, err := RenderServer(&model.Model)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(server.Server.Name)
+	fmt.Println(strings.Contains(string(server.Server.Source), "GetPet(ctx context.Context, request GetPetRequest) (GetPetResult, error)"))
+
+	// Output:
+	// server.go
+	// true
+}
+
+func ExampleWithValidationMethods() {
+	minLength := int64(1)
+	properties := orderedmap.New[string, *highbase.SchemaProxy]()
+	properties.Set("name", highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"string"}, MinLength: &minLength}))
+
+	schema := highbase.CreateSchemaProxy(&highbase.Schema{
+		Type:       []string{"object"},
+		Required:   []string{"name"},
+		Properties: properties,
+	})
+	source, err := RenderSchema("Pet", schema, WithValidationMethods(true))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(strings.Contains(string(source), "func (m Pet) Validate() error"))
+	fmt.Println(strings.Contains(string(source), `errs.add("/name", "length must be at least 1")`))
+
+	// Output:
+	// true
+	// true
+}
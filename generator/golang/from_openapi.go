@@ -320,7 +320,11 @@ func (g *Generator) collectShapeDiagnostics(path string, schema *highbase.Schema
 	if schema.Const != nil {
 		g.addDiagnostic(DiagnosticConstKeyword, path, "const is validation-only and was not enforced by the generated Go model")
 	}
-	if hasValidationKeyword(schema) {
+	if g.validationMethods {
+		if schema.ContentEncoding != "" || schema.ContentMediaType != "" {
+			g.addDiagnostic(DiagnosticValidationKeyword, path, "contentEncoding and contentMediaType are not enforced by generated Validate methods")
+		}
+	} else if hasValidationKeyword(schema) {
 		g.addDiagnostic(DiagnosticValidationKeyword, path, "JSON Schema validation keywords are not enforced by generated Go models")
 	}
 	if schema.UnevaluatedProperties != nil {
@@ -29,6 +29,8 @@ type Generator struct {
 	generatedComment                 bool
 	openapiTags                      bool
 	schemaMetadataSidecar            bool
+	validationMethods                bool
+	unionVariantDecoding             bool
 	nestedTypeNameDelimiter          string
 
 	nameResolver          NameResolver
@@ -51,6 +53,11 @@ type Generator struct {
 	metadataSchemas map[string]*highbase.Schema
 	metadataOrder   []string
 
+	helperNeeds            map[string]bool
+	validationNeeds        map[string]bool
+	validationPatterns     map[string]string
+	validationPatternOrder []string
+
 	openapiCache map[*highbase.SchemaProxy]*SchemaIR
 	reflectCache map[reflect.Type]*SchemaIR
 	reflectStack map[reflect.Type]bool
@@ -80,6 +87,14 @@ type SchemaSet struct {
 	Diagnostics []Diagnostic
 }
 
+// SchemaIRSet contains the neutral IR built from OpenAPI schemas.
+type SchemaIRSet struct {
+	// Schemas contains one IR per input schema, in input order.
+	Schemas []*SchemaIR
+	// Diagnostics reports schema shapes noted while building the IR.
+	Diagnostics []Diagnostic
+}
+
 const SchemaMetadataFileName = "schema_metadata.go"
 
 // GeneratedFile contains Go source generated from OpenAPI schemas.
@@ -198,7 +213,7 @@ func (g *Generator) RenderSchema(name string, schema *highbase.SchemaProxy) ([]b
 		return nil, wrapPath(ErrNilSchema, name)
 	}
 	r := g.run()
-	r.typeNames = newNameRegistry()
+	r.typeNames = newTypeNameRegistry(r.validationMethods)
 	ir, err := r.irFromOpenAPI(name, schema, name)
 	if err != nil {
 		return nil, err
@@ -220,15 +235,9 @@ func (g *Generator) RenderSchemas(schemas *orderedmap.Map[string, *highbase.Sche
 	if schemas == nil {
 		return r.renderFile(nil)
 	}
-	r.typeNames = newNameRegistry()
-	r.componentTypeNames = r.resolveComponentTypeNames(schemas)
-	irs := make([]*SchemaIR, 0, schemas.Len())
-	for name, schema := range schemas.FromOldest() {
-		ir, err := r.irFromOpenAPI(name, schema, name)
-		if err != nil {
-			return nil, err
-		}
-		irs = append(irs, ir)
+	irs, err := r.componentIRs(schemas)
+	if err != nil {
+		return nil, err
 	}
 	r.componentKinds = make(map[string]Kind, len(irs))
 	for _, ir := range irs {
@@ -239,6 +248,39 @@ func (g *Generator) RenderSchemas(schemas *orderedmap.Map[string, *highbase.Sche
 	return r.renderFile(irs)
 }
 
+// SchemaIRs converts an ordered map of OpenAPI schemas into the neutral IR
+// without rendering Go source. Type names are resolved with this generator's
+// naming options, which lets generators for other languages share the
+// OpenAPI -> IR path and its diagnostics.
+func (g *Generator) SchemaIRs(schemas *orderedmap.Map[string, *highbase.SchemaProxy]) (*SchemaIRSet, error) {
+	r := g.run()
+	if schemas == nil {
+		return &SchemaIRSet{}, nil
+	}
+	irs, err := r.componentIRs(schemas)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaIRSet{
+		Schemas:     irs,
+		Diagnostics: append([]Diagnostic(nil), r.diagnostics...),
+	}, nil
+}
+
+func (g *Generator) componentIRs(schemas *orderedmap.Map[string, *highbase.SchemaProxy]) ([]*SchemaIR, error) {
+	g.typeNames = newTypeNameRegistry(g.validationMethods)
+	g.componentTypeNames = g.resolveComponentTypeNames(schemas)
+	irs := make([]*SchemaIR, 0, schemas.Len())
+	for name, schema := range schemas.FromOldest() {
+		ir, err := g.irFromOpenAPI(name, schema, name)
+		if err != nil {
+			return nil, err
+		}
+		irs = append(irs, ir)
+	}
+	return irs, nil
+}
+
 func (g *Generator) resolveComponentTypeNames(schemas *orderedmap.Map[string, *highbase.SchemaProxy]) map[string]string {
 	names := make(map[string]string)
 	if schemas == nil {
@@ -311,7 +353,6 @@ func (g *Generator) SchemasFromValues(values ...any) (*SchemaSet, error) {
 func (g *Generator) SchemasFromTypes(types ...reflect.Type) (*SchemaSet, error) {
 	r := g.run()
 	roots := orderedmap.New[string, *highbase.SchemaProxy]()
-	components := orderedmap.New[string, *highbase.SchemaProxy]()
 	var root *highbase.SchemaProxy
 	for i, t := range types {
 		if t == nil {
@@ -333,8 +374,21 @@ func (g *Generator) SchemasFromTypes(types ...reflect.Type) (*SchemaSet, error)
 		}
 		roots.Set(rootName, rootProxy)
 	}
-	irs := make([]*SchemaIR, 0, len(r.reflectCache))
-	for _, ir := range r.reflectCache {
+	components := r.reflectedComponents()
+	return &SchemaSet{
+		Root:        root,
+		Roots:       roots,
+		Components:  components,
+		Diagnostics: append([]Diagnostic(nil), r.diagnostics...),
+	}, nil
+}
+
+// reflectedComponents renders every named component discovered by the
+// reflection walks of this run. Proxies built afterwards reference these
+// components instead of inlining them.
+func (g *Generator) reflectedComponents() *orderedmap.Map[string, *highbase.SchemaProxy] {
+	irs := make([]*SchemaIR, 0, len(g.reflectCache))
+	for _, ir := range g.reflectCache {
 		if ir != nil && ir.Name != "" && isComponentKind(ir.Kind) {
 			irs = append(irs, ir)
 		}
@@ -344,21 +398,18 @@ func (g *Generator) SchemasFromTypes(types ...reflect.Type) (*SchemaSet, error)
 	for _, ir := range irs {
 		componentNames[ir.Name] = struct{}{}
 	}
-	r.componentNames = componentNames
+	g.componentNames = componentNames
+	components := orderedmap.New[string, *highbase.SchemaProxy]()
 	for _, ir := range irs {
 		if _, exists := components.Get(ir.Name); exists {
-			r.addDiagnostic(DiagnosticComponentNameCollision, ir.Name, "component name collision resolved by keeping first schema")
+			g.addDiagnostic(DiagnosticComponentNameCollision, ir.Name, "component name collision resolved by keeping first schema")
 			continue
 		}
-		r.currentComponent = ir.Name
-		components.Set(ir.Name, r.openapiFromIR(ir))
+		g.currentComponent = ir.Name
+		components.Set(ir.Name, g.openapiFromIR(ir))
 	}
-	return &SchemaSet{
-		Root:        root,
-		Roots:       roots,
-		Components:  components,
-		Diagnostics: append([]Diagnostic(nil), r.diagnostics...),
-	}, nil
+	g.currentComponent = ""
+	return components
 }
 
 func (g *Generator) rootProxy(ir *SchemaIR) *highbase.SchemaProxy {
@@ -757,3 +757,43 @@ func TestManualRenderSchemasNilAndFormatMapping(t *testing.T) {
 		t.Fatalf("unexpected empty file %q", file.Source)
 	}
 }
+
+func TestSchemaIRsSharesOpenAPIPath(t *testing.T) {
+	spec, err := os.ReadFile("testdata/train-travel.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := libopenapi.NewDocument(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := model.Model.Components.Schemas
+	set, err := NewGenerator().SchemaIRs(schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Schemas) != schemas.Len() {
+		t.Fatalf("expected %d schemas, got %d", schemas.Len(), len(set.Schemas))
+	}
+	file, err := NewGenerator().RenderSchemas(schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, typ := range file.Types {
+		if set.Schemas[i].Name != typ.Name || set.Schemas[i].Kind != typ.Kind {
+			t.Fatalf("IR %d = %s/%d, rendered type = %s/%d", i, set.Schemas[i].Name, set.Schemas[i].Kind, typ.Name, typ.Kind)
+		}
+	}
+	if len(set.Diagnostics) != len(file.Diagnostics) {
+		t.Fatalf("expected %d diagnostics, got %d", len(file.Diagnostics), len(set.Diagnostics))
+	}
+
+	empty, err := NewGenerator().SchemaIRs(nil)
+	if err != nil || len(empty.Schemas) != 0 {
+		t.Fatalf("unexpected nil schemas result: %#v %v", empty, err)
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// Operation describes one code-first operation registered with an
+// OperationBuilder. Params, Body, and response values are only inspected for
+// their types, so zero values, typed nil pointers, and reflect.Type values all
+// work.
+type Operation struct {
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	// Params is a struct whose fields tagged path, query, header, or cookie
+	// become parameters, for example `path:"id"` or `query:"limit,required"`.
+	// Path parameters are always required; other parameters are required only
+	// with the required option. Embedded structs are flattened.
+	Params any
+	// Body becomes an application/json request body.
+	Body any
+	// OptionalBody marks the request body as not required.
+	OptionalBody bool
+	// Responses maps status codes such as "200", "4XX", or "default" to
+	// application/json response bodies. A nil value documents a response
+	// without content.
+	Responses map[string]any
+}
+
+// OperationBuilder assembles an OpenAPI document from Go request and response
+// types. Every type reachable from the registered operations is walked in one
+// reflection run, so shared types become a single component schema referenced
+// from each operation.
+type OperationBuilder struct {
+	generator *Generator
+	routes    []operationRoute
+}
+
+// OperationDocument contains a document built by OperationBuilder.
+type OperationDocument struct {
+	Document *v3high.Document
+	// Diagnostics reports schema features that required a lossy or notable
+	// model-generation decision.
+	Diagnostics []Diagnostic
+}
+
+type operationRoute struct {
+	method string
+	path   string
+	op     Operation
+}
+
+type builtParam struct {
+	param *v3high.Parameter
+	ir    *SchemaIR
+}
+
+type builtResponse struct {
+	code string
+	ir   *SchemaIR
+}
+
+type builtOperation struct {
+	route     operationRoute
+	params    []builtParam
+	body      *SchemaIR
+	responses []builtResponse
+}
+
+var builderParamLocations = []string{paramInPath, paramInQuery, paramInHeader, paramInCookie}
+
+// NewOperationBuilder creates an operation builder. Options configure schema
+// reflection exactly as they do for SchemasFromTypes.
+func NewOperationBuilder(opts ...Option) *OperationBuilder {
+	return &OperationBuilder{generator: NewGenerator(opts...)}
+}
+
+// Add registers an operation for method and path. Paths keep registration
+// order in the built document. Problems with the route are reported by Build.
+func (b *OperationBuilder) Add(method, path string, op Operation) *OperationBuilder {
+	b.routes = append(b.routes, operationRoute{method: strings.ToUpper(method), path: path, op: op})
+	return b
+}
+
+// Build reflects every registered operation and returns an OpenAPI 3.1
+// document with info, paths, and the component schemas they reference.
+func (b *OperationBuilder) Build(info *highbase.Info) (*OperationDocument, error) {
+	r := b.generator.run()
+	seen := make(map[string]struct{}, len(b.routes))
+	ops := make([]*builtOperation, 0, len(b.routes))
+	for _, route := range b.routes {
+		label := route.method + " " + route.path
+		if _, ok := seen[label]; ok {
+			return nil, wrapPath(fmt.Errorf("%w: duplicate route", ErrInvalidOperation), label)
+		}
+		seen[label] = struct{}{}
+		op, err := r.reflectOperation(route, label)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	components := r.reflectedComponents()
+
+	paths := orderedmap.New[string, *v3high.PathItem]()
+	for _, op := range ops {
+		item, ok := paths.Get(op.route.path)
+		if !ok {
+			item = &v3high.PathItem{}
+			paths.Set(op.route.path, item)
+		}
+		setPathItemOperation(item, op.route.method, r.buildOperation(op))
+	}
+	doc := &v3high.Document{
+		Version: "3.1.0",
+		Info:    info,
+		Paths:   &v3high.Paths{PathItems: paths},
+	}
+	if components.Len() > 0 {
+		doc.Components = &v3high.Components{Schemas: components}
+	}
+	return &OperationDocument{
+		Document:    doc,
+		Diagnostics: append([]Diagnostic(nil), r.diagnostics...),
+	}, nil
+}
+
+// reflectOperation validates a route and walks its parameter, body, and
+// response types. Proxies are built later, once every component is known.
+func (g *Generator) reflectOperation(route operationRoute, label string) (*builtOperation, error) {
+	if !isBuilderMethod(route.method) {
+		return nil, wrapPath(fmt.Errorf("%w: unsupported method", ErrInvalidOperation), label)
+	}
+	if !strings.HasPrefix(route.path, "/") {
+		return nil, wrapPath(fmt.Errorf("%w: path must start with /", ErrInvalidOperation), label)
+	}
+	if len(route.op.Responses) == 0 {
+		return nil, wrapPath(fmt.Errorf("%w: no responses", ErrInvalidOperation), label)
+	}
+	op := &builtOperation{route: route}
+	if t := builderType(route.op.Params); t != nil {
+		t = derefType(t)
+		if t.Kind() != reflect.Struct {
+			return nil, wrapPath(fmt.Errorf("%w: params must be a struct, got %s", ErrInvalidOperation, t), label)
+		}
+		params, err := g.reflectParams(t, g.publicName(typeName(t)), label)
+		if err != nil {
+			return nil, err
+		}
+		op.params = params
+	}
+	if err := checkBuilderPathParams(route.path, op.params); err != nil {
+		return nil, wrapPath(err, label)
+	}
+	if t := builderType(route.op.Body); t != nil {
+		ir, err := g.reflectBuilderRoot(t)
+		if err != nil {
+			return nil, err
+		}
+		op.body = ir
+	}
+	codes := make([]string, 0, len(route.op.Responses))
+	for code := range route.op.Responses {
+		if !isResponseCode(code) {
+			return nil, wrapPath(fmt.Errorf("%w: invalid response code %q", ErrInvalidOperation, code), label)
+		}
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i] == "default" || codes[j] == "default" {
+			return codes[j] == "default" && codes[i] != "default"
+		}
+		return codes[i] < codes[j]
+	})
+	for _, code := range codes {
+		response := builtResponse{code: code}
+		if t := builderType(route.op.Responses[code]); t != nil {
+			ir, err := g.reflectBuilderRoot(t)
+			if err != nil {
+				return nil, err
+			}
+			response.ir = ir
+		}
+		op.responses = append(op.responses, response)
+	}
+	return op, nil
+}
+
+// reflectBuilderRoot walks a body or response type. Pointers only carry the
+// type, so they do not make the schema nullable.
+func (g *Generator) reflectBuilderRoot(t reflect.Type) (*SchemaIR, error) {
+	t = derefType(t)
+	return g.irFromReflect(t, typeName(t), typeName(t))
+}
+
+// reflectParams collects the tagged parameter fields of a params struct.
+func (g *Generator) reflectParams(t reflect.Type, owner, label string) ([]builtParam, error) {
+	var params []builtParam
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		in, value, err := paramTag(field)
+		if err != nil {
+			return nil, wrapPath(err, label+" "+owner+"."+field.Name)
+		}
+		if in == "" {
+			if embedded := derefType(field.Type); field.Anonymous && embedded.Kind() == reflect.Struct {
+				nested, err := g.reflectParams(embedded, owner, label)
+				if err != nil {
+					return nil, err
+				}
+				params = append(params, nested...)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		parts := strings.Split(value, ",")
+		name := parts[0]
+		if name == "" {
+			name = field.Name
+		}
+		required := in == paramInPath
+		for _, opt := range parts[1:] {
+			if opt == "required" {
+				required = true
+			}
+		}
+		field.Type = derefType(field.Type)
+		tag := fieldTag{name: name, openapi: parseOpenAPITag(field.Tag.Get("openapi"))}
+		ir, err := g.irFromReflectField(t, field, tag, g.nestedTypeName(owner, name), label+" "+owner+"."+field.Name)
+		if err != nil {
+			return nil, err
+		}
+		description := tag.openapi.Description
+		tag.openapi.DescriptionSet = false
+		if tag.openapi.Present {
+			ir = cloneIR(ir)
+		}
+		g.applyOpenAPIMetadata(ir, tag.openapi)
+		params = append(params, builtParam{
+			param: &v3high.Parameter{
+				Name:        name,
+				In:          in,
+				Description: description,
+				Required:    &required,
+				Deprecated:  tag.openapi.Deprecated,
+			},
+			ir: ir,
+		})
+	}
+	return params, nil
+}
+
+// paramTag returns the parameter location and tag value of a field, or an
+// empty location when the field is not a parameter.
+func paramTag(field reflect.StructField) (string, string, error) {
+	var in, value string
+	for _, location := range builderParamLocations {
+		if tag, ok := field.Tag.Lookup(location); ok {
+			if in != "" {
+				return "", "", fmt.Errorf("%w: field has both %s and %s tags", ErrInvalidOperation, in, location)
+			}
+			in, value = location, tag
+		}
+	}
+	return in, value, nil
+}
+
+// checkBuilderPathParams requires every path template variable to have a path
+// parameter field and every path parameter field to appear in the template.
+func checkBuilderPathParams(path string, params []builtParam) error {
+	declared := make(map[string]struct{})
+	for _, p := range params {
+		if p.param.In == paramInPath {
+			declared[p.param.Name] = struct{}{}
+		}
+	}
+	used := make(map[string]struct{})
+	for _, segment := range splitPathTemplate(path) {
+		if !segment.param {
+			continue
+		}
+		used[segment.value] = struct{}{}
+		if _, ok := declared[segment.value]; !ok {
+			return fmt.Errorf("%w: path template variable %s has no path parameter field", ErrInvalidOperation, segment.value)
+		}
+	}
+	for _, p := range params {
+		if _, ok := used[p.param.Name]; p.param.In == paramInPath && !ok {
+			return fmt.Errorf("%w: path parameter %s does not appear in the path template", ErrInvalidOperation, p.param.Name)
+		}
+	}
+	return nil
+}
+
+// buildOperation renders the operation with schema proxies that reference the
+// components of this run.
+func (g *Generator) buildOperation(op *builtOperation) *v3high.Operation {
+	spec := op.route.op
+	operation := &v3high.Operation{
+		Tags:        append([]string(nil), spec.Tags...),
+		Summary:     spec.Summary,
+		Description: spec.Description,
+		OperationId: spec.OperationID,
+	}
+	if spec.Deprecated {
+		deprecated := true
+		operation.Deprecated = &deprecated
+	}
+	for _, p := range op.params {
+		param := *p.param
+		param.Schema = g.openapiFromIR(p.ir)
+		operation.Parameters = append(operation.Parameters, &param)
+	}
+	if op.body != nil {
+		required := !spec.OptionalBody
+		operation.RequestBody = &v3high.RequestBody{
+			Content:  jsonContent(g.rootProxy(op.body)),
+			Required: &required,
+		}
+	}
+	responses := &v3high.Responses{Codes: orderedmap.New[string, *v3high.Response]()}
+	for _, r := range op.responses {
+		response := &v3high.Response{Description: responseDescription(r.code)}
+		if r.ir != nil {
+			response.Content = jsonContent(g.rootProxy(r.ir))
+		}
+		if r.code == "default" {
+			responses.Default = response
+			continue
+		}
+		responses.Codes.Set(r.code, response)
+	}
+	operation.Responses = responses
+	return operation
+}
+
+func jsonContent(schema *highbase.SchemaProxy) *orderedmap.Map[string, *v3high.MediaType] {
+	content := orderedmap.New[string, *v3high.MediaType]()
+	content.Set("application/json", &v3high.MediaType{Schema: schema})
+	return content
+}
+
+func builderType(value any) reflect.Type {
+	if t, ok := value.(reflect.Type); ok {
+		return t
+	}
+	return reflect.TypeOf(value)
+}
+
+func isBuilderMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+		http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func setPathItemOperation(item *v3high.PathItem, method string, op *v3high.Operation) {
+	switch method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodDelete:
+		item.Delete = op
+	case http.MethodOptions:
+		item.Options = op
+	case http.MethodHead:
+		item.Head = op
+	case http.MethodPatch:
+		item.Patch = op
+	case http.MethodTrace:
+		item.Trace = op
+	}
+}
+
+// isResponseCode accepts "default", status codes from 100 to 599, and the
+// ranges 1XX through 5XX.
+func isResponseCode(code string) bool {
+	if code == "default" {
+		return true
+	}
+	if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return false
+	}
+	if code[1:] == "XX" {
+		return true
+	}
+	_, err := strconv.Atoi(code)
+	return err == nil
+}
+
+func responseDescription(code string) string {
+	if code == "default" {
+		return "Default response"
+	}
+	if status, err := strconv.Atoi(code); err == nil {
+		if text := http.StatusText(status); text != "" {
+			return text
+		}
+	}
+	return code + " response"
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+type builderPet struct {
+	ID    int64         `json:"id"`
+	Name  string        `json:"name" openapi:"minLength=1"`
+	Owner *builderOwner `json:"owner,omitempty"`
+}
+
+type builderOwner struct {
+	Email string `json:"email" openapi:"format=email"`
+}
+
+type builderProblem struct {
+	Message string `json:"message"`
+}
+
+type builderPaging struct {
+	Limit  int    `query:"limit" openapi:"minimum=1;maximum=100"`
+	Cursor string `query:"cursor"`
+}
+
+type builderListPets struct {
+	builderPaging
+	RequestID string `header:"X-Request-ID,required" openapi:"description=Correlates logs"`
+	Session   string `cookie:"session"`
+	Ignored   string `json:"ignored"`
+}
+
+type builderGetPet struct {
+	ID int64 `path:"petId"`
+}
+
+func TestOperationBuilderGolden(t *testing.T) {
+	built := buildPetsDocument(t)
+	rendered, err := built.Document.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, "testdata/operation_builder.golden.yaml", rendered)
+}
+
+func TestOperationBuilderDocumentIsValid(t *testing.T) {
+	built := buildPetsDocument(t)
+	rendered, err := built.Document.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := libopenapi.NewDocument(rendered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := model.Model.Components.Schemas
+	var names []string
+	for name := range schemas.KeysFromOldest() {
+		names = append(names, name)
+	}
+	if got := strings.Join(names, ","); got != "BuilderOwner,BuilderPet,BuilderProblem" {
+		t.Fatalf("unexpected components %s", got)
+	}
+	list := model.Model.Paths.PathItems.GetOrZero("/pets").Get
+	if len(list.Parameters) != 4 {
+		t.Fatalf("unexpected parameters %d", len(list.Parameters))
+	}
+	header := list.Parameters[2]
+	if header.Name != "X-Request-ID" || header.In != "header" || !*header.Required || header.Description != "Correlates logs" {
+		t.Fatalf("unexpected header parameter %#v", header)
+	}
+	if *list.Parameters[0].Required {
+		t.Fatal("query parameters are optional without the required option")
+	}
+	items := list.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema().Items.A
+	if items.GetReference() != "#/components/schemas/BuilderPet" {
+		t.Fatalf("unexpected list item schema %q", items.GetReference())
+	}
+	get := model.Model.Paths.PathItems.GetOrZero("/pets/{petId}").Get
+	if !*get.Parameters[0].Required || get.Parameters[0].In != "path" {
+		t.Fatalf("unexpected path parameter %#v", get.Parameters[0])
+	}
+	if get.Responses.Default.GetReference() != "" || get.Responses.Default.Description != "Default response" {
+		t.Fatalf("unexpected default response %#v", get.Responses.Default)
+	}
+	if _, err := RenderServer(&model.Model); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOperationBuilderRoundTripsThroughClient(t *testing.T) {
+	built := buildPetsDocument(t)
+	rendered, err := built.Document.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := RenderClient(mustBuildV3(t, string(rendered)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, string(client.Client.Source), "func (c *Client) CreatePet(ctx context.Context, body BuilderPet, ")
+	assertContains(t, string(client.Client.Source), "func (c *Client) GetPet(ctx context.Context, params GetPetParams, ")
+	assertParsesCompilesAndTestsWithFiles(t, map[string][]byte{
+		"models.go": client.Models.Source,
+		"client.go": client.Client.Source,
+	}, `package models
+
+import "testing"
+
+func TestParams(t *testing.T) {
+	_ = ListPetsParams{XRequestID: "abc", Limit: new(int)}
+	_ = GetPetParams{PetID: 1}
+}
+`)
+}
+
+func TestOperationBuilderErrors(t *testing.T) {
+	ok := map[string]any{"204": nil}
+	cases := []struct {
+		name    string
+		builder *OperationBuilder
+		want    string
+	}{
+		{
+			name:    "method",
+			builder: NewOperationBuilder().Add("FETCH", "/pets", Operation{Responses: ok}),
+			want:    "unsupported method at FETCH /pets",
+		},
+		{
+			name:    "path",
+			builder: NewOperationBuilder().Add("get", "pets", Operation{Responses: ok}),
+			want:    "path must start with / at GET pets",
+		},
+		{
+			name:    "duplicate",
+			builder: NewOperationBuilder().Add("GET", "/pets", Operation{Responses: ok}).Add("get", "/pets", Operation{Responses: ok}),
+			want:    "duplicate route at GET /pets",
+		},
+		{
+			name:    "responses",
+			builder: NewOperationBuilder().Add("GET", "/pets", Operation{}),
+			want:    "no responses at GET /pets",
+		},
+		{
+			name:    "response code",
+			builder: NewOperationBuilder().Add("GET", "/pets", Operation{Responses: map[string]any{"600": nil}}),
+			want:    `invalid response code "600" at GET /pets`,
+		},
+		{
+			name:    "params kind",
+			builder: NewOperationBuilder().Add("GET", "/pets", Operation{Params: "", Responses: ok}),
+			want:    "params must be a struct, got string at GET /pets",
+		},
+		{
+			name:    "undeclared template variable",
+			builder: NewOperationBuilder().Add("GET", "/pets/{id}", Operation{Responses: ok}),
+			want:    "path template variable id has no path parameter field at GET /pets/{id}",
+		},
+		{
+			name:    "unused path parameter",
+			builder: NewOperationBuilder().Add("GET", "/pets", Operation{Params: builderGetPet{}, Responses: ok}),
+			want:    "path parameter petId does not appear in the path template at GET /pets",
+		},
+		{
+			name: "two locations",
+			builder: NewOperationBuilder().Add("GET", "/pets", Operation{Params: struct {
+				Limit int `query:"limit" header:"limit"`
+			}{}, Responses: ok}),
+			want: "field has both query and header tags at GET /pets Struct.Limit",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.Build(&highbase.Info{Title: "Pets", Version: "1.0.0"})
+			if !errors.Is(err, ErrInvalidOperation) {
+				t.Fatalf("expected ErrInvalidOperation, got %v", err)
+			}
+			if !strings.HasSuffix(err.Error(), tc.want) {
+				t.Fatalf("unexpected error %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestOperationBuilderTypesAndOptions(t *testing.T) {
+	built, err := NewOperationBuilder(WithNameResolver(func(name string) string { return name })).
+		Add("POST", "/owners", Operation{
+			Body:         reflect.TypeOf(builderOwner{}),
+			OptionalBody: true,
+			Responses:    map[string]any{"201": reflect.TypeOf(&builderOwner{}), "4XX": []builderProblem{}},
+		}).
+		Build(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	op := built.Document.Paths.PathItems.GetOrZero("/owners").Post
+	if *op.RequestBody.Required {
+		t.Fatal("expected optional request body")
+	}
+	created := op.Responses.Codes.GetOrZero("201")
+	if created.Description != "Created" || created.Content.GetOrZero("application/json").Schema.GetReference() != "#/components/schemas/builderOwner" {
+		t.Fatalf("unexpected created response %#v", created)
+	}
+	problems := op.Responses.Codes.GetOrZero("4XX")
+	if problems.Description != "4XX response" {
+		t.Fatalf("unexpected range description %q", problems.Description)
+	}
+	if got := problems.Content.GetOrZero("application/json").Schema.Schema().Items.A.GetReference(); got != "#/components/schemas/builderProblem" {
+		t.Fatalf("unexpected range schema %q", got)
+	}
+}
+
+func buildPetsDocument(t *testing.T) *OperationDocument {
+	t.Helper()
+	built, err := NewOperationBuilder().
+		Add("GET", "/pets", Operation{
+			OperationID: "listPets",
+			Summary:     "List pets",
+			Tags:        []string{"pets"},
+			Params:      builderListPets{},
+			Responses: map[string]any{
+				"200":     []builderPet{},
+				"default": builderProblem{},
+			},
+		}).
+		Add("POST", "/pets", Operation{
+			OperationID: "createPet",
+			Tags:        []string{"pets"},
+			Body:        (*builderPet)(nil),
+			Responses: map[string]any{
+				"201": builderPet{},
+				"400": builderProblem{},
+			},
+		}).
+		Add("get", "/pets/{petId}", Operation{
+			OperationID: "getPet",
+			Deprecated:  true,
+			Params:      builderGetPet{},
+			Responses: map[string]any{
+				"default": builderProblem{},
+				"200":     &builderPet{},
+				"404":     nil,
+			},
+		}).
+		Build(&highbase.Info{Title: "Pets", Version: "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(built.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics %#v", built.Diagnostics)
+	}
+	return built
+}
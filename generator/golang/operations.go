@@ -0,0 +1,419 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"strings"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// operationIR is the Go view of one OpenAPI operation shared by client and
+// server generation.
+type operationIR struct {
+	Name        string
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Description string
+	Deprecated  bool
+	Params      []*paramIR
+	Body        *bodyIR
+	Responses   []*responseIR
+	ParamsType  string
+	ResultType  string
+	// RequestType and ServerResultType are the server request and result
+	// types. They are only set when rendering a server.
+	RequestType      string
+	ServerResultType string
+}
+
+// paramIR is one operation parameter rendered as a field of the operation's
+// params struct.
+type paramIR struct {
+	Name        string
+	In          string
+	Field       string
+	Type        string
+	Description string
+	Required    bool
+	Style       string
+	Explode     bool
+	// JSON reports a parameter declared with content instead of schema, which
+	// is serialized as a JSON string.
+	JSON   bool
+	schema *highbase.SchemaProxy
+}
+
+// bodyIR is an operation request body.
+type bodyIR struct {
+	ContentType string
+	Type        string
+	Required    bool
+	// JSON reports a JSON media type with a typed Go value. Other media types
+	// are passed through as an io.Reader.
+	JSON   bool
+	schema *highbase.SchemaProxy
+}
+
+// responseIR is one documented response status.
+type responseIR struct {
+	// Code is the status code, a range such as 2XX, or default.
+	Code        string
+	Field       string
+	Type        string
+	ContentType string
+	Description string
+	schema      *highbase.SchemaProxy
+}
+
+// operationSchema is an inline operation schema that has no Go spelling of its
+// own and is rendered as a model under a synthesized component name.
+type operationSchema struct {
+	key    string
+	schema *highbase.SchemaProxy
+}
+
+const (
+	paramInPath   = "path"
+	paramInQuery  = "query"
+	paramInHeader = "header"
+	paramInCookie = "cookie"
+)
+
+// collectOperations walks the document paths in source order and builds the
+// operation IR without Go types. Inline schemas that need a declaration are
+// returned so they can be rendered with the component models.
+func (g *Generator) collectOperations(doc *v3high.Document) ([]*operationIR, []operationSchema) {
+	if doc == nil || doc.Paths == nil || doc.Paths.PathItems == nil {
+		return nil, nil
+	}
+	names := newNameRegistry()
+	var ops []*operationIR
+	var synthesized []operationSchema
+	for path, item := range doc.Paths.PathItems.FromOldest() {
+		if item == nil {
+			continue
+		}
+		for method, op := range item.GetOperations().FromOldest() {
+			if op == nil {
+				continue
+			}
+			label := strings.ToUpper(method) + " " + path
+			seed := op.OperationId
+			if seed == "" {
+				seed = strings.ToLower(method) + " " + path
+				g.addDiagnostic(DiagnosticMissingOperationID, label, "operation has no operationId; method name derived from method and path")
+			}
+			name, collision := names.resolve(label, g.publicName(seed))
+			if collision {
+				g.addDiagnostic(DiagnosticOperationNameCollision, label, "operation name collision resolved as "+name)
+			}
+			operation := &operationIR{
+				Name:        name,
+				Method:      strings.ToUpper(method),
+				Path:        path,
+				OperationID: op.OperationId,
+				Summary:     op.Summary,
+				Description: op.Description,
+				Deprecated:  op.Deprecated != nil && *op.Deprecated,
+			}
+			synthesized = append(synthesized, g.collectParams(operation, item.Parameters, op.Parameters, label)...)
+			synthesized = append(synthesized, g.collectBody(operation, op.RequestBody, label)...)
+			synthesized = append(synthesized, g.collectResponses(operation, op.Responses)...)
+			g.checkPathParams(operation, label)
+			ops = append(ops, operation)
+		}
+	}
+	return ops, synthesized
+}
+
+// collectParams merges path-level and operation-level parameters. Operation
+// parameters override path parameters with the same name and location.
+func (g *Generator) collectParams(op *operationIR, shared, own []*v3high.Parameter, label string) []operationSchema {
+	merged := orderedmap.New[string, *v3high.Parameter]()
+	for _, params := range [][]*v3high.Parameter{shared, own} {
+		for _, param := range params {
+			if param == nil || param.Name == "" {
+				continue
+			}
+			merged.Set(param.In+":"+param.Name, param)
+		}
+	}
+	fields := newNameRegistry()
+	var synthesized []operationSchema
+	for _, param := range merged.FromOldest() {
+		switch param.In {
+		case paramInPath, paramInQuery, paramInHeader, paramInCookie:
+		default:
+			g.addDiagnostic(DiagnosticUnsupportedParameter, label+" "+param.Name, "parameter location "+param.In+" is not supported and was skipped")
+			continue
+		}
+		field, collision := fields.resolve(param.In+":"+param.Name, g.fieldName(param.Name))
+		if collision {
+			g.addDiagnostic(DiagnosticFieldNameCollision, label+" "+param.Name, "parameter field name collision resolved as "+field)
+		}
+		p := &paramIR{
+			Name:        param.Name,
+			In:          param.In,
+			Field:       field,
+			Description: param.Description,
+			Required:    param.In == paramInPath || (param.Required != nil && *param.Required),
+			Style:       paramStyle(param),
+			schema:      param.Schema,
+		}
+		p.Explode = p.Style == "form"
+		if param.Explode != nil {
+			p.Explode = *param.Explode
+		}
+		if p.schema == nil && param.Content != nil {
+			for _, media := range param.Content.FromOldest() {
+				if media != nil {
+					p.schema = media.Schema
+					p.JSON = true
+				}
+				break
+			}
+		}
+		if schema, ok := g.operationSchema(p.schema, op.Name+"_"+param.Name); ok {
+			synthesized = append(synthesized, schema)
+		}
+		op.Params = append(op.Params, p)
+	}
+	return synthesized
+}
+
+func paramStyle(param *v3high.Parameter) string {
+	if param.Style != "" {
+		return param.Style
+	}
+	switch param.In {
+	case paramInQuery, paramInCookie:
+		return "form"
+	default:
+		return "simple"
+	}
+}
+
+func (g *Generator) collectBody(op *operationIR, body *v3high.RequestBody, label string) []operationSchema {
+	if body == nil || body.Content == nil || body.Content.Len() == 0 {
+		return nil
+	}
+	contentType, media := selectMediaType(body.Content)
+	op.Body = &bodyIR{
+		ContentType: contentType,
+		Required:    body.Required != nil && *body.Required,
+		JSON:        isJSONMediaType(contentType) && media != nil && media.Schema != nil,
+	}
+	if !op.Body.JSON {
+		op.Body.Type = "io.Reader"
+		g.addDiagnostic(DiagnosticRawRequestBody, label, "request body "+contentType+" is not JSON and is passed through as an io.Reader")
+		return nil
+	}
+	op.Body.schema = media.Schema
+	if schema, ok := g.operationSchema(media.Schema, op.Name+"_Request"); ok {
+		return []operationSchema{schema}
+	}
+	return nil
+}
+
+func (g *Generator) collectResponses(op *operationIR, responses *v3high.Responses) []operationSchema {
+	if responses == nil {
+		return nil
+	}
+	var synthesized []operationSchema
+	add := func(code string, response *v3high.Response) {
+		if response == nil {
+			return
+		}
+		r := &responseIR{Code: code, Description: response.Description}
+		if response.Content != nil && response.Content.Len() > 0 {
+			contentType, media := selectMediaType(response.Content)
+			r.ContentType = contentType
+			if isJSONMediaType(contentType) && media != nil && media.Schema != nil {
+				r.schema = media.Schema
+				r.Field = "JSON" + code
+				if schema, ok := g.operationSchema(media.Schema, op.Name+"_"+code+"_Response"); ok {
+					synthesized = append(synthesized, schema)
+				}
+			}
+		}
+		op.Responses = append(op.Responses, r)
+	}
+	if responses.Codes != nil {
+		for code, response := range responses.Codes.FromOldest() {
+			add(strings.ToUpper(code), response)
+		}
+	}
+	add("Default", responses.Default)
+	return synthesized
+}
+
+// checkPathParams reports template variables without a matching path parameter
+// and path parameters that do not appear in the template.
+func (g *Generator) checkPathParams(op *operationIR, label string) {
+	declared := make(map[string]struct{})
+	for _, param := range op.Params {
+		if param.In == paramInPath {
+			declared[param.Name] = struct{}{}
+		}
+	}
+	used := make(map[string]struct{})
+	for _, segment := range splitPathTemplate(op.Path) {
+		if !segment.param {
+			continue
+		}
+		used[segment.value] = struct{}{}
+		if _, ok := declared[segment.value]; !ok {
+			g.addDiagnostic(DiagnosticUndeclaredPathParameter, label, "path template variable "+segment.value+" has no path parameter and is sent literally")
+		}
+	}
+	for _, param := range op.Params {
+		if _, ok := used[param.Name]; param.In == paramInPath && !ok {
+			g.addDiagnostic(DiagnosticUndeclaredPathParameter, label, "path parameter "+param.Name+" does not appear in the path template")
+		}
+	}
+}
+
+// operationSchema decides whether an inline schema needs a generated model.
+// References and shapes spelled with built-in Go types are used directly.
+func (g *Generator) operationSchema(proxy *highbase.SchemaProxy, key string) (operationSchema, bool) {
+	if proxy == nil || proxy.IsReference() {
+		return operationSchema{}, false
+	}
+	if !schemaNeedsDeclaration(proxy.Schema()) {
+		return operationSchema{}, false
+	}
+	return operationSchema{key: key, schema: proxy}, true
+}
+
+// schemaNeedsDeclaration reports whether an inline schema renders as a named Go
+// type: an object with properties, a composition, an enum, or a collection of
+// one of those.
+func schemaNeedsDeclaration(schema *highbase.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if (schema.Properties != nil && schema.Properties.Len() > 0) ||
+		len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 ||
+		len(schema.Enum) > 0 || len(nonNullTypes(schema.Type)) > 1 {
+		return true
+	}
+	if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil && !schema.Items.A.IsReference() {
+		if schemaNeedsDeclaration(schema.Items.A.Schema()) {
+			return true
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() && schema.AdditionalProperties.A != nil && !schema.AdditionalProperties.A.IsReference() {
+		return schemaNeedsDeclaration(schema.AdditionalProperties.A.Schema())
+	}
+	return false
+}
+
+// selectMediaType prefers the first JSON media type and falls back to the first
+// declared media type.
+func selectMediaType(content *orderedmap.Map[string, *v3high.MediaType]) (string, *v3high.MediaType) {
+	var firstType string
+	var first *v3high.MediaType
+	for contentType, media := range content.FromOldest() {
+		if isJSONMediaType(contentType) {
+			return contentType, media
+		}
+		if firstType == "" {
+			firstType, first = contentType, media
+		}
+	}
+	return firstType, first
+}
+
+func isJSONMediaType(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+type pathSegment struct {
+	value string
+	param bool
+}
+
+// splitPathTemplate splits an OpenAPI path template into literal and variable
+// segments.
+func splitPathTemplate(path string) []pathSegment {
+	var segments []pathSegment
+	for path != "" {
+		open := strings.IndexByte(path, '{')
+		if open < 0 {
+			segments = append(segments, pathSegment{value: path})
+			break
+		}
+		end := strings.IndexByte(path[open:], '}')
+		if end < 0 {
+			segments = append(segments, pathSegment{value: path})
+			break
+		}
+		if open > 0 {
+			segments = append(segments, pathSegment{value: path[:open]})
+		}
+		segments = append(segments, pathSegment{value: path[open+1 : open+end], param: true})
+		path = path[open+end+1:]
+	}
+	return segments
+}
+
+// operationModels returns the component schemas followed by the synthesized
+// operation schemas, in the order RenderSchemas renders them.
+func operationModels(doc *v3high.Document, synthesized []operationSchema) *orderedmap.Map[string, *highbase.SchemaProxy] {
+	models := orderedmap.New[string, *highbase.SchemaProxy]()
+	if doc != nil && doc.Components != nil && doc.Components.Schemas != nil {
+		for name, schema := range doc.Components.Schemas.FromOldest() {
+			models.Set(name, schema)
+		}
+	}
+	for _, schema := range synthesized {
+		models.Set(schema.key, schema.schema)
+	}
+	return models
+}
+
+// resolveOperationTypes assigns Go types to operation parameters, bodies, and
+// responses. It runs after the models are rendered so synthesized schemas and
+// union components use the names RenderSchemas chose.
+func (g *Generator) resolveOperationTypes(ops []*operationIR, models *orderedmap.Map[string, *highbase.SchemaProxy], file *GeneratedFile, synthesized []operationSchema) {
+	g.typeNames = newTypeNameRegistry(g.validationMethods)
+	g.componentTypeNames = g.resolveComponentTypeNames(models)
+	g.componentKinds = make(map[string]Kind, len(file.Types))
+	for _, typ := range file.Types {
+		g.componentKinds[typ.Name] = typ.Kind
+	}
+	synthesizedNames := make(map[*highbase.SchemaProxy]string, len(synthesized))
+	for _, schema := range synthesized {
+		synthesizedNames[schema.schema] = g.componentTypeNames[schema.key]
+	}
+	typeOf := func(proxy *highbase.SchemaProxy, required, field bool, path string) string {
+		if proxy == nil {
+			return "any"
+		}
+		if name := synthesizedNames[proxy]; name != "" {
+			return g.goType(&SchemaIR{Name: name, Kind: KindRef}, required, field)
+		}
+		ir := g.childIR("", proxy, path)
+		return g.goType(ir, required, field)
+	}
+	for _, op := range ops {
+		for _, param := range op.Params {
+			param.Type = typeOf(param.schema, param.Required, true, op.Name+"."+param.Name)
+		}
+		if op.Body != nil && op.Body.JSON {
+			op.Body.Type = typeOf(op.Body.schema, true, false, op.Name+".requestBody")
+		}
+		for _, response := range op.Responses {
+			if response.schema != nil {
+				response.Type = typeOf(response.schema, true, false, op.Name+"."+response.Code)
+			}
+		}
+	}
+}
@@ -58,6 +58,14 @@ const (
 	DiagnosticRootNameCollision          = "rootNameCollision"
 	DiagnosticUnevaluatedProperties      = "unevaluatedProperties"
 	DiagnosticValidationKeyword          = "validationKeyword"
+
+	DiagnosticMissingOperationID      = "missingOperationId"
+	DiagnosticOperationNameCollision  = "operationNameCollision"
+	DiagnosticRawRequestBody          = "rawRequestBody"
+	DiagnosticUndeclaredPathParameter = "undeclaredPathParameter"
+	DiagnosticUnsupportedParameter    = "unsupportedParameter"
+
+	DiagnosticUnsupportedPattern = "unsupportedPattern"
 )
 
 type formatMapping struct {
@@ -213,6 +221,16 @@ func WithOptionalConstDiscriminatorUnions(enabled bool) Option {
 	}
 }
 
+// WithUnionVariantDecoding controls whether json.RawMessage union wrappers also
+// decode into a typed Value, and whether raw and typed unions get As accessor
+// methods. A discriminator property selects the variant when present; otherwise
+// variants are tried in order with strict decoding and the first match wins.
+func WithUnionVariantDecoding(enabled bool) Option {
+	return func(g *Generator) {
+		g.unionVariantDecoding = enabled
+	}
+}
+
 // WithAdditionalPropertiesMethods controls whether schema-valued
 // additionalProperties generates JSON marshal/unmarshal methods that round-trip
 // unknown fields through the AdditionalProperties map.
@@ -222,6 +240,17 @@ func WithAdditionalPropertiesMethods(enabled bool) Option {
 	}
 }
 
+// WithValidationMethods controls whether generated types get a Validate method
+// that checks required fields, string lengths and patterns, numeric bounds,
+// enum membership, and array item counts and uniqueness, recursing into nested
+// and union types. Violations are aggregated as ValidationErrors whose paths
+// are JSON pointers built from the schema property names.
+func WithValidationMethods(enabled bool) Option {
+	return func(g *Generator) {
+		g.validationMethods = enabled
+	}
+}
+
 // WithNestedTypeNameDelimiter sets the separator inserted between generated
 // parent and child type names for inline schemas. The default is "_"; passing
 // an empty delimiter restores compact names like ParentChild.
@@ -49,7 +49,7 @@ func TestApplySchemaFidelityCoversSchemaFields(t *testing.T) {
 		"PatternProperties", "PrefixItems", "AdditionalProperties", "Required",
 		"Enum", "Const", "Nullable", "Items",
 		// Navigation only, no schema content.
-		"ParentProxy",
+		"ParentProxy", "DynamicRefTarget",
 	)
 
 	src := &highbase.Schema{}
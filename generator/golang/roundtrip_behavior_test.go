@@ -230,3 +230,201 @@ func TestRawUnionAndNullableEnumJSON(t *testing.T) {
 }
 `)
 }
+
+func TestGeneratedBehaviorUnionVariantDecodingJSON(t *testing.T) {
+	model := mustBuildV3(t, `openapi: 3.1.0
+info:
+  title: Unions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Card:
+      type: object
+      required: [number]
+      properties:
+        number:
+          type: string
+    Bank:
+      type: object
+      required: [iban]
+      properties:
+        iban:
+          type: string
+    Payment:
+      oneOf:
+        - $ref: '#/components/schemas/Card'
+        - $ref: '#/components/schemas/Bank'
+        - type: object
+          title: Voucher
+          properties:
+            code:
+              type: string
+    Amount:
+      anyOf:
+        - type: integer
+        - type: number
+        - type: string
+        - type: array
+          items:
+            type: string
+    Shape:
+      oneOf:
+        - type: object
+          title: Circle
+          properties:
+            kind:
+              const: circle
+            radius:
+              type: number
+        - type: object
+          title: Square
+          properties:
+            kind:
+              const: square
+            side:
+              type: number
+    Cat:
+      type: object
+      required: [kind, name]
+      properties:
+        kind:
+          type: string
+        name:
+          type: string
+    Dog:
+      type: object
+      required: [kind, bark]
+      properties:
+        kind:
+          type: string
+        bark:
+          type: boolean
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: '#/components/schemas/Cat'
+          dog: '#/components/schemas/Dog'
+    Holder:
+      type: object
+      properties:
+        value:
+          type: [string, integer, 'null']
+`)
+	file, err := NewGenerator(WithUnionVariantDecoding(true)).RenderSchemas(model.Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParsesCompilesAndTests(t, file.Source, `package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnionVariantDecoding(t *testing.T) {
+	var payment PaymentUnion
+	if err := json.Unmarshal([]byte(`+"`"+`{"iban":"DE00"}`+"`"+`), &payment); err != nil {
+		t.Fatal(err)
+	}
+	if bank, ok := payment.AsBank(); !ok || bank.IBAN != "DE00" || string(payment.Bytes()) != `+"`"+`{"iban":"DE00"}`+"`"+` {
+		t.Fatalf("unexpected bank payment: %#v", payment)
+	}
+	if err := json.Unmarshal([]byte(`+"`"+`{"code":"X1"}`+"`"+`), &payment); err != nil {
+		t.Fatal(err)
+	}
+	if voucher, ok := payment.AsVoucher(); !ok || *voucher.Code != "X1" {
+		t.Fatalf("unexpected voucher payment: %#v", payment.Value)
+	}
+	if _, ok := payment.AsCard(); ok {
+		t.Fatal("AsCard should not match a voucher")
+	}
+	if err := json.Unmarshal([]byte(`+"`"+`"card"`+"`"+`), &payment); err == nil || err.Error() != "PaymentUnion: data does not match any oneOf variant" {
+		t.Fatalf("expected variant mismatch, got %v", err)
+	}
+
+	for data, want := range map[string]string{`+"`"+`3`+"`"+`: "int", `+"`"+`2.5`+"`"+`: "float64", `+"`"+`"x"`+"`"+`: "string", `+"`"+`["a"]`+"`"+`: "list"} {
+		var amount AmountUnion
+		if err := json.Unmarshal([]byte(data), &amount); err != nil {
+			t.Fatal(err)
+		}
+		_, isInt := amount.AsInt()
+		_, isFloat := amount.AsFloat64()
+		_, isString := amount.AsString()
+		_, isList := amount.AsStringList()
+		got := map[bool]string{isInt: "int", isFloat: "float64", isString: "string", isList: "list"}[true]
+		if got != want {
+			t.Fatalf("%s decoded as %s, want %s", data, got, want)
+		}
+	}
+
+	var shape ShapeUnion
+	if err := json.Unmarshal([]byte(`+"`"+`{"kind":"square","side":2}`+"`"+`), &shape); err != nil {
+		t.Fatal(err)
+	}
+	if square, ok := shape.AsSquare(); !ok || *square.Side != 2 {
+		t.Fatalf("discriminator did not select square: %#v", shape.Value)
+	}
+	if err := json.Unmarshal([]byte(`+"`"+`{"side":3}`+"`"+`), &shape); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := shape.AsSquare(); !ok {
+		t.Fatalf("strict fallback did not select square: %#v", shape.Value)
+	}
+
+	var pet PetUnion
+	if err := json.Unmarshal([]byte(`+"`"+`{"kind":"cat","name":"milo"}`+"`"+`), &pet); err != nil {
+		t.Fatal(err)
+	}
+	if cat, ok := pet.AsCat(); !ok || cat.Name != "milo" {
+		t.Fatalf("unexpected AsCat result: %#v", pet.Value)
+	}
+	if _, ok := pet.AsDog(); ok {
+		t.Fatal("AsDog should not match a cat")
+	}
+
+	var holder Holder
+	if err := json.Unmarshal([]byte(`+"`"+`{"value":null}`+"`"+`), &holder); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`+"`"+`{"value":7}`+"`"+`), &holder); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := holder.Value.AsInt(); !ok || v != 7 {
+		t.Fatalf("unexpected holder value: %#v", holder.Value)
+	}
+	out, err := json.Marshal(Holder{Value: &Holder_ValueUnion{Value: "abc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `+"`"+`{"value":"abc"}`+"`"+` {
+		t.Fatalf("unexpected marshal output: %s", out)
+	}
+	if !(AmountUnion{}).IsZero() || (AmountUnion{Value: 1}).IsZero() {
+		t.Fatal("unexpected IsZero result")
+	}
+}
+`)
+}
+
+func TestUnionAccessorSuffix(t *testing.T) {
+	g := NewGenerator()
+	for typeName, want := range map[string]string{
+		"Pet_Cat":          "Cat",
+		"Pet_":             "Pet_",
+		"Card":             "Card",
+		"string":           "String",
+		"[]Pet_Cat":        "CatList",
+		"map[string]int64": "Int64Map",
+		"time.Time":        "Time",
+		"[][]float64":      "Float64ListList",
+	} {
+		if got := g.unionAccessorSuffix("Pet", typeName); got != want {
+			t.Fatalf("unionAccessorSuffix(%q) = %q, want %q", typeName, got, want)
+		}
+	}
+}
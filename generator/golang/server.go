@@ -0,0 +1,509 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ServerFileName is the conventional file name for generated server source.
+const ServerFileName = "server.go"
+
+// GeneratedServer contains a server interface and net/http adapter generated
+// from OpenAPI operations together with the models it uses.
+type GeneratedServer struct {
+	PackageName string
+	// Models contains the component models and the models for inline operation
+	// schemas. It is identical to GeneratedClient.Models for the same document
+	// and options, so a client and a server can share one package.
+	Models *GeneratedFile
+	// Server contains the server source for the same package as Models.
+	Server *GeneratedSourceFile
+	// Operations lists the ServerInterface methods in document order.
+	Operations []*GeneratedOperation
+	// Diagnostics reports operation shapes that required a notable decision.
+	// Model diagnostics are reported on Models.
+	Diagnostics []Diagnostic
+}
+
+const serverCoreSource = `// RequestError reports a request that could not be bound to the parameters or
+// body of an operation.
+type RequestError struct {
+	// In is the parameter location, or body.
+	In   string
+	Name string
+	// Status is the HTTP status written by the default error handler.
+	Status int
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	if e.Name == "" {
+		return e.In + ": " + e.Err.Error()
+	}
+	return e.In + " parameter " + e.Name + ": " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// HandlerOption configures the handler returned by NewHandler.
+type HandlerOption func(*serverHandler)
+
+// WithErrorHandler sets the function that writes binding, operation, and
+// response errors. The default writes a *RequestError with its Status and
+// every other error as 500 Internal Server Error.
+func WithErrorHandler(fn func(w http.ResponseWriter, r *http.Request, err error)) HandlerOption {
+	return func(h *serverHandler) {
+		h.errorHandler = fn
+	}
+}
+
+type serverRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(h *serverHandler, w http.ResponseWriter, r *http.Request, pathValues []string)
+}
+
+type serverHandler struct {
+	server       ServerInterface
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+	routes       []serverRoute
+}
+
+func (h *serverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	var allowed []string
+	for _, route := range h.routes {
+		match := route.pattern.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		if route.method != r.Method {
+			allowed = append(allowed, route.method)
+			continue
+		}
+		route.handle(h, w, r, match[1:])
+		return
+	}
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		http.Error(w, requestErr.Error(), requestErr.Status)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// writeResult writes a result with status, or fallback when status is zero.
+// A non-nil value is encoded as JSON; otherwise body is written as is.
+func writeResult(w http.ResponseWriter, header http.Header, status, fallback int, contentType string, value any, body []byte) error {
+	if status == 0 {
+		status = fallback
+	}
+	if status == 0 {
+		return errors.New("result has no status code")
+	}
+	if value != nil {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		body = encoded
+	}
+	for key, values := range header {
+		w.Header()[key] = values
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
+`
+
+// RenderServer renders a server interface and net/http adapter for the
+// operations in doc.
+func RenderServer(doc *v3high.Document, opts ...Option) (*GeneratedServer, error) {
+	return NewGenerator(opts...).RenderServer(doc)
+}
+
+// RenderServer renders a server interface and net/http adapter for the
+// operations in doc using this generator. ServerInterface has one method per
+// operation that receives a bound request and returns a typed result, and
+// NewHandler routes requests to it.
+func (g *Generator) RenderServer(doc *v3high.Document) (*GeneratedServer, error) {
+	if err := validatePackageName(g.packageName); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, wrapPath(ErrNilDocument, "")
+	}
+	r := g.run()
+	ops, synthesized := r.collectOperations(doc)
+	models := operationModels(doc, synthesized)
+	file, err := g.RenderSchemas(models)
+	if err != nil {
+		return nil, err
+	}
+	r.imports = make(map[string]struct{})
+	r.resolveOperationTypes(ops, models, file, synthesized)
+	names := r.resolveOperationTypeNames(ops, file)
+	for _, op := range ops {
+		if len(op.Params) > 0 || op.Body != nil {
+			op.RequestType = r.resolveOperationTypeName(names, op, "Request")
+		}
+		op.ServerResultType = r.resolveOperationTypeName(names, op, "Result")
+	}
+	source, err := r.renderServerSource(ops)
+	if err != nil {
+		return nil, err
+	}
+	return &GeneratedServer{
+		PackageName: g.packageName,
+		Models:      file,
+		Server:      &GeneratedSourceFile{Name: ServerFileName, Source: source},
+		Operations:  generatedOperations(ops),
+		Diagnostics: append([]Diagnostic(nil), r.diagnostics...),
+	}, nil
+}
+
+func (g *Generator) renderServerSource(ops []*operationIR) ([]byte, error) {
+	for _, path := range []string{"context", "encoding/json", "errors", "net/http", "regexp", "strings"} {
+		g.addImport(path)
+	}
+	decls := []string{renderServerInterface(ops), renderUnimplementedServer(ops), renderNewHandler(ops), serverCoreSource}
+	for _, op := range ops {
+		if op.RequestType != "" {
+			decls = append(decls, renderServerRequestDecl(op))
+		}
+		decls = append(decls, renderServerResultDecl(op))
+		decls = append(decls, g.renderServerHandler(op))
+	}
+	for _, helper := range serverHelpers(ops) {
+		for _, path := range helper.imports {
+			g.addImport(path)
+		}
+		decls = append(decls, helper.source)
+	}
+	return g.renderSourceFile(decls)
+}
+
+// serverHelpers returns the runtime helpers needed by ops, in a stable order.
+func serverHelpers(ops []*operationIR) []runtimeHelper {
+	var params, query, header, cookie, missing, body bool
+	for _, op := range ops {
+		for _, param := range op.Params {
+			params = true
+			switch param.In {
+			case paramInQuery:
+				query = true
+			case paramInHeader:
+				header = true
+			case paramInCookie:
+				cookie = true
+			}
+		}
+		body = body || (op.Body != nil && op.Body.JSON)
+	}
+	missing = query || header || cookie
+	var helpers []runtimeHelper
+	for _, helper := range []struct {
+		used   bool
+		helper runtimeHelper
+	}{
+		{params, bindValueHelper},
+		{query, bindQueryHelper},
+		{header, bindHeaderHelper},
+		{cookie, bindCookieHelper},
+		{missing, bindMissingHelper},
+		{body, bindBodyHelper},
+	} {
+		if helper.used {
+			helpers = append(helpers, helper.helper)
+		}
+	}
+	return helpers
+}
+
+func serverMethodSignature(op *operationIR) string {
+	signature := op.Name + "(ctx context.Context"
+	if op.RequestType != "" {
+		signature += ", request " + op.RequestType
+	}
+	return signature + ") (" + op.ServerResultType + ", error)"
+}
+
+func renderServerInterface(ops []*operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, "ServerInterface is implemented by the API server. NewHandler adapts it to net/http")
+	b.WriteString("type ServerInterface interface {\n")
+	for _, op := range ops {
+		writeOperationComment(&b, op)
+		b.WriteByte('\t')
+		b.WriteString(serverMethodSignature(op))
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func renderUnimplementedServer(ops []*operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, "UnimplementedServer answers every operation with 501 Not Implemented")
+	writeLineComment(&b, "Embed it to implement ServerInterface incrementally")
+	b.WriteString("type UnimplementedServer struct{}\n")
+	for _, op := range ops {
+		b.WriteString("\nfunc (UnimplementedServer) ")
+		b.WriteString(serverMethodSignature(op))
+		b.WriteString(" {\n\treturn ")
+		b.WriteString(op.ServerResultType)
+		b.WriteString("{StatusCode: http.StatusNotImplemented}, nil\n}\n")
+	}
+	return b.String()
+}
+
+// routeIR is one generated route with the capture index of each declared path
+// parameter.
+type routeIR struct {
+	op       *operationIR
+	pattern  string
+	captures map[string]int
+	params   int
+}
+
+// serverRoutes orders routes so templates with fewer parameters match first,
+// which lets literal paths win over templated siblings.
+func serverRoutes(ops []*operationIR) []*routeIR {
+	routes := make([]*routeIR, 0, len(ops))
+	for _, op := range ops {
+		declared := make(map[string]bool)
+		for _, param := range op.Params {
+			if param.In == paramInPath {
+				declared[param.Name] = true
+			}
+		}
+		route := &routeIR{op: op, captures: make(map[string]int)}
+		var pattern strings.Builder
+		pattern.WriteByte('^')
+		for _, segment := range splitPathTemplate(op.Path) {
+			switch {
+			case !segment.param:
+				pattern.WriteString(regexp.QuoteMeta(segment.value))
+			case declared[segment.value]:
+				route.captures[segment.value] = len(route.captures)
+				pattern.WriteString("([^/]+)")
+				route.params++
+			default:
+				pattern.WriteString("[^/]+")
+				route.params++
+			}
+		}
+		pattern.WriteByte('$')
+		route.pattern = pattern.String()
+		routes = append(routes, route)
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].params < routes[j].params
+	})
+	return routes
+}
+
+func renderNewHandler(ops []*operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, "NewHandler returns an http.Handler that binds requests and calls server")
+	b.WriteString("func NewHandler(server ServerInterface, opts ...HandlerOption) http.Handler {\n")
+	b.WriteString("\th := &serverHandler{server: server, errorHandler: defaultErrorHandler}\n")
+	b.WriteString("\tfor _, opt := range opts {\n\t\topt(h)\n\t}\n")
+	b.WriteString("\th.routes = []serverRoute{\n")
+	for _, route := range serverRoutes(ops) {
+		b.WriteString("\t\t{method: ")
+		b.WriteString(strconv.Quote(route.op.Method))
+		b.WriteString(", pattern: regexp.MustCompile(")
+		b.WriteString(strconv.Quote(route.pattern))
+		b.WriteString("), handle: (*serverHandler).")
+		b.WriteString(serverHandlerName(route.op))
+		b.WriteString("},\n")
+	}
+	b.WriteString("\t}\n\treturn h\n}\n")
+	return b.String()
+}
+
+func serverHandlerName(op *operationIR) string {
+	return "handle" + op.Name
+}
+
+// serverBodyField names the request body field without colliding with a
+// parameter field.
+func serverBodyField(op *operationIR, name string) string {
+	names := newNameRegistry()
+	for _, param := range op.Params {
+		names.resolve(param.In+":"+param.Name, param.Field)
+	}
+	field, _ := names.resolve("body:"+name, name)
+	return field
+}
+
+func renderServerRequestDecl(op *operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, op.RequestType+" contains the bound request of "+op.Name)
+	b.WriteString("type ")
+	b.WriteString(op.RequestType)
+	b.WriteString(" struct {\n")
+	for _, param := range op.Params {
+		if param.Description != "" {
+			writeComment(&b, param.Field, param.Description)
+		} else {
+			writeLineComment(&b, param.Field+" is the "+param.Name+" "+param.In+" parameter")
+		}
+		b.WriteString("\t" + param.Field + " " + param.Type + "\n")
+	}
+	if op.Body != nil {
+		body := serverBodyField(op, "Body")
+		if op.Body.JSON {
+			writeLineComment(&b, body+" is the decoded "+op.Body.ContentType+" request body")
+			typ := op.Body.Type
+			if !op.Body.Required {
+				typ = optionalType(typ)
+			}
+			b.WriteString("\t" + body + " " + typ + "\n")
+		} else {
+			contentType := serverBodyField(op, "ContentType")
+			writeLineComment(&b, contentType+" is the request Content-Type")
+			b.WriteString("\t" + contentType + " string\n")
+			writeLineComment(&b, body+" is the unread request body")
+			b.WriteString("\t" + body + " io.Reader\n")
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func renderServerResultDecl(op *operationIR) string {
+	var b strings.Builder
+	writeLineComment(&b, op.ServerResultType+" is the response written for "+op.Name)
+	b.WriteString("type ")
+	b.WriteString(op.ServerResultType)
+	b.WriteString(" struct {\n")
+	b.WriteString("\t// StatusCode defaults to the documented status of the JSON field that is\n")
+	b.WriteString("\t// set, or " + intString(serverRawStatus(op)) + " when none is.\n")
+	b.WriteString("\tStatusCode int\n\tHeader     http.Header\n")
+	for _, response := range op.Responses {
+		if response.Field == "" {
+			continue
+		}
+		text := response.Field + " is written as the " + strings.ToLower(response.Code) + " response"
+		if description := strings.TrimSpace(strings.Split(response.Description, "\n")[0]); description != "" {
+			text += ": " + description
+		}
+		writeLineComment(&b, text)
+		b.WriteString("\t" + response.Field + " " + optionalType(response.Type) + "\n")
+	}
+	b.WriteString("\t// ContentType and Body are written when no JSON field is set.\n")
+	b.WriteString("\tContentType string\n\tBody        []byte\n}\n\n")
+
+	b.WriteString("func (r " + op.ServerResultType + ") write(w http.ResponseWriter) error {\n")
+	var fields []*responseIR
+	for _, response := range op.Responses {
+		if response.Field != "" {
+			fields = append(fields, response)
+		}
+	}
+	if len(fields) > 0 {
+		b.WriteString("\tswitch {\n")
+		for _, response := range fields {
+			fallback := "0"
+			if status, err := strconv.Atoi(response.Code); err == nil {
+				fallback = strconv.Itoa(status)
+			}
+			b.WriteString("\tcase r." + response.Field + " != nil:\n")
+			b.WriteString("\t\treturn writeResult(w, r.Header, r.StatusCode, " + fallback + ", " + strconv.Quote(response.ContentType) + ", r." + response.Field + ", nil)\n")
+		}
+		b.WriteString("\t}\n")
+	}
+	b.WriteString("\treturn writeResult(w, r.Header, r.StatusCode, " + intString(serverRawStatus(op)) + ", r.ContentType, nil, r.Body)\n}\n")
+	return b.String()
+}
+
+// serverRawStatus is the status written for a result without a JSON field or
+// StatusCode: the first documented status, or 200.
+func serverRawStatus(op *operationIR) int {
+	for _, response := range op.Responses {
+		if status, err := strconv.Atoi(response.Code); err == nil && status >= 100 && status <= 599 {
+			return status
+		}
+	}
+	return 200
+}
+
+func (g *Generator) renderServerHandler(op *operationIR) string {
+	var b strings.Builder
+	b.WriteString("func (h *serverHandler) " + serverHandlerName(op) + "(w http.ResponseWriter, r *http.Request, pathValues []string) {\n")
+	if op.RequestType != "" {
+		b.WriteString("\tvar request " + op.RequestType + "\n")
+	}
+	captures := serverRoutes([]*operationIR{op})[0].captures
+	for _, param := range op.Params {
+		if param.In == paramInQuery {
+			b.WriteString("\tquery := r.URL.Query()\n")
+			break
+		}
+	}
+	for _, param := range op.Params {
+		style := param.Style
+		if param.JSON {
+			style = "json"
+		}
+		dest := "&request." + param.Field
+		args := strconv.Quote(style) + ", " + strconv.FormatBool(param.Explode)
+		var call string
+		switch param.In {
+		case paramInPath:
+			call = "bindPathParam(" + dest + ", " + args + ", " + strconv.Quote(param.Name) + ", pathValues[" + intString(captures[param.Name]) + "])"
+		case paramInQuery:
+			call = "bindQueryParam(" + dest + ", query, " + args + ", " + strconv.FormatBool(param.Required) + ", " + strconv.Quote(param.Name) + ")"
+		case paramInHeader:
+			call = "bindHeaderParam(" + dest + ", r.Header, " + args + ", " + strconv.FormatBool(param.Required) + ", " + strconv.Quote(param.Name) + ")"
+		default:
+			call = "bindCookieParam(" + dest + ", r, " + args + ", " + strconv.FormatBool(param.Required) + ", " + strconv.Quote(param.Name) + ")"
+		}
+		writeServerCheck(&b, call)
+	}
+	if op.Body != nil {
+		body := serverBodyField(op, "Body")
+		if op.Body.JSON {
+			writeServerCheck(&b, "bindJSONBody(&request."+body+", r, "+strconv.FormatBool(op.Body.Required)+")")
+		} else {
+			g.addImport("io")
+			b.WriteString("\trequest." + serverBodyField(op, "ContentType") + " = r.Header.Get(\"Content-Type\")\n")
+			b.WriteString("\trequest." + body + " = r.Body\n")
+		}
+	}
+	b.WriteString("\tresult, err := h.server." + op.Name + "(r.Context()")
+	if op.RequestType != "" {
+		b.WriteString(", request")
+	}
+	b.WriteString(")\n\tif err != nil {\n\t\th.errorHandler(w, r, err)\n\t\treturn\n\t}\n")
+	b.WriteString("\tif err := result.write(w); err != nil {\n\t\th.errorHandler(w, r, err)\n\t}\n}\n")
+	return b.String()
+}
+
+func writeServerCheck(b *strings.Builder, call string) {
+	b.WriteString("\tif err := " + call + "; err != nil {\n\t\th.errorHandler(w, r, err)\n\t\treturn\n\t}\n")
+}
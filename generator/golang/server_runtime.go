@@ -0,0 +1,370 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+// Server helpers use bind and server prefixes so a client and a server can be
+// generated into the same package.
+
+var bindValueHelper = runtimeHelper{
+	imports: []string{"encoding", "encoding/json", "errors", "net/http", "net/url", "reflect", "strconv", "strings"},
+	source: `type bindKind int
+
+const (
+	bindPrimitive bindKind = iota
+	bindArray
+	bindObject
+)
+
+var bindTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// bindTarget reports how a parameter destination is serialized.
+func bindTarget(dest any) bindKind {
+	t := reflect.TypeOf(dest).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case reflect.PointerTo(t).Implements(bindTextUnmarshaler):
+		return bindPrimitive
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8, t.Kind() == reflect.Array:
+		return bindArray
+	case t.Kind() == reflect.Struct, t.Kind() == reflect.Map:
+		return bindObject
+	default:
+		return bindPrimitive
+	}
+}
+
+// bindAlloc allocates nil pointers on the way to the settable value.
+func bindAlloc(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func bindScalar(v reflect.Value, raw string) error {
+	v = bindAlloc(v)
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return errors.New("unsupported parameter type " + v.Type().String())
+		}
+		v.Set(reflect.ValueOf(raw))
+	default:
+		return json.Unmarshal([]byte(raw), v.Addr().Interface())
+	}
+	return nil
+}
+
+// bindParts binds serialized parts into dest. Objects read alternating keys
+// and values, or key=value pairs when keyValue is set.
+func bindParts(dest any, parts []string, keyValue bool) error {
+	v := reflect.ValueOf(dest).Elem()
+	switch bindTarget(dest) {
+	case bindArray:
+		v = bindAlloc(v)
+		if v.Kind() == reflect.Array {
+			if len(parts) > v.Len() {
+				return errors.New("too many values")
+			}
+		} else {
+			v.Set(reflect.MakeSlice(v.Type(), len(parts), len(parts)))
+		}
+		for i, part := range parts {
+			if err := bindScalar(v.Index(i), part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case bindObject:
+		fields := make(map[string]string)
+		if keyValue {
+			for _, part := range parts {
+				key, value, _ := strings.Cut(part, "=")
+				fields[key] = value
+			}
+		} else {
+			for i := 0; i+1 < len(parts); i += 2 {
+				fields[parts[i]] = parts[i+1]
+			}
+		}
+		return bindFields(v, fields)
+	default:
+		return bindScalar(v, strings.Join(parts, ","))
+	}
+}
+
+// bindFields binds object fields into a struct by JSON name or into a map.
+func bindFields(v reflect.Value, fields map[string]string) error {
+	v = bindAlloc(v)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for key, raw := range fields {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := bindScalar(elem, raw); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			raw, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err := bindScalar(v.Field(i), raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bindValue binds one raw path, header, or cookie value serialized with the
+// simple, label, matrix, or form style. Parameters declared with content use
+// the json pseudo style.
+func bindValue(dest any, style string, explode bool, name, raw string) error {
+	if style == "json" {
+		return json.Unmarshal([]byte(raw), dest)
+	}
+	kind := bindTarget(dest)
+	separator := ","
+	keyValue := false
+	switch style {
+	case "label":
+		raw = strings.TrimPrefix(raw, ".")
+		if explode && kind != bindPrimitive {
+			separator = "."
+			keyValue = kind == bindObject
+		}
+	case "matrix":
+		raw = strings.TrimPrefix(raw, ";")
+		switch {
+		case explode && kind == bindObject:
+			separator = ";"
+			keyValue = true
+		case explode && kind == bindArray:
+			separator = ";"
+		default:
+			raw = strings.TrimPrefix(raw, name+"=")
+		}
+	case "simple":
+		keyValue = explode && kind == bindObject
+	}
+	var parts []string
+	if raw != "" || kind == bindPrimitive {
+		parts = strings.Split(raw, separator)
+	}
+	if style == "matrix" && explode && kind == bindArray {
+		for i := range parts {
+			parts[i] = strings.TrimPrefix(parts[i], name+"=")
+		}
+	}
+	return bindParts(dest, parts, keyValue)
+}
+
+// bindPathParam binds a path parameter captured by the router.
+func bindPathParam(dest any, style string, explode bool, name, raw string) error {
+	unescaped, err := url.PathUnescape(raw)
+	if err == nil {
+		err = bindValue(dest, style, explode, name, unescaped)
+	}
+	if err != nil {
+		return &RequestError{In: "path", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+`,
+}
+
+var bindQueryHelper = runtimeHelper{
+	imports: []string{"encoding/json", "errors", "net/http", "net/url", "reflect", "strings"},
+	source: `// bindQueryParam binds a query parameter serialized with the form,
+// spaceDelimited, pipeDelimited, or deepObject style.
+func bindQueryParam(dest any, query url.Values, style string, explode, required bool, name string) error {
+	err := bindQuery(dest, query, style, explode, name)
+	if errors.Is(err, errMissingParam) && !required {
+		return nil
+	}
+	if err != nil {
+		return &RequestError{In: "query", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+func bindQuery(dest any, query url.Values, style string, explode bool, name string) error {
+	if style == "json" {
+		if !query.Has(name) {
+			return errMissingParam
+		}
+		return json.Unmarshal([]byte(query.Get(name)), dest)
+	}
+	kind := bindTarget(dest)
+	switch {
+	case kind == bindObject && style == "deepObject":
+		fields := make(map[string]string)
+		prefix := name + "["
+		for key, values := range query {
+			if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, "]") && len(values) > 0 {
+				fields[key[len(prefix):len(key)-1]] = values[0]
+			}
+		}
+		if len(fields) == 0 {
+			return errMissingParam
+		}
+		return bindFields(reflect.ValueOf(dest).Elem(), fields)
+	case kind == bindObject && explode:
+		fields := make(map[string]string)
+		for key, values := range query {
+			if len(values) > 0 {
+				fields[key] = values[0]
+			}
+		}
+		if len(fields) == 0 {
+			return errMissingParam
+		}
+		return bindFields(reflect.ValueOf(dest).Elem(), fields)
+	case kind == bindArray && explode:
+		values, ok := query[name]
+		if !ok {
+			return errMissingParam
+		}
+		return bindParts(dest, values, false)
+	default:
+		if !query.Has(name) {
+			return errMissingParam
+		}
+		raw := query.Get(name)
+		separator := ","
+		if kind == bindArray && style == "spaceDelimited" {
+			separator = " "
+		} else if kind == bindArray && style == "pipeDelimited" {
+			separator = "|"
+		}
+		var parts []string
+		if raw != "" || kind == bindPrimitive {
+			parts = strings.Split(raw, separator)
+		}
+		return bindParts(dest, parts, false)
+	}
+}
+`,
+}
+
+var bindHeaderHelper = runtimeHelper{
+	imports: []string{"net/http", "strings"},
+	source: `// bindHeaderParam binds a header parameter serialized with the simple style.
+func bindHeaderParam(dest any, header http.Header, style string, explode, required bool, name string) error {
+	values := header.Values(name)
+	if len(values) == 0 {
+		if required {
+			return &RequestError{In: "header", Name: name, Status: http.StatusBadRequest, Err: errMissingParam}
+		}
+		return nil
+	}
+	if err := bindValue(dest, style, explode, name, strings.Join(values, ",")); err != nil {
+		return &RequestError{In: "header", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+`,
+}
+
+var bindCookieHelper = runtimeHelper{
+	imports: []string{"net/http"},
+	source: `// bindCookieParam binds a cookie parameter serialized with the form style.
+func bindCookieParam(dest any, r *http.Request, style string, explode, required bool, name string) error {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		if required {
+			return &RequestError{In: "cookie", Name: name, Status: http.StatusBadRequest, Err: errMissingParam}
+		}
+		return nil
+	}
+	if err := bindValue(dest, style, explode, name, cookie.Value); err != nil {
+		return &RequestError{In: "cookie", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+`,
+}
+
+var bindMissingHelper = runtimeHelper{
+	imports: []string{"errors"},
+	source: `var errMissingParam = errors.New("required parameter is missing")
+`,
+}
+
+var bindBodyHelper = runtimeHelper{
+	imports: []string{"encoding/json", "errors", "io", "mime", "net/http", "strings"},
+	source: `// bindJSONBody decodes a JSON request body. Requests without a Content-Type
+// are decoded; other non-JSON media types are rejected.
+func bindJSONBody(dest any, r *http.Request, required bool) error {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return &RequestError{In: "body", Status: http.StatusUnsupportedMediaType, Err: errors.New("unsupported content type " + contentType)}
+		}
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return &RequestError{In: "body", Status: http.StatusBadRequest, Err: err}
+	}
+	if len(data) == 0 {
+		if required {
+			return &RequestError{In: "body", Status: http.StatusBadRequest, Err: errors.New("request body is required")}
+		}
+		return nil
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return &RequestError{In: "body", Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+`,
+}
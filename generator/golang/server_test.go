@@ -0,0 +1,380 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+func TestBookingsServerGolden(t *testing.T) {
+	assertGolden(t, "testdata/bookings_server.golden.go", renderBookingsServer(t).Server.Source)
+}
+
+func TestRenderServerSharesModelsWithClient(t *testing.T) {
+	server := renderBookingsServer(t)
+	client := renderBookingsClient(t)
+	if !bytes.Equal(server.Models.Source, client.Models.Source) {
+		t.Fatal("server and client models differ")
+	}
+	var got []string
+	for _, op := range server.Operations {
+		got = append(got, op.Name+" "+op.RequestType+" "+op.ServerResultType+" "+op.ParamsType+" "+op.ResultType)
+	}
+	want := []string{
+		"GetStations GetStationsRequest GetStationsResult GetStationsParams GetStationsResponse",
+		"GetBooking GetBookingRequest GetBookingResult GetBookingParams GetBookingResponse",
+		"DeleteBooking DeleteBookingRequest DeleteBookingResult DeleteBookingParams DeleteBookingResponse",
+		"CreateBooking CreateBookingRequest CreateBookingResult  CreateBookingResponse",
+		"PutBookingsBookingIDTicketFormat PutBookingsBookingIDTicketFormatRequest PutBookingsBookingIDTicketFormatResult PutBookingsBookingIDTicketFormatParams PutBookingsBookingIDTicketFormatResponse",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected operations:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRenderServerRoundTripsWithClient(t *testing.T) {
+	client := renderBookingsClient(t)
+	server := renderBookingsServer(t)
+	assertParsesCompilesAndTestsWithFiles(t, map[string][]byte{
+		"models.go": client.Models.Source,
+		"client.go": client.Client.Source,
+		"server.go": server.Server.Source,
+	}, `package models
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type bookings struct {
+	UnimplementedServer
+}
+
+func (s *bookings) GetStations(ctx context.Context, request GetStationsRequest) (GetStationsResult, error) {
+	if request.Country == "XX" {
+		title := "bad country"
+		return GetStationsResult{StatusCode: 422, JSON4XX: &Problem{Title: &title}}, nil
+	}
+	return GetStationsResult{JSON200: &GetStations200Response{Data: []Station{{ID: "s1", Name: describeStations(request)}}}}, nil
+}
+
+func describeStations(request GetStationsRequest) string {
+	parts := []string{request.Country}
+	if request.Page != nil {
+		parts = append(parts, "page="+strconv.Itoa(*request.Page))
+	}
+	for _, coordinate := range request.Coordinates {
+		parts = append(parts, "coordinate="+strconv.FormatFloat(coordinate, 'f', -1, 64))
+	}
+	if request.Filter != nil && request.Filter.Name != nil {
+		parts = append(parts, "filter.name="+*request.Filter.Name)
+	}
+	if request.XRequestID != nil {
+		parts = append(parts, "request="+*request.XRequestID)
+	}
+	if request.Session != nil {
+		parts = append(parts, "session="+*request.Session)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *bookings) GetBooking(ctx context.Context, request GetBookingRequest) (GetBookingResult, error) {
+	switch request.BookingID {
+	case "missing":
+		return GetBookingResult{StatusCode: http.StatusNotFound}, nil
+	case "broken":
+		return GetBookingResult{}, errors.New("storage failed")
+	}
+	id := request.BookingID
+	return GetBookingResult{JSON200: &Booking{ID: &id}}, nil
+}
+
+func (s *bookings) CreateBooking(ctx context.Context, request CreateBookingRequest) (CreateBookingResult, error) {
+	id := "b1"
+	request.Body.ID = &id
+	return CreateBookingResult{JSON201: &request.Body, Header: http.Header{"Location": {"/bookings/b1"}}}, nil
+}
+
+func (s *bookings) PutBookingsBookingIDTicketFormat(ctx context.Context, request PutBookingsBookingIDTicketFormatRequest) (PutBookingsBookingIDTicketFormatResult, error) {
+	data, err := io.ReadAll(request.Body)
+	if err != nil {
+		return PutBookingsBookingIDTicketFormatResult{}, err
+	}
+	return PutBookingsBookingIDTicketFormatResult{ContentType: "text/plain", Body: []byte(request.BookingID + " " + request.Format + " " + request.ContentType + " " + string(data))}, nil
+}
+
+func TestGeneratedServer(t *testing.T) {
+	impl := &bookings{}
+	server := httptest.NewServer(NewHandler(impl))
+	defer server.Close()
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	page, name, requestID, session := 2, "Ber lin", "req-1", "abc"
+	stations, err := client.GetStations(ctx, GetStationsParams{
+		Page:        &page,
+		Country:     "DE",
+		Coordinates: []float64{52.5, 13.4},
+		Filter:      &GetStationsFilter{Name: &name},
+		XRequestID:  &requestID,
+		Session:     &session,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stations.StatusCode() != 200 || stations.JSON200 == nil {
+		t.Fatalf("unexpected stations response: %d %s", stations.StatusCode(), stations.Body)
+	}
+	if got := stations.JSON200.Data[0].Name; got != "DE page=2 coordinate=52.5 coordinate=13.4 filter.name=Ber lin request=req-1 session=abc" {
+		t.Fatalf("unexpected bound request: %q", got)
+	}
+
+	problem, err := client.GetStations(ctx, GetStationsParams{Country: "XX"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problem.StatusCode() != 422 || problem.JSON4XX == nil || *problem.JSON4XX.Title != "bad country" {
+		t.Fatalf("unexpected problem response: %d %s", problem.StatusCode(), problem.Body)
+	}
+
+	booking, err := client.GetBooking(ctx, GetBookingParams{BookingID: "b 1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if booking.JSON200 == nil || *booking.JSON200.ID != "b 1" {
+		t.Fatalf("unexpected booking response: %d %s", booking.StatusCode(), booking.Body)
+	}
+	missing, err := client.GetBooking(ctx, GetBookingParams{BookingID: "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing.StatusCode() != 404 {
+		t.Fatalf("unexpected missing response: %d", missing.StatusCode())
+	}
+	broken, err := client.GetBooking(ctx, GetBookingParams{BookingID: "broken"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if broken.StatusCode() != 500 {
+		t.Fatalf("unexpected broken response: %d", broken.StatusCode())
+	}
+
+	deleted, err := client.DeleteBooking(ctx, DeleteBookingParams{BookingID: "b1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted.StatusCode() != 501 {
+		t.Fatalf("unexpected unimplemented response: %d", deleted.StatusCode())
+	}
+
+	passenger := "Ada"
+	created, err := client.CreateBooking(ctx, Booking{PassengerName: &passenger})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.StatusCode() != 201 || *created.JSON201.ID != "b1" || *created.JSON201.PassengerName != "Ada" || created.HTTPResponse.Header.Get("Location") != "/bookings/b1" {
+		t.Fatalf("unexpected created response: %d %s", created.StatusCode(), created.Body)
+	}
+
+	ticket, err := client.PutBookingsBookingIDTicketFormat(ctx, PutBookingsBookingIDTicketFormatParams{BookingID: "b1", Format: "pdf"}, "application/pdf", strings.NewReader("%PDF"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.StatusCode() != 200 || string(ticket.Body) != "b1 pdf application/pdf %PDF" {
+		t.Fatalf("unexpected ticket response: %d %s", ticket.StatusCode(), ticket.Body)
+	}
+
+	for _, tc := range []struct {
+		method, path, contentType, body string
+		status                          int
+	}{
+		{"GET", "/stations", "", "", http.StatusBadRequest},
+		{"GET", "/stations?country=DE&page=two", "", "", http.StatusBadRequest},
+		{"POST", "/bookings", "text/plain", "hello", http.StatusUnsupportedMediaType},
+		{"POST", "/bookings", "application/json", "", http.StatusBadRequest},
+		{"PATCH", "/bookings/b1", "", "", http.StatusMethodNotAllowed},
+		{"GET", "/trains", "", "", http.StatusNotFound},
+	} {
+		req, err := http.NewRequest(tc.method, server.URL+tc.path, strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.contentType != "" {
+			req.Header.Set("Content-Type", tc.contentType)
+		}
+		rsp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rsp.Body.Close()
+		if rsp.StatusCode != tc.status {
+			t.Fatalf("%s %s: got status %d, want %d", tc.method, tc.path, rsp.StatusCode, tc.status)
+		}
+	}
+}
+`)
+}
+
+func TestRenderServerBindsParameterStyles(t *testing.T) {
+	model := mustBuildV3(t, `openapi: 3.1.0
+info:
+  title: Styles
+  version: 1.0.0
+paths:
+  /points/{label}/{matrix}/{object}:
+    get:
+      operationId: getPoint
+      parameters:
+        - name: label
+          in: path
+          required: true
+          style: label
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer
+        - name: matrix
+          in: path
+          required: true
+          style: matrix
+          schema:
+            type: array
+            items:
+              type: string
+        - name: object
+          in: path
+          required: true
+          explode: true
+          schema:
+            $ref: '#/components/schemas/Point'
+        - name: tags
+          in: query
+          style: pipeDelimited
+          explode: false
+          schema:
+            type: array
+            items:
+              type: string
+        - name: where
+          in: query
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Point'
+      responses:
+        '200':
+          description: OK
+components:
+  schemas:
+    Point:
+      type: object
+      properties:
+        x:
+          type: integer
+        y:
+          type: integer
+`)
+	client, err := RenderClient(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := RenderServer(model)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertParsesCompilesAndTestsWithFiles(t, map[string][]byte{
+		"models.go": client.Models.Source,
+		"client.go": client.Client.Source,
+		"server.go": server.Server.Source,
+	}, `package models
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+)
+
+type points struct {
+	got GetPointRequest
+}
+
+func (p *points) GetPoint(ctx context.Context, request GetPointRequest) (GetPointResult, error) {
+	p.got = request
+	return GetPointResult{}, nil
+}
+
+func TestGeneratedStyles(t *testing.T) {
+	impl := &points{}
+	server := httptest.NewServer(NewHandler(impl))
+	defer server.Close()
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, y := 1, 2
+	if _, err := client.GetPoint(context.Background(), GetPointParams{
+		Label:  []int{3, 4},
+		Matrix: []string{"a", "b"},
+		Object: Point{X: &x, Y: &y},
+		Tags:   []string{"red", "blue"},
+		Where:  &Point{X: &y},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprintf("%v %v %d,%d %v %d", impl.got.Label, impl.got.Matrix, *impl.got.Object.X, *impl.got.Object.Y, impl.got.Tags, *impl.got.Where.X)
+	if got != "[3 4] [a b] 1,2 [red blue] 2" {
+		t.Fatalf("unexpected bound request: %s", got)
+	}
+}
+`)
+}
+
+func TestRenderServerErrors(t *testing.T) {
+	if _, err := RenderServer(nil); !errors.Is(err, ErrNilDocument) {
+		t.Fatalf("expected ErrNilDocument, got %v", err)
+	}
+	if _, err := RenderServer(&v3high.Document{}, WithPackageName("not-valid")); !errors.Is(err, ErrInvalidPackageName) {
+		t.Fatalf("expected ErrInvalidPackageName, got %v", err)
+	}
+}
+
+func TestServerRoutesPreferLiteralPaths(t *testing.T) {
+	routes := serverRoutes([]*operationIR{
+		{Name: "GetBooking", Method: "GET", Path: "/bookings/{id}", Params: []*paramIR{{Name: "id", In: paramInPath}}},
+		{Name: "SearchBookings", Method: "GET", Path: "/bookings/search"},
+	})
+	if routes[0].op.Name != "SearchBookings" || routes[1].pattern != "^/bookings/([^/]+)$" {
+		t.Fatalf("unexpected routes: %s %s", routes[0].op.Name, routes[1].pattern)
+	}
+}
+
+func renderBookingsServer(t *testing.T, opts ...Option) *GeneratedServer {
+	t.Helper()
+	spec, err := os.ReadFile("testdata/bookings-client.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := RenderServer(mustBuildV3(t, string(spec)), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
@@ -0,0 +1,611 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HTTPRequestDoer performs HTTP requests. *http.Client satisfies it.
+type HTTPRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RequestEditorFn edits a request before it is sent.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Client calls the API operations.
+type Client struct {
+	// Server is the base URL that operation paths are appended to.
+	Server string
+	// Client performs requests. NewClient defaults it to http.DefaultClient.
+	Client HTTPRequestDoer
+	// RequestEditors run on every request before per-call editors.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client) error
+
+// NewClient creates a Client for the server base URL.
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	c := &Client{Server: strings.TrimSuffix(server, "/")}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.Client == nil {
+		c.Client = http.DefaultClient
+	}
+	return c, nil
+}
+
+// WithHTTPClient sets the HTTPRequestDoer used to send requests.
+func WithHTTPClient(doer HTTPRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn adds a RequestEditorFn that runs on every request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, editors []RequestEditorFn) (*http.Response, error) {
+	for _, editor := range c.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	for _, editor := range editors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.Do(req)
+}
+
+// GetStationsParams contains the parameters of GetStations.
+type GetStationsParams struct {
+	// Page is the page query parameter.
+	Page *int
+	// Country is the country query parameter.
+	Country string
+	// Coordinates is the coordinates query parameter.
+	Coordinates []float64
+	// Filter is the filter query parameter.
+	Filter *GetStationsFilter
+	// XRequestID Correlates the request in server logs.
+	XRequestID *string
+	// Session is the session cookie parameter.
+	Session *string
+}
+
+// GetStationsResponse is the response of GetStations.
+type GetStationsResponse struct {
+	// HTTPResponse is the raw response. Its body has been read into Body.
+	HTTPResponse *http.Response
+	Body         []byte
+	// JSON200 is the decoded 200 response: OK.
+	JSON200 *GetStations200Response
+	// JSON4XX is the decoded 4xx response: Client error.
+	JSON4XX *Problem
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r *GetStationsResponse) StatusCode() int {
+	if r == nil || r.HTTPResponse == nil {
+		return 0
+	}
+	return r.HTTPResponse.StatusCode
+}
+
+// GetStations Get a list of train stations.
+func (c *Client) GetStations(ctx context.Context, params GetStationsParams, reqEditors ...RequestEditorFn) (*GetStationsResponse, error) {
+	target := c.Server + "/stations"
+	query := url.Values{}
+	if params.Page != nil {
+		if err := addQueryParam(query, "form", true, "page", params.Page); err != nil {
+			return nil, err
+		}
+	}
+	if err := addQueryParam(query, "form", true, "country", params.Country); err != nil {
+		return nil, err
+	}
+	if params.Coordinates != nil {
+		if err := addQueryParam(query, "form", false, "coordinates", params.Coordinates); err != nil {
+			return nil, err
+		}
+	}
+	if params.Filter != nil {
+		if err := addQueryParam(query, "deepObject", false, "filter", params.Filter); err != nil {
+			return nil, err
+		}
+	}
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	var reqBody io.Reader
+	req, err := http.NewRequestWithContext(ctx, "GET", target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if params.XRequestID != nil {
+		value, err := headerParam(false, params.XRequestID)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Request-Id", value)
+	}
+	if params.Session != nil {
+		value, err := headerParam(true, params.Session)
+		if err != nil {
+			return nil, err
+		}
+		req.AddCookie(&http.Cookie{Name: "session", Value: value})
+	}
+	rsp, err := c.do(ctx, req, reqEditors)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &GetStationsResponse{HTTPResponse: rsp, Body: data}
+	if !decodesJSON(rsp, data) {
+		return response, nil
+	}
+	switch {
+	case rsp.StatusCode == 200:
+		var dest GetStations200Response
+		if err := json.Unmarshal(data, &dest); err != nil {
+			return response, err
+		}
+		response.JSON200 = &dest
+	case rsp.StatusCode/100 == 4:
+		var dest Problem
+		if err := json.Unmarshal(data, &dest); err != nil {
+			return response, err
+		}
+		response.JSON4XX = &dest
+	}
+	return response, nil
+}
+
+// GetBookingParams contains the parameters of GetBooking.
+type GetBookingParams struct {
+	// BookingID is the bookingId path parameter.
+	BookingID string
+}
+
+// GetBookingResponse is the response of GetBooking.
+type GetBookingResponse struct {
+	// HTTPResponse is the raw response. Its body has been read into Body.
+	HTTPResponse *http.Response
+	Body         []byte
+	// JSON200 is the decoded 200 response: The booking.
+	JSON200 *Booking
+	// JSONDefault is the decoded default response: Unexpected error.
+	JSONDefault *Problem
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r *GetBookingResponse) StatusCode() int {
+	if r == nil || r.HTTPResponse == nil {
+		return 0
+	}
+	return r.HTTPResponse.StatusCode
+}
+
+// GetBooking Get a booking.
+func (c *Client) GetBooking(ctx context.Context, params GetBookingParams, reqEditors ...RequestEditorFn) (*GetBookingResponse, error) {
+	pathValue1, err := pathParam("simple", false, "bookingId", params.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	target := c.Server + "/bookings/" + pathValue1
+	var reqBody io.Reader
+	req, err := http.NewRequestWithContext(ctx, "GET", target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := c.do(ctx, req, reqEditors)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &GetBookingResponse{HTTPResponse: rsp, Body: data}
+	if !decodesJSON(rsp, data) {
+		return response, nil
+	}
+	switch {
+	case rsp.StatusCode == 200:
+		var dest Booking
+		if err := json.Unmarshal(data, &dest); err != nil {
+			return response, err
+		}
+		response.JSON200 = &dest
+	case rsp.StatusCode == 404:
+	default:
+		var dest Problem
+		if err := json.Unmarshal(data, &dest); err != nil {
+			return response, err
+		}
+		response.JSONDefault = &dest
+	}
+	return response, nil
+}
+
+// DeleteBookingParams contains the parameters of DeleteBooking.
+type DeleteBookingParams struct {
+	// BookingID is the bookingId path parameter.
+	BookingID string
+}
+
+// DeleteBookingResponse is the response of DeleteBooking.
+type DeleteBookingResponse struct {
+	// HTTPResponse is the raw response. Its body has been read into Body.
+	HTTPResponse *http.Response
+	Body         []byte
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r *DeleteBookingResponse) StatusCode() int {
+	if r == nil || r.HTTPResponse == nil {
+		return 0
+	}
+	return r.HTTPResponse.StatusCode
+}
+
+// DeleteBooking calls DELETE /bookings/{bookingId}.
+//
+// Deprecated: the DeleteBooking operation is deprecated.
+func (c *Client) DeleteBooking(ctx context.Context, params DeleteBookingParams, reqEditors ...RequestEditorFn) (*DeleteBookingResponse, error) {
+	pathValue1, err := pathParam("simple", false, "bookingId", params.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	target := c.Server + "/bookings/" + pathValue1
+	var reqBody io.Reader
+	req, err := http.NewRequestWithContext(ctx, "DELETE", target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := c.do(ctx, req, reqEditors)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &DeleteBookingResponse{HTTPResponse: rsp, Body: data}
+	return response, nil
+}
+
+// CreateBookingResponse is the response of CreateBooking.
+type CreateBookingResponse struct {
+	// HTTPResponse is the raw response. Its body has been read into Body.
+	HTTPResponse *http.Response
+	Body         []byte
+	// JSON201 is the decoded 201 response: Created.
+	JSON201 *Booking
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r *CreateBookingResponse) StatusCode() int {
+	if r == nil || r.HTTPResponse == nil {
+		return 0
+	}
+	return r.HTTPResponse.StatusCode
+}
+
+// CreateBooking calls POST /bookings.
+func (c *Client) CreateBooking(ctx context.Context, body Booking, reqEditors ...RequestEditorFn) (*CreateBookingResponse, error) {
+	target := c.Server + "/bookings"
+	var reqBody io.Reader
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	reqBody = bytes.NewReader(encoded)
+	req, err := http.NewRequestWithContext(ctx, "POST", target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	rsp, err := c.do(ctx, req, reqEditors)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &CreateBookingResponse{HTTPResponse: rsp, Body: data}
+	if !decodesJSON(rsp, data) {
+		return response, nil
+	}
+	switch {
+	case rsp.StatusCode == 201:
+		var dest Booking
+		if err := json.Unmarshal(data, &dest); err != nil {
+			return response, err
+		}
+		response.JSON201 = &dest
+	}
+	return response, nil
+}
+
+// PutBookingsBookingIDTicketFormatParams contains the parameters of PutBookingsBookingIDTicketFormat.
+type PutBookingsBookingIDTicketFormatParams struct {
+	// BookingID is the bookingId path parameter.
+	BookingID string
+	// Format is the format path parameter.
+	Format string
+}
+
+// PutBookingsBookingIDTicketFormatResponse is the response of PutBookingsBookingIDTicketFormat.
+type PutBookingsBookingIDTicketFormatResponse struct {
+	// HTTPResponse is the raw response. Its body has been read into Body.
+	HTTPResponse *http.Response
+	Body         []byte
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r *PutBookingsBookingIDTicketFormatResponse) StatusCode() int {
+	if r == nil || r.HTTPResponse == nil {
+		return 0
+	}
+	return r.HTTPResponse.StatusCode
+}
+
+// PutBookingsBookingIDTicketFormat calls PUT /bookings/{bookingId}/ticket{format}.
+func (c *Client) PutBookingsBookingIDTicketFormat(ctx context.Context, params PutBookingsBookingIDTicketFormatParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutBookingsBookingIDTicketFormatResponse, error) {
+	pathValue1, err := pathParam("simple", false, "bookingId", params.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	pathValue2, err := pathParam("label", false, "format", params.Format)
+	if err != nil {
+		return nil, err
+	}
+	target := c.Server + "/bookings/" + pathValue1 + "/ticket" + pathValue2
+	var reqBody io.Reader
+	reqBody = body
+	req, err := http.NewRequestWithContext(ctx, "PUT", target, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	rsp, err := c.do(ctx, req, reqEditors)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	response := &PutBookingsBookingIDTicketFormatResponse{HTTPResponse: rsp, Body: data}
+	return response, nil
+}
+
+type paramKind int
+
+const (
+	paramPrimitive paramKind = iota
+	paramArray
+	paramObject
+)
+
+// paramValue flattens a parameter value into its serialized parts. Arrays
+// return their elements and objects return alternating keys and values sorted
+// by key.
+func paramValue(value any) ([]string, paramKind, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, paramPrimitive, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, paramPrimitive, nil
+	}
+	if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := paramScalar(v)
+		return []string{text}, paramPrimitive, err
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			part, err := paramScalar(v.Index(i))
+			if err != nil {
+				return nil, paramArray, err
+			}
+			parts = append(parts, part)
+		}
+		return parts, paramArray, nil
+	case reflect.Map, reflect.Struct:
+		encoded, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, paramObject, err
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(encoded, &fields); err != nil {
+			return nil, paramObject, err
+		}
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(keys)*2)
+		for _, key := range keys {
+			part, err := paramScalar(reflect.ValueOf(fields[key]))
+			if err != nil {
+				return nil, paramObject, err
+			}
+			parts = append(parts, key, part)
+		}
+		return parts, paramObject, nil
+	default:
+		part, err := paramScalar(v)
+		return []string{part}, paramPrimitive, err
+	}
+}
+
+func paramScalar(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", nil
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	default:
+		encoded, err := json.Marshal(v.Interface())
+		return string(encoded), err
+	}
+}
+
+// simpleParam joins parameter parts with the simple style. Exploded objects
+// render as key=value pairs.
+func simpleParam(parts []string, kind paramKind, explode bool, separator string) string {
+	if kind == paramObject && explode {
+		pairs := make([]string, 0, len(parts)/2)
+		for i := 0; i+1 < len(parts); i += 2 {
+			pairs = append(pairs, parts[i]+"="+parts[i+1])
+		}
+		return strings.Join(pairs, separator)
+	}
+	if kind == paramArray && explode {
+		return strings.Join(parts, separator)
+	}
+	return strings.Join(parts, ",")
+}
+
+// pathParam serializes a path parameter with the simple, label, or matrix
+// style.
+func pathParam(style string, explode bool, name string, value any) (string, error) {
+	parts, kind, err := paramValue(value)
+	if err != nil {
+		return "", err
+	}
+	// percent-encode everything but unreserved characters, so values cannot
+	// introduce the delimiters used by the styles below.
+	for i := range parts {
+		parts[i] = strings.ReplaceAll(url.QueryEscape(parts[i]), "+", "%20")
+	}
+	switch style {
+	case "label":
+		return "." + simpleParam(parts, kind, explode, "."), nil
+	case "matrix":
+		switch {
+		case kind == paramObject && explode:
+			return ";" + simpleParam(parts, kind, explode, ";"), nil
+		case kind == paramArray && explode:
+			return ";" + name + "=" + strings.Join(parts, ";"+name+"="), nil
+		default:
+			return ";" + name + "=" + strings.Join(parts, ","), nil
+		}
+	default:
+		return simpleParam(parts, kind, explode, ","), nil
+	}
+}
+
+// addQueryParam serializes a query parameter with the form, spaceDelimited,
+// pipeDelimited, or deepObject style.
+func addQueryParam(query url.Values, style string, explode bool, name string, value any) error {
+	parts, kind, err := paramValue(value)
+	if err != nil {
+		return err
+	}
+	switch {
+	case kind == paramObject && style == "deepObject":
+		for i := 0; i+1 < len(parts); i += 2 {
+			query.Add(name+"["+parts[i]+"]", parts[i+1])
+		}
+	case kind == paramObject && explode:
+		for i := 0; i+1 < len(parts); i += 2 {
+			query.Add(parts[i], parts[i+1])
+		}
+	case kind == paramArray && explode:
+		for _, part := range parts {
+			query.Add(name, part)
+		}
+	default:
+		separator := ","
+		if kind == paramArray && style == "spaceDelimited" {
+			separator = " "
+		} else if kind == paramArray && style == "pipeDelimited" {
+			separator = "|"
+		}
+		query.Add(name, strings.Join(parts, separator))
+	}
+	return nil
+}
+
+// headerParam serializes a header or cookie parameter with the simple style.
+func headerParam(explode bool, value any) (string, error) {
+	parts, kind, err := paramValue(value)
+	if err != nil {
+		return "", err
+	}
+	return simpleParam(parts, kind, explode, ","), nil
+}
+
+// decodesJSON reports whether a response body should be decoded as JSON.
+// Responses without a Content-Type are decoded.
+func decodesJSON(rsp *http.Response, body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+	contentType := rsp.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
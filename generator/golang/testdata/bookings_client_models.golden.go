@@ -0,0 +1,26 @@
+package models
+
+type Station struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Booking struct {
+	ID            *string `json:"id,omitempty"`
+	TripID        *string `json:"trip_id,omitempty"`
+	PassengerName *string `json:"passenger_name,omitempty"`
+}
+
+type Problem struct {
+	Title  *string `json:"title,omitempty"`
+	Status *int    `json:"status,omitempty"`
+}
+
+type GetStationsFilter struct {
+	Name     *string `json:"name,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+type GetStations200Response struct {
+	Data []Station `json:"data,omitempty"`
+}
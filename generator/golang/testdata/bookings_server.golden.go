@@ -0,0 +1,770 @@
+package models
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ServerInterface is implemented by the API server. NewHandler adapts it to net/http.
+type ServerInterface interface {
+	// GetStations Get a list of train stations.
+	GetStations(ctx context.Context, request GetStationsRequest) (GetStationsResult, error)
+	// GetBooking Get a booking.
+	GetBooking(ctx context.Context, request GetBookingRequest) (GetBookingResult, error)
+	// DeleteBooking calls DELETE /bookings/{bookingId}.
+	//
+	// Deprecated: the DeleteBooking operation is deprecated.
+	DeleteBooking(ctx context.Context, request DeleteBookingRequest) (DeleteBookingResult, error)
+	// CreateBooking calls POST /bookings.
+	CreateBooking(ctx context.Context, request CreateBookingRequest) (CreateBookingResult, error)
+	// PutBookingsBookingIDTicketFormat calls PUT /bookings/{bookingId}/ticket{format}.
+	PutBookingsBookingIDTicketFormat(ctx context.Context, request PutBookingsBookingIDTicketFormatRequest) (PutBookingsBookingIDTicketFormatResult, error)
+}
+
+// UnimplementedServer answers every operation with 501 Not Implemented.
+// Embed it to implement ServerInterface incrementally.
+type UnimplementedServer struct{}
+
+func (UnimplementedServer) GetStations(ctx context.Context, request GetStationsRequest) (GetStationsResult, error) {
+	return GetStationsResult{StatusCode: http.StatusNotImplemented}, nil
+}
+
+func (UnimplementedServer) GetBooking(ctx context.Context, request GetBookingRequest) (GetBookingResult, error) {
+	return GetBookingResult{StatusCode: http.StatusNotImplemented}, nil
+}
+
+func (UnimplementedServer) DeleteBooking(ctx context.Context, request DeleteBookingRequest) (DeleteBookingResult, error) {
+	return DeleteBookingResult{StatusCode: http.StatusNotImplemented}, nil
+}
+
+func (UnimplementedServer) CreateBooking(ctx context.Context, request CreateBookingRequest) (CreateBookingResult, error) {
+	return CreateBookingResult{StatusCode: http.StatusNotImplemented}, nil
+}
+
+func (UnimplementedServer) PutBookingsBookingIDTicketFormat(ctx context.Context, request PutBookingsBookingIDTicketFormatRequest) (PutBookingsBookingIDTicketFormatResult, error) {
+	return PutBookingsBookingIDTicketFormatResult{StatusCode: http.StatusNotImplemented}, nil
+}
+
+// NewHandler returns an http.Handler that binds requests and calls server.
+func NewHandler(server ServerInterface, opts ...HandlerOption) http.Handler {
+	h := &serverHandler{server: server, errorHandler: defaultErrorHandler}
+	for _, opt := range opts {
+		opt(h)
+	}
+	h.routes = []serverRoute{
+		{method: "GET", pattern: regexp.MustCompile("^/stations$"), handle: (*serverHandler).handleGetStations},
+		{method: "POST", pattern: regexp.MustCompile("^/bookings$"), handle: (*serverHandler).handleCreateBooking},
+		{method: "GET", pattern: regexp.MustCompile("^/bookings/([^/]+)$"), handle: (*serverHandler).handleGetBooking},
+		{method: "DELETE", pattern: regexp.MustCompile("^/bookings/([^/]+)$"), handle: (*serverHandler).handleDeleteBooking},
+		{method: "PUT", pattern: regexp.MustCompile("^/bookings/([^/]+)/ticket([^/]+)$"), handle: (*serverHandler).handlePutBookingsBookingIDTicketFormat},
+	}
+	return h
+}
+
+// RequestError reports a request that could not be bound to the parameters or
+// body of an operation.
+type RequestError struct {
+	// In is the parameter location, or body.
+	In   string
+	Name string
+	// Status is the HTTP status written by the default error handler.
+	Status int
+	Err    error
+}
+
+func (e *RequestError) Error() string {
+	if e.Name == "" {
+		return e.In + ": " + e.Err.Error()
+	}
+	return e.In + " parameter " + e.Name + ": " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// HandlerOption configures the handler returned by NewHandler.
+type HandlerOption func(*serverHandler)
+
+// WithErrorHandler sets the function that writes binding, operation, and
+// response errors. The default writes a *RequestError with its Status and
+// every other error as 500 Internal Server Error.
+func WithErrorHandler(fn func(w http.ResponseWriter, r *http.Request, err error)) HandlerOption {
+	return func(h *serverHandler) {
+		h.errorHandler = fn
+	}
+}
+
+type serverRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handle  func(h *serverHandler, w http.ResponseWriter, r *http.Request, pathValues []string)
+}
+
+type serverHandler struct {
+	server       ServerInterface
+	errorHandler func(w http.ResponseWriter, r *http.Request, err error)
+	routes       []serverRoute
+}
+
+func (h *serverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	var allowed []string
+	for _, route := range h.routes {
+		match := route.pattern.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		if route.method != r.Method {
+			allowed = append(allowed, route.method)
+			continue
+		}
+		route.handle(h, w, r, match[1:])
+		return
+	}
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		http.Error(w, requestErr.Error(), requestErr.Status)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// writeResult writes a result with status, or fallback when status is zero.
+// A non-nil value is encoded as JSON; otherwise body is written as is.
+func writeResult(w http.ResponseWriter, header http.Header, status, fallback int, contentType string, value any, body []byte) error {
+	if status == 0 {
+		status = fallback
+	}
+	if status == 0 {
+		return errors.New("result has no status code")
+	}
+	if value != nil {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		body = encoded
+	}
+	for key, values := range header {
+		w.Header()[key] = values
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
+
+// GetStationsRequest contains the bound request of GetStations.
+type GetStationsRequest struct {
+	// Page is the page query parameter.
+	Page *int
+	// Country is the country query parameter.
+	Country string
+	// Coordinates is the coordinates query parameter.
+	Coordinates []float64
+	// Filter is the filter query parameter.
+	Filter *GetStationsFilter
+	// XRequestID Correlates the request in server logs.
+	XRequestID *string
+	// Session is the session cookie parameter.
+	Session *string
+}
+
+// GetStationsResult is the response written for GetStations.
+type GetStationsResult struct {
+	// StatusCode defaults to the documented status of the JSON field that is
+	// set, or 200 when none is.
+	StatusCode int
+	Header     http.Header
+	// JSON200 is written as the 200 response: OK.
+	JSON200 *GetStations200Response
+	// JSON4XX is written as the 4xx response: Client error.
+	JSON4XX *Problem
+	// ContentType and Body are written when no JSON field is set.
+	ContentType string
+	Body        []byte
+}
+
+func (r GetStationsResult) write(w http.ResponseWriter) error {
+	switch {
+	case r.JSON200 != nil:
+		return writeResult(w, r.Header, r.StatusCode, 200, "application/json", r.JSON200, nil)
+	case r.JSON4XX != nil:
+		return writeResult(w, r.Header, r.StatusCode, 0, "application/problem+json", r.JSON4XX, nil)
+	}
+	return writeResult(w, r.Header, r.StatusCode, 200, r.ContentType, nil, r.Body)
+}
+
+func (h *serverHandler) handleGetStations(w http.ResponseWriter, r *http.Request, pathValues []string) {
+	var request GetStationsRequest
+	query := r.URL.Query()
+	if err := bindQueryParam(&request.Page, query, "form", true, false, "page"); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := bindQueryParam(&request.Country, query, "form", true, true, "country"); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := bindQueryParam(&request.Coordinates, query, "form", false, false, "coordinates"); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := bindQueryParam(&request.Filter, query, "deepObject", false, false, "filter"); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := bindHeaderParam(&request.XRequestID, r.Header, "simple", false, false, "X-Request-Id"); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := bindCookieParam(&request.Session, r, "form", true, false, "session"); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	result, err := h.server.GetStations(r.Context(), request)
+	if err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := result.write(w); err != nil {
+		h.errorHandler(w, r, err)
+	}
+}
+
+// GetBookingRequest contains the bound request of GetBooking.
+type GetBookingRequest struct {
+	// BookingID is the bookingId path parameter.
+	BookingID string
+}
+
+// GetBookingResult is the response written for GetBooking.
+type GetBookingResult struct {
+	// StatusCode defaults to the documented status of the JSON field that is
+	// set, or 200 when none is.
+	StatusCode int
+	Header     http.Header
+	// JSON200 is written as the 200 response: The booking.
+	JSON200 *Booking
+	// JSONDefault is written as the default response: Unexpected error.
+	JSONDefault *Problem
+	// ContentType and Body are written when no JSON field is set.
+	ContentType string
+	Body        []byte
+}
+
+func (r GetBookingResult) write(w http.ResponseWriter) error {
+	switch {
+	case r.JSON200 != nil:
+		return writeResult(w, r.Header, r.StatusCode, 200, "application/json", r.JSON200, nil)
+	case r.JSONDefault != nil:
+		return writeResult(w, r.Header, r.StatusCode, 0, "application/json", r.JSONDefault, nil)
+	}
+	return writeResult(w, r.Header, r.StatusCode, 200, r.ContentType, nil, r.Body)
+}
+
+func (h *serverHandler) handleGetBooking(w http.ResponseWriter, r *http.Request, pathValues []string) {
+	var request GetBookingRequest
+	if err := bindPathParam(&request.BookingID, "simple", false, "bookingId", pathValues[0]); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	result, err := h.server.GetBooking(r.Context(), request)
+	if err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := result.write(w); err != nil {
+		h.errorHandler(w, r, err)
+	}
+}
+
+// DeleteBookingRequest contains the bound request of DeleteBooking.
+type DeleteBookingRequest struct {
+	// BookingID is the bookingId path parameter.
+	BookingID string
+}
+
+// DeleteBookingResult is the response written for DeleteBooking.
+type DeleteBookingResult struct {
+	// StatusCode defaults to the documented status of the JSON field that is
+	// set, or 204 when none is.
+	StatusCode int
+	Header     http.Header
+	// ContentType and Body are written when no JSON field is set.
+	ContentType string
+	Body        []byte
+}
+
+func (r DeleteBookingResult) write(w http.ResponseWriter) error {
+	return writeResult(w, r.Header, r.StatusCode, 204, r.ContentType, nil, r.Body)
+}
+
+func (h *serverHandler) handleDeleteBooking(w http.ResponseWriter, r *http.Request, pathValues []string) {
+	var request DeleteBookingRequest
+	if err := bindPathParam(&request.BookingID, "simple", false, "bookingId", pathValues[0]); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	result, err := h.server.DeleteBooking(r.Context(), request)
+	if err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := result.write(w); err != nil {
+		h.errorHandler(w, r, err)
+	}
+}
+
+// CreateBookingRequest contains the bound request of CreateBooking.
+type CreateBookingRequest struct {
+	// Body is the decoded application/json request body.
+	Body Booking
+}
+
+// CreateBookingResult is the response written for CreateBooking.
+type CreateBookingResult struct {
+	// StatusCode defaults to the documented status of the JSON field that is
+	// set, or 201 when none is.
+	StatusCode int
+	Header     http.Header
+	// JSON201 is written as the 201 response: Created.
+	JSON201 *Booking
+	// ContentType and Body are written when no JSON field is set.
+	ContentType string
+	Body        []byte
+}
+
+func (r CreateBookingResult) write(w http.ResponseWriter) error {
+	switch {
+	case r.JSON201 != nil:
+		return writeResult(w, r.Header, r.StatusCode, 201, "application/json", r.JSON201, nil)
+	}
+	return writeResult(w, r.Header, r.StatusCode, 201, r.ContentType, nil, r.Body)
+}
+
+func (h *serverHandler) handleCreateBooking(w http.ResponseWriter, r *http.Request, pathValues []string) {
+	var request CreateBookingRequest
+	if err := bindJSONBody(&request.Body, r, true); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	result, err := h.server.CreateBooking(r.Context(), request)
+	if err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := result.write(w); err != nil {
+		h.errorHandler(w, r, err)
+	}
+}
+
+// PutBookingsBookingIDTicketFormatRequest contains the bound request of PutBookingsBookingIDTicketFormat.
+type PutBookingsBookingIDTicketFormatRequest struct {
+	// BookingID is the bookingId path parameter.
+	BookingID string
+	// Format is the format path parameter.
+	Format string
+	// ContentType is the request Content-Type.
+	ContentType string
+	// Body is the unread request body.
+	Body io.Reader
+}
+
+// PutBookingsBookingIDTicketFormatResult is the response written for PutBookingsBookingIDTicketFormat.
+type PutBookingsBookingIDTicketFormatResult struct {
+	// StatusCode defaults to the documented status of the JSON field that is
+	// set, or 200 when none is.
+	StatusCode int
+	Header     http.Header
+	// ContentType and Body are written when no JSON field is set.
+	ContentType string
+	Body        []byte
+}
+
+func (r PutBookingsBookingIDTicketFormatResult) write(w http.ResponseWriter) error {
+	return writeResult(w, r.Header, r.StatusCode, 200, r.ContentType, nil, r.Body)
+}
+
+func (h *serverHandler) handlePutBookingsBookingIDTicketFormat(w http.ResponseWriter, r *http.Request, pathValues []string) {
+	var request PutBookingsBookingIDTicketFormatRequest
+	if err := bindPathParam(&request.BookingID, "simple", false, "bookingId", pathValues[0]); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := bindPathParam(&request.Format, "label", false, "format", pathValues[1]); err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	request.ContentType = r.Header.Get("Content-Type")
+	request.Body = r.Body
+	result, err := h.server.PutBookingsBookingIDTicketFormat(r.Context(), request)
+	if err != nil {
+		h.errorHandler(w, r, err)
+		return
+	}
+	if err := result.write(w); err != nil {
+		h.errorHandler(w, r, err)
+	}
+}
+
+type bindKind int
+
+const (
+	bindPrimitive bindKind = iota
+	bindArray
+	bindObject
+)
+
+var bindTextUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// bindTarget reports how a parameter destination is serialized.
+func bindTarget(dest any) bindKind {
+	t := reflect.TypeOf(dest).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case reflect.PointerTo(t).Implements(bindTextUnmarshaler):
+		return bindPrimitive
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8, t.Kind() == reflect.Array:
+		return bindArray
+	case t.Kind() == reflect.Struct, t.Kind() == reflect.Map:
+		return bindObject
+	default:
+		return bindPrimitive
+	}
+}
+
+// bindAlloc allocates nil pointers on the way to the settable value.
+func bindAlloc(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func bindScalar(v reflect.Value, raw string) error {
+	v = bindAlloc(v)
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return errors.New("unsupported parameter type " + v.Type().String())
+		}
+		v.Set(reflect.ValueOf(raw))
+	default:
+		return json.Unmarshal([]byte(raw), v.Addr().Interface())
+	}
+	return nil
+}
+
+// bindParts binds serialized parts into dest. Objects read alternating keys
+// and values, or key=value pairs when keyValue is set.
+func bindParts(dest any, parts []string, keyValue bool) error {
+	v := reflect.ValueOf(dest).Elem()
+	switch bindTarget(dest) {
+	case bindArray:
+		v = bindAlloc(v)
+		if v.Kind() == reflect.Array {
+			if len(parts) > v.Len() {
+				return errors.New("too many values")
+			}
+		} else {
+			v.Set(reflect.MakeSlice(v.Type(), len(parts), len(parts)))
+		}
+		for i, part := range parts {
+			if err := bindScalar(v.Index(i), part); err != nil {
+				return err
+			}
+		}
+		return nil
+	case bindObject:
+		fields := make(map[string]string)
+		if keyValue {
+			for _, part := range parts {
+				key, value, _ := strings.Cut(part, "=")
+				fields[key] = value
+			}
+		} else {
+			for i := 0; i+1 < len(parts); i += 2 {
+				fields[parts[i]] = parts[i+1]
+			}
+		}
+		return bindFields(v, fields)
+	default:
+		return bindScalar(v, strings.Join(parts, ","))
+	}
+}
+
+// bindFields binds object fields into a struct by JSON name or into a map.
+func bindFields(v reflect.Value, fields map[string]string) error {
+	v = bindAlloc(v)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for key, raw := range fields {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := bindScalar(elem, raw); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" || !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			raw, ok := fields[name]
+			if !ok {
+				continue
+			}
+			if err := bindScalar(v.Field(i), raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bindValue binds one raw path, header, or cookie value serialized with the
+// simple, label, matrix, or form style. Parameters declared with content use
+// the json pseudo style.
+func bindValue(dest any, style string, explode bool, name, raw string) error {
+	if style == "json" {
+		return json.Unmarshal([]byte(raw), dest)
+	}
+	kind := bindTarget(dest)
+	separator := ","
+	keyValue := false
+	switch style {
+	case "label":
+		raw = strings.TrimPrefix(raw, ".")
+		if explode && kind != bindPrimitive {
+			separator = "."
+			keyValue = kind == bindObject
+		}
+	case "matrix":
+		raw = strings.TrimPrefix(raw, ";")
+		switch {
+		case explode && kind == bindObject:
+			separator = ";"
+			keyValue = true
+		case explode && kind == bindArray:
+			separator = ";"
+		default:
+			raw = strings.TrimPrefix(raw, name+"=")
+		}
+	case "simple":
+		keyValue = explode && kind == bindObject
+	}
+	var parts []string
+	if raw != "" || kind == bindPrimitive {
+		parts = strings.Split(raw, separator)
+	}
+	if style == "matrix" && explode && kind == bindArray {
+		for i := range parts {
+			parts[i] = strings.TrimPrefix(parts[i], name+"=")
+		}
+	}
+	return bindParts(dest, parts, keyValue)
+}
+
+// bindPathParam binds a path parameter captured by the router.
+func bindPathParam(dest any, style string, explode bool, name, raw string) error {
+	unescaped, err := url.PathUnescape(raw)
+	if err == nil {
+		err = bindValue(dest, style, explode, name, unescaped)
+	}
+	if err != nil {
+		return &RequestError{In: "path", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+// bindQueryParam binds a query parameter serialized with the form,
+// spaceDelimited, pipeDelimited, or deepObject style.
+func bindQueryParam(dest any, query url.Values, style string, explode, required bool, name string) error {
+	err := bindQuery(dest, query, style, explode, name)
+	if errors.Is(err, errMissingParam) && !required {
+		return nil
+	}
+	if err != nil {
+		return &RequestError{In: "query", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+func bindQuery(dest any, query url.Values, style string, explode bool, name string) error {
+	if style == "json" {
+		if !query.Has(name) {
+			return errMissingParam
+		}
+		return json.Unmarshal([]byte(query.Get(name)), dest)
+	}
+	kind := bindTarget(dest)
+	switch {
+	case kind == bindObject && style == "deepObject":
+		fields := make(map[string]string)
+		prefix := name + "["
+		for key, values := range query {
+			if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, "]") && len(values) > 0 {
+				fields[key[len(prefix):len(key)-1]] = values[0]
+			}
+		}
+		if len(fields) == 0 {
+			return errMissingParam
+		}
+		return bindFields(reflect.ValueOf(dest).Elem(), fields)
+	case kind == bindObject && explode:
+		fields := make(map[string]string)
+		for key, values := range query {
+			if len(values) > 0 {
+				fields[key] = values[0]
+			}
+		}
+		if len(fields) == 0 {
+			return errMissingParam
+		}
+		return bindFields(reflect.ValueOf(dest).Elem(), fields)
+	case kind == bindArray && explode:
+		values, ok := query[name]
+		if !ok {
+			return errMissingParam
+		}
+		return bindParts(dest, values, false)
+	default:
+		if !query.Has(name) {
+			return errMissingParam
+		}
+		raw := query.Get(name)
+		separator := ","
+		if kind == bindArray && style == "spaceDelimited" {
+			separator = " "
+		} else if kind == bindArray && style == "pipeDelimited" {
+			separator = "|"
+		}
+		var parts []string
+		if raw != "" || kind == bindPrimitive {
+			parts = strings.Split(raw, separator)
+		}
+		return bindParts(dest, parts, false)
+	}
+}
+
+// bindHeaderParam binds a header parameter serialized with the simple style.
+func bindHeaderParam(dest any, header http.Header, style string, explode, required bool, name string) error {
+	values := header.Values(name)
+	if len(values) == 0 {
+		if required {
+			return &RequestError{In: "header", Name: name, Status: http.StatusBadRequest, Err: errMissingParam}
+		}
+		return nil
+	}
+	if err := bindValue(dest, style, explode, name, strings.Join(values, ",")); err != nil {
+		return &RequestError{In: "header", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+// bindCookieParam binds a cookie parameter serialized with the form style.
+func bindCookieParam(dest any, r *http.Request, style string, explode, required bool, name string) error {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		if required {
+			return &RequestError{In: "cookie", Name: name, Status: http.StatusBadRequest, Err: errMissingParam}
+		}
+		return nil
+	}
+	if err := bindValue(dest, style, explode, name, cookie.Value); err != nil {
+		return &RequestError{In: "cookie", Name: name, Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
+
+var errMissingParam = errors.New("required parameter is missing")
+
+// bindJSONBody decodes a JSON request body. Requests without a Content-Type
+// are decoded; other non-JSON media types are rejected.
+func bindJSONBody(dest any, r *http.Request, required bool) error {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+			return &RequestError{In: "body", Status: http.StatusUnsupportedMediaType, Err: errors.New("unsupported content type " + contentType)}
+		}
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return &RequestError{In: "body", Status: http.StatusBadRequest, Err: err}
+	}
+	if len(data) == 0 {
+		if required {
+			return &RequestError{In: "body", Status: http.StatusBadRequest, Err: errors.New("request body is required")}
+		}
+		return nil
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return &RequestError{In: "body", Status: http.StatusBadRequest, Err: err}
+	}
+	return nil
+}
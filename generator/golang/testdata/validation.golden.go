@@ -0,0 +1,451 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type Booking_Extras struct {
+	AdditionalProperties map[string]int `json:"-"`
+}
+
+func (m *Booking_Extras) UnmarshalJSON(data []byte) error {
+	type Alias Booking_Extras
+	var known Alias
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	*m = Booking_Extras(known)
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	m.AdditionalProperties = make(map[string]int, len(raw))
+	for key, value := range raw {
+		var decoded int
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return err
+		}
+		m.AdditionalProperties[key] = decoded
+	}
+	return nil
+}
+
+func (m Booking_Extras) MarshalJSON() ([]byte, error) {
+	type Alias Booking_Extras
+	encoded, err := json.Marshal(Alias(m))
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &object); err != nil {
+		return nil, err
+	}
+	for key, value := range m.AdditionalProperties {
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = encodedValue
+	}
+	return json.Marshal(object)
+}
+
+func (m Booking_Extras) Validate() error {
+	var errs ValidationErrors
+	for key, value := range m.AdditionalProperties {
+		if value < 1 {
+			errs.add("/"+validationToken(key), "must be at least 1")
+		}
+	}
+	return errs.orNil()
+}
+
+type Booking struct {
+	ID         string         `json:"id"`
+	Passengers []Passenger    `json:"passengers"`
+	Seat       SeatClass      `json:"seat"`
+	Trip       TripUnion      `json:"trip"`
+	Price      *float64       `json:"price,omitempty"`
+	Tags       []string       `json:"tags,omitempty"`
+	Extras     map[string]int `json:"extras,omitempty"`
+	Note       *string        `json:"note,omitempty"`
+}
+
+func (m Booking) Validate() error {
+	var errs ValidationErrors
+	if !validationPattern1.MatchString(m.ID) {
+		errs.add("/id", "must match pattern ^[A-Z]{3}-[0-9]+$")
+	}
+	if m.Passengers == nil {
+		errs.add("/passengers", "is required")
+	} else {
+		if len(m.Passengers) < 1 {
+			errs.add("/passengers", "must have at least 1 items")
+		}
+		if len(m.Passengers) > 4 {
+			errs.add("/passengers", "must have at most 4 items")
+		}
+		for i, item := range m.Passengers {
+			errs.nested("/passengers/"+strconv.Itoa(i), item)
+		}
+	}
+	errs.nested("/seat", m.Seat)
+	if m.Trip.IsZero() {
+		errs.add("/trip", "is required")
+	} else {
+		errs.nested("/trip", m.Trip)
+	}
+	if m.Price != nil {
+		if *m.Price > 10000 {
+			errs.add("/price", "must be at most 10000")
+		}
+		if *m.Price <= 0 {
+			errs.add("/price", "must be greater than 0")
+		}
+		if !validationMultipleOf(float64(*m.Price), 0.01) {
+			errs.add("/price", "must be a multiple of 0.01")
+		}
+	}
+	if !validationUnique(m.Tags) {
+		errs.add("/tags", "items must be unique")
+	}
+	for i, item := range m.Tags {
+		if utf8.RuneCountInString(item) > 8 {
+			errs.add("/tags/"+strconv.Itoa(i), "length must be at most 8")
+		}
+	}
+	if len(m.Extras) > 2 {
+		errs.add("/extras", "must have at most 2 properties")
+	}
+	for key, value := range m.Extras {
+		if value < 1 {
+			errs.add("/extras/"+validationToken(key), "must be at least 1")
+		}
+	}
+	if m.Note != nil {
+		if utf8.RuneCountInString(*m.Note) < 2 {
+			errs.add("/note", "length must be at least 2")
+		}
+	}
+	return errs.orNil()
+}
+
+type Passenger struct {
+	Name    string        `json:"name"`
+	Age     *int          `json:"age,omitempty"`
+	Contact *ContactUnion `json:"contact,omitempty"`
+}
+
+func (m Passenger) Validate() error {
+	var errs ValidationErrors
+	if utf8.RuneCountInString(m.Name) < 1 {
+		errs.add("/name", "length must be at least 1")
+	}
+	if utf8.RuneCountInString(m.Name) > 20 {
+		errs.add("/name", "length must be at most 20")
+	}
+	if m.Age != nil {
+		if *m.Age < 0 {
+			errs.add("/age", "must be at least 0")
+		}
+		if *m.Age > 130 {
+			errs.add("/age", "must be at most 130")
+		}
+	}
+	if m.Contact != nil {
+		errs.nested("/contact", *m.Contact)
+	}
+	return errs.orNil()
+}
+
+type SeatClass string
+
+const (
+	SeatClassEconomy  SeatClass = "economy"
+	SeatClassBusiness SeatClass = "business"
+)
+
+func (v SeatClass) Validate() error {
+	var errs ValidationErrors
+	switch v {
+	case "economy", "business":
+	default:
+		errs.add("", "must be one of \"economy\", \"business\"")
+	}
+	return errs.orNil()
+}
+
+type Trip interface {
+	isTrip()
+}
+
+func (Rail) isTrip() {}
+
+func (Coach) isTrip() {}
+
+type TripUnion struct {
+	Value Trip
+}
+
+func (u TripUnion) MarshalJSON() ([]byte, error) {
+	if u.Value == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.Value)
+}
+
+func (u TripUnion) IsZero() bool {
+	return u.Value == nil
+}
+
+func (u *TripUnion) UnmarshalJSON(data []byte) error {
+	var discriminator struct {
+		Value string `json:"mode"`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+	switch discriminator.Value {
+	case "coach":
+		var v Coach
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		u.Value = v
+	case "rail":
+		var v Rail
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		u.Value = v
+	default:
+		return fmt.Errorf("unknown mode discriminator value %q", discriminator.Value)
+	}
+	return nil
+}
+
+func (u TripUnion) Validate() error {
+	var errs ValidationErrors
+	errs.nested("", u.Value)
+	return errs.orNil()
+}
+
+type Rail struct {
+	Mode     string `json:"mode"`
+	Carriage int    `json:"carriage"`
+}
+
+func (m Rail) Validate() error {
+	var errs ValidationErrors
+	if m.Carriage < 1 {
+		errs.add("/carriage", "must be at least 1")
+	}
+	return errs.orNil()
+}
+
+type Coach struct {
+	Mode     string  `json:"mode"`
+	Operator *string `json:"operator,omitempty"`
+}
+
+func (m Coach) Validate() error {
+	var errs ValidationErrors
+	if m.Operator != nil {
+		if !validationPattern2.MatchString(*m.Operator) {
+			errs.add("/operator", "must match pattern ^[a-z]+$")
+		}
+	}
+	return errs.orNil()
+}
+
+type ContactUnion struct {
+	Raw json.RawMessage
+}
+
+func (u *ContactUnion) UnmarshalJSON(data []byte) error {
+	u.Raw = append(u.Raw[:0], data...)
+	return nil
+}
+
+func (u ContactUnion) MarshalJSON() ([]byte, error) {
+	if len(u.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return u.Raw, nil
+}
+
+func (u ContactUnion) IsZero() bool {
+	return len(u.Raw) == 0
+}
+
+func (u ContactUnion) Bytes() []byte {
+	return append([]byte(nil), u.Raw...)
+}
+
+func (u ContactUnion) Validate() error {
+	var errs ValidationErrors
+	if len(u.Raw) == 0 {
+		return nil
+	}
+	var variant1 Email
+	if validationDecode(u.Raw, &variant1) {
+		var variantErrs ValidationErrors
+		variantErrs.nested("", variant1)
+		if len(variantErrs) == 0 {
+			return nil
+		}
+	}
+	var variant2 int
+	if validationDecode(u.Raw, &variant2) {
+		var variantErrs ValidationErrors
+		if variant2 < 1000 {
+			variantErrs.add("", "must be at least 1000")
+		}
+		if len(variantErrs) == 0 {
+			return nil
+		}
+	}
+	errs.add("", "does not match any anyOf variant")
+	return errs.orNil()
+}
+
+type Email string
+
+func (v Email) Validate() error {
+	var errs ValidationErrors
+	if !validationPattern3.MatchString(string(v)) {
+		errs.add("", "must match pattern ^[^@]+@[^@]+$")
+	}
+	return errs.orNil()
+}
+
+type Ratings []int
+
+func (v Ratings) Validate() error {
+	var errs ValidationErrors
+	if len(v) > 3 {
+		errs.add("", "must have at most 3 items")
+	}
+	for i, item := range v {
+		if item >= 6 {
+			errs.add("/"+strconv.Itoa(i), "must be less than 6")
+		}
+	}
+	return errs.orNil()
+}
+
+// ValidationError is one schema constraint violated by a generated value. Path
+// is a JSON pointer to the value, built from the schema property names.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors lists every constraint violation found by a Validate method.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationErrors) add(path, message string) {
+	*e = append(*e, ValidationError{Path: path, Message: message})
+}
+
+// nested validates value when it has a Validate method and reports its
+// violations below path.
+func (e *ValidationErrors) nested(path string, value any) {
+	validator, ok := value.(interface{ Validate() error })
+	if !ok {
+		return
+	}
+	err := validator.Validate()
+	var errs ValidationErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &errs):
+		for _, nested := range errs {
+			e.add(path+nested.Path, nested.Message)
+		}
+	default:
+		e.add(path, err.Error())
+	}
+}
+
+// validationToken escapes a map key as a JSON pointer reference token.
+func validationToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// validationUnique reports whether a slice holds no two equal JSON values.
+func validationUnique(items any) bool {
+	v := reflect.ValueOf(items)
+	seen := make(map[string]struct{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		data, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[string(data)]; ok {
+			return false
+		}
+		seen[string(data)] = struct{}{}
+	}
+	return true
+}
+
+func validationMultipleOf(value, divisor float64) bool {
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) <= 1e-9*math.Max(1, math.Abs(quotient))
+}
+
+func validationNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// validationDecode strictly decodes raw union data into one variant. Unknown
+// object fields and null do not match a variant.
+func validationDecode(data []byte, dest any) bool {
+	if validationNull(data) {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(dest) == nil && !decoder.More()
+}
+
+var (
+	validationPattern1 = regexp.MustCompile("^[A-Z]{3}-[0-9]+$")
+	validationPattern2 = regexp.MustCompile("^[a-z]+$")
+	validationPattern3 = regexp.MustCompile("^[^@]+@[^@]+$")
+)
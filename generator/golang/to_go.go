@@ -23,6 +23,10 @@ func (g *Generator) renderFile(irs []*SchemaIR) (*GeneratedFile, error) {
 	g.seenDecls = make(map[string]struct{})
 	g.metadataSchemas = make(map[string]*highbase.Schema)
 	g.metadataOrder = nil
+	g.helperNeeds = make(map[string]bool)
+	g.validationNeeds = make(map[string]bool)
+	g.validationPatterns = make(map[string]string)
+	g.validationPatternOrder = nil
 	types := make([]*GeneratedType, 0, len(irs))
 	for _, ir := range irs {
 		if ir == nil {
@@ -31,6 +35,9 @@ func (g *Generator) renderFile(irs []*SchemaIR) (*GeneratedFile, error) {
 		g.renderDecl(ir)
 		types = append(types, &GeneratedType{Name: ir.Name, Kind: ir.Kind})
 	}
+	if decl := g.renderModelHelpersDecl(); decl != "" {
+		g.decls = append(g.decls, decl)
+	}
 	metadataSource, err := g.renderSchemaMetadataSource()
 	if err != nil {
 		return nil, err
@@ -103,6 +110,31 @@ func (g *Generator) renderSchemaMetadataSource() (*GeneratedSourceFile, error) {
 	return &GeneratedSourceFile{Name: SchemaMetadataFileName, Source: src}, nil
 }
 
+// modelHelpers returns the runtime helpers used by the current file's
+// declarations, other than validation, in a stable order.
+func (g *Generator) modelHelpers() []runtimeHelper {
+	var helpers []runtimeHelper
+	if g.helperNeeds["unionDecode"] {
+		helpers = append(helpers, unionDecodeHelper)
+	}
+	return helpers
+}
+
+// renderModelHelpersDecl renders the runtime helpers, validation helpers, and
+// compiled validation patterns used by the current file.
+func (g *Generator) renderModelHelpersDecl() string {
+	var b strings.Builder
+	for _, helper := range g.modelHelpers() {
+		for _, path := range helper.imports {
+			g.addImport(path)
+		}
+		b.WriteString(helper.source)
+		b.WriteByte('\n')
+	}
+	b.WriteString(g.renderValidationDecl())
+	return b.String()
+}
+
 func (g *Generator) writeImports(b *strings.Builder) {
 	if len(g.imports) == 0 {
 		return
@@ -169,6 +201,7 @@ func (g *Generator) renderObjectDecl(ir *SchemaIR) {
 	b.WriteString(ir.Name)
 	b.WriteString(" struct {\n")
 	fields := newNameRegistry()
+	var validated []validationField
 	additionalFieldName := "AdditionalProperties"
 	if ir.AllOf != nil {
 		for _, embed := range ir.AllOf {
@@ -187,6 +220,7 @@ func (g *Generator) renderObjectDecl(ir *SchemaIR) {
 				g.addDiagnostic(DiagnosticFieldNameCollision, ir.Name+"."+propName, "field name collision resolved as "+fieldName)
 			}
 			fieldType := g.goType(prop, required, true)
+			validated = append(validated, validationField{name: fieldName, propName: propName, prop: prop, typ: fieldType, required: required})
 			writeFieldComments(&b, fieldName, prop)
 			b.WriteByte('\t')
 			b.WriteString(fieldName)
@@ -218,6 +252,9 @@ func (g *Generator) renderObjectDecl(ir *SchemaIR) {
 		g.addImport("encoding/json")
 		writeAdditionalPropertiesMethods(&b, ir, additionalFieldName, additionalValueType)
 	}
+	if g.validationMethods {
+		g.writeObjectValidation(&b, ir, validated, additionalFieldName, additionalValueType)
+	}
 	g.decls = append(g.decls, b.String())
 	g.recordSchemaMetadata(ir.Name, ir.SourceSchema)
 }
@@ -273,8 +310,12 @@ func (g *Generator) renderAliasDecl(ir *SchemaIR) {
 	b.WriteString("type ")
 	b.WriteString(ir.Name)
 	b.WriteByte(' ')
-	b.WriteString(g.goType(ir, true, false))
+	typ := g.goType(ir, true, false)
+	b.WriteString(typ)
 	b.WriteByte('\n')
+	if g.validationMethods {
+		g.writeAliasValidation(&b, ir, typ)
+	}
 	g.decls = append(g.decls, b.String())
 	g.recordSchemaMetadata(ir.Name, ir.SourceSchema)
 }
@@ -321,6 +362,9 @@ func (g *Generator) renderEnumDecl(ir *SchemaIR) {
 		}
 		b.WriteString(")\n")
 	}
+	if g.validationMethods {
+		g.writeEnumValidation(&b, ir, shape)
+	}
 	g.decls = append(g.decls, b.String())
 	g.recordSchemaMetadata(ir.Name, ir.SourceSchema)
 }
@@ -9,6 +9,37 @@ import (
 	"strings"
 )
 
+var unionDecodeHelper = runtimeHelper{
+	imports: []string{"bytes", "encoding/json"},
+	source: `func unionNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// unionDecode strictly decodes union data into one variant. Unknown object
+// fields and null do not match a variant.
+func unionDecode(data []byte, dest any) bool {
+	if unionNull(data) {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(dest) == nil && !decoder.More()
+}
+`,
+}
+
+// unionTarget is one discriminator value and the Go type it decodes into.
+type unionTarget struct {
+	value    string
+	typeName string
+}
+
+// unionAccessor is one generated As method and the variant type it returns.
+type unionAccessor struct {
+	name     string
+	typeName string
+}
+
 func (g *Generator) renderUnionDecl(ir *SchemaIR) {
 	if ir == nil || ir.Union == nil {
 		return
@@ -22,6 +53,12 @@ func (g *Generator) renderUnionDecl(ir *SchemaIR) {
 
 func (g *Generator) renderRawUnion(ir *SchemaIR) {
 	name := ir.Name + "Union"
+	decoding := g.unionVariantDecoding && len(nonNullVariants(ir.Union.Variants)) > 0
+	if decoding {
+		for _, variant := range ir.Union.Variants {
+			g.renderNested(variant)
+		}
+	}
 	if !g.rememberDecl(name) {
 		return
 	}
@@ -29,21 +66,85 @@ func (g *Generator) renderRawUnion(ir *SchemaIR) {
 	var b strings.Builder
 	b.WriteString("type ")
 	b.WriteString(name)
-	b.WriteString(" struct {\n\tRaw json.RawMessage\n}\n\n")
+	b.WriteString(" struct {\n\tRaw json.RawMessage\n")
+	if decoding {
+		b.WriteString("\tValue any\n")
+	}
+	b.WriteString("}\n\n")
+	if decoding {
+		g.writeRawUnionDecoding(&b, ir)
+	} else {
+		b.WriteString("func (u *")
+		b.WriteString(name)
+		b.WriteString(") UnmarshalJSON(data []byte) error {\n\tu.Raw = append(u.Raw[:0], data...)\n\treturn nil\n}\n\n")
+		b.WriteString("func (u ")
+		b.WriteString(name)
+		b.WriteString(") MarshalJSON() ([]byte, error) {\n\tif len(u.Raw) == 0 {\n\t\treturn []byte(\"null\"), nil\n\t}\n\treturn u.Raw, nil\n}\n")
+		b.WriteString("\nfunc (u ")
+		b.WriteString(name)
+		b.WriteString(") IsZero() bool {\n\treturn len(u.Raw) == 0\n}\n")
+	}
+	b.WriteString("\nfunc (u ")
+	b.WriteString(name)
+	b.WriteString(") Bytes() []byte {\n\treturn append([]byte(nil), u.Raw...)\n}\n")
+	if decoding {
+		writeUnionAccessors(&b, name, g.rawUnionAccessors(ir))
+	}
+	if g.validationMethods {
+		g.writeRawUnionValidation(&b, ir)
+	}
+	g.decls = append(g.decls, b.String())
+	g.recordSchemaMetadata(name, ir.SourceSchema)
+}
+
+// writeRawUnionDecoding writes JSON methods that keep the raw bytes and decode
+// them into Value. A discriminator property selects the variant when present;
+// otherwise the first variant that strictly decodes wins.
+func (g *Generator) writeRawUnionDecoding(b *strings.Builder, ir *SchemaIR) {
+	name := ir.Name + "Union"
+	g.addImport("errors")
+	g.helperNeeds["unionDecode"] = true
 	b.WriteString("func (u *")
 	b.WriteString(name)
-	b.WriteString(") UnmarshalJSON(data []byte) error {\n\tu.Raw = append(u.Raw[:0], data...)\n\treturn nil\n}\n\n")
+	b.WriteString(") UnmarshalJSON(data []byte) error {\n\tu.Raw = append(u.Raw[:0], data...)\n\tu.Value = nil\n\tif unionNull(data) {\n\t\treturn nil\n\t}\n")
+	if disc := ir.Union.Discriminator; disc != nil && len(disc.Mapping) > 0 {
+		b.WriteString("\tvar discriminator struct {\n\t\tValue *string `json:\"")
+		b.WriteString(disc.PropertyName)
+		b.WriteString("\"`\n\t}\n\tif json.Unmarshal(data, &discriminator) == nil && discriminator.Value != nil {\n\t\tswitch *discriminator.Value {\n")
+		for _, target := range g.discriminatorTargets(disc) {
+			b.WriteString("\t\tcase ")
+			b.WriteString(strconv.Quote(target.value))
+			b.WriteString(":\n\t\t\tvar v ")
+			b.WriteString(target.typeName)
+			b.WriteString("\n\t\t\tif err := json.Unmarshal(data, &v); err != nil {\n\t\t\t\treturn err\n\t\t\t}\n\t\t\tu.Value = v\n\t\t\treturn nil\n")
+		}
+		b.WriteString("\t\t}\n\t}\n")
+	}
+	for i, variant := range nonNullVariants(ir.Union.Variants) {
+		variantName := "variant" + intString(i+1)
+		b.WriteString("\tvar ")
+		b.WriteString(variantName)
+		b.WriteByte(' ')
+		b.WriteString(g.goType(variant, true, false))
+		b.WriteString("\n\tif unionDecode(data, &")
+		b.WriteString(variantName)
+		b.WriteString(") {\n\t\tu.Value = ")
+		b.WriteString(variantName)
+		b.WriteString("\n\t\treturn nil\n\t}\n")
+	}
+	keyword := "anyOf"
+	if ir.Union.Kind == UnionOneOf {
+		keyword = "oneOf"
+	}
+	b.WriteString("\treturn errors.New(")
+	b.WriteString(strconv.Quote(name + ": data does not match any " + keyword + " variant"))
+	b.WriteString(")\n}\n\n")
 	b.WriteString("func (u ")
 	b.WriteString(name)
-	b.WriteString(") MarshalJSON() ([]byte, error) {\n\tif len(u.Raw) == 0 {\n\t\treturn []byte(\"null\"), nil\n\t}\n\treturn u.Raw, nil\n}\n")
-	b.WriteString("\nfunc (u ")
-	b.WriteString(name)
-	b.WriteString(") IsZero() bool {\n\treturn len(u.Raw) == 0\n}\n")
+	b.WriteString(") MarshalJSON() ([]byte, error) {\n\tif u.Value != nil {\n\t\treturn json.Marshal(u.Value)\n\t}\n\tif len(u.Raw) == 0 {\n\t\treturn []byte(\"null\"), nil\n\t}\n\treturn u.Raw, nil\n}\n")
 	b.WriteString("\nfunc (u ")
 	b.WriteString(name)
-	b.WriteString(") Bytes() []byte {\n\treturn append([]byte(nil), u.Raw...)\n}\n")
-	g.decls = append(g.decls, b.String())
-	g.recordSchemaMetadata(name, ir.SourceSchema)
+	b.WriteString(") IsZero() bool {\n\treturn len(u.Raw) == 0 && u.Value == nil\n}\n")
 }
 
 func (g *Generator) renderDiscriminatedUnion(ir *SchemaIR) {
@@ -91,26 +192,112 @@ func (g *Generator) renderDiscriminatedUnion(ir *SchemaIR) {
 	b.WriteString("Union) UnmarshalJSON(data []byte) error {\n\tvar discriminator struct {\n\t\tValue string `json:\"")
 	b.WriteString(ir.Union.Discriminator.PropertyName)
 	b.WriteString("\"`\n\t}\n\tif err := json.Unmarshal(data, &discriminator); err != nil {\n\t\treturn err\n\t}\n\tswitch discriminator.Value {\n")
-	values := make([]string, 0, len(ir.Union.Discriminator.Mapping))
-	for value := range ir.Union.Discriminator.Mapping {
-		values = append(values, value)
-	}
-	sort.Strings(values)
-	for _, value := range values {
-		target := ir.Union.Discriminator.Mapping[value]
-		typeName := target
-		if strings.HasPrefix(target, "#") || strings.Contains(target, "/") || strings.Contains(target, ".") {
-			typeName = g.refTypeName(target)
-		}
+	targets := g.discriminatorTargets(ir.Union.Discriminator)
+	for _, target := range targets {
 		b.WriteString("\tcase ")
-		b.WriteString(strconv.Quote(value))
+		b.WriteString(strconv.Quote(target.value))
 		b.WriteString(":\n\t\tvar v ")
-		b.WriteString(typeName)
+		b.WriteString(target.typeName)
 		b.WriteString("\n\t\tif err := json.Unmarshal(data, &v); err != nil {\n\t\t\treturn err\n\t\t}\n\t\tu.Value = v\n")
 	}
 	b.WriteString("\tdefault:\n\t\treturn fmt.Errorf(\"unknown ")
 	b.WriteString(ir.Union.Discriminator.PropertyName)
 	b.WriteString(" discriminator value %q\", discriminator.Value)\n\t}\n\treturn nil\n}\n")
+	if g.unionVariantDecoding {
+		writeUnionAccessors(&b, ir.Name+"Union", g.discriminatedUnionAccessors(ir, targets))
+	}
+	if g.validationMethods {
+		g.writeDiscriminatedUnionValidation(&b, ir)
+	}
 	g.decls = append(g.decls, b.String())
 	g.recordSchemaMetadata(ir.Name+"Union", ir.SourceSchema)
 }
+
+// discriminatorTargets resolves discriminator mapping values to Go type names,
+// sorted by value.
+func (g *Generator) discriminatorTargets(disc *Discriminator) []unionTarget {
+	targets := make([]unionTarget, 0, len(disc.Mapping))
+	for value, target := range disc.Mapping {
+		typeName := target
+		if strings.HasPrefix(target, "#") || strings.Contains(target, "/") || strings.Contains(target, ".") {
+			typeName = g.refTypeName(target)
+		}
+		targets = append(targets, unionTarget{value: value, typeName: typeName})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].value < targets[j].value })
+	return targets
+}
+
+// discriminatedUnionAccessors returns one accessor per variant that a
+// discriminator value decodes into, in variant order.
+func (g *Generator) discriminatedUnionAccessors(ir *SchemaIR, targets []unionTarget) []unionAccessor {
+	decoded := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		decoded[target.typeName] = struct{}{}
+	}
+	var typeNames []string
+	for _, variant := range ir.Union.Variants {
+		if variant == nil {
+			continue
+		}
+		if _, ok := decoded[variant.Name]; ok {
+			typeNames = append(typeNames, variant.Name)
+		}
+	}
+	return g.unionAccessors(ir.Name, typeNames)
+}
+
+func (g *Generator) rawUnionAccessors(ir *SchemaIR) []unionAccessor {
+	var typeNames []string
+	for _, variant := range nonNullVariants(ir.Union.Variants) {
+		typeNames = append(typeNames, g.goType(variant, true, false))
+	}
+	return g.unionAccessors(ir.Name, typeNames)
+}
+
+// unionAccessors names one As method per distinct variant type. Nested
+// variant names drop the union name prefix, so Pet_Cat becomes AsCat.
+func (g *Generator) unionAccessors(parent string, typeNames []string) []unionAccessor {
+	names := newNameRegistry()
+	seen := make(map[string]struct{}, len(typeNames))
+	var accessors []unionAccessor
+	for _, typeName := range typeNames {
+		if _, ok := seen[typeName]; ok || typeName == "" {
+			continue
+		}
+		seen[typeName] = struct{}{}
+		name, _ := names.resolve(typeName, "As"+g.unionAccessorSuffix(parent, typeName))
+		accessors = append(accessors, unionAccessor{name: name, typeName: typeName})
+	}
+	return accessors
+}
+
+func (g *Generator) unionAccessorSuffix(parent, typeName string) string {
+	switch {
+	case strings.HasPrefix(typeName, "[]"):
+		return g.unionAccessorSuffix(parent, typeName[2:]) + "List"
+	case strings.HasPrefix(typeName, "map[string]"):
+		return g.unionAccessorSuffix(parent, typeName[len("map[string]"):]) + "Map"
+	}
+	if i := strings.LastIndex(typeName, "."); i >= 0 {
+		typeName = typeName[i+1:]
+	}
+	if prefix := parent + g.nestedTypeNameDelimiter; strings.HasPrefix(typeName, prefix) && len(typeName) > len(prefix) {
+		typeName = typeName[len(prefix):]
+	}
+	return strings.ToUpper(typeName[:1]) + typeName[1:]
+}
+
+func writeUnionAccessors(b *strings.Builder, unionName string, accessors []unionAccessor) {
+	for _, accessor := range accessors {
+		b.WriteString("\nfunc (u ")
+		b.WriteString(unionName)
+		b.WriteString(") ")
+		b.WriteString(accessor.name)
+		b.WriteString("() (")
+		b.WriteString(accessor.typeName)
+		b.WriteString(", bool) {\n\tv, ok := u.Value.(")
+		b.WriteString(accessor.typeName)
+		b.WriteString(")\n\treturn v, ok\n}\n")
+	}
+}
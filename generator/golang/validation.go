@@ -0,0 +1,659 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// validationDecls are the exported declarations emitted with Validate methods.
+// They are reserved before model names are resolved so a component with the
+// same name is renamed instead of breaking the generated package.
+var validationDecls = []string{"ValidationError", "ValidationErrors"}
+
+var validationCoreHelper = runtimeHelper{
+	imports: []string{"errors", "strings"},
+	source: `// ValidationError is one schema constraint violated by a generated value. Path
+// is a JSON pointer to the value, built from the schema property names.
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors lists every constraint violation found by a Validate method.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationErrors) add(path, message string) {
+	*e = append(*e, ValidationError{Path: path, Message: message})
+}
+
+// nested validates value when it has a Validate method and reports its
+// violations below path.
+func (e *ValidationErrors) nested(path string, value any) {
+	validator, ok := value.(interface{ Validate() error })
+	if !ok {
+		return
+	}
+	err := validator.Validate()
+	var errs ValidationErrors
+	switch {
+	case err == nil:
+	case errors.As(err, &errs):
+		for _, nested := range errs {
+			e.add(path+nested.Path, nested.Message)
+		}
+	default:
+		e.add(path, err.Error())
+	}
+}
+`,
+}
+
+var validationTokenHelper = runtimeHelper{
+	imports: []string{"strings"},
+	source: `// validationToken escapes a map key as a JSON pointer reference token.
+func validationToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+`,
+}
+
+var validationUniqueHelper = runtimeHelper{
+	imports: []string{"encoding/json", "reflect"},
+	source: `// validationUnique reports whether a slice holds no two equal JSON values.
+func validationUnique(items any) bool {
+	v := reflect.ValueOf(items)
+	seen := make(map[string]struct{}, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		data, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[string(data)]; ok {
+			return false
+		}
+		seen[string(data)] = struct{}{}
+	}
+	return true
+}
+`,
+}
+
+var validationMultipleOfHelper = runtimeHelper{
+	imports: []string{"math"},
+	source: `func validationMultipleOf(value, divisor float64) bool {
+	quotient := value / divisor
+	return math.Abs(quotient-math.Round(quotient)) <= 1e-9*math.Max(1, math.Abs(quotient))
+}
+`,
+}
+
+var validationDecodeHelper = runtimeHelper{
+	imports: []string{"bytes", "encoding/json"},
+	source: `func validationNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// validationDecode strictly decodes raw union data into one variant. Unknown
+// object fields and null do not match a variant.
+func validationDecode(data []byte, dest any) bool {
+	if validationNull(data) {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(dest) == nil && !decoder.More()
+}
+`,
+}
+
+// validationHelpers returns the helpers used by the Validate methods of the
+// current file, in a stable order.
+func (g *Generator) validationHelpers() []runtimeHelper {
+	if !g.validationNeeds["core"] {
+		return nil
+	}
+	helpers := []runtimeHelper{validationCoreHelper}
+	for _, helper := range []struct {
+		need   string
+		helper runtimeHelper
+	}{
+		{"token", validationTokenHelper},
+		{"unique", validationUniqueHelper},
+		{"multipleOf", validationMultipleOfHelper},
+		{"decode", validationDecodeHelper},
+	} {
+		if g.validationNeeds[helper.need] {
+			helpers = append(helpers, helper.helper)
+		}
+	}
+	return helpers
+}
+
+// renderValidationDecl renders the validation helpers and compiled patterns
+// used by the current file.
+func (g *Generator) renderValidationDecl() string {
+	var b strings.Builder
+	for _, helper := range g.validationHelpers() {
+		for _, path := range helper.imports {
+			g.addImport(path)
+		}
+		b.WriteString(helper.source)
+		b.WriteByte('\n')
+	}
+	if len(g.validationPatternOrder) > 0 {
+		g.addImport("regexp")
+		b.WriteString("var (\n")
+		for _, pattern := range g.validationPatternOrder {
+			b.WriteString(g.validationPatterns[pattern])
+			b.WriteString(" = regexp.MustCompile(")
+			b.WriteString(strconv.Quote(pattern))
+			b.WriteString(")\n")
+		}
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+func newTypeNameRegistry(validationMethods bool) *nameRegistry {
+	names := newNameRegistry()
+	if validationMethods {
+		for _, name := range validationDecls {
+			names.resolve("$validation."+name, name)
+		}
+	}
+	return names
+}
+
+// validationWriter collects the body of one Validate method. Lines are written
+// unindented and gofmt indents the rendered file.
+type validationWriter struct {
+	g     *Generator
+	lines []string
+	depth int
+	// errs names the ValidationErrors variable checks report to.
+	errs string
+	// owner names the declaration in pattern diagnostics.
+	owner string
+}
+
+func newValidationWriter(g *Generator, owner string) *validationWriter {
+	return &validationWriter{g: g, errs: "errs", owner: owner}
+}
+
+func (w *validationWriter) line(parts ...string) {
+	w.lines = append(w.lines, strings.Join(parts, ""))
+}
+
+func (w *validationWriter) fail(path, message string) {
+	w.line(w.errs, ".add(", path, ", ", strconv.Quote(message), ")")
+}
+
+// block writes open, body, and a closing brace, or nothing when body writes
+// no lines.
+func (w *validationWriter) block(open string, body func()) bool {
+	start := len(w.lines)
+	w.line(open)
+	mark := len(w.lines)
+	body()
+	if len(w.lines) == mark {
+		w.lines = w.lines[:start]
+		return false
+	}
+	w.line("}")
+	return true
+}
+
+// writeValidateMethod renders a Validate method with a value receiver.
+func (g *Generator) writeValidateMethod(b *strings.Builder, typeName, receiver string, w *validationWriter) {
+	g.validationNeeds["core"] = true
+	b.WriteString("\nfunc (")
+	b.WriteString(receiver)
+	b.WriteByte(' ')
+	b.WriteString(typeName)
+	b.WriteString(") Validate() error {\n")
+	if len(w.lines) == 0 {
+		b.WriteString("return nil\n}\n")
+		return
+	}
+	b.WriteString("var errs ValidationErrors\n")
+	for _, line := range w.lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString("return errs.orNil()\n}\n")
+}
+
+type validationField struct {
+	name     string
+	propName string
+	prop     *SchemaIR
+	typ      string
+	required bool
+}
+
+func (g *Generator) writeObjectValidation(b *strings.Builder, ir *SchemaIR, fields []validationField, additionalField, additionalType string) {
+	w := newValidationWriter(g, ir.Name)
+	for _, embed := range ir.AllOf {
+		if embed != nil && embed.Kind == KindRef {
+			w.line("errs.nested(\"\", m.", g.goType(embed, true, false), ")")
+		}
+	}
+	for _, field := range fields {
+		expr := "m." + field.name
+		path := validationPathJoin(`""`, field.propName)
+		owner := w.owner
+		w.owner = ir.Name + "." + field.propName
+		if check := g.requiredCheck(field, expr); check != "" {
+			w.line("if ", check, " {")
+			w.fail(path, "is required")
+			if !w.block("} else {", func() {
+				w.value(field.prop, strings.TrimPrefix(field.typ, "*"), derefExpr(field.typ, expr), path, false)
+			}) {
+				w.line("}")
+			}
+		} else {
+			w.value(field.prop, field.typ, expr, path, false)
+		}
+		w.owner = owner
+	}
+	if ir.AdditionalProperties != nil && additionalField != "" {
+		w.mapValues(ir.AdditionalProperties, additionalType, "m."+additionalField, `""`)
+	}
+	g.writeValidateMethod(b, ir.Name, "m", w)
+}
+
+// requiredCheck returns the condition reporting a missing required field. Only
+// values that can represent absence are checked, and nullable fields accept a
+// missing or null value.
+func (g *Generator) requiredCheck(field validationField, expr string) string {
+	if !field.required || field.prop == nil || field.prop.Nullable {
+		return ""
+	}
+	switch {
+	case g.isUnionType(field.prop, field.typ):
+		return expr + ".IsZero()"
+	case strings.HasPrefix(field.typ, "*"), strings.HasPrefix(field.typ, "[]"), strings.HasPrefix(field.typ, "map["), field.typ == "any":
+		return expr + " == nil"
+	}
+	return ""
+}
+
+func (g *Generator) isUnionType(ir *SchemaIR, typ string) bool {
+	typ = strings.TrimPrefix(typ, "*")
+	switch ir.Kind {
+	case KindUnion:
+		return true
+	case KindRef:
+		return strings.HasSuffix(typ, "Union") && g.componentKinds[strings.TrimSuffix(typ, "Union")] == KindUnion
+	}
+	return false
+}
+
+func (g *Generator) writeAliasValidation(b *strings.Builder, ir *SchemaIR, typ string) {
+	w := newValidationWriter(g, ir.Name)
+	expr := "v"
+	if typ == "string" {
+		expr = "string(v)"
+	}
+	w.value(ir, typ, expr, `""`, true)
+	g.writeValidateMethod(b, ir.Name, "v", w)
+}
+
+func (g *Generator) writeEnumValidation(b *strings.Builder, ir *SchemaIR, shape enumShape) {
+	w := newValidationWriter(g, ir.Name)
+	if shape.constants {
+		w.enum(ir.Enum, shape.goType, "v", `""`)
+	}
+	g.writeValidateMethod(b, ir.Name, "v", w)
+}
+
+func (g *Generator) writeDiscriminatedUnionValidation(b *strings.Builder, ir *SchemaIR) {
+	w := newValidationWriter(g, ir.Name)
+	w.line("errs.nested(\"\", u.Value)")
+	g.writeValidateMethod(b, ir.Name+"Union", "u", w)
+}
+
+// writeRawUnionValidation accepts raw union data when it strictly decodes into
+// a variant that also validates, trying variants in order. Unions with inline
+// variants that have no declared Go type are not checked.
+func (g *Generator) writeRawUnionValidation(b *strings.Builder, ir *SchemaIR) {
+	w := newValidationWriter(g, ir.Name)
+	variants := nonNullVariants(ir.Union.Variants)
+	typed := len(variants) > 0
+	for _, variant := range variants {
+		typed = typed && g.validationTypeable(variant)
+	}
+	if typed {
+		g.validationNeeds["decode"] = true
+		w.line("if len(u.Raw) == 0 {")
+		w.line("return nil")
+		w.line("}")
+		if ir.Nullable || len(variants) != len(ir.Union.Variants) {
+			w.line("if validationNull(u.Raw) {")
+			w.line("return nil")
+			w.line("}")
+		}
+		for i, variant := range variants {
+			if ir.Union.FromMultiType && variant.SourceSchema == nil {
+				scoped := *variant
+				scoped.SourceSchema = ir.SourceSchema
+				variant = &scoped
+			}
+			name := "variant" + intString(i+1)
+			w.line("var ", name, " ", g.goType(variant, true, false))
+			w.line("if validationDecode(u.Raw, &", name, ") {")
+			w.line("var variantErrs ValidationErrors")
+			w.errs = "variantErrs"
+			w.value(variant, g.goType(variant, true, false), name, `""`, false)
+			w.errs = "errs"
+			w.line("if len(variantErrs) == 0 {")
+			w.line("return nil")
+			w.line("}")
+			w.line("}")
+		}
+		keyword := "anyOf"
+		if ir.Union.Kind == UnionOneOf {
+			keyword = "oneOf"
+		}
+		w.fail(`""`, "does not match any "+keyword+" variant")
+	}
+	g.writeValidateMethod(b, ir.Name+"Union", "u", w)
+}
+
+// validationTypeable reports whether a raw union variant renders as a declared
+// or builtin Go type that union data can be decoded into. Union variant
+// decoding declares every variant type.
+func (g *Generator) validationTypeable(ir *SchemaIR) bool {
+	if ir == nil {
+		return false
+	}
+	if g.unionVariantDecoding {
+		return true
+	}
+	switch ir.Kind {
+	case KindRef, KindString, KindInteger, KindNumber, KindBoolean, KindAny:
+		return true
+	case KindArray:
+		return len(ir.PrefixItems) == 0 && (ir.Items == nil || g.validationTypeable(ir.Items))
+	case KindMap:
+		return ir.AdditionalProperties == nil || g.validationTypeable(ir.AdditionalProperties)
+	case KindObject:
+		return strings.HasPrefix(g.goType(ir, true, false), "map[") &&
+			(ir.AdditionalProperties == nil || g.validationTypeable(ir.AdditionalProperties))
+	case KindEnum:
+		return ir.Name == ""
+	}
+	return false
+}
+
+// value writes the checks for expr, a value of Go type typ described by ir.
+// self is set when expr is the receiver of the type's own Validate method.
+func (w *validationWriter) value(ir *SchemaIR, typ, expr, path string, self bool) {
+	if ir == nil || typ == "" || typ == "any" {
+		return
+	}
+	if strings.HasPrefix(typ, "*") {
+		w.block("if "+expr+" != nil {", func() { w.value(ir, typ[1:], "*"+expr, path, self) })
+		return
+	}
+	switch {
+	case strings.HasPrefix(typ, "[]"):
+		w.array(ir, typ[2:], expr, path)
+	case strings.HasPrefix(typ, "map[string]"):
+		w.object(ir, typ[len("map[string]"):], expr, path)
+	case typ == "string":
+		w.str(ir, expr, path)
+	case isGoNumber(typ):
+		w.number(ir, typ, expr, path)
+	case typ == "bool", strings.Contains(typ, "."):
+	case !self:
+		w.line(w.errs, ".nested(", path, ", ", expr, ")")
+	}
+}
+
+func (w *validationWriter) str(ir *SchemaIR, expr, path string) {
+	schema := ir.SourceSchema
+	if schema != nil && schema.MinLength != nil {
+		w.line("if utf8.RuneCountInString(", expr, ") < ", strconv.FormatInt(*schema.MinLength, 10), " {")
+		w.fail(path, "length must be at least "+strconv.FormatInt(*schema.MinLength, 10))
+		w.line("}")
+		w.g.addImport("unicode/utf8")
+	}
+	if schema != nil && schema.MaxLength != nil {
+		w.line("if utf8.RuneCountInString(", expr, ") > ", strconv.FormatInt(*schema.MaxLength, 10), " {")
+		w.fail(path, "length must be at most "+strconv.FormatInt(*schema.MaxLength, 10))
+		w.line("}")
+		w.g.addImport("unicode/utf8")
+	}
+	if schema != nil && schema.Pattern != "" {
+		if name := w.g.validationPattern(schema.Pattern, w.owner); name != "" {
+			w.line("if !", name, ".MatchString(", expr, ") {")
+			w.fail(path, "must match pattern "+schema.Pattern)
+			w.line("}")
+		}
+	}
+	if ir.Kind == KindEnum && ir.Name == "" {
+		if shape := enumShapeFor(ir.Enum); shape.constants && shape.goType == "string" {
+			w.enum(ir.Enum, "string", expr, path)
+		}
+	}
+}
+
+func (w *validationWriter) enum(nodes []*yaml.Node, goType, expr, path string) {
+	var literals []string
+	for _, node := range nodes {
+		if literal := enumLiteral(node, goType); literal != "" {
+			literals = append(literals, literal)
+		}
+	}
+	if len(literals) == 0 {
+		return
+	}
+	w.line("switch ", expr, " {")
+	w.line("case ", strings.Join(literals, ", "), ":")
+	w.line("default:")
+	w.fail(path, "must be one of "+strings.Join(literals, ", "))
+	w.line("}")
+}
+
+func (w *validationWriter) number(ir *SchemaIR, typ, expr, path string) {
+	schema := ir.SourceSchema
+	if schema == nil {
+		return
+	}
+	minExclusive := schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsA() && schema.ExclusiveMinimum.A
+	maxExclusive := schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsA() && schema.ExclusiveMaximum.A
+	if schema.Minimum != nil {
+		if minExclusive {
+			w.bound(typ, expr, path, "<=", "must be greater than ", *schema.Minimum)
+		} else {
+			w.bound(typ, expr, path, "<", "must be at least ", *schema.Minimum)
+		}
+	}
+	if schema.Maximum != nil {
+		if maxExclusive {
+			w.bound(typ, expr, path, ">=", "must be less than ", *schema.Maximum)
+		} else {
+			w.bound(typ, expr, path, ">", "must be at most ", *schema.Maximum)
+		}
+	}
+	if schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsB() {
+		w.bound(typ, expr, path, "<=", "must be greater than ", schema.ExclusiveMinimum.B)
+	}
+	if schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsB() {
+		w.bound(typ, expr, path, ">=", "must be less than ", schema.ExclusiveMaximum.B)
+	}
+	if divisor := schema.MultipleOf; divisor != nil && *divisor > 0 && !(isGoInteger(typ) && *divisor == 1) {
+		divisor := *divisor
+		literal := formatValidationNumber(divisor)
+		if isGoInteger(typ) && divisor == math.Trunc(divisor) && divisor <= math.MaxInt32 {
+			w.line("if ", expr, "%", literal, " != 0 {")
+		} else {
+			w.g.validationNeeds["multipleOf"] = true
+			w.line("if !validationMultipleOf(float64(", expr, "), ", literal, ") {")
+		}
+		w.fail(path, "must be a multiple of "+literal)
+		w.line("}")
+	}
+}
+
+// bound writes one numeric comparison. Integer values are compared as
+// float64 when the bound is fractional or may not fit the Go type.
+func (w *validationWriter) bound(typ, expr, path, op, message string, limit float64) {
+	literal := formatValidationNumber(limit)
+	operand := expr
+	switch {
+	case isGoInteger(typ) && (limit != math.Trunc(limit) || math.Abs(limit) > math.MaxInt32):
+		operand = "float64(" + expr + ")"
+	case typ == "float32" && math.Abs(limit) > math.MaxFloat32:
+		operand = "float64(" + expr + ")"
+	}
+	w.line("if ", operand, " ", op, " ", literal, " {")
+	w.fail(path, message+literal)
+	w.line("}")
+}
+
+func (w *validationWriter) array(ir *SchemaIR, itemType, expr, path string) {
+	schema := ir.SourceSchema
+	if schema != nil && schema.MinItems != nil {
+		w.line("if len(", expr, ") < ", strconv.FormatInt(*schema.MinItems, 10), " {")
+		w.fail(path, "must have at least "+strconv.FormatInt(*schema.MinItems, 10)+" items")
+		w.line("}")
+	}
+	if schema != nil && schema.MaxItems != nil {
+		w.line("if len(", expr, ") > ", strconv.FormatInt(*schema.MaxItems, 10), " {")
+		w.fail(path, "must have at most "+strconv.FormatInt(*schema.MaxItems, 10)+" items")
+		w.line("}")
+	}
+	if schema != nil && schema.UniqueItems != nil && *schema.UniqueItems {
+		w.g.validationNeeds["unique"] = true
+		w.line("if !validationUnique(", expr, ") {")
+		w.fail(path, "items must be unique")
+		w.line("}")
+	}
+	if ir.Items == nil || len(ir.PrefixItems) > 0 {
+		return
+	}
+	index, item := "i"+w.suffix(), "item"+w.suffix()
+	w.depth++
+	itemPath := validationPathExpr(path, "strconv.Itoa("+index+")")
+	if w.block("for "+index+", "+item+" := range "+expr+" {", func() { w.value(ir.Items, itemType, item, itemPath, false) }) {
+		w.g.addImport("strconv")
+	}
+	w.depth--
+}
+
+func (w *validationWriter) object(ir *SchemaIR, valueType, expr, path string) {
+	schema := ir.SourceSchema
+	if schema != nil && schema.MinProperties != nil {
+		w.line("if len(", expr, ") < ", strconv.FormatInt(*schema.MinProperties, 10), " {")
+		w.fail(path, "must have at least "+strconv.FormatInt(*schema.MinProperties, 10)+" properties")
+		w.line("}")
+	}
+	if schema != nil && schema.MaxProperties != nil {
+		w.line("if len(", expr, ") > ", strconv.FormatInt(*schema.MaxProperties, 10), " {")
+		w.fail(path, "must have at most "+strconv.FormatInt(*schema.MaxProperties, 10)+" properties")
+		w.line("}")
+	}
+	w.mapValues(ir.AdditionalProperties, valueType, expr, path)
+}
+
+func (w *validationWriter) mapValues(ir *SchemaIR, valueType, expr, path string) {
+	if ir == nil {
+		return
+	}
+	key, value := "key"+w.suffix(), "value"+w.suffix()
+	w.depth++
+	valuePath := validationPathExpr(path, "validationToken("+key+")")
+	if w.block("for "+key+", "+value+" := range "+expr+" {", func() { w.value(ir, valueType, value, valuePath, false) }) {
+		w.g.validationNeeds["token"] = true
+	}
+	w.depth--
+}
+
+func (w *validationWriter) suffix() string {
+	if w.depth == 0 {
+		return ""
+	}
+	return intString(w.depth)
+}
+
+// validationPattern returns the package variable holding a compiled pattern.
+// Patterns Go's regexp package cannot compile are reported and not enforced.
+func (g *Generator) validationPattern(pattern, owner string) string {
+	if name, ok := g.validationPatterns[pattern]; ok {
+		return name
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		g.addDiagnostic(DiagnosticUnsupportedPattern, owner, "pattern is not supported by Go regexp and is not validated: "+err.Error())
+		g.validationPatterns[pattern] = ""
+		return ""
+	}
+	name := "validationPattern" + intString(len(g.validationPatternOrder)+1)
+	g.validationPatterns[pattern] = name
+	g.validationPatternOrder = append(g.validationPatternOrder, pattern)
+	return name
+}
+
+// validationPathJoin appends a constant JSON pointer token to a Go string
+// literal path.
+func validationPathJoin(path, token string) string {
+	prefix, _ := strconv.Unquote(path)
+	return strconv.Quote(prefix + "/" + strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+}
+
+// validationPathExpr appends a computed token to a Go string path expression.
+func validationPathExpr(path, token string) string {
+	if prefix, err := strconv.Unquote(path); err == nil {
+		return strconv.Quote(prefix+"/") + " + " + token
+	}
+	return path + ` + "/" + ` + token
+}
+
+func derefExpr(typ, expr string) string {
+	if strings.HasPrefix(typ, "*") {
+		return "*" + expr
+	}
+	return expr
+}
+
+func isGoInteger(typ string) bool {
+	return typ == "int" || typ == "int32" || typ == "int64"
+}
+
+func isGoNumber(typ string) bool {
+	return isGoInteger(typ) || typ == "float32" || typ == "float64"
+}
+
+func formatValidationNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
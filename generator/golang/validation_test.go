@@ -0,0 +1,257 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package golang
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidationMethodsGolden(t *testing.T) {
+	assertGolden(t, "testdata/validation.golden.go", renderValidation(t).Source)
+}
+
+func TestValidationMethodsDisabledByDefault(t *testing.T) {
+	spec, err := os.ReadFile("testdata/validation.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewGenerator().RenderSchemas(mustBuildV3(t, string(spec)).Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(file.Source), "Validate() error") || strings.Contains(string(file.Source), "ValidationErrors") {
+		t.Fatalf("unexpected validation methods:\n%s", file.Source)
+	}
+	assertDiagnostic(t, file.Diagnostics, DiagnosticValidationKeyword, "Passenger.name")
+}
+
+func TestValidationMethodsCompileAndValidate(t *testing.T) {
+	file := renderValidation(t)
+	for _, diagnostic := range file.Diagnostics {
+		if diagnostic.Code == DiagnosticValidationKeyword {
+			t.Fatalf("unexpected diagnostic %#v", diagnostic)
+		}
+	}
+	assertParsesCompilesAndTests(t, file.Source, `package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func decodeBooking(t *testing.T, data string) Booking {
+	t.Helper()
+	var booking Booking
+	if err := json.Unmarshal([]byte(data), &booking); err != nil {
+		t.Fatal(err)
+	}
+	return booking
+}
+
+func violations(err error) string {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return "not ValidationErrors: " + err.Error()
+	}
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestValidBooking(t *testing.T) {
+	booking := decodeBooking(t, `+"`"+`{
+		"id": "ABC-1",
+		"passengers": [{"name": "Ada", "age": 36, "contact": "ada@example.com"}, {"name": "Bob", "contact": 1234}],
+		"seat": "economy",
+		"trip": {"mode": "rail", "carriage": 3},
+		"price": 19.99,
+		"tags": ["a", "b"],
+		"extras": {"bags": 2},
+		"note": null
+	}`+"`"+`)
+	if err := booking.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInvalidBooking(t *testing.T) {
+	booking := decodeBooking(t, `+"`"+`{
+		"id": "abc",
+		"passengers": [{"name": "", "age": 200, "contact": "nobody"}, {"name": "Bob", "contact": 5}],
+		"seat": "first",
+		"trip": {"mode": "coach", "operator": "Big Bus"},
+		"price": 0,
+		"tags": ["a", "a", "toolongtag"],
+		"extras": {"a/b": 0, "c": 1, "d": 2},
+		"note": "x"
+	}`+"`"+`)
+	want := strings.Join([]string{
+		"/id: must match pattern ^[A-Z]{3}-[0-9]+$",
+		"/passengers/0/name: length must be at least 1",
+		"/passengers/0/age: must be at most 130",
+		"/passengers/0/contact: does not match any anyOf variant",
+		"/passengers/1/contact: does not match any anyOf variant",
+		"/seat: must be one of \"economy\", \"business\"",
+		"/trip/operator: must match pattern ^[a-z]+$",
+		"/price: must be greater than 0",
+		"/tags: items must be unique",
+		"/tags/2: length must be at most 8",
+		"/extras: must have at most 2 properties",
+		"/extras/a~1b: must be at least 1",
+		"/note: length must be at least 2",
+	}, "\n")
+	if got := violations(booking.Validate()); got != want {
+		t.Fatalf("unexpected violations:\n%s", got)
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	got := violations(Booking{ID: "ABC-1", Seat: SeatClassEconomy}.Validate())
+	want := "/passengers: is required\n/trip: is required"
+	if got != want {
+		t.Fatalf("unexpected violations:\n%s", got)
+	}
+	err := Booking{ID: "ABC-1", Seat: SeatClassEconomy, Passengers: []Passenger{}, Trip: TripUnion{Value: Rail{Carriage: 0}}}.Validate()
+	want = "/passengers: must have at least 1 items\n/trip/carriage: must be at least 1"
+	if got := violations(err); got != want {
+		t.Fatalf("unexpected violations:\n%s", got)
+	}
+	if err.Error() != "/passengers: must have at least 1 items; /trip/carriage: must be at least 1" {
+		t.Fatalf("unexpected error string %q", err.Error())
+	}
+}
+
+func TestAliasValidation(t *testing.T) {
+	if err := (Ratings{1, 5}).Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := violations(Ratings{1, 6, 2, 3}.Validate())
+	if got != "must have at most 3 items\n/1: must be less than 6" {
+		t.Fatalf("unexpected violations:\n%s", got)
+	}
+	if got := violations(Email("nope").Validate()); got != "must match pattern ^[^@]+@[^@]+$" {
+		t.Fatalf("unexpected violations:\n%s", got)
+	}
+}
+`)
+}
+
+func TestValidationMethodsReserveHelperNames(t *testing.T) {
+	model := mustBuildV3(t, `openapi: 3.1.0
+info:
+  title: Collisions
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ValidationError:
+      type: object
+      properties:
+        loc:
+          type: array
+          minItems: 1
+          items:
+            type: string
+`)
+	file, err := NewGenerator(WithValidationMethods(true)).RenderSchemas(model.Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDiagnostic(t, file.Diagnostics, DiagnosticComponentNameCollision, "ValidationError")
+	assertContains(t, string(file.Source), "type ValidationError__2 struct")
+	assertParsesCompilesAndTests(t, file.Source, `package models
+
+import "testing"
+
+func TestCollision(t *testing.T) {
+	err := ValidationError__2{Loc: []string{}}.Validate()
+	if err == nil || err.Error() != "/loc: must have at least 1 items" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+`)
+}
+
+func TestValidationMethodsUnsupportedPattern(t *testing.T) {
+	model := mustBuildV3(t, `openapi: 3.1.0
+info:
+  title: Patterns
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Code:
+      type: object
+      properties:
+        value:
+          type: string
+          pattern: '^(?!x)[a-z]+$'
+`)
+	file, err := NewGenerator(WithValidationMethods(true)).RenderSchemas(model.Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertDiagnostic(t, file.Diagnostics, DiagnosticUnsupportedPattern, "Code.value")
+	if strings.Contains(string(file.Source), "regexp") {
+		t.Fatalf("unexpected pattern check:\n%s", file.Source)
+	}
+	assertParsesCompilesAndTests(t, file.Source, `package models
+
+import "testing"
+
+func TestPattern(t *testing.T) {
+	if err := (Code{}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+`)
+}
+
+func TestValidationMethodsTrainTravelCompiles(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithValidationMethods(true)},
+		{WithValidationMethods(true), WithOptionalConstDiscriminatorUnions(true), WithEnumConstants(true)},
+		{WithValidationMethods(true), WithUnionVariantDecoding(true)},
+	} {
+		assertParsesCompilesAndTests(t, renderTrainTravel(t, opts...).Source, `package models
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	var _ error = Booking{}.Validate()
+}
+`)
+	}
+}
+
+func TestValidationPathExpressions(t *testing.T) {
+	if got := validationPathJoin(`""`, "a/b~c"); got != `"/a~1b~0c"` {
+		t.Fatalf("unexpected path %s", got)
+	}
+	if got := validationPathExpr(`"/items"`, "strconv.Itoa(i)"); got != `"/items/" + strconv.Itoa(i)` {
+		t.Fatalf("unexpected path %s", got)
+	}
+	if got := validationPathExpr(`"/items/" + strconv.Itoa(i)`, "strconv.Itoa(i1)"); got != `"/items/" + strconv.Itoa(i) + "/" + strconv.Itoa(i1)` {
+		t.Fatalf("unexpected path %s", got)
+	}
+}
+
+func renderValidation(t *testing.T, opts ...Option) *GeneratedFile {
+	t.Helper()
+	spec, err := os.ReadFile("testdata/validation.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewGenerator(append([]Option{WithValidationMethods(true), WithEnumConstants(true)}, opts...)...).RenderSchemas(mustBuildV3(t, string(spec)).Components.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
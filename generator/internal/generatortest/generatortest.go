@@ -0,0 +1,110 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package generatortest holds the test helpers shared by the generator packages that render the schema IR of the
+// golang package into other languages.
+package generatortest
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// updateGoldensEnv is the environment variable that rewrites golden files with the generated output when "true".
+const updateGoldensEnv = "LIBOPENAPI_GENERATOR_UPDATE_GOLDENS"
+
+// Schemas builds the component schemas of a document of an OpenAPI version, declaring the (indented) schemas.
+func Schemas(t testing.TB, version, schemas string) *orderedmap.Map[string, *highbase.SchemaProxy] {
+	t.Helper()
+	spec := "openapi: " + version + "\ninfo:\n  title: Test\n  version: 1.0.0\npaths: {}\ncomponents:\n  schemas:\n" +
+		strings.TrimPrefix(schemas, "\n")
+	return DocumentSchemas(t, []byte(spec))
+}
+
+// TestdataSchemas builds the component schemas of the document at path.
+func TestdataSchemas(t testing.TB, path string) *orderedmap.Map[string, *highbase.SchemaProxy] {
+	t.Helper()
+	spec, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return DocumentSchemas(t, spec)
+}
+
+// DocumentSchemas builds the component schemas of a document.
+func DocumentSchemas(t testing.TB, spec []byte) *orderedmap.Map[string, *highbase.SchemaProxy] {
+	t.Helper()
+	doc, err := libopenapi.NewDocument(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := doc.BuildV3Model()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return model.Model.Components.Schemas
+}
+
+// Contains fails the test if s does not contain substr.
+func Contains(t testing.TB, s, substr string) {
+	t.Helper()
+	if !strings.Contains(s, substr) {
+		t.Fatalf("expected %q in:\n%s", substr, s)
+	}
+}
+
+// NotContains fails the test if s contains substr.
+func NotContains(t testing.TB, s, substr string) {
+	t.Helper()
+	if strings.Contains(s, substr) {
+		t.Fatalf("did not expect %q in:\n%s", substr, s)
+	}
+}
+
+// HasDiagnostic fails the test if no diagnostic has the code.
+func HasDiagnostic(t testing.TB, diagnostics []golang.Diagnostic, code string) {
+	t.Helper()
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Code == code {
+			return
+		}
+	}
+	t.Fatalf("expected %s diagnostic in %#v", code, diagnostics)
+}
+
+// NoDiagnostic fails the test if a diagnostic has the code.
+func NoDiagnostic(t testing.TB, diagnostics []golang.Diagnostic, code string) {
+	t.Helper()
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Code == code {
+			t.Fatalf("did not expect %s diagnostic in %#v", code, diagnostics)
+		}
+	}
+}
+
+// Golden fails the test if got differs from the golden file at path, ignoring line endings. The golden file is
+// rewritten instead when updateGoldensEnv is "true".
+func Golden(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if os.Getenv(updateGoldensEnv) == "true" {
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantText := strings.ReplaceAll(string(want), "\r\n", "\n")
+	gotText := strings.ReplaceAll(string(got), "\r\n", "\n")
+	if gotText != wantText {
+		t.Fatalf("golden mismatch for %s:\n%s", path, gotText)
+	}
+}
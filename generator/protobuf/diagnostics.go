@@ -0,0 +1,44 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/generator/golang"
+)
+
+// exactIRDiagnostics lists IR diagnostics that do not apply to proto output:
+// multi-type schemas render as a oneof, mixed and null enums render as proto
+// enums, const discriminators do not affect the generated messages, and tuple
+// arrays are reported by this package instead.
+var exactIRDiagnostics = map[string]struct{}{
+	golang.DiagnosticMultiTypeSchema:            {},
+	golang.DiagnosticMixedEnum:                  {},
+	golang.DiagnosticNullEnum:                   {},
+	golang.DiagnosticOptionalConstDiscriminator: {},
+	golang.DiagnosticPrefixItems:                {},
+	golang.DiagnosticBooleanItems:               {},
+}
+
+var irDiagnosticWording = strings.NewReplacer(
+	"generated Go models", "generated proto messages",
+	"generated Go model", "generated proto message",
+	"Go model shape", "proto message shape",
+	"Go struct fields", "proto fields",
+	"Go reference name", "proto type name",
+	"Go type", "proto type",
+	"rendered as any", "rendered as google.protobuf.Value",
+	"uses any", "uses google.protobuf.Value",
+)
+
+// translateIRDiagnostic rewords a diagnostic raised while building the shared
+// IR for proto output. It returns false for shapes proto3 represents exactly.
+func translateIRDiagnostic(diagnostic Diagnostic) (Diagnostic, bool) {
+	if _, exact := exactIRDiagnostics[diagnostic.Code]; exact {
+		return diagnostic, false
+	}
+	diagnostic.Message = irDiagnosticWording.Replace(diagnostic.Message)
+	return diagnostic, true
+}
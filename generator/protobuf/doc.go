@@ -0,0 +1,28 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package protobuf generates proto3 schema files from OpenAPI component
+// schemas.
+//
+// The package shares the OpenAPI -> IR path with generator/golang and renders
+// golang.SchemaIR values as proto3 messages and enums. Objects become messages,
+// enums become proto enums with a zero _UNSPECIFIED value, oneOf and anyOf
+// become messages holding a single oneof, arrays become repeated fields,
+// schema-valued additionalProperties become map<string, V> fields, and
+// nullable scalars use the google.protobuf wrapper types. allOf references are
+// flattened into the referencing message because proto3 has no inheritance.
+// Free-form values use google.protobuf.Value and google.protobuf.Struct.
+//
+// Field numbers and enum values are stable across runs. Every generation reads
+// numbers from a Lock and returns the updated Lock in GeneratedFile.Lock;
+// callers persist it (conventionally as LockFileName) and pass it back with
+// WithLock next time. Existing fields keep their numbers, new fields get the
+// next free number, and fields that disappear from the schema are emitted as
+// reserved numbers and names instead of being renumbered. A field that later
+// reappears gets its original number back, so regenerating never breaks wire
+// compatibility.
+//
+// The generated file is a starting point for a gRPC migration. Like
+// generator/golang the package is library-only; it does not invoke protoc or
+// generate services.
+package protobuf
@@ -0,0 +1,26 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/generator/golang"
+)
+
+// ErrNilSchema is shared with generator/golang, which reports it while building
+// the IR for nil component schemas.
+var ErrNilSchema = golang.ErrNilSchema
+
+// ErrInvalidPackageName is returned when the configured proto package is not a
+// dotted sequence of identifiers.
+var ErrInvalidPackageName = errors.New("invalid proto package name")
+
+func wrapPath(err error, path string) error {
+	if path == "" {
+		return fmt.Errorf("generator/protobuf: %w", err)
+	}
+	return fmt.Errorf("generator/protobuf: %w at %s", err, path)
+}
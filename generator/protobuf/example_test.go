@@ -0,0 +1,42 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"fmt"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+func ExampleRenderSchemas() {
+	properties := orderedmap.New[string, *highbase.SchemaProxy]()
+	properties.Set("id", highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"string"}}))
+	properties.Set("tag", highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"string", "null"}}))
+	schemas := orderedmap.New[string, *highbase.SchemaProxy]()
+	schemas.Set("Pet", highbase.CreateSchemaProxy(&highbase.Schema{
+		Type:       []string{"object"},
+		Properties: properties,
+	}))
+
+	// Pass the lock from the previous run so existing fields keep their numbers.
+	file, err := RenderSchemas(schemas, WithPackageName("pets.v1"), WithLock(NewLock()))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(string(file.Source))
+
+	// Output:
+	// syntax = "proto3";
+	//
+	// package pets.v1;
+	//
+	// import "google/protobuf/wrappers.proto";
+	//
+	// message Pet {
+	//   string id = 1;
+	//   google.protobuf.StringValue tag = 2;
+	// }
+}
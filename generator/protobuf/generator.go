@@ -0,0 +1,149 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// Generator holds immutable configuration for proto3 generation. Each public
+// entry point runs against a fresh copy of this configuration (see run), so a
+// configured Generator is safe to reuse and to share across goroutines.
+type Generator struct {
+	packageName             string
+	goPackage               string
+	lock                    *Lock
+	generatedComment        bool
+	headerComment           string
+	nestedTypeNameDelimiter string
+
+	nameResolver        NameResolver
+	typeNameResolver    NameResolver
+	externalRefResolver ExternalRefResolver
+
+	formatMappings map[string]formatMapping
+
+	diagnostics []Diagnostic
+	decls       []string
+	types       []*GeneratedType
+	seenDecls   map[string]struct{}
+	inlined     map[string]struct{}
+	imports     map[string]struct{}
+	components  map[string]*golang.SchemaIR
+	inlining    map[string]struct{}
+	numbers     *Lock
+}
+
+// GeneratedFile contains a proto3 file generated from OpenAPI schemas.
+type GeneratedFile struct {
+	PackageName string
+	Source      []byte
+	// Lock holds the field numbers used by Source. Persist it and pass it back
+	// with WithLock so the next run keeps the same numbers.
+	Lock        *Lock
+	Types       []*GeneratedType
+	Diagnostics []Diagnostic
+}
+
+// GeneratedType describes one top-level proto message or enum.
+type GeneratedType struct {
+	Name string
+	Kind golang.Kind
+}
+
+// NewGenerator creates a proto3 generator.
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
+		packageName:    "models",
+		formatMappings: make(map[string]formatMapping),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(g)
+		}
+	}
+	return g
+}
+
+// run returns a generator carrying fresh per-invocation state. Configuration is
+// shared with the receiver and treated as read-only during generation; the
+// configured lock is copied so callers keep their original.
+func (g *Generator) run() *Generator {
+	r := *g
+	r.diagnostics = nil
+	r.decls = nil
+	r.types = nil
+	r.seenDecls = make(map[string]struct{})
+	r.inlined = make(map[string]struct{})
+	r.imports = make(map[string]struct{})
+	r.components = make(map[string]*golang.SchemaIR)
+	r.inlining = make(map[string]struct{})
+	r.numbers = g.lock.clone()
+	return &r
+}
+
+// irGenerator returns the generator/golang generator used to build the shared
+// IR.
+func (g *Generator) irGenerator() *golang.Generator {
+	return golang.NewGenerator(
+		golang.WithNameResolver(g.nameResolver),
+		golang.WithTypeNameResolver(g.typeNameResolver),
+		golang.WithExternalRefTypeResolver(g.externalRefResolver),
+		golang.WithNestedTypeNameDelimiter(g.nestedTypeNameDelimiter),
+		golang.WithOptionalConstDiscriminatorUnions(true),
+	)
+}
+
+// RenderSchema renders a single OpenAPI schema as a proto3 file.
+func RenderSchema(name string, schema *highbase.SchemaProxy, opts ...Option) ([]byte, error) {
+	return NewGenerator(opts...).RenderSchema(name, schema)
+}
+
+// RenderSchemas renders an ordered map of OpenAPI schemas as one proto3 file.
+func RenderSchemas(schemas *orderedmap.Map[string, *highbase.SchemaProxy], opts ...Option) (*GeneratedFile, error) {
+	return NewGenerator(opts...).RenderSchemas(schemas)
+}
+
+// RenderSchema renders a single OpenAPI schema as a proto3 file using this
+// generator.
+func (g *Generator) RenderSchema(name string, schema *highbase.SchemaProxy) ([]byte, error) {
+	if schema == nil {
+		return nil, wrapPath(ErrNilSchema, name)
+	}
+	schemas := orderedmap.New[string, *highbase.SchemaProxy]()
+	schemas.Set(name, schema)
+	file, err := g.RenderSchemas(schemas)
+	if err != nil {
+		return nil, err
+	}
+	return file.Source, nil
+}
+
+// RenderSchemas renders an ordered map of OpenAPI schemas as one proto3 file
+// using this generator.
+func (g *Generator) RenderSchemas(schemas *orderedmap.Map[string, *highbase.SchemaProxy]) (*GeneratedFile, error) {
+	if !isPackageName(g.packageName) {
+		return nil, wrapPath(ErrInvalidPackageName, g.packageName)
+	}
+	r := g.run()
+	if schemas == nil {
+		return r.renderFile(nil), nil
+	}
+	set, err := r.irGenerator().SchemaIRs(schemas)
+	if err != nil {
+		return nil, err
+	}
+	for _, diagnostic := range set.Diagnostics {
+		if translated, ok := translateIRDiagnostic(diagnostic); ok {
+			r.diagnostics = append(r.diagnostics, translated)
+		}
+	}
+	return r.renderFile(set.Schemas), nil
+}
+
+func (g *Generator) addDiagnostic(code, path, message string) {
+	g.diagnostics = append(g.diagnostics, Diagnostic{Code: code, Path: path, Message: message})
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/generator/internal/generatortest"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+func TestRenderMessagesAndScalars(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Pet:
+      description: A pet.
+      type: object
+      properties:
+        id:
+          type: string
+        petType:
+          type: string
+        age:
+          type: integer
+          format: int32
+        weight:
+          type: number
+          format: float
+        photo:
+          type: string
+          format: byte
+        tag:
+          type: [string, "null"]
+        score:
+          type: [number, "null"]
+        legacy:
+          type: boolean
+          deprecated: true
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "syntax = \"proto3\";\n\npackage models;\n")
+	generatortest.Contains(t, src, `import "google/protobuf/wrappers.proto";`)
+	generatortest.Contains(t, src, "// A pet.\nmessage Pet {\n  string id = 1;\n")
+	generatortest.Contains(t, src, "  string pet_type = 2;\n")
+	generatortest.Contains(t, src, "  int32 age = 3;\n")
+	generatortest.Contains(t, src, "  float weight = 4;\n")
+	generatortest.Contains(t, src, "  bytes photo = 5;\n")
+	generatortest.Contains(t, src, "  google.protobuf.StringValue tag = 6;\n")
+	generatortest.Contains(t, src, "  google.protobuf.DoubleValue score = 7;\n")
+	generatortest.Contains(t, src, "  bool legacy = 8 [deprecated = true];\n")
+	if len(file.Types) != 1 || file.Types[0].Name != "Pet" || file.Types[0].Kind != golang.KindObject {
+		t.Fatalf("unexpected types: %#v", file.Types)
+	}
+}
+
+func TestRenderJSONNames(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Booking:
+      type: object
+      properties:
+        trip_id:
+          type: string
+        passengerName:
+          type: string
+        "x-trace":
+          type: string
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, `  string trip_id = 1 [json_name = "trip_id"];`)
+	generatortest.Contains(t, src, "  string passenger_name = 2;\n")
+	generatortest.Contains(t, src, `  string x_trace = 3 [json_name = "x-trace"];`)
+}
+
+func TestRenderEnums(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Status:
+      type: string
+      enum: [active, in-review, null]
+    Pet:
+      type: object
+      properties:
+        status:
+          $ref: '#/components/schemas/Status'
+        level:
+          type: string
+          enum: [low, high]
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "enum Status {\n  STATUS_UNSPECIFIED = 0;\n  STATUS_ACTIVE = 1;\n  STATUS_IN_REVIEW = 2;\n}\n")
+	generatortest.Contains(t, src, "  optional Status status = 1;\n")
+	generatortest.Contains(t, src, "enum PetLevel {\n  PET_LEVEL_UNSPECIFIED = 0;\n  PET_LEVEL_LOW = 1;\n  PET_LEVEL_HIGH = 2;\n}\n")
+	generatortest.Contains(t, src, "  PetLevel level = 2;\n")
+	generatortest.NoDiagnostic(t, file.Diagnostics, golang.DiagnosticNullEnum)
+}
+
+func TestRenderOneOfAsOneofMessage(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Cat:
+      type: object
+      properties:
+        name:
+          type: string
+    Dog:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+        - type: string
+        - type: "null"
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "message Pet {\n  oneof value {\n    Cat cat = 1;\n    Dog dog = 2;\n    string string_value = 3;\n  }\n}\n")
+	generatortest.Contains(t, src, "message Owner {\n  Pet pet = 1;\n}\n")
+}
+
+func TestRenderArraysAndMaps(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Tags:
+      type: array
+      items:
+        type: string
+    Bag:
+      type: object
+      properties:
+        tags:
+          $ref: '#/components/schemas/Tags'
+        counts:
+          type: object
+          additionalProperties:
+            type: integer
+        grid:
+          type: array
+          items:
+            type: array
+            items:
+              type: number
+        groups:
+          type: object
+          additionalProperties:
+            type: array
+            items:
+              type: string
+        metadata:
+          type: object
+        anything: {}
+      additionalProperties:
+        type: string
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, `import "google/protobuf/struct.proto";`)
+	generatortest.Contains(t, src, "  repeated string tags = 1;\n")
+	generatortest.Contains(t, src, "  map<string, int64> counts = 2;\n")
+	generatortest.Contains(t, src, "  repeated google.protobuf.ListValue grid = 3;\n")
+	generatortest.Contains(t, src, "  map<string, google.protobuf.ListValue> groups = 4;\n")
+	generatortest.Contains(t, src, "  google.protobuf.Struct metadata = 5;\n")
+	generatortest.Contains(t, src, "  google.protobuf.Value anything = 6;\n")
+	generatortest.Contains(t, src, "  map<string, string> additional_properties = 7;\n")
+	generatortest.NotContains(t, src, "message Tags")
+	generatortest.HasDiagnostic(t, file.Diagnostics, DiagnosticInlinedComponent)
+	generatortest.HasDiagnostic(t, file.Diagnostics, DiagnosticNestedCollection)
+}
+
+func TestRenderAllOfFlattensReferencedFields(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Base:
+      type: object
+      properties:
+        id:
+          type: string
+        createdAt:
+          type: string
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          properties:
+            name:
+              type: string
+            id:
+              type: string
+              description: Overrides the base id.
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "message Pet {\n  // Overrides the base id.\n  string id = 1;\n  string created_at = 2;\n  string name = 3;\n}\n")
+	generatortest.HasDiagnostic(t, file.Diagnostics, DiagnosticAllOfFlattened)
+}
+
+func TestRenderFormatMappings(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Event:
+      type: object
+      properties:
+        at:
+          type: [string, "null"]
+          format: date-time
+        count:
+          type: [integer, "null"]
+          format: uint64
+`, WithFormatMapping("date-time", "google.protobuf.Timestamp", "google/protobuf/timestamp.proto"))
+	src := string(file.Source)
+	generatortest.Contains(t, src, "import \"google/protobuf/timestamp.proto\";\nimport \"google/protobuf/wrappers.proto\";\n")
+	generatortest.Contains(t, src, "  google.protobuf.Timestamp at = 1;\n")
+	generatortest.Contains(t, src, "  google.protobuf.UInt64Value count = 2;\n")
+}
+
+func TestNullableFollowsOpenAPI30(t *testing.T) {
+	file := renderSpec(t, "3.0.3", `
+    Pet:
+      type: object
+      properties:
+        nickname:
+          type: string
+          nullable: true
+        age:
+          type: integer
+          nullable: true
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "  google.protobuf.StringValue nickname = 1;\n")
+	generatortest.Contains(t, src, "  google.protobuf.Int64Value age = 2;\n")
+}
+
+func TestRenderSchemasOptionsAndHeaders(t *testing.T) {
+	file := renderSpec(t, "3.1.0", "    A:\n      type: object\n      properties:\n        id:\n          type: string\n",
+		WithPackageName("acme.pets.v1"),
+		WithGoPackage("example.com/acme/pets/v1;petsv1"),
+		WithGeneratedComment(true),
+		WithHeaderComment("Pets API\nversion 1"),
+	)
+	src := string(file.Source)
+	if !strings.HasPrefix(src, "// Code generated by libopenapi generator/protobuf. DO NOT EDIT.\n// Pets API\n// version 1\n\nsyntax = \"proto3\";\n\npackage acme.pets.v1;\n\noption go_package = \"example.com/acme/pets/v1;petsv1\";\n") {
+		t.Fatalf("unexpected header:\n%s", src)
+	}
+	if file.PackageName != "acme.pets.v1" {
+		t.Fatalf("unexpected package name %q", file.PackageName)
+	}
+
+	empty, err := RenderSchemas(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(empty.Source) != "syntax = \"proto3\";\n\npackage models;\n" {
+		t.Fatalf("unexpected empty file:\n%s", empty.Source)
+	}
+}
+
+func TestRenderSchemasErrors(t *testing.T) {
+	if _, err := RenderSchemas(nil, WithPackageName("acme-pets")); !errors.Is(err, ErrInvalidPackageName) {
+		t.Fatalf("expected ErrInvalidPackageName, got %v", err)
+	}
+	if _, err := RenderSchema("Pet", nil); !errors.Is(err, ErrNilSchema) {
+		t.Fatalf("expected ErrNilSchema, got %v", err)
+	}
+	schemas := orderedmap.New[string, *highbase.SchemaProxy]()
+	schemas.Set("Pet", nil)
+	if _, err := RenderSchemas(schemas); !errors.Is(err, ErrNilSchema) {
+		t.Fatalf("expected ErrNilSchema, got %v", err)
+	}
+}
+
+func TestTranslateIRDiagnostic(t *testing.T) {
+	diagnostic, ok := translateIRDiagnostic(Diagnostic{Code: golang.DiagnosticExternalReference, Message: "external reference rendered as Go type Pet"})
+	if !ok || diagnostic.Message != "external reference rendered as proto type Pet" {
+		t.Fatalf("unexpected translation: %#v", diagnostic)
+	}
+	if _, ok := translateIRDiagnostic(Diagnostic{Code: golang.DiagnosticMultiTypeSchema}); ok {
+		t.Fatal("multi-type schemas render as a oneof")
+	}
+}
+
+func TestNames(t *testing.T) {
+	cases := map[string]string{
+		"petType":      "pet_type",
+		"HTTPStatus":   "http_status",
+		"trip_id":      "trip_id",
+		"x-trace-id":   "x_trace_id",
+		"2fa":          "field_2fa",
+		"":             "field",
+		"already__bad": "already_bad",
+	}
+	for in, want := range cases {
+		if got := snakeName(in); got != want {
+			t.Fatalf("snakeName(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := upperSnakeName("in-review"); got != "IN_REVIEW" {
+		t.Fatalf("unexpected enum value name %q", got)
+	}
+	if got := jsonName("trip_id"); got != "tripId" {
+		t.Fatalf("unexpected json name %q", got)
+	}
+	if isPackageName("acme..v1") || isPackageName("1acme") || !isPackageName("acme.v1") {
+		t.Fatal("unexpected package name validation")
+	}
+}
+
+func TestGeneratorReuse(t *testing.T) {
+	generator := NewGenerator()
+	first := renderSpecWith(t, generator, "3.1.0", "    A:\n      type: object\n      properties:\n        id:\n          type: string\n")
+	second := renderSpecWith(t, generator, "3.1.0", "    A:\n      type: object\n      properties:\n        name:\n          type: string\n")
+	if !strings.Contains(string(second.Source), "string name = 1;") {
+		t.Fatalf("lock state leaked between runs:\n%s", second.Source)
+	}
+	if first.Lock == second.Lock {
+		t.Fatal("runs share a lock")
+	}
+}
+
+func renderSpec(t *testing.T, version, schemas string, opts ...Option) *GeneratedFile {
+	t.Helper()
+	return renderSpecWith(t, NewGenerator(opts...), version, schemas)
+}
+
+func renderSpecWith(t *testing.T, generator *Generator, version, schemas string) *GeneratedFile {
+	t.Helper()
+	return renderSchemas(t, generator, generatortest.Schemas(t, version, schemas))
+}
+
+func renderTestdata(t *testing.T, path string, opts ...Option) *GeneratedFile {
+	t.Helper()
+	return renderSchemas(t, NewGenerator(opts...), generatortest.TestdataSchemas(t, path))
+}
+
+func renderSchemas(t *testing.T, generator *Generator, schemas *orderedmap.Map[string, *highbase.SchemaProxy]) *GeneratedFile {
+	t.Helper()
+	file, err := generator.RenderSchemas(schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
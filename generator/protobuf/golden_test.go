@@ -0,0 +1,23 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/generator/internal/generatortest"
+)
+
+func TestTrainTravelGolden(t *testing.T) {
+	generatortest.Golden(t, "testdata/train_travel.golden.proto", renderTestdata(t, "../golang/testdata/train-travel.yaml",
+		WithPackageName("train.travel.v1"),
+		WithGoPackage("example.com/train/travel/v1;travelv1"),
+	).Source)
+}
+
+func TestJSONSchema202012Golden(t *testing.T) {
+	generatortest.Golden(t, "testdata/jsonschema_2020_12.golden.proto", renderTestdata(t, "../golang/testdata/jsonschema-2020-12.yaml",
+		WithGeneratedComment(true),
+	).Source)
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// LockFileName is the conventional file name for a persisted Lock.
+const LockFileName = "proto.lock.json"
+
+const lockVersion = 1
+
+// Field numbers 19000 through 19999 are reserved by the protobuf
+// implementation and cannot be assigned.
+const (
+	firstReservedImplementationNumber = 19000
+	lastReservedImplementationNumber  = 19999
+)
+
+// Lock records the field numbers and enum values assigned to every generated
+// message and enum. Numbers in a Lock are never reassigned to a different
+// name.
+type Lock struct {
+	Version  int                    `json:"version"`
+	Messages map[string]*NumberLock `json:"messages,omitempty"`
+	Enums    map[string]*NumberLock `json:"enums,omitempty"`
+}
+
+// NumberLock records the numbers assigned within one message or enum.
+type NumberLock struct {
+	// Numbers maps field or enum value names to their assigned numbers,
+	// including names that have since been removed.
+	Numbers map[string]int `json:"numbers"`
+	// Reserved lists the names from Numbers that no longer appear in the
+	// schema. Their numbers and names are rendered as reserved.
+	Reserved []string `json:"reserved,omitempty"`
+}
+
+// NewLock creates an empty Lock.
+func NewLock() *Lock {
+	return &Lock{
+		Version:  lockVersion,
+		Messages: make(map[string]*NumberLock),
+		Enums:    make(map[string]*NumberLock),
+	}
+}
+
+// ReadLock decodes a Lock previously produced by Marshal.
+func ReadLock(data []byte) (*Lock, error) {
+	lock := NewLock()
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("generator/protobuf: invalid lock file: %w", err)
+	}
+	if lock.Version != lockVersion {
+		return nil, fmt.Errorf("generator/protobuf: unsupported lock file version %d", lock.Version)
+	}
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]*NumberLock)
+	}
+	if lock.Enums == nil {
+		lock.Enums = make(map[string]*NumberLock)
+	}
+	return lock, nil
+}
+
+// Marshal encodes the Lock as indented JSON with sorted keys so lock files
+// diff cleanly.
+func (l *Lock) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (l *Lock) clone() *Lock {
+	out := NewLock()
+	if l == nil {
+		return out
+	}
+	for name, numbers := range l.Messages {
+		out.Messages[name] = numbers.clone()
+	}
+	for name, numbers := range l.Enums {
+		out.Enums[name] = numbers.clone()
+	}
+	return out
+}
+
+func (l *Lock) message(name string) *NumberLock {
+	return lockEntry(l.Messages, name)
+}
+
+func (l *Lock) enum(name string) *NumberLock {
+	return lockEntry(l.Enums, name)
+}
+
+func lockEntry(entries map[string]*NumberLock, name string) *NumberLock {
+	entry := entries[name]
+	if entry == nil {
+		entry = &NumberLock{}
+		entries[name] = entry
+	}
+	if entry.Numbers == nil {
+		entry.Numbers = make(map[string]int)
+	}
+	return entry
+}
+
+func (n *NumberLock) clone() *NumberLock {
+	if n == nil {
+		return &NumberLock{Numbers: make(map[string]int)}
+	}
+	out := &NumberLock{Numbers: make(map[string]int, len(n.Numbers))}
+	for name, number := range n.Numbers {
+		out.Numbers[name] = number
+	}
+	out.Reserved = append([]string(nil), n.Reserved...)
+	return out
+}
+
+// assign returns the locked number for name, allocating the next free number
+// when name has never been seen. A previously reserved name is restored with
+// its original number.
+func (n *NumberLock) assign(name string, field bool) int {
+	if number, ok := n.Numbers[name]; ok {
+		n.unreserve(name)
+		return number
+	}
+	next := 1
+	for _, number := range n.Numbers {
+		if number >= next {
+			next = number + 1
+		}
+	}
+	if field && next >= firstReservedImplementationNumber && next <= lastReservedImplementationNumber {
+		next = lastReservedImplementationNumber + 1
+	}
+	n.Numbers[name] = next
+	return next
+}
+
+// retire reserves every locked name that is not active and returns the names
+// reserved by this call.
+func (n *NumberLock) retire(active map[string]struct{}) []string {
+	var retired []string
+	for name := range n.Numbers {
+		if _, ok := active[name]; ok || n.isReserved(name) {
+			continue
+		}
+		n.Reserved = append(n.Reserved, name)
+		retired = append(retired, name)
+	}
+	sort.Strings(n.Reserved)
+	sort.Strings(retired)
+	return retired
+}
+
+func (n *NumberLock) unreserve(name string) {
+	for i, reserved := range n.Reserved {
+		if reserved == name {
+			n.Reserved = append(n.Reserved[:i], n.Reserved[i+1:]...)
+			return
+		}
+	}
+}
+
+func (n *NumberLock) isReserved(name string) bool {
+	for _, reserved := range n.Reserved {
+		if reserved == name {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedNumbers returns the sorted numbers of the reserved names.
+func (n *NumberLock) reservedNumbers() []int {
+	numbers := make([]int, 0, len(n.Reserved))
+	for _, name := range n.Reserved {
+		numbers = append(numbers, n.Numbers[name])
+	}
+	sort.Ints(numbers)
+	return numbers
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/generator/internal/generatortest"
+)
+
+const lockedPetV1 = `
+    Pet:
+      type: object
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+        tag:
+          type: string
+    Status:
+      type: string
+      enum: [active, retired]
+`
+
+func TestLockKeepsNumbersAcrossRuns(t *testing.T) {
+	first := renderSpec(t, "3.1.0", lockedPetV1)
+	generatortest.Contains(t, string(first.Source), "  string tag = 3;\n")
+
+	// tag is removed, a field is inserted ahead of name, and an enum value is
+	// dropped. Existing numbers must not move.
+	second := renderSpec(t, "3.1.0", `
+    Pet:
+      type: object
+      properties:
+        id:
+          type: string
+        nickname:
+          type: string
+        name:
+          type: string
+    Status:
+      type: string
+      enum: [retired, pending]
+`, WithLock(first.Lock))
+	src := string(second.Source)
+	generatortest.Contains(t, src, "message Pet {\n  string id = 1;\n  string nickname = 4;\n  string name = 2;\n  reserved 3;\n  reserved \"tag\";\n}\n")
+	generatortest.Contains(t, src, "enum Status {\n  STATUS_UNSPECIFIED = 0;\n  STATUS_RETIRED = 2;\n  STATUS_PENDING = 3;\n  reserved 1;\n  reserved \"STATUS_ACTIVE\";\n}\n")
+	generatortest.HasDiagnostic(t, second.Diagnostics, DiagnosticFieldReserved)
+
+	// tag returns and gets its original number back.
+	third := renderSpec(t, "3.1.0", lockedPetV1, WithLock(second.Lock))
+	src = string(third.Source)
+	generatortest.Contains(t, src, "  string tag = 3;\n")
+	generatortest.Contains(t, src, "  STATUS_ACTIVE = 1;\n")
+	generatortest.Contains(t, src, "  reserved 4;\n  reserved \"nickname\";\n")
+}
+
+func TestWithLockDoesNotMutateCallerLock(t *testing.T) {
+	lock := NewLock()
+	file := renderSpec(t, "3.1.0", lockedPetV1, WithLock(lock))
+	if len(lock.Messages) != 0 || len(lock.Enums) != 0 {
+		t.Fatalf("caller lock was mutated: %#v", lock)
+	}
+	if file.Lock.Messages["Pet"].Numbers["name"] != 2 {
+		t.Fatalf("unexpected lock: %#v", file.Lock.Messages["Pet"])
+	}
+}
+
+func TestLockRoundTrip(t *testing.T) {
+	file := renderSpec(t, "3.1.0", lockedPetV1)
+	data, err := file.Lock.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "{\n  \"version\": 1,\n") || !strings.HasSuffix(string(data), "}\n") {
+		t.Fatalf("unexpected lock file:\n%s", data)
+	}
+	lock, err := ReadLock(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again := renderSpec(t, "3.1.0", lockedPetV1, WithLock(lock))
+	if string(again.Source) != string(file.Source) {
+		t.Fatalf("round-tripped lock changed output:\n%s", again.Source)
+	}
+
+	if _, err := ReadLock([]byte(`{"version": 2}`)); err == nil {
+		t.Fatal("expected unsupported version error")
+	}
+	if _, err := ReadLock([]byte(`{`)); err == nil {
+		t.Fatal("expected invalid lock error")
+	}
+	empty, err := ReadLock([]byte(`{"version": 1}`))
+	if err != nil || empty.Messages == nil || empty.Enums == nil {
+		t.Fatalf("expected initialized lock, got %#v, %v", empty, err)
+	}
+}
+
+func TestNumberLockSkipsImplementationRange(t *testing.T) {
+	entry := &NumberLock{Numbers: map[string]int{"last": firstReservedImplementationNumber - 1}}
+	if got := entry.assign("next", true); got != lastReservedImplementationNumber+1 {
+		t.Fatalf("field number %d is in the reserved implementation range", got)
+	}
+	values := &NumberLock{Numbers: map[string]int{"last": firstReservedImplementationNumber - 1}}
+	if got := values.assign("next", false); got != firstReservedImplementationNumber {
+		t.Fatalf("enum values may use the implementation range, got %d", got)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// snakeName converts an OpenAPI property name to a lower_snake_case proto
+// field name.
+func snakeName(name string) string {
+	out := snakeWords(name)
+	if out == "" {
+		return "field"
+	}
+	if unicode.IsDigit([]rune(out)[0]) {
+		return "field_" + out
+	}
+	return out
+}
+
+// upperSnakeName converts an enum value to an UPPER_SNAKE_CASE suffix. Enum
+// values are always prefixed with their enum name, so a leading digit is
+// allowed.
+func upperSnakeName(value string) string {
+	out := strings.ToUpper(snakeWords(value))
+	if out == "" {
+		return "EMPTY"
+	}
+	return out
+}
+
+// snakeWords lowercases name and joins its words with underscores, splitting
+// on punctuation and camel case boundaries.
+func snakeWords(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r)) {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteByte('_')
+			}
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// jsonName returns the JSON name protoc derives for a field name.
+func jsonName(field string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range field {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isPackageName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, part := range strings.Split(name, ".") {
+		if !isIdentifier(part) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r)) {
+			continue
+		}
+		if i > 0 && r < unicode.MaxASCII && unicode.IsDigit(r) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func uniqueName(base string, used map[string]struct{}) string {
+	if _, ok := used[base]; !ok {
+		used[base] = struct{}{}
+		return base
+	}
+	for i := 2; ; i++ {
+		name := base + "_" + strconv.Itoa(i)
+		if _, ok := used[name]; !ok {
+			used[name] = struct{}{}
+			return name
+		}
+	}
+}
+
+func refName(ref string) string {
+	i := strings.LastIndex(ref, "/")
+	if i < 0 || i == len(ref)-1 {
+		return ref
+	}
+	return ref[i+1:]
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import "github.com/pb33f/libopenapi/generator/golang"
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// NameResolver maps OpenAPI names to proto message and enum names. Returning an
+// empty string falls back to the generator's default naming.
+type NameResolver = golang.NameResolver
+
+// ExternalRefResolver maps an external OpenAPI $ref to a proto message name.
+// Returning an empty string falls back to deriving the name from the reference
+// tail.
+type ExternalRefResolver = golang.ExternalRefResolver
+
+// Diagnostic describes a notable generator decision. It is shared with
+// generator/golang so diagnostics raised while building the IR keep their codes.
+type Diagnostic = golang.Diagnostic
+
+const (
+	DiagnosticAllOfFlattened   = "allOfFlattened"
+	DiagnosticFieldReserved    = "fieldReserved"
+	DiagnosticInlinedComponent = "inlinedComponent"
+	DiagnosticNestedCollection = "nestedCollection"
+	DiagnosticUntypedValue     = "untypedValue"
+)
+
+type formatMapping struct {
+	protoType  string
+	importPath string
+}
+
+// WithPackageName sets the proto package name. Dotted names such as
+// "acme.pets.v1" are allowed.
+func WithPackageName(name string) Option {
+	return func(g *Generator) {
+		g.packageName = name
+	}
+}
+
+// WithGoPackage writes an option go_package file option.
+func WithGoPackage(importPath string) Option {
+	return func(g *Generator) {
+		g.goPackage = importPath
+	}
+}
+
+// WithLock supplies the field number lock from a previous run. The lock is
+// copied; the updated lock is returned in GeneratedFile.Lock.
+func WithLock(lock *Lock) Option {
+	return func(g *Generator) {
+		g.lock = lock
+	}
+}
+
+// WithHeaderComment writes a file header comment before the syntax statement.
+func WithHeaderComment(text string) Option {
+	return func(g *Generator) {
+		g.headerComment = text
+	}
+}
+
+// WithGeneratedComment writes a standard generated-code comment.
+func WithGeneratedComment(enabled bool) Option {
+	return func(g *Generator) {
+		g.generatedComment = enabled
+	}
+}
+
+// WithFormatMapping maps an OpenAPI format to a proto type and the import that
+// declares it, for example "date-time" to "google.protobuf.Timestamp" from
+// "google/protobuf/timestamp.proto". Mapped types are treated as messages, so
+// nullable mapped fields do not use wrapper types.
+func WithFormatMapping(format, protoType, importPath string) Option {
+	return func(g *Generator) {
+		if g.formatMappings == nil {
+			g.formatMappings = make(map[string]formatMapping)
+		}
+		g.formatMappings[format] = formatMapping{protoType: protoType, importPath: importPath}
+	}
+}
+
+// WithNameResolver sets a broad fallback resolver for generated names.
+func WithNameResolver(resolver NameResolver) Option {
+	return func(g *Generator) {
+		g.nameResolver = resolver
+	}
+}
+
+// WithTypeNameResolver sets a resolver for generated message and enum names.
+func WithTypeNameResolver(resolver NameResolver) Option {
+	return func(g *Generator) {
+		g.typeNameResolver = resolver
+	}
+}
+
+// WithNestedTypeNameDelimiter sets the separator inserted between generated
+// parent and child message names for inline schemas. The default is empty,
+// which produces names like OrderPaymentSource.
+func WithNestedTypeNameDelimiter(delimiter string) Option {
+	return func(g *Generator) {
+		g.nestedTypeNameDelimiter = delimiter
+	}
+}
+
+// WithExternalRefTypeResolver sets a resolver for external OpenAPI $ref values
+// when rendering proto type names. The resolver is not used for local
+// component references.
+func WithExternalRefTypeResolver(resolver ExternalRefResolver) Option {
+	return func(g *Generator) {
+		g.externalRefResolver = resolver
+	}
+}
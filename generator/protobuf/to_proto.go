@@ -0,0 +1,602 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package protobuf
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+const indentUnit = "  "
+
+const (
+	structImport   = "google/protobuf/struct.proto"
+	wrappersImport = "google/protobuf/wrappers.proto"
+)
+
+// wrapperTypes maps proto scalar types to the google.protobuf wrapper messages
+// used for nullable fields.
+var wrapperTypes = map[string]string{
+	"string": "StringValue",
+	"bytes":  "BytesValue",
+	"int32":  "Int32Value",
+	"int64":  "Int64Value",
+	"uint32": "UInt32Value",
+	"uint64": "UInt64Value",
+	"float":  "FloatValue",
+	"double": "DoubleValue",
+	"bool":   "BoolValue",
+}
+
+// protoField is the rendered type of a field. Repeated and map fields are
+// collections, which proto3 cannot nest or place in a oneof.
+type protoField struct {
+	typ        string
+	label      string
+	collection bool
+	isMap      bool
+}
+
+func (g *Generator) renderFile(irs []*golang.SchemaIR) *GeneratedFile {
+	for _, ir := range irs {
+		if ir != nil && ir.Name != "" {
+			g.components[ir.Name] = ir
+		}
+	}
+	for _, ir := range irs {
+		if ir == nil {
+			continue
+		}
+		if declaredName(ir) == "" {
+			g.noteInlined(ir)
+			continue
+		}
+		g.renderDecl(ir)
+		g.types = append(g.types, &GeneratedType{Name: ir.Name, Kind: ir.Kind})
+	}
+	var b strings.Builder
+	if g.generatedComment {
+		b.WriteString("// Code generated by libopenapi generator/protobuf. DO NOT EDIT.\n")
+	}
+	if g.headerComment != "" {
+		for _, line := range strings.Split(strings.TrimSpace(g.headerComment), "\n") {
+			b.WriteString("// ")
+			b.WriteString(strings.TrimSpace(line))
+			b.WriteByte('\n')
+		}
+	}
+	if g.generatedComment || g.headerComment != "" {
+		b.WriteByte('\n')
+	}
+	b.WriteString("syntax = \"proto3\";\n\npackage ")
+	b.WriteString(g.packageName)
+	b.WriteString(";\n")
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			imports = append(imports, path)
+		}
+		sort.Strings(imports)
+		b.WriteByte('\n')
+		for _, path := range imports {
+			b.WriteString("import ")
+			b.WriteString(strconv.Quote(path))
+			b.WriteString(";\n")
+		}
+	}
+	if g.goPackage != "" {
+		b.WriteString("\noption go_package = ")
+		b.WriteString(strconv.Quote(g.goPackage))
+		b.WriteString(";\n")
+	}
+	for _, decl := range g.decls {
+		b.WriteByte('\n')
+		b.WriteString(decl)
+	}
+	return &GeneratedFile{
+		PackageName: g.packageName,
+		Source:      []byte(b.String()),
+		Lock:        g.numbers,
+		Types:       g.types,
+		Diagnostics: append([]Diagnostic(nil), g.diagnostics...),
+	}
+}
+
+func (g *Generator) rememberDecl(name string) bool {
+	if name == "" {
+		return false
+	}
+	if _, ok := g.seenDecls[name]; ok {
+		return false
+	}
+	g.seenDecls[name] = struct{}{}
+	return true
+}
+
+func (g *Generator) renderDecl(ir *golang.SchemaIR) {
+	if !g.rememberDecl(declaredName(ir)) {
+		return
+	}
+	switch ir.Kind {
+	case golang.KindEnum:
+		g.renderEnum(ir)
+	case golang.KindUnion:
+		g.renderUnion(ir)
+	default:
+		g.renderMessage(ir)
+	}
+}
+
+// noteInlined reports a component that has no proto declaration of its own.
+// proto3 has no type aliases, so scalar, array, and map components are inlined
+// wherever they are referenced.
+func (g *Generator) noteInlined(ir *golang.SchemaIR) {
+	if ir == nil || ir.Name == "" {
+		return
+	}
+	if _, ok := g.inlined[ir.Name]; ok {
+		return
+	}
+	g.inlined[ir.Name] = struct{}{}
+	g.addDiagnostic(DiagnosticInlinedComponent, ir.Name, "proto3 has no type aliases; "+ir.Name+" is inlined wherever it is referenced")
+}
+
+func (g *Generator) renderMessage(ir *golang.SchemaIR) {
+	properties := g.messageProperties(ir)
+	entry := g.numbers.message(ir.Name)
+	active := make(map[string]struct{})
+	used := make(map[string]struct{})
+	var body strings.Builder
+	if ir.Deprecated {
+		body.WriteString(indentUnit)
+		body.WriteString("option deprecated = true;\n")
+	}
+	for propName, prop := range properties.FromOldest() {
+		path := ir.Name + "." + propName
+		field := uniqueName(snakeName(propName), used)
+		active[field] = struct{}{}
+		number := entry.assign(field, true)
+		var options []string
+		if jsonName(field) != propName {
+			options = append(options, "json_name = "+strconv.Quote(propName))
+		}
+		if prop != nil && prop.Deprecated {
+			options = append(options, "deprecated = true")
+		}
+		writeComment(&body, indentUnit, prop)
+		writeField(&body, indentUnit, g.fieldType(prop, path), field, number, options)
+	}
+	if ir.AdditionalProperties != nil && properties.Len() > 0 {
+		field := uniqueName("additional_properties", used)
+		active[field] = struct{}{}
+		number := entry.assign(field, true)
+		value := g.mapValueType(ir.AdditionalProperties, ir.Name+".additionalProperties")
+		writeField(&body, indentUnit, protoField{typ: "map<string, " + value + ">"}, field, number, nil)
+	}
+	g.writeReserved(&body, entry, entry.retire(active), ir.Name, false)
+	var b strings.Builder
+	writeComment(&b, "", ir)
+	b.WriteString("message ")
+	b.WriteString(ir.Name)
+	b.WriteString(" {\n")
+	b.WriteString(body.String())
+	b.WriteString("}\n")
+	g.decls = append(g.decls, b.String())
+}
+
+// messageProperties returns the fields of an object message. proto3 has no
+// inheritance, so properties of referenced allOf members are copied into the
+// message ahead of its own properties.
+func (g *Generator) messageProperties(ir *golang.SchemaIR) *orderedmap.Map[string, *golang.SchemaIR] {
+	properties := orderedmap.New[string, *golang.SchemaIR]()
+	if len(ir.AllOf) > 0 {
+		g.flattenAllOf(ir, properties, map[*golang.SchemaIR]struct{}{ir: {}})
+		g.addDiagnostic(DiagnosticAllOfFlattened, ir.Name, "allOf members were flattened into the fields of "+ir.Name+" because proto3 has no inheritance")
+	}
+	if ir.Properties != nil {
+		for name, prop := range ir.Properties.FromOldest() {
+			properties.Set(name, prop)
+		}
+	}
+	return properties
+}
+
+func (g *Generator) flattenAllOf(ir *golang.SchemaIR, properties *orderedmap.Map[string, *golang.SchemaIR], seen map[*golang.SchemaIR]struct{}) {
+	for _, child := range ir.AllOf {
+		target := child
+		if child != nil && child.Kind == golang.KindRef {
+			_, target = g.resolveRef(child)
+		}
+		if target == nil || (target.Kind != golang.KindObject && target.Kind != golang.KindAllOf) {
+			g.addDiagnostic(DiagnosticUntypedValue, ir.Name+".allOf", "allOf member of "+ir.Name+" is not an object and contributes no fields")
+			continue
+		}
+		if _, ok := seen[target]; ok {
+			continue
+		}
+		seen[target] = struct{}{}
+		g.flattenAllOf(target, properties, seen)
+		if target.Properties != nil {
+			for name, prop := range target.Properties.FromOldest() {
+				properties.Set(name, prop)
+			}
+		}
+	}
+}
+
+func (g *Generator) renderUnion(ir *golang.SchemaIR) {
+	entry := g.numbers.message(ir.Name)
+	active := make(map[string]struct{})
+	used := make(map[string]struct{})
+	var members strings.Builder
+	if ir.Union != nil {
+		for _, variant := range ir.Union.Variants {
+			if variant == nil || isNullOnly(variant) {
+				continue
+			}
+			path := ir.Name + ".oneOf"
+			f := g.fieldType(variant, path)
+			if f.collection {
+				g.addDiagnostic(DiagnosticNestedCollection, path, "oneof members cannot be repeated or maps; variant rendered as "+g.collectionValue(f))
+				f = protoField{typ: g.collectionValue(f)}
+			}
+			f.label = ""
+			field := uniqueName(variantFieldName(ir.Name, variant, f.typ), used)
+			active[field] = struct{}{}
+			writeComment(&members, indentUnit+indentUnit, variant)
+			writeField(&members, indentUnit+indentUnit, f, field, entry.assign(field, true), nil)
+		}
+	}
+	var body strings.Builder
+	if members.Len() > 0 {
+		body.WriteString(indentUnit)
+		body.WriteString("oneof value {\n")
+		body.WriteString(members.String())
+		body.WriteString(indentUnit)
+		body.WriteString("}\n")
+	}
+	g.writeReserved(&body, entry, entry.retire(active), ir.Name, false)
+	var b strings.Builder
+	writeComment(&b, "", ir)
+	b.WriteString("message ")
+	b.WriteString(ir.Name)
+	b.WriteString(" {\n")
+	b.WriteString(body.String())
+	b.WriteString("}\n")
+	g.decls = append(g.decls, b.String())
+}
+
+// variantFieldName names a oneof member after its message, or after its
+// scalar type for unnamed variants. Inline variants are named after their
+// union, so the union name prefix is dropped.
+func variantFieldName(union string, variant *golang.SchemaIR, typ string) string {
+	switch {
+	case variant.Kind == golang.KindRef && variant.Name != "":
+		return snakeName(variant.Name)
+	case variant.Kind == golang.KindRef:
+		return snakeName(refName(variant.Ref))
+	case declaredName(variant) != "":
+		if trimmed := strings.TrimPrefix(variant.Name, union); snakeWords(trimmed) != "" {
+			return snakeName(trimmed)
+		}
+		return snakeName(variant.Name)
+	}
+	typ = strings.TrimSuffix(typ[strings.LastIndex(typ, ".")+1:], "Value")
+	return snakeName(typ) + "_value"
+}
+
+func (g *Generator) renderEnum(ir *golang.SchemaIR) {
+	prefix := upperSnakeName(ir.Name)
+	entry := g.numbers.enum(ir.Name)
+	active := make(map[string]struct{})
+	used := map[string]struct{}{prefix + "_UNSPECIFIED": {}}
+	var body strings.Builder
+	if ir.Deprecated {
+		body.WriteString(indentUnit)
+		body.WriteString("option deprecated = true;\n")
+	}
+	body.WriteString(indentUnit)
+	body.WriteString(prefix)
+	body.WriteString("_UNSPECIFIED = 0;\n")
+	for _, node := range ir.Enum {
+		if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+			continue
+		}
+		name := uniqueName(prefix+"_"+upperSnakeName(node.Value), used)
+		active[name] = struct{}{}
+		body.WriteString(indentUnit)
+		body.WriteString(name)
+		body.WriteString(" = ")
+		body.WriteString(strconv.Itoa(entry.assign(name, false)))
+		body.WriteString(";\n")
+	}
+	g.writeReserved(&body, entry, entry.retire(active), ir.Name, true)
+	var b strings.Builder
+	writeComment(&b, "", ir)
+	b.WriteString("enum ")
+	b.WriteString(ir.Name)
+	b.WriteString(" {\n")
+	b.WriteString(body.String())
+	b.WriteString("}\n")
+	g.decls = append(g.decls, b.String())
+}
+
+// writeReserved renders the reserved numbers and names of entry and reports
+// the names retired by this run.
+func (g *Generator) writeReserved(b *strings.Builder, entry *NumberLock, retired []string, owner string, enum bool) {
+	kind := "field"
+	if enum {
+		kind = "enum value"
+	}
+	for _, name := range retired {
+		g.addDiagnostic(DiagnosticFieldReserved, owner+"."+name, kind+" "+name+" was removed from the schema; number "+strconv.Itoa(entry.Numbers[name])+" and its name are reserved")
+	}
+	if len(entry.Reserved) == 0 {
+		return
+	}
+	numbers := entry.reservedNumbers()
+	parts := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		parts = append(parts, strconv.Itoa(number))
+	}
+	b.WriteString(indentUnit)
+	b.WriteString("reserved ")
+	b.WriteString(strings.Join(parts, ", "))
+	b.WriteString(";\n")
+	names := make([]string, 0, len(entry.Reserved))
+	for _, name := range entry.Reserved {
+		names = append(names, strconv.Quote(name))
+	}
+	b.WriteString(indentUnit)
+	b.WriteString("reserved ")
+	b.WriteString(strings.Join(names, ", "))
+	b.WriteString(";\n")
+}
+
+func writeField(b *strings.Builder, indent string, f protoField, name string, number int, options []string) {
+	b.WriteString(indent)
+	if f.label != "" {
+		b.WriteString(f.label)
+		b.WriteByte(' ')
+	}
+	b.WriteString(f.typ)
+	b.WriteByte(' ')
+	b.WriteString(name)
+	b.WriteString(" = ")
+	b.WriteString(strconv.Itoa(number))
+	if len(options) > 0 {
+		b.WriteString(" [")
+		b.WriteString(strings.Join(options, ", "))
+		b.WriteByte(']')
+	}
+	b.WriteString(";\n")
+}
+
+// fieldType renders the proto type used to refer to ir, which is its declared
+// name when it has a declaration and its inline shape otherwise.
+func (g *Generator) fieldType(ir *golang.SchemaIR, path string) protoField {
+	if ir == nil {
+		return protoField{typ: g.structType("Value")}
+	}
+	if ir.Kind == golang.KindRef {
+		name, target := g.resolveRef(ir)
+		if target == nil {
+			return protoField{typ: name}
+		}
+		if declaredName(target) != "" {
+			return declaredField(target, ir.Nullable || target.Nullable)
+		}
+		if _, ok := g.inlining[name]; ok {
+			g.addDiagnostic(DiagnosticUntypedValue, path, "recursive reference to "+name+" cannot be inlined and was rendered as google.protobuf.Value")
+			return protoField{typ: g.structType("Value")}
+		}
+		g.inlining[name] = struct{}{}
+		defer delete(g.inlining, name)
+		g.noteInlined(target)
+		if target.Kind == golang.KindRef {
+			return g.fieldType(target, path)
+		}
+		return g.shapeField(target, ir.Nullable || target.Nullable, path)
+	}
+	if declaredName(ir) != "" {
+		g.renderDecl(ir)
+		return declaredField(ir, ir.Nullable)
+	}
+	return g.shapeField(ir, ir.Nullable, path)
+}
+
+// declaredField refers to a generated message or enum. Messages always have
+// presence; nullable enums are marked optional so null and the zero value stay
+// distinguishable.
+func declaredField(ir *golang.SchemaIR, nullable bool) protoField {
+	f := protoField{typ: ir.Name}
+	if ir.Kind == golang.KindEnum && nullable {
+		f.label = "optional"
+	}
+	return f
+}
+
+func (g *Generator) resolveRef(ir *golang.SchemaIR) (string, *golang.SchemaIR) {
+	name := ir.Name
+	if name == "" {
+		name = refName(ir.Ref)
+	}
+	return name, g.components[name]
+}
+
+// shapeField renders the structural type of ir for schemas that have no
+// declaration of their own.
+func (g *Generator) shapeField(ir *golang.SchemaIR, nullable bool, path string) protoField {
+	switch ir.Kind {
+	case golang.KindString, golang.KindInteger, golang.KindNumber, golang.KindBoolean:
+		typ, message := g.scalarType(ir)
+		if wrapper := wrapperTypes[typ]; nullable && !message && wrapper != "" {
+			g.imports[wrappersImport] = struct{}{}
+			typ = "google.protobuf." + wrapper
+		}
+		return protoField{typ: typ}
+	case golang.KindEnum:
+		typ := "string"
+		if len(ir.Enum) > 0 && ir.Enum[0] != nil && ir.Enum[0].Tag == "!!int" {
+			typ = "int64"
+		}
+		if nullable {
+			g.imports[wrappersImport] = struct{}{}
+			typ = "google.protobuf." + wrapperTypes[typ]
+		}
+		return protoField{typ: typ}
+	case golang.KindArray:
+		if len(ir.PrefixItems) > 0 {
+			g.addDiagnostic(DiagnosticUntypedValue, path, "prefixItems tuples have no proto3 equivalent and were rendered as google.protobuf.ListValue")
+			return protoField{typ: g.structType("ListValue")}
+		}
+		item := g.fieldType(ir.Items, path+".items")
+		if item.collection {
+			g.addDiagnostic(DiagnosticNestedCollection, path, "repeated fields cannot nest collections; items rendered as "+g.collectionValue(item))
+			item = protoField{typ: g.collectionValue(item)}
+		}
+		return protoField{typ: item.typ, label: "repeated", collection: true}
+	case golang.KindMap:
+		value := g.mapValueType(ir.AdditionalProperties, path+".additionalProperties")
+		return protoField{typ: "map<string, " + value + ">", collection: true, isMap: true}
+	case golang.KindObject, golang.KindAllOf:
+		if ir.AdditionalProperties != nil && (ir.Properties == nil || ir.Properties.Len() == 0) {
+			value := g.mapValueType(ir.AdditionalProperties, path+".additionalProperties")
+			return protoField{typ: "map<string, " + value + ">", collection: true, isMap: true}
+		}
+		if ir.Properties != nil && ir.Properties.Len() > 0 {
+			g.addDiagnostic(DiagnosticUntypedValue, path, "unnamed object rendered as google.protobuf.Struct")
+		}
+		return protoField{typ: g.structType("Struct")}
+	case golang.KindUnion:
+		g.addDiagnostic(DiagnosticUntypedValue, path, "unnamed union rendered as google.protobuf.Value")
+		return protoField{typ: g.structType("Value")}
+	default:
+		return protoField{typ: g.structType("Value")}
+	}
+}
+
+// mapValueType renders a map value type. proto3 map values cannot be
+// collections.
+func (g *Generator) mapValueType(ir *golang.SchemaIR, path string) string {
+	value := g.fieldType(ir, path)
+	if value.collection {
+		g.addDiagnostic(DiagnosticNestedCollection, path, "map values cannot be collections; values rendered as "+g.collectionValue(value))
+		return g.collectionValue(value)
+	}
+	return value.typ
+}
+
+// collectionValue returns the well-known type that holds a nested collection.
+func (g *Generator) collectionValue(f protoField) string {
+	if f.isMap {
+		return g.structType("Struct")
+	}
+	return g.structType("ListValue")
+}
+
+func (g *Generator) structType(name string) string {
+	g.imports[structImport] = struct{}{}
+	return "google.protobuf." + name
+}
+
+// scalarType maps a scalar schema to a proto type. The second result reports
+// whether the type is a message from a format mapping, which already has
+// presence and needs no wrapper.
+func (g *Generator) scalarType(ir *golang.SchemaIR) (string, bool) {
+	if mapped, ok := g.formatMappings[ir.Format]; ok && ir.Format != "" {
+		if mapped.importPath != "" {
+			g.imports[mapped.importPath] = struct{}{}
+		}
+		_, scalar := wrapperTypes[mapped.protoType]
+		return mapped.protoType, !scalar
+	}
+	switch ir.Kind {
+	case golang.KindString:
+		if ir.Format == "byte" || ir.Format == "binary" {
+			return "bytes", false
+		}
+		return "string", false
+	case golang.KindInteger:
+		switch ir.Format {
+		case "int32", "uint32", "uint64":
+			return ir.Format, false
+		}
+		return "int64", false
+	case golang.KindNumber:
+		if ir.Format == "float" {
+			return "float", false
+		}
+		return "double", false
+	default:
+		return "bool", false
+	}
+}
+
+// declaredName returns the name of the message or enum that renders ir, or an
+// empty string when ir is inlined.
+func declaredName(ir *golang.SchemaIR) string {
+	if ir == nil || ir.Name == "" {
+		return ""
+	}
+	switch ir.Kind {
+	case golang.KindEnum, golang.KindUnion:
+		return ir.Name
+	case golang.KindObject, golang.KindAllOf:
+		if (ir.Properties != nil && ir.Properties.Len() > 0) || len(ir.AllOf) > 0 {
+			return ir.Name
+		}
+	}
+	return ""
+}
+
+// isNullOnly reports whether ir only admits null, such as a `type: "null"`
+// union variant.
+func isNullOnly(ir *golang.SchemaIR) bool {
+	if ir.Const != nil && ir.Const.Tag == "!!null" {
+		return true
+	}
+	schema := ir.SourceSchema
+	if schema == nil || len(schema.Type) == 0 {
+		return false
+	}
+	for _, typ := range schema.Type {
+		if typ != "null" {
+			return false
+		}
+	}
+	return true
+}
+
+func writeComment(b *strings.Builder, indent string, ir *golang.SchemaIR) {
+	if ir == nil {
+		return
+	}
+	description := ir.Description
+	if description == "" {
+		description = ir.Title
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	for _, comment := range ir.Comments {
+		if comment != "Deprecated." {
+			lines = append(lines, comment)
+		}
+	}
+	for _, line := range lines {
+		b.WriteString(indent)
+		b.WriteString("// ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+}
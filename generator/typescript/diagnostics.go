@@ -0,0 +1,44 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/generator/golang"
+)
+
+// exactIRDiagnostics lists IR diagnostics for shapes TypeScript represents
+// directly: literal and mixed-type unions, tuples, and const literal types.
+var exactIRDiagnostics = map[string]struct{}{
+	golang.DiagnosticMultiTypeSchema:            {},
+	golang.DiagnosticMixedEnum:                  {},
+	golang.DiagnosticNullEnum:                   {},
+	golang.DiagnosticOptionalConstDiscriminator: {},
+	golang.DiagnosticPrefixItems:                {},
+	golang.DiagnosticBooleanItems:               {},
+	golang.DiagnosticConstKeyword:               {},
+}
+
+var irDiagnosticWording = strings.NewReplacer(
+	"generated Go models", "generated TypeScript types",
+	"generated Go model", "generated TypeScript type",
+	"Go model shape", "TypeScript type shape",
+	"Go struct fields", "TypeScript properties",
+	"Go reference name", "TypeScript type name",
+	"Go type", "TypeScript type",
+	"rendered as any", "rendered as unknown",
+	"uses any", "uses unknown",
+)
+
+// translateIRDiagnostic rewords a diagnostic raised while building the shared
+// IR for TypeScript output. It returns false for shapes TypeScript represents
+// exactly.
+func translateIRDiagnostic(diagnostic Diagnostic) (Diagnostic, bool) {
+	if _, exact := exactIRDiagnostics[diagnostic.Code]; exact {
+		return diagnostic, false
+	}
+	diagnostic.Message = irDiagnosticWording.Replace(diagnostic.Message)
+	return diagnostic, true
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package typescript generates TypeScript type declarations from OpenAPI
+// schemas.
+//
+// The package shares the OpenAPI -> IR path with generator/golang: schemas are
+// converted to golang.SchemaIR values by golang.Generator.SchemaIRs and then
+// rendered as TypeScript, so naming, collision handling, allOf merging, union
+// detection and discriminator inference behave the same for both languages.
+// Like generator/golang it is library-only and emits types, not a client or a
+// validation runtime.
+//
+// Objects render as exported interfaces, readOnly properties carry the readonly
+// modifier, enums render as string-literal (or mixed literal) union types, and
+// oneOf/anyOf schemas render as union types. Discriminated oneOf unions
+// intersect each variant with its discriminator literal so TypeScript can
+// narrow on the discriminator property. Multi-type schemas and prefixItems
+// tuples map directly to TypeScript unions and tuple types.
+//
+// Nullability follows the OpenAPI version of the source document. OpenAPI 3.0
+// schemas honor the nullable keyword, while OpenAPI 3.1 and later only treat
+// "null" in type arrays, null const values, and null union variants as
+// nullable; a stray nullable keyword in a 3.1 document is reported as a
+// diagnostic and ignored. The version is detected from the index behind the
+// schemas and can be set explicitly with WithOpenAPIVersion.
+//
+// GeneratedFile.Diagnostics reports schema shapes that do not map directly to
+// TypeScript types. Diagnostics raised by the shared IR path keep their codes
+// and are reworded for TypeScript output; IR diagnostics for shapes TypeScript
+// represents exactly, such as mixed enums and tuples, are dropped.
+package typescript
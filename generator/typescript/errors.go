@@ -0,0 +1,21 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/generator/golang"
+)
+
+// ErrNilSchema is shared with generator/golang, which reports it while building
+// the IR for nil component schemas.
+var ErrNilSchema = golang.ErrNilSchema
+
+func wrapPath(err error, path string) error {
+	if path == "" {
+		return fmt.Errorf("generator/typescript: %w", err)
+	}
+	return fmt.Errorf("generator/typescript: %w at %s", err, path)
+}
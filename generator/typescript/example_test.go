@@ -0,0 +1,36 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"fmt"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+func ExampleRenderSchema() {
+	readOnly := true
+	properties := orderedmap.New[string, *highbase.SchemaProxy]()
+	properties.Set("id", highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"string"}, ReadOnly: &readOnly}))
+	properties.Set("tag", highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"string", "null"}}))
+
+	schema := highbase.CreateSchemaProxy(&highbase.Schema{
+		Type:       []string{"object"},
+		Required:   []string{"id"},
+		Properties: properties,
+	})
+	source, err := RenderSchema("Pet", schema)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Print(string(source))
+
+	// Output:
+	// export interface Pet {
+	//   readonly id: string;
+	//   tag?: string | null;
+	// }
+}
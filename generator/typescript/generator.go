@@ -0,0 +1,142 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// Generator holds immutable configuration for TypeScript generation. Each
+// public entry point runs against a fresh copy of this configuration (see run),
+// so a configured Generator is safe to reuse and to share across goroutines.
+type Generator struct {
+	generatedComment        bool
+	headerComment           string
+	enumConstants           bool
+	readonlyModifiers       bool
+	openAPIVersion          string
+	nestedTypeNameDelimiter string
+
+	nameResolver          NameResolver
+	typeNameResolver      NameResolver
+	enumValueNameResolver NameResolver
+	externalRefResolver   ExternalRefResolver
+
+	formatMappings map[string]string
+
+	diagnostics        []Diagnostic
+	decls              []string
+	seenDecls          map[string]struct{}
+	nullableKeywords   map[*golang.SchemaIR]struct{}
+	nullableInterfaces map[string]struct{}
+	sourceVersion      sourceVersion
+}
+
+// GeneratedFile contains TypeScript source generated from OpenAPI schemas.
+type GeneratedFile struct {
+	Source      []byte
+	Types       []*GeneratedType
+	Diagnostics []Diagnostic
+}
+
+// GeneratedType describes one top-level generated TypeScript declaration.
+type GeneratedType struct {
+	Name string
+	Kind golang.Kind
+}
+
+// NewGenerator creates a TypeScript type generator.
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
+		readonlyModifiers:       true,
+		nestedTypeNameDelimiter: "_",
+		formatMappings:          make(map[string]string),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(g)
+		}
+	}
+	return g
+}
+
+// run returns a generator carrying fresh per-invocation state. Configuration is
+// shared with the receiver and treated as read-only during generation.
+func (g *Generator) run() *Generator {
+	r := *g
+	r.diagnostics = nil
+	r.decls = nil
+	r.seenDecls = make(map[string]struct{})
+	r.nullableKeywords = make(map[*golang.SchemaIR]struct{})
+	r.nullableInterfaces = make(map[string]struct{})
+	r.sourceVersion = parseSourceVersion(g.openAPIVersion)
+	return &r
+}
+
+// irGenerator returns the generator/golang generator used to build the shared
+// IR. Const discriminators always produce typed unions because TypeScript
+// unions do not need a required discriminator to narrow.
+func (g *Generator) irGenerator() *golang.Generator {
+	return golang.NewGenerator(
+		golang.WithNameResolver(g.nameResolver),
+		golang.WithTypeNameResolver(g.typeNameResolver),
+		golang.WithExternalRefTypeResolver(g.externalRefResolver),
+		golang.WithNestedTypeNameDelimiter(g.nestedTypeNameDelimiter),
+		golang.WithOptionalConstDiscriminatorUnions(true),
+	)
+}
+
+// RenderSchema renders a single OpenAPI schema as TypeScript source.
+func RenderSchema(name string, schema *highbase.SchemaProxy, opts ...Option) ([]byte, error) {
+	return NewGenerator(opts...).RenderSchema(name, schema)
+}
+
+// RenderSchemas renders an ordered map of OpenAPI schemas as one TypeScript
+// source file.
+func RenderSchemas(schemas *orderedmap.Map[string, *highbase.SchemaProxy], opts ...Option) (*GeneratedFile, error) {
+	return NewGenerator(opts...).RenderSchemas(schemas)
+}
+
+// RenderSchema renders a single OpenAPI schema as TypeScript source using this
+// generator.
+func (g *Generator) RenderSchema(name string, schema *highbase.SchemaProxy) ([]byte, error) {
+	if schema == nil {
+		return nil, wrapPath(ErrNilSchema, name)
+	}
+	schemas := orderedmap.New[string, *highbase.SchemaProxy]()
+	schemas.Set(name, schema)
+	file, err := g.RenderSchemas(schemas)
+	if err != nil {
+		return nil, err
+	}
+	return file.Source, nil
+}
+
+// RenderSchemas renders an ordered map of OpenAPI schemas as one TypeScript
+// source file using this generator.
+func (g *Generator) RenderSchemas(schemas *orderedmap.Map[string, *highbase.SchemaProxy]) (*GeneratedFile, error) {
+	r := g.run()
+	if schemas == nil {
+		return r.renderFile(nil), nil
+	}
+	if r.sourceVersion == versionUnknown {
+		r.sourceVersion = detectSourceVersion(schemas)
+	}
+	set, err := r.irGenerator().SchemaIRs(schemas)
+	if err != nil {
+		return nil, err
+	}
+	for _, diagnostic := range set.Diagnostics {
+		if translated, ok := translateIRDiagnostic(diagnostic); ok {
+			r.diagnostics = append(r.diagnostics, translated)
+		}
+	}
+	return r.renderFile(set.Schemas), nil
+}
+
+func (g *Generator) addDiagnostic(code, path, message string) {
+	g.diagnostics = append(g.diagnostics, Diagnostic{Code: code, Path: path, Message: message})
+}
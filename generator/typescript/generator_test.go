@@ -0,0 +1,355 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"errors"
+	"testing"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/generator/internal/generatortest"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+func TestRenderObjectsReadonlyAndOptional(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Pet:
+      description: A pet.
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: string
+          readOnly: true
+        name:
+          type: string
+          description: The pet name.
+        "x-tag":
+          type: integer
+        legacy:
+          type: boolean
+          deprecated: true
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "/** A pet. */\nexport interface Pet {")
+	generatortest.Contains(t, src, "  readonly id: string;")
+	generatortest.Contains(t, src, "  /** The pet name. */\n  name: string;")
+	generatortest.Contains(t, src, `  "x-tag"?: number;`)
+	generatortest.Contains(t, src, "  /** @deprecated */\n  legacy?: boolean;")
+
+	plain := renderSpec(t, "3.1.0", `
+    Pet:
+      type: object
+      properties:
+        id:
+          type: string
+          readOnly: true
+`, WithReadonlyModifiers(false))
+	generatortest.Contains(t, string(plain.Source), "  /** readOnly */\n  id?: string;")
+}
+
+func TestRenderEnumsAsLiteralUnions(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Status:
+      type: string
+      enum: [active, in-review]
+    Level:
+      type: integer
+      enum: [1, 2]
+`, WithEnumConstants(true))
+	src := string(file.Source)
+	generatortest.Contains(t, src, `export type Status = "active" | "in-review";`)
+	generatortest.Contains(t, src, "export const Status = {\n  Active: \"active\",\n  InReview: \"in-review\",\n} as const;")
+	generatortest.Contains(t, src, "export type Level = 1 | 2;")
+
+	noConstants := renderSpec(t, "3.1.0", `
+    Status:
+      type: string
+      enum: [active]
+`)
+	generatortest.NotContains(t, string(noConstants.Source), "export const")
+}
+
+func TestRenderDiscriminatedUnion(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          cat: '#/components/schemas/Cat'
+          kitten: '#/components/schemas/Cat'
+          dog: '#/components/schemas/Dog'
+    Cat:
+      type: object
+      properties:
+        petType:
+          type: string
+    Dog:
+      type: object
+      properties:
+        petType:
+          type: string
+`)
+	generatortest.Contains(t, string(file.Source), `export type Pet =
+  | (Cat & { petType: "cat" | "kitten" })
+  | (Dog & { petType: "dog" });`)
+}
+
+func TestRenderConstDiscriminatedUnionNarrowsWithoutIntersections(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Shape:
+      oneOf:
+        - type: object
+          title: circle
+          properties:
+            kind:
+              const: circle
+            radius:
+              type: number
+        - type: object
+          title: square
+          properties:
+            kind:
+              const: square
+            side:
+              type: number
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "export type Shape =\n  | Shape_Circle\n  | Shape_Square;")
+	generatortest.Contains(t, src, "export interface Shape_Circle {\n  kind?: \"circle\";")
+}
+
+func TestRenderArraysTuplesAndMaps(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Tags:
+      type: array
+      items:
+        type: string
+    Pair:
+      type: array
+      prefixItems:
+        - type: string
+        - type: integer
+    Closed:
+      type: array
+      prefixItems:
+        - type: string
+      items: false
+    Scores:
+      type: object
+      additionalProperties:
+        type: number
+    Mixed:
+      type: array
+      items:
+        type: [string, integer]
+    Config:
+      type: object
+      properties:
+        name:
+          type: string
+      additionalProperties:
+        type: integer
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "export type Tags = string[];")
+	generatortest.Contains(t, src, "export type Pair = [string, number, ...unknown[]];")
+	generatortest.Contains(t, src, "export type Closed = [string];")
+	generatortest.Contains(t, src, "export type Scores = Record<string, number>;")
+	generatortest.Contains(t, src, "export type Mixed = Mixed_Item[];")
+	generatortest.Contains(t, src, "export type Mixed_Item =\n  | string\n  | number;")
+	generatortest.Contains(t, src, "  [key: string]: unknown;")
+	generatortest.HasDiagnostic(t, file.Diagnostics, DiagnosticIndexSignature)
+	generatortest.NoDiagnostic(t, file.Diagnostics, golang.DiagnosticMultiTypeSchema)
+	generatortest.NoDiagnostic(t, file.Diagnostics, golang.DiagnosticPrefixItems)
+}
+
+func TestRenderAllOfAsIntersection(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Base:
+      type: object
+      properties:
+        id:
+          type: string
+    Named:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [name]
+          properties:
+            name:
+              type: string
+`)
+	generatortest.Contains(t, string(file.Source), "export type Named = Base & {\n  name: string;\n};")
+}
+
+func TestNullableFollowsOpenAPI30(t *testing.T) {
+	file := renderSpec(t, "3.0.3", `
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          nullable: true
+        owner:
+          nullable: true
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      nullable: true
+      properties:
+        id:
+          type: string
+    Holder:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "  name?: string | null;")
+	generatortest.Contains(t, src, "  owner?: Pet_Owner;")
+	generatortest.Contains(t, src, "export type Pet_Owner = Owner | null;")
+	generatortest.Contains(t, src, "export interface Holder {\n  owner?: Owner | null;")
+	generatortest.NoDiagnostic(t, file.Diagnostics, DiagnosticNullableKeyword)
+}
+
+func TestNullableFollowsOpenAPI31(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    Pet:
+      type: object
+      properties:
+        name:
+          type: [string, "null"]
+        legacy:
+          type: string
+          nullable: true
+        nickname:
+          oneOf:
+            - type: string
+            - type: "null"
+`)
+	src := string(file.Source)
+	generatortest.Contains(t, src, "  name?: string | null;")
+	generatortest.Contains(t, src, "  legacy?: string;")
+	generatortest.Contains(t, src, "  nickname?: string | null;")
+	generatortest.HasDiagnostic(t, file.Diagnostics, DiagnosticNullableKeyword)
+
+	// an explicit version overrides the detected one.
+	lenient := renderSpec(t, "3.1.0", `
+    Pet:
+      type: object
+      properties:
+        legacy:
+          type: string
+          nullable: true
+`, WithOpenAPIVersion("3.0.3"))
+	generatortest.Contains(t, string(lenient.Source), "  legacy?: string | null;")
+}
+
+func TestRenderSchemaWithoutIndexHonorsEveryNullableSource(t *testing.T) {
+	nullable := true
+	properties := orderedmap.New[string, *highbase.SchemaProxy]()
+	properties.Set("when", highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"string"}, Format: "date-time", Nullable: &nullable}))
+	schema := highbase.CreateSchemaProxy(&highbase.Schema{Type: []string{"object"}, Properties: properties})
+
+	src, err := RenderSchema("Event", schema, WithFormatMapping("date-time", "Date"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	generatortest.Contains(t, string(src), "export interface Event {\n  when?: Date | null;\n}")
+
+	if _, err := RenderSchema("Event", nil); !errors.Is(err, ErrNilSchema) {
+		t.Fatalf("expected ErrNilSchema, got %v", err)
+	}
+}
+
+func TestRenderSchemasNilAndHeaders(t *testing.T) {
+	file, err := NewGenerator(WithGeneratedComment(true), WithHeaderComment("Models for the pet store.")).RenderSchemas(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "// Code generated by libopenapi generator/typescript. DO NOT EDIT.\n// Models for the pet store.\n"
+	if string(file.Source) != want {
+		t.Fatalf("unexpected source: %q", file.Source)
+	}
+
+	headed := renderSpec(t, "3.1.0", `
+    Name:
+      type: string
+`, WithGeneratedComment(true))
+	generatortest.Contains(t, string(headed.Source), "DO NOT EDIT.\n\nexport type Name = string;\n")
+	if len(headed.Types) != 1 || headed.Types[0].Name != "Name" || headed.Types[0].Kind != golang.KindString {
+		t.Fatalf("unexpected types: %#v", headed.Types)
+	}
+}
+
+func TestNameOptionsShareIRNaming(t *testing.T) {
+	file := renderSpec(t, "3.1.0", `
+    pet_record:
+      type: object
+      properties:
+        owner:
+          type: object
+          properties:
+            id:
+              type: string
+`, WithNestedTypeNameDelimiter(""), WithTypeNameResolver(func(name string) string {
+		if name == "pet_record" {
+			return "PetRecord"
+		}
+		return ""
+	}))
+	src := string(file.Source)
+	generatortest.Contains(t, src, "export interface PetRecordOwner {")
+	generatortest.Contains(t, src, "  owner?: PetRecordOwner;")
+}
+
+func TestTranslateIRDiagnostic(t *testing.T) {
+	diagnostic, ok := translateIRDiagnostic(Diagnostic{Code: golang.DiagnosticExternalReference, Message: "external reference rendered as Go type Pet"})
+	if !ok || diagnostic.Message != "external reference rendered as TypeScript type Pet" {
+		t.Fatalf("unexpected translation: %#v", diagnostic)
+	}
+	if _, ok := translateIRDiagnostic(Diagnostic{Code: golang.DiagnosticMixedEnum}); ok {
+		t.Fatal("mixed enums are exact in TypeScript")
+	}
+}
+
+func TestGeneratorReuse(t *testing.T) {
+	generator := NewGenerator()
+	first := renderSpecWith(t, generator, "3.1.0", "    A:\n      type: string\n      nullable: true\n")
+	second := renderSpecWith(t, generator, "3.1.0", "    A:\n      type: string\n      nullable: true\n")
+	if string(first.Source) != string(second.Source) || len(first.Diagnostics) != len(second.Diagnostics) {
+		t.Fatal("generator state leaked between runs")
+	}
+}
+
+func renderSpec(t *testing.T, version, schemas string, opts ...Option) *GeneratedFile {
+	t.Helper()
+	return renderSpecWith(t, NewGenerator(opts...), version, schemas)
+}
+
+func renderSpecWith(t *testing.T, generator *Generator, version, schemas string) *GeneratedFile {
+	t.Helper()
+	return renderSchemas(t, generator, generatortest.Schemas(t, version, schemas))
+}
+
+func renderTestdata(t *testing.T, path string, opts ...Option) *GeneratedFile {
+	t.Helper()
+	return renderSchemas(t, NewGenerator(opts...), generatortest.TestdataSchemas(t, path))
+}
+
+func renderSchemas(t *testing.T, generator *Generator, schemas *orderedmap.Map[string, *highbase.SchemaProxy]) *GeneratedFile {
+	t.Helper()
+	file, err := generator.RenderSchemas(schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
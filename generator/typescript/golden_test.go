@@ -0,0 +1,20 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/generator/internal/generatortest"
+)
+
+func TestTrainTravelGolden(t *testing.T) {
+	generatortest.Golden(t, "testdata/train_travel.golden.ts", renderTestdata(t, "../golang/testdata/train-travel.yaml").Source)
+}
+
+func TestJSONSchema202012GoldenEnumConstants(t *testing.T) {
+	generatortest.Golden(t, "testdata/jsonschema_2020_12_enum_constants.golden.ts", renderTestdata(t, "../golang/testdata/jsonschema-2020-12.yaml",
+		WithEnumConstants(true),
+	).Source)
+}
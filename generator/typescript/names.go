@@ -0,0 +1,106 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// propertyKey renders an object property name, quoting names that are not
+// valid TypeScript identifiers.
+func propertyKey(name string) string {
+	if isIdentifier(name) {
+		return name
+	}
+	return quote(name)
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) {
+			continue
+		}
+		if i > 0 && unicode.IsDigit(r) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+func quote(value string) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return `""`
+	}
+	return string(encoded)
+}
+
+func (g *Generator) enumValueName(value string) string {
+	if g.enumValueNameResolver != nil {
+		if resolved := g.enumValueNameResolver(value); resolved != "" {
+			return resolved
+		}
+	}
+	if g.nameResolver != nil {
+		if resolved := g.nameResolver(value); resolved != "" {
+			return resolved
+		}
+	}
+	return toPascalName(value)
+}
+
+// toPascalName converts an enum value to a PascalCase object key.
+func toPascalName(value string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" {
+		return "Empty"
+	}
+	if unicode.IsDigit([]rune(out)[0]) {
+		return "Value" + out
+	}
+	return out
+}
+
+func uniqueName(base string, used map[string]struct{}) string {
+	if _, ok := used[base]; !ok {
+		used[base] = struct{}{}
+		return base
+	}
+	for i := 2; ; i++ {
+		name := base + "__" + strconv.Itoa(i)
+		if _, ok := used[name]; !ok {
+			used[name] = struct{}{}
+			return name
+		}
+	}
+}
+
+func refName(ref string) string {
+	i := strings.LastIndex(ref, "/")
+	if i < 0 || i == len(ref)-1 {
+		return ref
+	}
+	return ref[i+1:]
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"strings"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/generator/golang"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+type sourceVersion int
+
+const (
+	versionUnknown sourceVersion = iota
+	version30
+	version31
+)
+
+func parseSourceVersion(version string) sourceVersion {
+	version = strings.TrimSpace(version)
+	switch {
+	case version == "":
+		return versionUnknown
+	case strings.HasPrefix(version, "2") || strings.HasPrefix(version, "3.0"):
+		return version30
+	default:
+		return version31
+	}
+}
+
+// detectSourceVersion reads the OpenAPI version from the index the schemas were
+// built with. Schemas created in code carry no index and leave the version
+// unknown, in which case every nullability source is honored.
+func detectSourceVersion(schemas *orderedmap.Map[string, *highbase.SchemaProxy]) sourceVersion {
+	for _, proxy := range schemas.FromOldest() {
+		if proxy == nil || proxy.GoLow() == nil {
+			continue
+		}
+		idx := proxy.GoLow().GetIndex()
+		if idx == nil || idx.GetConfig() == nil || idx.GetConfig().SpecInfo == nil {
+			continue
+		}
+		switch numeric := idx.GetConfig().SpecInfo.VersionNumeric; {
+		case numeric == 0:
+			continue
+		case numeric < 3.1:
+			return version30
+		default:
+			return version31
+		}
+	}
+	return versionUnknown
+}
+
+// nullable reports whether ir renders with a "| null" member. The shared IR
+// merges every source of nullability, so a schema whose only source is the
+// OpenAPI 3.0 nullable keyword is re-checked against the source version.
+func (g *Generator) nullable(ir *golang.SchemaIR, path string) bool {
+	if ir == nil || !ir.Nullable {
+		return false
+	}
+	schema := ir.SourceSchema
+	if g.sourceVersion != version31 || schema == nil || schema.Nullable == nil || !*schema.Nullable {
+		return true
+	}
+	if schemaAllowsNull(schema) {
+		return true
+	}
+	if _, seen := g.nullableKeywords[ir]; !seen {
+		g.nullableKeywords[ir] = struct{}{}
+		g.addDiagnostic(DiagnosticNullableKeyword, path, "nullable is not an OpenAPI 3.1 keyword and was ignored; use a type array that includes \"null\"")
+	}
+	return false
+}
+
+func schemaAllowsNull(schema *highbase.Schema) bool {
+	for _, typ := range schema.Type {
+		if typ == "null" {
+			return true
+		}
+	}
+	if schema.Const != nil && schema.Const.Tag == "!!null" {
+		return true
+	}
+	for _, node := range schema.Enum {
+		if node == nil || node.Tag == "!!null" {
+			return true
+		}
+	}
+	return false
+}
+
+// isNullOnly reports whether ir only admits null, such as a `type: "null"`
+// union variant.
+func isNullOnly(ir *golang.SchemaIR) bool {
+	if ir == nil {
+		return false
+	}
+	if ir.Const != nil && ir.Const.Tag == "!!null" {
+		return true
+	}
+	schema := ir.SourceSchema
+	if schema == nil || len(schema.Type) == 0 {
+		return false
+	}
+	for _, typ := range schema.Type {
+		if typ != "null" {
+			return false
+		}
+	}
+	return true
+}
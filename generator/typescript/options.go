@@ -0,0 +1,116 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import "github.com/pb33f/libopenapi/generator/golang"
+
+// Option configures a Generator.
+type Option func(*Generator)
+
+// NameResolver maps OpenAPI names to TypeScript identifiers. Returning an empty
+// string falls back to the generator's default naming.
+type NameResolver = golang.NameResolver
+
+// ExternalRefResolver maps an external OpenAPI $ref to a TypeScript type name.
+// Returning an empty string falls back to deriving the type name from the
+// reference tail.
+type ExternalRefResolver = golang.ExternalRefResolver
+
+// Diagnostic describes a notable generator decision. It is shared with
+// generator/golang so diagnostics raised while building the IR keep their codes.
+type Diagnostic = golang.Diagnostic
+
+const (
+	DiagnosticIndexSignature  = "indexSignature"
+	DiagnosticNullableKeyword = "nullableKeyword"
+)
+
+// WithGeneratedComment writes a standard generated-code comment.
+func WithGeneratedComment(enabled bool) Option {
+	return func(g *Generator) {
+		g.generatedComment = enabled
+	}
+}
+
+// WithHeaderComment writes a file header comment before the declarations.
+func WithHeaderComment(text string) Option {
+	return func(g *Generator) {
+		g.headerComment = text
+	}
+}
+
+// WithEnumConstants controls whether enum types also generate a const object
+// of their values, declared with the same name as the enum type.
+func WithEnumConstants(enabled bool) Option {
+	return func(g *Generator) {
+		g.enumConstants = enabled
+	}
+}
+
+// WithReadonlyModifiers controls whether readOnly properties render with the
+// readonly modifier.
+func WithReadonlyModifiers(enabled bool) Option {
+	return func(g *Generator) {
+		g.readonlyModifiers = enabled
+	}
+}
+
+// WithOpenAPIVersion sets the OpenAPI version used for nullable handling (for
+// example "3.0.3" or "3.1.0"). By default the version is detected from the
+// index the schemas were built with.
+func WithOpenAPIVersion(version string) Option {
+	return func(g *Generator) {
+		g.openAPIVersion = version
+	}
+}
+
+// WithFormatMapping maps an OpenAPI format to a TypeScript type, for example
+// "date-time" to "Date" or "int64" to "bigint".
+func WithFormatMapping(format, tsType string) Option {
+	return func(g *Generator) {
+		if g.formatMappings == nil {
+			g.formatMappings = make(map[string]string)
+		}
+		g.formatMappings[format] = tsType
+	}
+}
+
+// WithNameResolver sets a broad fallback resolver for generated names.
+func WithNameResolver(resolver NameResolver) Option {
+	return func(g *Generator) {
+		g.nameResolver = resolver
+	}
+}
+
+// WithTypeNameResolver sets a resolver for generated TypeScript type names.
+func WithTypeNameResolver(resolver NameResolver) Option {
+	return func(g *Generator) {
+		g.typeNameResolver = resolver
+	}
+}
+
+// WithEnumValueNameResolver sets a resolver for generated enum constant keys.
+func WithEnumValueNameResolver(resolver NameResolver) Option {
+	return func(g *Generator) {
+		g.enumValueNameResolver = resolver
+	}
+}
+
+// WithNestedTypeNameDelimiter sets the separator inserted between generated
+// parent and child type names for inline schemas. The default is "_"; passing
+// an empty delimiter restores compact names like ParentChild.
+func WithNestedTypeNameDelimiter(delimiter string) Option {
+	return func(g *Generator) {
+		g.nestedTypeNameDelimiter = delimiter
+	}
+}
+
+// WithExternalRefTypeResolver sets a resolver for external OpenAPI $ref values
+// when rendering TypeScript type names. The resolver is not used for local
+// component references.
+func WithExternalRefTypeResolver(resolver ExternalRefResolver) Option {
+	return func(g *Generator) {
+		g.externalRefResolver = resolver
+	}
+}
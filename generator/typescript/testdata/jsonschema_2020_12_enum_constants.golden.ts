@@ -0,0 +1,112 @@
+/** A nullable multi-type value. */
+export type TortureDocument_MultiValue =
+  | string
+  | number
+  | null;
+
+export interface TortureDocument {
+  readonly id: string;
+  kind: "torture";
+  /** A nullable multi-type value. */
+  multi_value?: TortureDocument_MultiValue;
+  nullable_status?: NullableStatus;
+  mixed_enum?: MixedEnum;
+  string_enum?: StringEnum;
+  int_enum?: IntEnum;
+  float_enum?: FloatEnum;
+  bool_enum?: BoolEnum;
+  closed_config?: ClosedConfig;
+  labels?: StringMap;
+  tuple?: TupleProbe;
+  object_rules?: ObjectRules;
+  encoded_payload?: EncodedPayload;
+  payment: PaymentSource;
+  loose_choice?: LooseChoice;
+  dynamic_node?: TreeNode;
+}
+
+export type StringEnum = "draft" | "published";
+
+export const StringEnum = {
+  Draft: "draft",
+  Published: "published",
+} as const;
+
+export type IntEnum = 1 | 2;
+
+export const IntEnum = {
+  Value1: 1,
+  Value2: 2,
+} as const;
+
+export type FloatEnum = 1.5 | 2;
+
+export const FloatEnum = {
+  Value15: 1.5,
+  Value2: 2,
+} as const;
+
+export type BoolEnum = true | false;
+
+export const BoolEnum = {
+  True: true,
+  False: false,
+} as const;
+
+export type NullableStatus = null | "active" | "inactive";
+
+export const NullableStatus = {
+  Active: "active",
+  Inactive: "inactive",
+} as const;
+
+export type MixedEnum = "off" | 1 | true;
+
+export const MixedEnum = {
+  Off: "off",
+  Value1: 1,
+  True: true,
+} as const;
+
+export interface ClosedConfig {
+  enabled: boolean;
+  threshold?: number;
+}
+
+export type StringMap = Record<string, string>;
+
+export type TupleProbe = [string, number];
+
+export interface ObjectRules {
+  name?: string;
+  count?: number;
+}
+
+export type EncodedPayload = string;
+
+export interface TreeNode {
+  name?: string;
+  children?: TreeNode[];
+}
+
+/** A discriminated payment source. */
+export type PaymentSource =
+  | (CardSource & { object: "card" })
+  | (BankSource & { object: "bank_account" });
+
+export interface CardSource {
+  object: "card";
+  number: string;
+  /** writeOnly */
+  cvc: string;
+}
+
+export interface BankSource {
+  object: "bank_account";
+  account_number: string;
+  bank_name?: string;
+}
+
+export type LooseChoice =
+  | string
+  | number;
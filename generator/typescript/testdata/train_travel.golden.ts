@@ -0,0 +1,73 @@
+/** A train station. */
+export interface Station {
+  id: string;
+  name: string;
+  address: string;
+  country_code: string;
+  timezone?: string;
+}
+
+/** A train trip. */
+export interface Trip {
+  id?: string;
+  origin?: string;
+  destination?: string;
+  departure_time?: string;
+  arrival_time?: string;
+  price?: number;
+  bicycles_allowed?: boolean;
+  dogs_allowed?: boolean;
+}
+
+/** A booking for a train trip. */
+export interface Booking {
+  readonly id?: string;
+  trip_id?: string;
+  passenger_name?: string;
+  has_bicycle?: boolean;
+  has_dog?: boolean;
+}
+
+export type BookingPayment_Currency = "bam" | "bgn" | "chf" | "eur" | "gbp" | "nok" | "sek" | "try";
+
+/** A card to take payment from. */
+export interface BookingPayment_Source_Card {
+  object?: "card";
+  name: string;
+  number: string;
+  /** writeOnly */
+  cvc: string;
+  exp_month: number;
+  exp_year: number;
+  address_country: string;
+}
+
+export type BookingPayment_Source_BankAccount_AccountType = "individual" | "company";
+
+/** A bank account to take payment from. */
+export interface BookingPayment_Source_BankAccount {
+  object?: "bank_account";
+  name: string;
+  number: string;
+  account_type: BookingPayment_Source_BankAccount_AccountType;
+  bank_name: string;
+  country: string;
+}
+
+/** The payment source to take the payment from. */
+export type BookingPayment_Source =
+  | BookingPayment_Source_Card
+  | BookingPayment_Source_BankAccount;
+
+/** readOnly */
+export type BookingPayment_Status = "pending" | "succeeded" | "failed";
+
+/** A payment for a booking. */
+export interface BookingPayment {
+  readonly id?: string;
+  amount?: number;
+  currency?: BookingPayment_Currency;
+  /** The payment source to take the payment from. */
+  source?: BookingPayment_Source;
+  readonly status?: BookingPayment_Status;
+}
@@ -0,0 +1,625 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package typescript
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/generator/golang"
+	"go.yaml.in/yaml/v4"
+)
+
+const indentUnit = "  "
+
+func (g *Generator) renderFile(irs []*golang.SchemaIR) *GeneratedFile {
+	g.markNullableInterfaces(irs)
+	types := make([]*GeneratedType, 0, len(irs))
+	for _, ir := range irs {
+		if ir == nil {
+			continue
+		}
+		g.renderDecl(ir)
+		types = append(types, &GeneratedType{Name: ir.Name, Kind: ir.Kind})
+	}
+	var b strings.Builder
+	if g.generatedComment {
+		b.WriteString("// Code generated by libopenapi generator/typescript. DO NOT EDIT.\n")
+	}
+	if g.headerComment != "" {
+		for _, line := range strings.Split(strings.TrimSpace(g.headerComment), "\n") {
+			b.WriteString("// ")
+			b.WriteString(strings.TrimSpace(line))
+			b.WriteByte('\n')
+		}
+	}
+	if (g.generatedComment || g.headerComment != "") && len(g.decls) > 0 {
+		b.WriteByte('\n')
+	}
+	for i, decl := range g.decls {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(decl)
+	}
+	return &GeneratedFile{
+		Source:      []byte(b.String()),
+		Types:       types,
+		Diagnostics: append([]Diagnostic(nil), g.diagnostics...),
+	}
+}
+
+// markNullableInterfaces records nullable components that render as
+// interfaces. An interface declaration cannot carry "| null", so references to
+// these components add it at the use site instead.
+func (g *Generator) markNullableInterfaces(irs []*golang.SchemaIR) {
+	for _, ir := range irs {
+		if rendersAsInterface(ir) && g.nullable(ir, ir.Name) {
+			g.nullableInterfaces[ir.Name] = struct{}{}
+		}
+	}
+}
+
+func (g *Generator) rememberDecl(name string) bool {
+	if name == "" {
+		return false
+	}
+	if _, ok := g.seenDecls[name]; ok {
+		return false
+	}
+	g.seenDecls[name] = struct{}{}
+	return true
+}
+
+func (g *Generator) renderDecl(ir *golang.SchemaIR) {
+	if ir == nil || ir.Name == "" || ir.Kind == golang.KindRef {
+		return
+	}
+	switch {
+	case ir.Kind == golang.KindUnion:
+		g.renderUnionDecl(ir)
+	case ir.Kind == golang.KindEnum:
+		g.renderEnumDecl(ir)
+	case rendersAsInterface(ir):
+		g.renderInterfaceDecl(ir)
+	default:
+		g.renderAliasDecl(ir)
+	}
+}
+
+func (g *Generator) renderChildren(ir *golang.SchemaIR) {
+	if ir.Properties != nil {
+		for _, prop := range ir.Properties.FromOldest() {
+			g.renderNested(prop)
+		}
+	}
+	if ir.PatternProperties != nil {
+		for _, prop := range ir.PatternProperties.FromOldest() {
+			g.renderNested(prop)
+		}
+	}
+	g.renderNested(ir.Items)
+	for _, item := range ir.PrefixItems {
+		g.renderNested(item)
+	}
+	g.renderNested(ir.AdditionalProperties)
+	for _, child := range ir.AllOf {
+		g.renderNested(child)
+	}
+	if ir.Union != nil {
+		for _, variant := range ir.Union.Variants {
+			g.renderNested(variant)
+		}
+	}
+}
+
+func (g *Generator) renderNested(ir *golang.SchemaIR) {
+	if ir == nil || ir.Kind == golang.KindRef {
+		return
+	}
+	if declaredName(ir) != "" {
+		g.renderDecl(ir)
+		return
+	}
+	g.renderChildren(ir)
+}
+
+func (g *Generator) renderInterfaceDecl(ir *golang.SchemaIR) {
+	if !g.rememberDecl(ir.Name) {
+		return
+	}
+	g.renderChildren(ir)
+	var b strings.Builder
+	writeDocComment(&b, "", ir, false)
+	b.WriteString("export interface ")
+	b.WriteString(ir.Name)
+	b.WriteString(" {\n")
+	b.WriteString(g.objectMembers(ir, ir.Name, indentUnit))
+	b.WriteString("}\n")
+	g.decls = append(g.decls, b.String())
+}
+
+func (g *Generator) renderAliasDecl(ir *golang.SchemaIR) {
+	if !g.rememberDecl(ir.Name) {
+		return
+	}
+	g.renderChildren(ir)
+	typ := g.shapeType(ir, ir.Name, "")
+	if g.nullable(ir, ir.Name) {
+		typ = withNull(typ)
+	}
+	var b strings.Builder
+	writeDocComment(&b, "", ir, false)
+	b.WriteString("export type ")
+	b.WriteString(ir.Name)
+	b.WriteString(" = ")
+	b.WriteString(typ)
+	b.WriteString(";\n")
+	g.decls = append(g.decls, b.String())
+}
+
+func (g *Generator) renderEnumDecl(ir *golang.SchemaIR) {
+	if !g.rememberDecl(ir.Name) {
+		return
+	}
+	members := enumMembers(ir.Enum)
+	if g.nullable(ir, ir.Name) && !containsMember(members, "null") && !containsMember(members, "unknown") {
+		members = append(members, "null")
+	}
+	var b strings.Builder
+	writeDocComment(&b, "", ir, false)
+	b.WriteString("export type ")
+	b.WriteString(ir.Name)
+	b.WriteString(" = ")
+	b.WriteString(strings.Join(members, " | "))
+	b.WriteString(";\n")
+	if g.enumConstants && !containsMember(members, "unknown") {
+		g.writeEnumConstants(&b, ir)
+	}
+	g.decls = append(g.decls, b.String())
+}
+
+func (g *Generator) writeEnumConstants(b *strings.Builder, ir *golang.SchemaIR) {
+	var entries strings.Builder
+	used := make(map[string]struct{})
+	for _, node := range ir.Enum {
+		literal, ok := literalType(node)
+		if !ok || literal == "null" {
+			continue
+		}
+		entries.WriteString(indentUnit)
+		entries.WriteString(propertyKey(uniqueName(g.enumValueName(node.Value), used)))
+		entries.WriteString(": ")
+		entries.WriteString(literal)
+		entries.WriteString(",\n")
+	}
+	if entries.Len() == 0 {
+		return
+	}
+	b.WriteString("\nexport const ")
+	b.WriteString(ir.Name)
+	b.WriteString(" = {\n")
+	b.WriteString(entries.String())
+	b.WriteString("} as const;\n")
+}
+
+func (g *Generator) renderUnionDecl(ir *golang.SchemaIR) {
+	if !g.rememberDecl(ir.Name) {
+		return
+	}
+	g.renderChildren(ir)
+	members := g.unionMembers(ir, ir.Name)
+	if g.nullable(ir, ir.Name) && !containsMember(members, "null") {
+		members = append(members, "null")
+	}
+	var b strings.Builder
+	writeDocComment(&b, "", ir, false)
+	b.WriteString("export type ")
+	b.WriteString(ir.Name)
+	b.WriteString(" =")
+	if len(members) == 1 {
+		b.WriteByte(' ')
+		b.WriteString(members[0])
+	} else {
+		for _, member := range members {
+			b.WriteString("\n")
+			b.WriteString(indentUnit)
+			b.WriteString("| ")
+			b.WriteString(member)
+		}
+	}
+	b.WriteString(";\n")
+	g.decls = append(g.decls, b.String())
+}
+
+// unionMembers renders the union variants. Variants selected by an explicit
+// discriminator mapping are intersected with their discriminator literals so
+// the union narrows on the discriminator property.
+func (g *Generator) unionMembers(ir *golang.SchemaIR, path string) []string {
+	if ir.Union == nil {
+		return []string{"unknown"}
+	}
+	discriminated := discriminatorValues(ir.Union)
+	members := make([]string, 0, len(ir.Union.Variants))
+	for i, variant := range ir.Union.Variants {
+		if variant == nil {
+			continue
+		}
+		var member string
+		switch {
+		case isNullOnly(variant):
+			member = "null"
+		default:
+			member = g.tsType(variant, path+".union")
+			values := discriminated[i]
+			property := ir.Union.Discriminator
+			if len(values) > 0 && property != nil && !hasConstProperty(variant, property.PropertyName) {
+				member = "(" + parenthesize(member) + " & { " + propertyKey(property.PropertyName) + ": " + strings.Join(values, " | ") + " })"
+			}
+		}
+		if !containsMember(members, member) {
+			members = append(members, member)
+		}
+	}
+	if len(members) == 0 {
+		return []string{"unknown"}
+	}
+	return members
+}
+
+// discriminatorValues maps union variant indexes to their sorted discriminator
+// literals.
+func discriminatorValues(union *golang.UnionIR) map[int][]string {
+	if union.Strategy != golang.UnionDiscriminator || union.Discriminator == nil {
+		return nil
+	}
+	values := make(map[int][]string)
+	for value, target := range union.Discriminator.Mapping {
+		for i, variant := range union.Variants {
+			if variantMatches(variant, target) {
+				values[i] = append(values[i], quote(value))
+				break
+			}
+		}
+	}
+	for i := range values {
+		sort.Strings(values[i])
+	}
+	return values
+}
+
+func variantMatches(variant *golang.SchemaIR, target string) bool {
+	if variant == nil || target == "" {
+		return false
+	}
+	if target == variant.Name {
+		return true
+	}
+	return variant.Ref != "" && (target == variant.Ref || refName(target) == refName(variant.Ref))
+}
+
+func hasConstProperty(ir *golang.SchemaIR, name string) bool {
+	if ir.Properties == nil {
+		return false
+	}
+	prop, ok := ir.Properties.Get(name)
+	return ok && prop != nil && prop.Const != nil
+}
+
+func (g *Generator) objectMembers(ir *golang.SchemaIR, path, indent string) string {
+	var b strings.Builder
+	if ir.Properties != nil {
+		for propName, prop := range ir.Properties.FromOldest() {
+			readonly := g.readonlyModifiers && prop != nil && prop.ReadOnly
+			writeDocComment(&b, indent, prop, readonly)
+			b.WriteString(indent)
+			if readonly {
+				b.WriteString("readonly ")
+			}
+			b.WriteString(propertyKey(propName))
+			if !isRequired(ir, propName) {
+				b.WriteByte('?')
+			}
+			b.WriteString(": ")
+			b.WriteString(g.tsTypeIndented(prop, path+"."+propName, indent))
+			b.WriteString(";\n")
+		}
+	}
+	if ir.AdditionalProperties != nil {
+		valueType := g.tsTypeIndented(ir.AdditionalProperties, path+".additionalProperties", indent)
+		if ir.Properties != nil && ir.Properties.Len() > 0 && valueType != "unknown" {
+			g.addDiagnostic(DiagnosticIndexSignature, path, "additionalProperties value type widened to unknown because a TypeScript index signature must also admit the declared properties")
+			valueType = "unknown"
+		}
+		b.WriteString(indent)
+		b.WriteString("[key: string]: ")
+		b.WriteString(valueType)
+		b.WriteString(";\n")
+	}
+	return b.String()
+}
+
+// tsType renders the type used to refer to ir, which is its declared name when
+// it has a declaration and its inline shape otherwise.
+func (g *Generator) tsType(ir *golang.SchemaIR, path string) string {
+	return g.tsTypeIndented(ir, path, "")
+}
+
+func (g *Generator) tsTypeIndented(ir *golang.SchemaIR, path, indent string) string {
+	if ir == nil {
+		return "unknown"
+	}
+	if ir.Kind == golang.KindRef {
+		name := ir.Name
+		if name == "" {
+			name = refName(ir.Ref)
+		}
+		_, nullableInterface := g.nullableInterfaces[name]
+		if nullableInterface || g.nullable(ir, path) {
+			return name + " | null"
+		}
+		return name
+	}
+	if name := declaredName(ir); name != "" {
+		// alias declarations carry their own null member, interfaces cannot.
+		if rendersAsInterface(ir) && g.nullable(ir, path) {
+			return name + " | null"
+		}
+		return name
+	}
+	typ := g.shapeType(ir, path, indent)
+	if g.nullable(ir, path) {
+		typ = withNull(typ)
+	}
+	return typ
+}
+
+// withNull adds a null member to typ unless it already admits null.
+func withNull(typ string) string {
+	if typ == "unknown" || typ == "null" || strings.HasSuffix(typ, " | null") {
+		return typ
+	}
+	if strings.Contains(typ, " & ") {
+		typ = "(" + typ + ")"
+	}
+	return typ + " | null"
+}
+
+// shapeType renders the structural type of ir without consulting its declared
+// name or nullability.
+func (g *Generator) shapeType(ir *golang.SchemaIR, path, indent string) string {
+	switch ir.Kind {
+	case golang.KindRef:
+		return g.tsType(ir, path)
+	case golang.KindObject, golang.KindAllOf:
+		return g.objectShape(ir, path, indent)
+	case golang.KindArray:
+		return g.arrayShape(ir, path, indent)
+	case golang.KindMap:
+		return "Record<string, " + g.tsTypeIndented(ir.AdditionalProperties, path+".additionalProperties", indent) + ">"
+	case golang.KindString, golang.KindInteger, golang.KindNumber, golang.KindBoolean:
+		if literal, ok := literalType(ir.Const); ok {
+			return literal
+		}
+		if mapped := g.formatMappings[ir.Format]; ir.Format != "" && mapped != "" {
+			return mapped
+		}
+		switch ir.Kind {
+		case golang.KindString:
+			return "string"
+		case golang.KindBoolean:
+			return "boolean"
+		default:
+			return "number"
+		}
+	case golang.KindEnum:
+		return strings.Join(enumMembers(ir.Enum), " | ")
+	case golang.KindUnion:
+		members := g.unionMembers(ir, path)
+		return strings.Join(members, " | ")
+	default:
+		if isNullOnly(ir) {
+			return "null"
+		}
+		if literal, ok := literalType(ir.Const); ok {
+			return literal
+		}
+		return "unknown"
+	}
+}
+
+func (g *Generator) objectShape(ir *golang.SchemaIR, path, indent string) string {
+	hasProperties := ir.Properties != nil && ir.Properties.Len() > 0
+	if len(ir.AllOf) > 0 {
+		parts := make([]string, 0, len(ir.AllOf)+1)
+		for _, child := range ir.AllOf {
+			parts = append(parts, g.tsTypeIndented(child, path+".allOf", indent))
+		}
+		if hasProperties || ir.AdditionalProperties != nil {
+			parts = append(parts, "{\n"+g.objectMembers(ir, path, indent+indentUnit)+indent+"}")
+		}
+		if len(parts) > 1 {
+			for i := range parts {
+				parts[i] = parenthesize(parts[i])
+			}
+		}
+		return strings.Join(parts, " & ")
+	}
+	switch {
+	case hasProperties:
+		return "{\n" + g.objectMembers(ir, path, indent+indentUnit) + indent + "}"
+	case ir.AdditionalProperties != nil:
+		return "Record<string, " + g.tsTypeIndented(ir.AdditionalProperties, path+".additionalProperties", indent) + ">"
+	case ir.AdditionalAllowed != nil && !*ir.AdditionalAllowed:
+		return "Record<string, never>"
+	default:
+		return "Record<string, unknown>"
+	}
+}
+
+func (g *Generator) arrayShape(ir *golang.SchemaIR, path, indent string) string {
+	closed := ir.SourceSchema != nil && ir.SourceSchema.Items != nil && ir.SourceSchema.Items.IsB() && !ir.SourceSchema.Items.B
+	if len(ir.PrefixItems) > 0 {
+		elements := make([]string, 0, len(ir.PrefixItems)+1)
+		for _, item := range ir.PrefixItems {
+			elements = append(elements, g.tsTypeIndented(item, path+".prefixItems", indent))
+		}
+		if !closed {
+			elements = append(elements, "..."+arrayOf(g.tsTypeIndented(ir.Items, path+".items", indent)))
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+	if closed {
+		return "[]"
+	}
+	return arrayOf(g.tsTypeIndented(ir.Items, path+".items", indent))
+}
+
+func arrayOf(typ string) string {
+	if strings.ContainsAny(typ, " \n") {
+		return "Array<" + typ + ">"
+	}
+	return typ + "[]"
+}
+
+func parenthesize(typ string) string {
+	if strings.Contains(typ, " | ") && !strings.HasPrefix(typ, "{") {
+		return "(" + typ + ")"
+	}
+	return typ
+}
+
+// declaredName returns the name of the top-level declaration that renders ir,
+// or an empty string when ir renders inline.
+func declaredName(ir *golang.SchemaIR) string {
+	if ir == nil || ir.Name == "" {
+		return ""
+	}
+	switch ir.Kind {
+	case golang.KindEnum, golang.KindUnion:
+		return ir.Name
+	case golang.KindObject, golang.KindAllOf:
+		if (ir.Properties != nil && ir.Properties.Len() > 0) || len(ir.AllOf) > 0 {
+			return ir.Name
+		}
+	}
+	return ""
+}
+
+func rendersAsInterface(ir *golang.SchemaIR) bool {
+	return ir != nil &&
+		ir.Name != "" &&
+		(ir.Kind == golang.KindObject || ir.Kind == golang.KindAllOf) &&
+		ir.Properties != nil && ir.Properties.Len() > 0 &&
+		len(ir.AllOf) == 0
+}
+
+func isRequired(ir *golang.SchemaIR, name string) bool {
+	if ir == nil || ir.Required == nil {
+		return false
+	}
+	_, ok := ir.Required[name]
+	return ok
+}
+
+func enumMembers(nodes []*yaml.Node) []string {
+	members := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		literal, ok := literalType(node)
+		if !ok {
+			return []string{"unknown"}
+		}
+		if !containsMember(members, literal) {
+			members = append(members, literal)
+		}
+	}
+	if len(members) == 0 {
+		return []string{"never"}
+	}
+	return members
+}
+
+// literalType renders a scalar YAML value as a TypeScript literal type.
+func literalType(node *yaml.Node) (string, bool) {
+	if node == nil {
+		return "", false
+	}
+	switch node.Tag {
+	case "!!null":
+		return "null", true
+	case "!!str":
+		return quote(node.Value), true
+	case "!!bool":
+		value, err := strconv.ParseBool(strings.ToLower(node.Value))
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatBool(value), true
+	case "!!int", "!!float":
+		if _, err := strconv.ParseFloat(node.Value, 64); err != nil {
+			return "", false
+		}
+		return node.Value, true
+	default:
+		return "", false
+	}
+}
+
+func containsMember(members []string, member string) bool {
+	for _, existing := range members {
+		if existing == member {
+			return true
+		}
+	}
+	return false
+}
+
+func writeDocComment(b *strings.Builder, indent string, ir *golang.SchemaIR, readonlyRendered bool) {
+	if ir == nil {
+		return
+	}
+	var lines []string
+	description := ir.Description
+	if description == "" {
+		description = ir.Title
+	}
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	for _, comment := range ir.Comments {
+		if comment == "Deprecated." || (comment == "readOnly" && readonlyRendered) {
+			continue
+		}
+		lines = append(lines, comment)
+	}
+	if ir.Deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	if len(lines) == 0 {
+		return
+	}
+	for i := range lines {
+		lines[i] = strings.ReplaceAll(lines[i], "*/", "*\\/")
+	}
+	if len(lines) == 1 {
+		b.WriteString(indent)
+		b.WriteString("/** ")
+		b.WriteString(lines[0])
+		b.WriteString(" */\n")
+		return
+	}
+	b.WriteString(indent)
+	b.WriteString("/**\n")
+	for _, line := range lines {
+		b.WriteString(indent)
+		b.WriteString(" * ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteString(indent)
+	b.WriteString(" */\n")
+}
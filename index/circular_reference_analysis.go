@@ -0,0 +1,478 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// SchemaEdgeKind describes how a schema references another schema, and so whether a loop through the reference
+// can terminate.
+type SchemaEdgeKind string
+
+const (
+	// SchemaEdgeRequired is a reference every valid instance must follow: a required property, an allOf member or
+	// a schema that is only a $ref.
+	SchemaEdgeRequired SchemaEdgeKind = "required"
+
+	// SchemaEdgeOptional is a reference through an optional property, additionalProperties, patternProperties or a
+	// conditional keyword, which an instance can leave out.
+	SchemaEdgeOptional SchemaEdgeKind = "optional"
+
+	// SchemaEdgeArray is a reference through array items, which an instance can leave empty.
+	SchemaEdgeArray SchemaEdgeKind = "array"
+
+	// SchemaEdgePolymorphic is a reference through oneOf or anyOf, which an instance can satisfy with another
+	// member.
+	SchemaEdgePolymorphic SchemaEdgeKind = "polymorphic"
+)
+
+// CycleBreakAction is the change suggested to make a required schema reference safe.
+type CycleBreakAction string
+
+const (
+	// CycleBreakMakeOptional suggests removing the property from the required list of the schema declaring it.
+	CycleBreakMakeOptional CycleBreakAction = "make optional"
+
+	// CycleBreakWrapInArray suggests wrapping the reference in an array, for references that are not made through
+	// a required property.
+	CycleBreakWrapInArray CycleBreakAction = "wrap in array"
+)
+
+// SchemaCycleEdge is a reference from one schema to another within a SchemaCycle. References between the same two
+// schemas are folded into one edge, which is as strict as its strictest reference.
+type SchemaCycleEdge struct {
+	// From and To are the full definitions of the schemas, as used for ReferenceGraphNode Ids.
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Kind is the strictest kind of the references folded into the edge.
+	Kind SchemaEdgeKind `json:"kind"`
+
+	// References are the references folded into the edge.
+	References []*Reference `json:"-"`
+}
+
+// CycleBreakSuggestion is a schema reference that, changed as suggested, stops being required.
+type CycleBreakSuggestion struct {
+	// Edge is the required edge to change.
+	Edge *SchemaCycleEdge `json:"edge"`
+
+	// Action is the suggested change.
+	Action CycleBreakAction `json:"action"`
+
+	// Property is the required property holding the reference, for CycleBreakMakeOptional. When the edge is made
+	// through several required properties, it is the first one.
+	Property string `json:"property,omitempty"`
+
+	// Sites are the required references that have to change, there is more than one when a schema references
+	// another in several required places.
+	Sites []*ReferenceSite `json:"sites"`
+}
+
+// SchemaCycle is a strongly connected component of the schema reference graph: a set of schemas that can all reach
+// each other, containing one or more overlapping loops.
+type SchemaCycle struct {
+	// Schemas are the full definitions of the schemas in the component, sorted.
+	Schemas []string `json:"schemas"`
+
+	// Edges are the references between the schemas of the component.
+	Edges []*SchemaCycleEdge `json:"edges"`
+
+	// Label is "infinite" when at least one loop is made only of required references, so no finite instance can
+	// satisfy it, and "safe" otherwise.
+	Label string `json:"label"`
+
+	// SafeKinds are the kinds of the non-required edges that keep the loops safe, sorted. Empty for infinite cycles.
+	SafeKinds []SchemaEdgeKind `json:"safeKinds,omitempty"`
+
+	// Suggestions are the fewest required edges that, changed, make every loop of an infinite cycle safe. The set
+	// is minimal for cycles with up to 16 required edges, and found greedily above that.
+	Suggestions []*CycleBreakSuggestion `json:"suggestions,omitempty"`
+}
+
+// SchemaCycleAnalysis is the result of analysing the schema reference graph for circular references.
+type SchemaCycleAnalysis struct {
+	// Cycles are the strongly connected components of the schema reference graph that contain at least one loop,
+	// ordered by their first schema.
+	Cycles []*SchemaCycle `json:"cycles"`
+
+	// Infinite is the number of infinite cycles.
+	Infinite int `json:"infinite"`
+
+	// Suggestions are the suggestions of every infinite cycle, which together make every loop safe.
+	Suggestions []*CycleBreakSuggestion `json:"suggestions"`
+}
+
+// maxExactCycleBreakEdges is the number of required edges in a component above which suggestions are found
+// greedily rather than by exhaustive search.
+const maxExactCycleBreakEdges = 16
+
+// AnalyzeSchemaCycles computes the strongly connected components of the schema reference graph of this index,
+// labels each one as safe or infinite, and suggests the fewest references to change to make every loop safe.
+func (index *SpecIndex) AnalyzeSchemaCycles() *SchemaCycleAnalysis {
+	indexes := []*SpecIndex{index}
+	return analyzeSchemaCycles(buildReferenceGraph(indexes, nil, nil), indexes)
+}
+
+// AnalyzeSchemaCycles computes the strongly connected components of the schema reference graph across every file
+// in the rolodex, labels each one as safe or infinite, and suggests the fewest references to change to make every
+// loop safe.
+func (r *Rolodex) AnalyzeSchemaCycles() *SchemaCycleAnalysis {
+	indexes := append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...)
+	return analyzeSchemaCycles(buildReferenceGraph(indexes, nil, nil), indexes)
+}
+
+func analyzeSchemaCycles(g *ReferenceGraph, indexes []*SpecIndex) *SchemaCycleAnalysis {
+	analysis := &SchemaCycleAnalysis{Cycles: []*SchemaCycle{}, Suggestions: []*CycleBreakSuggestion{}}
+	byFile := make(map[string]*SpecIndex)
+	for _, idx := range indexes {
+		if idx != nil {
+			byFile[idx.specAbsolutePath] = idx
+		}
+	}
+
+	// fold the graph down to references between schemas, classifying each one.
+	edges := make(map[string]*SchemaCycleEdge)
+	for _, e := range g.Edges {
+		from, to := g.GetNode(e.From), g.GetNode(e.To)
+		if !isSchemaGraphNode(from) || !isSchemaGraphNode(to) {
+			continue
+		}
+		var root *yaml.Node
+		if idx := byFile[from.File]; idx != nil {
+			root = nodeAtPointer(idx.GetRootNode(), from.Pointer)
+		}
+		for _, ref := range e.References {
+			kind, _ := classifySchemaReference(root, from.Pointer, ref.SourcePath)
+			key := e.From + "\x00" + e.To
+			se := edges[key]
+			if se == nil {
+				se = &SchemaCycleEdge{From: e.From, To: e.To, Kind: kind}
+				edges[key] = se
+			}
+			if schemaEdgeStrictness(kind) > schemaEdgeStrictness(se.Kind) {
+				se.Kind = kind
+			}
+			se.References = append(se.References, ref)
+		}
+	}
+	var all []*SchemaCycleEdge
+	for _, k := range sortedKeys(edges) {
+		all = append(all, edges[k])
+	}
+
+	for _, component := range stronglyConnectedSchemas(all, nil) {
+		cycle := &SchemaCycle{Schemas: component}
+		members := make(map[string]bool)
+		for _, s := range component {
+			members[s] = true
+		}
+		var hard []*SchemaCycleEdge
+		safe := make(map[SchemaEdgeKind]bool)
+		for _, e := range all {
+			if !members[e.From] || !members[e.To] {
+				continue
+			}
+			cycle.Edges = append(cycle.Edges, e)
+			if e.Kind == SchemaEdgeRequired {
+				hard = append(hard, e)
+			} else {
+				safe[e.Kind] = true
+			}
+		}
+		if len(stronglyConnectedSchemas(hard, nil)) > 0 {
+			cycle.Label = "infinite"
+			analysis.Infinite++
+			for _, e := range minimalCycleBreak(hard) {
+				cycle.Suggestions = append(cycle.Suggestions, newCycleBreakSuggestion(e, g, byFile))
+			}
+			analysis.Suggestions = append(analysis.Suggestions, cycle.Suggestions...)
+		} else {
+			cycle.Label = "safe"
+			for _, k := range []SchemaEdgeKind{SchemaEdgeArray, SchemaEdgeOptional, SchemaEdgePolymorphic} {
+				if safe[k] {
+					cycle.SafeKinds = append(cycle.SafeKinds, k)
+				}
+			}
+		}
+		analysis.Cycles = append(analysis.Cycles, cycle)
+	}
+	return analysis
+}
+
+// isSchemaGraphNode returns true for schema components, and whole files, which are referenced as schemas.
+func isSchemaGraphNode(n *ReferenceGraphNode) bool {
+	if n == nil {
+		return false
+	}
+	if n.Kind == ReferenceGraphFile {
+		return true
+	}
+	return n.Kind == ReferenceGraphComponent && (strings.HasPrefix(n.Pointer, "/components/schemas/") ||
+		strings.HasPrefix(n.Pointer, "/definitions/") || strings.HasPrefix(n.Pointer, "/$defs/"))
+}
+
+// classifySchemaReference walks from a schema down the source path of one of its references, and returns the kind
+// of the reference along with the innermost required property it passes through.
+func classifySchemaReference(schema *yaml.Node, schemaPointer string, sourcePath []string) (SchemaEdgeKind, string) {
+	depth := 0
+	if schemaPointer != "" {
+		depth = len(strings.Split(strings.TrimPrefix(schemaPointer, "/"), "/"))
+	}
+	if depth > len(sourcePath) {
+		return SchemaEdgeRequired, ""
+	}
+	segments := sourcePath[depth:]
+
+	// allOf members are held in arrays that the source path does not index, so every member is a candidate.
+	candidates := []*yaml.Node{schema}
+	property := ""
+	for i := 0; i < len(segments); i++ {
+		switch segments[i] {
+		case "properties":
+			if i+1 >= len(segments) {
+				return SchemaEdgeRequired, property
+			}
+			name := strings.ReplaceAll(segments[i+1], "~1", "/")
+			required := false
+			var next []*yaml.Node
+			for _, c := range candidates {
+				if c == nil || !utils.IsNodeMap(c) {
+					continue
+				}
+				_, props := utils.FindKeyNodeTop("properties", c.Content)
+				if props == nil {
+					continue
+				}
+				_, prop := utils.FindKeyNodeTop(name, props.Content)
+				if prop == nil {
+					continue
+				}
+				next = append(next, prop)
+				if _, req := utils.FindKeyNodeTop("required", c.Content); req != nil {
+					for _, r := range req.Content {
+						if r.Value == name {
+							required = true
+						}
+					}
+				}
+			}
+			if !required {
+				return SchemaEdgeOptional, ""
+			}
+			property = name
+			candidates = next
+			i++
+		case "allOf":
+			var next []*yaml.Node
+			for _, c := range candidates {
+				if c == nil || !utils.IsNodeMap(c) {
+					continue
+				}
+				if _, of := utils.FindKeyNodeTop("allOf", c.Content); of != nil {
+					next = append(next, of.Content...)
+				}
+			}
+			candidates = next
+		case "items", "prefixItems", "additionalItems", "contains", "unevaluatedItems":
+			return SchemaEdgeArray, ""
+		case "oneOf", "anyOf":
+			return SchemaEdgePolymorphic, ""
+		default:
+			return SchemaEdgeOptional, ""
+		}
+	}
+	return SchemaEdgeRequired, property
+}
+
+func schemaEdgeStrictness(kind SchemaEdgeKind) int {
+	switch kind {
+	case SchemaEdgeRequired:
+		return 3
+	case SchemaEdgeOptional:
+		return 2
+	case SchemaEdgePolymorphic:
+		return 1
+	}
+	return 0
+}
+
+// stronglyConnectedSchemas returns the strongly connected components (Tarjan's algorithm) of the graph formed by
+// edges, leaving out removed edges. Only components containing a loop are returned: more than one schema, or a
+// schema referencing itself. Components and their schemas are sorted.
+func stronglyConnectedSchemas(edges []*SchemaCycleEdge, removed map[*SchemaCycleEdge]bool) [][]string {
+	adjacent := make(map[string][]string)
+	selfLoop := make(map[string]bool)
+	var nodes []string
+	seenNode := make(map[string]bool)
+	for _, e := range edges {
+		if removed[e] {
+			continue
+		}
+		for _, n := range []string{e.From, e.To} {
+			if !seenNode[n] {
+				seenNode[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+		adjacent[e.From] = append(adjacent[e.From], e.To)
+		if e.From == e.To {
+			selfLoop[e.From] = true
+		}
+	}
+
+	indexOf := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+	next := 0
+	var connect func(n string)
+	connect = func(n string) {
+		indexOf[n], lowLink[n] = next, next
+		next++
+		stack = append(stack, n)
+		onStack[n] = true
+		for _, m := range adjacent[n] {
+			if _, visited := indexOf[m]; !visited {
+				connect(m)
+				lowLink[n] = min(lowLink[n], lowLink[m])
+			} else if onStack[m] {
+				lowLink[n] = min(lowLink[n], indexOf[m])
+			}
+		}
+		if lowLink[n] != indexOf[n] {
+			return
+		}
+		var component []string
+		for {
+			m := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[m] = false
+			component = append(component, m)
+			if m == n {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop[n] {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	for _, n := range nodes {
+		if _, visited := indexOf[n]; !visited {
+			connect(n)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool { return components[i][0] < components[j][0] })
+	return components
+}
+
+// minimalCycleBreak returns the fewest edges whose removal leaves the graph without loops (a minimum feedback arc
+// set). Only edges inside a loop are candidates. The search is exhaustive up to maxExactCycleBreakEdges
+// candidates, and greedy above that, removing the edge that leaves the fewest schemas in loops each time.
+func minimalCycleBreak(edges []*SchemaCycleEdge) []*SchemaCycleEdge {
+	var candidates []*SchemaCycleEdge
+	inLoop := make(map[string]int)
+	for i, component := range stronglyConnectedSchemas(edges, nil) {
+		for _, s := range component {
+			inLoop[s] = i + 1
+		}
+	}
+	for _, e := range edges {
+		if inLoop[e.From] != 0 && inLoop[e.From] == inLoop[e.To] {
+			candidates = append(candidates, e)
+		}
+	}
+
+	acyclic := func(removed map[*SchemaCycleEdge]bool) bool {
+		return len(stronglyConnectedSchemas(candidates, removed)) == 0
+	}
+	if len(candidates) <= maxExactCycleBreakEdges {
+		for size := 1; size <= len(candidates); size++ {
+			if found := searchCycleBreak(candidates, 0, size, map[*SchemaCycleEdge]bool{}, acyclic); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+
+	removed := make(map[*SchemaCycleEdge]bool)
+	var picked []*SchemaCycleEdge
+	for !acyclic(removed) {
+		var best *SchemaCycleEdge
+		bestRemaining := -1
+		for _, e := range candidates {
+			if removed[e] {
+				continue
+			}
+			removed[e] = true
+			remaining := 0
+			for _, c := range stronglyConnectedSchemas(candidates, removed) {
+				remaining += len(c)
+			}
+			delete(removed, e)
+			if best == nil || remaining < bestRemaining {
+				best, bestRemaining = e, remaining
+			}
+		}
+		removed[best] = true
+		picked = append(picked, best)
+	}
+	return picked
+}
+
+// searchCycleBreak tries every combination of size edges, from start onwards, returning the first that breaks
+// every loop.
+func searchCycleBreak(candidates []*SchemaCycleEdge, start, size int, removed map[*SchemaCycleEdge]bool,
+	acyclic func(map[*SchemaCycleEdge]bool) bool,
+) []*SchemaCycleEdge {
+	if size == 0 {
+		if !acyclic(removed) {
+			return nil
+		}
+		var found []*SchemaCycleEdge
+		for _, e := range candidates {
+			if removed[e] {
+				found = append(found, e)
+			}
+		}
+		return found
+	}
+	for i := start; i <= len(candidates)-size; i++ {
+		removed[candidates[i]] = true
+		if found := searchCycleBreak(candidates, i+1, size-1, removed, acyclic); found != nil {
+			return found
+		}
+		delete(removed, candidates[i])
+	}
+	return nil
+}
+
+func newCycleBreakSuggestion(e *SchemaCycleEdge, g *ReferenceGraph, byFile map[string]*SpecIndex) *CycleBreakSuggestion {
+	suggestion := &CycleBreakSuggestion{Edge: e, Action: CycleBreakWrapInArray, Sites: []*ReferenceSite{}}
+	from := g.GetNode(e.From)
+	var root *yaml.Node
+	if idx := byFile[from.File]; idx != nil {
+		root = nodeAtPointer(idx.GetRootNode(), from.Pointer)
+	}
+	graphEdge := &ReferenceGraphEdge{From: e.From, To: e.To}
+	for _, ref := range e.References {
+		kind, property := classifySchemaReference(root, from.Pointer, ref.SourcePath)
+		if kind != SchemaEdgeRequired {
+			continue
+		}
+		if property != "" && suggestion.Property == "" {
+			suggestion.Action = CycleBreakMakeOptional
+			suggestion.Property = property
+		}
+		suggestion.Sites = append(suggestion.Sites, newReferenceSite(ref, graphEdge, 1))
+	}
+	return suggestion
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+const schemaCycleSpec = `openapi: 3.1.0
+info:
+  title: cycles
+  version: 1.0.0
+components:
+  schemas:
+    Tree:
+      type: object
+      required: [children]
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tree'
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      required: [pet]
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Shape:
+      oneOf:
+        - $ref: '#/components/schemas/Circle'
+    Circle:
+      type: object
+      required: [parent]
+      properties:
+        parent:
+          $ref: '#/components/schemas/Shape'
+    X:
+      type: object
+      required: [y]
+      properties:
+        y:
+          $ref: '#/components/schemas/Y'
+    Y:
+      type: object
+      required: [x, z]
+      properties:
+        x:
+          $ref: '#/components/schemas/X'
+        z:
+          $ref: '#/components/schemas/Z'
+    Z:
+      type: object
+      required: [wrapper]
+      properties:
+        wrapper:
+          type: object
+          required: [x]
+          properties:
+            x:
+              $ref: '#/components/schemas/X'
+    Base:
+      allOf:
+        - $ref: '#/components/schemas/Derived'
+    Derived:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+`
+
+func TestSpecIndex_AnalyzeSchemaCycles(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(schemaCycleSpec), &root))
+	idx := NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+
+	analysis := idx.AnalyzeSchemaCycles()
+	assert.Equal(t, 2, analysis.Infinite)
+
+	var summary []string
+	for _, c := range analysis.Cycles {
+		summary = append(summary, fmt.Sprintf("%v %s %v", c.Schemas, c.Label, c.SafeKinds))
+	}
+	s := "#/components/schemas/"
+	assert.Equal(t, []string{
+		fmt.Sprintf("[%sBase %sDerived] infinite []", s, s),
+		fmt.Sprintf("[%sCircle %sShape] safe [polymorphic]", s, s),
+		fmt.Sprintf("[%sOwner %sPet] safe [optional]", s, s),
+		fmt.Sprintf("[%sTree] safe [array]", s),
+		fmt.Sprintf("[%sX %sY %sZ] infinite []", s, s, s),
+	}, summary)
+
+	allOf := analysis.Cycles[0].Suggestions
+	assert.Len(t, allOf, 1)
+	assert.Equal(t, CycleBreakWrapInArray, allOf[0].Action)
+	assert.Empty(t, allOf[0].Property)
+	assert.Len(t, allOf[0].Sites, 1)
+
+	// both loops (X -> Y -> X, X -> Y -> Z -> X) share a single edge.
+	xyz := analysis.Cycles[4].Suggestions
+	assert.Len(t, xyz, 1)
+	assert.Equal(t, s+"X", xyz[0].Edge.From)
+	assert.Equal(t, s+"Y", xyz[0].Edge.To)
+	assert.Equal(t, CycleBreakMakeOptional, xyz[0].Action)
+	assert.Equal(t, "y", xyz[0].Property)
+	assert.Equal(t, "/components/schemas/X/properties/y", xyz[0].Sites[0].Pointer)
+
+	assert.Len(t, analysis.Suggestions, 2)
+
+	b, err := json.Marshal(analysis)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"label":"infinite"`)
+}
+
+func TestClassifySchemaReference(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(`type: object
+required: [a]
+allOf:
+  - required: [b]
+    properties:
+      b:
+        $ref: 'b.yaml'
+properties:
+  a:
+    additionalProperties:
+      $ref: 'a.yaml'
+`), &root))
+
+	kind, property := classifySchemaReference(root.Content[0], "", []string{"allOf", "properties", "b"})
+	assert.Equal(t, SchemaEdgeRequired, kind)
+	assert.Equal(t, "b", property)
+
+	kind, _ = classifySchemaReference(root.Content[0], "", []string{"properties", "a", "additionalProperties"})
+	assert.Equal(t, SchemaEdgeOptional, kind)
+
+	kind, _ = classifySchemaReference(root.Content[0], "", []string{"properties", "c"})
+	assert.Equal(t, SchemaEdgeOptional, kind)
+
+	kind, _ = classifySchemaReference(root.Content[0], "", []string{"anyOf"})
+	assert.Equal(t, SchemaEdgePolymorphic, kind)
+
+	kind, _ = classifySchemaReference(root.Content[0], "", nil)
+	assert.Equal(t, SchemaEdgeRequired, kind)
+}
+
+func TestMinimalCycleBreak_Greedy(t *testing.T) {
+	// a ring of required references, too many for an exhaustive search, is broken by removing any single edge.
+	var edges []*SchemaCycleEdge
+	for i := 0; i <= maxExactCycleBreakEdges; i++ {
+		edges = append(edges, &SchemaCycleEdge{
+			From: fmt.Sprintf("s%02d", i),
+			To:   fmt.Sprintf("s%02d", (i+1)%(maxExactCycleBreakEdges+1)),
+			Kind: SchemaEdgeRequired,
+		})
+	}
+	assert.Len(t, minimalCycleBreak(edges), 1)
+	assert.Nil(t, minimalCycleBreak(edges[:3]))
+}
@@ -0,0 +1,633 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// applyFileEdits applies the edits of every file to the content they were planned against, and returns the changed
+// files.
+func applyFileEdits(files []*RenameFileEdits) ([]RolodexFileChange, error) {
+	changes := make([]RolodexFileChange, 0, len(files))
+	for _, f := range files {
+		content, err := f.Apply(f.content)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, RolodexFileChange{Path: f.File, Content: content})
+	}
+	return changes, nil
+}
+
+// refactorer collects the edits of a refactoring, reading the content of each file once.
+type refactorer struct {
+	rolodex   *Rolodex
+	indexes   []*SpecIndex
+	documents map[string]*refactorDocument
+	edits     map[string][]*RenameEdit
+}
+
+func newRefactorer(r *Rolodex, indexes []*SpecIndex) *refactorer {
+	c := &refactorer{
+		rolodex:   r,
+		documents: make(map[string]*refactorDocument),
+		edits:     make(map[string][]*RenameEdit),
+	}
+	// a file is indexed more than once when it is the root document and also referenced, the first index wins.
+	seen := make(map[string]bool)
+	for _, idx := range indexes {
+		if idx != nil && !seen[idx.specAbsolutePath] {
+			seen[idx.specAbsolutePath] = true
+			c.indexes = append(c.indexes, idx)
+		}
+	}
+	return c
+}
+
+// document returns the document of an index.
+func (c *refactorer) document(idx *SpecIndex) (*refactorDocument, error) {
+	file := idx.specAbsolutePath
+	if d := c.documents[file]; d != nil {
+		return d, nil
+	}
+	content, err := refactorContent(c.rolodex, idx)
+	if err != nil {
+		return nil, err
+	}
+	d := newRefactorDocument(file, content, idx.GetRootNode())
+	d.index = idx
+	c.documents[file] = d
+	return d, nil
+}
+
+// indexFor returns the index of a file, or nil when the file is not indexed.
+func (c *refactorer) indexFor(file string) *SpecIndex {
+	for _, idx := range c.indexes {
+		if idx.specAbsolutePath == file {
+			return idx
+		}
+	}
+	return nil
+}
+
+// edit records a replacement of the bytes between start and end of a document.
+func (c *refactorer) edit(d *refactorDocument, start, end int, replacement string, kind RenameEditKind) {
+	old := string(d.content[start:end])
+	if old == replacement {
+		return
+	}
+	lineStart := bytes.LastIndexByte(d.content[:start], '\n') + 1
+	c.edits[d.file] = append(c.edits[d.file], &RenameEdit{
+		Start:  start,
+		End:    end,
+		Line:   1 + bytes.Count(d.content[:start], []byte{'\n'}),
+		Column: utf8.RuneCount(d.content[lineStart:start]) + 1,
+		Old:    old,
+		New:    replacement,
+		Kind:   kind,
+	})
+}
+
+// replaceText records an edit replacing old, found at offset bytes into the value of a scalar node, with
+// replacement.
+func (c *refactorer) replaceText(d *refactorDocument, node *yaml.Node, offset int, old, replacement string,
+	kind RenameEditKind,
+) error {
+	start := d.start(node)
+	if start >= 0 && start < len(d.content) && (d.content[start] == '\'' || d.content[start] == '"') {
+		start++
+	}
+	if start >= 0 {
+		start += offset
+	}
+	end := start + len(old)
+	if start < 0 || end > len(d.content) || string(d.content[start:end]) != old {
+		return fmt.Errorf("unable to refactor '%s' in '%s' at line %d, column %d, the content does not match "+
+			"the index", old, d.file, node.Line, node.Column)
+	}
+	c.edit(d, start, end, replacement, kind)
+	return nil
+}
+
+// replaceScalar records an edit replacing the value of a scalar node, keeping its quoting style when it has one.
+func (c *refactorer) replaceScalar(d *refactorDocument, node *yaml.Node, value string, kind RenameEditKind) error {
+	if value == node.Value {
+		return nil
+	}
+	start, end := d.start(node), d.end(node)
+	if start < 0 || end < start {
+		return fmt.Errorf("unable to refactor '%s' in '%s' at line %d, column %d, the content does not match "+
+			"the index", node.Value, d.file, node.Line, node.Column)
+	}
+	c.edit(d, start, end, quoteScalar(node, value), kind)
+	return nil
+}
+
+// files sorts the collected edits, checks none of them overlap, and returns the edits of every file with a preview.
+func (c *refactorer) files() ([]*RenameFileEdits, string, error) {
+	files := []*RenameFileEdits{}
+	var preview strings.Builder
+	for _, file := range sortedKeys(c.edits) {
+		d := c.documents[file]
+		edits := c.edits[file]
+		sort.SliceStable(edits, func(i, j int) bool {
+			if edits[i].Start != edits[j].Start {
+				return edits[i].Start < edits[j].Start
+			}
+			return edits[i].End < edits[j].End
+		})
+		var kept []*RenameEdit
+		for _, e := range edits {
+			if n := len(kept); n > 0 && *kept[n-1] == *e {
+				continue
+			}
+			if n := len(kept); n > 0 && e.Start < kept[n-1].End {
+				return nil, "", fmt.Errorf("unable to refactor '%s', edits at line %d and line %d overlap",
+					file, kept[n-1].Line, e.Line)
+			}
+			kept = append(kept, e)
+		}
+		f := &RenameFileEdits{File: file, Created: d.created, Edits: kept, content: d.content}
+		files = append(files, f)
+		writeRefactorPreview(&preview, refactorPreviewPath(c.rolodex, c.indexes, file), f)
+	}
+	return files, preview.String(), nil
+}
+
+// refactorComponent is a component found by findComponent.
+type refactorComponent struct {
+	index         *SpecIndex
+	file          string
+	pointer       string
+	parentPointer string
+	name          string
+	section       *yaml.Node
+	key           *yaml.Node
+	value         *yaml.Node
+}
+
+// findComponent returns the component (or any mapping entry) at a definition, or nil if it does not exist. The
+// definition is a full definition, a definition relative to the BasePath of the index configuration, or a
+// definition, looked up in the first index, then in every other index in sorted order.
+func findComponent(r *Rolodex, indexes []*SpecIndex, definition string) *refactorComponent {
+	file, pointer := splitFullDefinition(definition)
+	if pointer == "" || pointer == "/" || !strings.HasPrefix(pointer, "/") {
+		return nil
+	}
+	parentPointer := pointer[:strings.LastIndexByte(pointer, '/')]
+	name := decodeJSONPointerToken(pointer[len(parentPointer)+1:])
+	for _, idx := range refactorCandidates(r, indexes, file) {
+		section := nodeAtPointer(idx.GetRootNode(), parentPointer)
+		if key, value := mappingEntry(section, name); key != nil {
+			return &refactorComponent{
+				index: idx, file: idx.specAbsolutePath, pointer: pointer, parentPointer: parentPointer, name: name,
+				section: section, key: key, value: value,
+			}
+		}
+	}
+	return nil
+}
+
+// refactorCandidates returns the indexes the file of a definition may be: the index of the file, or every index
+// when the file is empty, the first index first and the others in sorted order.
+func refactorCandidates(r *Rolodex, indexes []*SpecIndex, file string) []*SpecIndex {
+	var candidates []*SpecIndex
+	for _, idx := range indexes {
+		if file == "" || idx.specAbsolutePath == file || (r != nil && idx.specAbsolutePath == r.absoluteFilePath(file)) {
+			candidates = append(candidates, idx)
+		}
+	}
+	if len(candidates) > 1 {
+		rest := candidates[1:]
+		sort.Slice(rest, func(i, j int) bool { return rest[i].specAbsolutePath < rest[j].specAbsolutePath })
+	}
+	return candidates
+}
+
+// refactorDocument is the content of a file, used to find the text of its nodes.
+type refactorDocument struct {
+	file    string
+	content []byte
+	root    *yaml.Node
+	index   *SpecIndex
+	created bool
+	flow    bool
+	offsets []int
+}
+
+func newRefactorDocument(file string, content []byte, root *yaml.Node) *refactorDocument {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	d := &refactorDocument{file: file, content: content, root: root}
+	d.flow = root != nil && root.Kind == yaml.MappingNode && root.Style&yaml.FlowStyle != 0
+	return d
+}
+
+// start returns the byte offset a node starts at, the quote of a quoted scalar, or -1.
+func (d *refactorDocument) start(node *yaml.Node) int {
+	return lineColumnOffset(d.content, node.Line, node.Column)
+}
+
+// end returns the byte offset just after the text of a node. The text of a block collection ends with the last
+// line holding its content, excluding any trailing blank or comment lines.
+func (d *refactorDocument) end(node *yaml.Node) int {
+	start := d.start(node)
+	if start < 0 {
+		return -1
+	}
+	switch {
+	case (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && node.Style&yaml.FlowStyle != 0:
+		return scanFlow(d.content, start)
+	case node.Kind == yaml.ScalarNode && node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0:
+		return scanQuoted(d.content, start)
+	case node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 &&
+		bytes.HasPrefix(d.content[start:], []byte(node.Value)):
+		return start + len(node.Value)
+	}
+
+	// a block collection (or block scalar) runs up to the next node of the document. Lines of a block scalar
+	// starting with # are content, not comments.
+	lastNode := lastDescendant(node)
+	last := d.start(lastNode)
+	blockScalar := lastNode.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0
+	limit := len(d.content)
+	if next := d.nextOffset(last); next >= 0 {
+		limit = bytes.LastIndexByte(d.content[:next], '\n') + 1
+	}
+	end := limit
+	for end > last {
+		lineStart := bytes.LastIndexByte(d.content[:max(end-1, 0)], '\n') + 1
+		if lineStart <= last {
+			break
+		}
+		line := strings.TrimSpace(string(d.content[lineStart:end]))
+		if line != "" && (blockScalar || !strings.HasPrefix(line, "#")) {
+			break
+		}
+		end = lineStart
+	}
+	for end > last && (d.content[end-1] == '\n' || d.content[end-1] == '\r' || d.content[end-1] == ' ') {
+		end--
+	}
+	return end
+}
+
+// nextOffset returns the offset of the first node starting after an offset, or -1.
+func (d *refactorDocument) nextOffset(offset int) int {
+	if d.offsets == nil {
+		var walk func(n *yaml.Node)
+		walk = func(n *yaml.Node) {
+			if o := d.start(n); o >= 0 {
+				d.offsets = append(d.offsets, o)
+			}
+			for _, child := range n.Content {
+				walk(child)
+			}
+		}
+		if d.root != nil {
+			walk(d.root)
+		}
+		sort.Ints(d.offsets)
+	}
+	i := sort.SearchInts(d.offsets, offset+1)
+	if i < len(d.offsets) {
+		return d.offsets[i]
+	}
+	return -1
+}
+
+// indentation returns the number of spaces indenting the line holding an offset.
+func (d *refactorDocument) indentation(offset int) int {
+	lineStart := bytes.LastIndexByte(d.content[:offset], '\n') + 1
+	n := 0
+	for lineStart+n < len(d.content) && d.content[lineStart+n] == ' ' {
+		n++
+	}
+	return n
+}
+
+// base returns the indentation the lines of a node are relative to: the column of a block collection, or the
+// indentation of the line it starts on.
+func (d *refactorDocument) base(node *yaml.Node) int {
+	if (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && node.Style&yaml.FlowStyle == 0 {
+		return node.Column - 1
+	}
+	return d.indentation(d.start(node))
+}
+
+// step returns the indentation step of a block document, defaulting to two spaces.
+func (d *refactorDocument) step() int {
+	if d.root != nil && d.root.Kind == yaml.MappingNode && d.root.Style&yaml.FlowStyle == 0 {
+		for i := 0; i+1 < len(d.root.Content); i += 2 {
+			key, value := d.root.Content[i], d.root.Content[i+1]
+			if value.Kind == yaml.MappingNode && value.Style&yaml.FlowStyle == 0 && value.Column > key.Column {
+				return value.Column - key.Column
+			}
+		}
+	}
+	return 2
+}
+
+// jsonIndent returns the indentation of a flow document, defaulting to two spaces.
+func (d *refactorDocument) jsonIndent() string {
+	if d.root != nil && len(d.root.Content) > 0 && d.root.Content[0].Line > d.root.Line {
+		if n := d.indentation(d.start(d.root.Content[0])); n > 0 {
+			return strings.Repeat(" ", n)
+		}
+	}
+	return "  "
+}
+
+// lastDescendant returns the last node, in document order, of a node and its children.
+func lastDescendant(node *yaml.Node) *yaml.Node {
+	for len(node.Content) > 0 {
+		node = node.Content[len(node.Content)-1]
+	}
+	return node
+}
+
+// scanFlow returns the offset just after the flow collection starting at an offset.
+func scanFlow(content []byte, start int) int {
+	depth := 0
+	for i := start; i < len(content); i++ {
+		switch content[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '\'', '"':
+			i = scanQuoted(content, i) - 1
+		case '#':
+			if i > start && (content[i-1] == ' ' || content[i-1] == '\t') {
+				for i < len(content) && content[i] != '\n' {
+					i++
+				}
+			}
+		}
+	}
+	return len(content)
+}
+
+// scanQuoted returns the offset just after the quoted scalar starting at an offset.
+func scanQuoted(content []byte, start int) int {
+	quote := content[start]
+	for i := start + 1; i < len(content); i++ {
+		switch {
+		case quote == '"' && content[i] == '\\':
+			i++
+		case content[i] == quote && quote == '\'' && i+1 < len(content) && content[i+1] == '\'':
+			i++
+		case content[i] == quote:
+			return i + 1
+		}
+	}
+	return len(content)
+}
+
+// quoteScalar renders a string value in the quoting style of a scalar node. A plain scalar is single quoted when
+// the value would not survive as a plain scalar.
+func quoteScalar(node *yaml.Node, value string) string {
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		_ = encoder.Encode(value)
+		return strings.TrimSuffix(buf.String(), "\n")
+	case node.Style&yaml.SingleQuotedStyle != 0 || value == "" || strings.ContainsAny(value[:1], "#&*!|>'\"%@`{[-?:,") ||
+		strings.Contains(value, ": ") || strings.Contains(value, " #"):
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	return value
+}
+
+// lineColumnOffset returns the byte offset of a 1-based line and column (counted in characters), or -1.
+func lineColumnOffset(content []byte, line, column int) int {
+	offset := 0
+	for l := 1; l < line; l++ {
+		next := bytes.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return -1
+		}
+		offset += next + 1
+	}
+	for c := 1; c < column; c++ {
+		if offset >= len(content) || content[offset] == '\n' {
+			return -1
+		}
+		_, size := utf8.DecodeRune(content[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// refactorContent returns the content of the file held by an index. The root document is read from the SpecInfo
+// of the index configuration, other files from the rolodex, falling back to reading the file.
+func refactorContent(r *Rolodex, idx *SpecIndex) ([]byte, error) {
+	file := idx.specAbsolutePath
+	if (r == nil || idx == r.GetRootIndex()) && idx.config != nil && idx.config.SpecInfo != nil &&
+		idx.config.SpecInfo.SpecBytes != nil {
+		return *idx.config.SpecInfo.SpecBytes, nil
+	}
+	if r != nil {
+		for _, fileSystems := range []map[string]fs.FS{r.localFS, r.remoteFS} {
+			for _, fileSystem := range fileSystems {
+				rfs, ok := fileSystem.(RolodexFS)
+				if !ok {
+					continue
+				}
+				for key, f := range rfs.GetFiles() {
+					if key == file || f.GetFullPath() == file {
+						return []byte(f.GetContent()), nil
+					}
+				}
+			}
+		}
+	}
+	if !strings.HasPrefix(file, "http") {
+		if content, err := os.ReadFile(file); err == nil {
+			return content, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to refactor, the content of '%s' is not available", file)
+}
+
+// refactorPreviewPath returns the path of a file relative to the directory of the root document, when it is
+// inside. A document without a path is named after the theoretical root.
+func refactorPreviewPath(r *Rolodex, indexes []*SpecIndex, file string) string {
+	if file == "" {
+		return theoreticalRoot
+	}
+	base := ""
+	if r != nil && r.indexConfig != nil && r.indexConfig.BasePath != "" {
+		base = r.indexConfig.BasePath
+	} else if len(indexes) > 0 && !strings.HasPrefix(indexes[0].specAbsolutePath, "http") {
+		base = filepath.Dir(indexes[0].specAbsolutePath)
+	}
+	if base == "" || strings.HasPrefix(file, "http") {
+		return file
+	}
+	if rel, err := filepath.Rel(base, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return file
+}
+
+// writeRefactorPreview writes the edits of a file as a unified diff. Edits sharing lines are written as one hunk.
+func writeRefactorPreview(preview *strings.Builder, name string, f *RenameFileEdits) {
+	if f.Created {
+		fmt.Fprintf(preview, "--- /dev/null\n+++ b/%s\n", name)
+	} else {
+		fmt.Fprintf(preview, "--- a/%s\n+++ b/%s\n", name, name)
+	}
+	content := f.content
+	lineEnd := func(offset int) int {
+		if next := bytes.IndexByte(content[offset:], '\n'); next >= 0 {
+			return offset + next
+		}
+		return len(content)
+	}
+	delta := 0
+	for i := 0; i < len(f.Edits); {
+		start := bytes.LastIndexByte(content[:f.Edits[i].Start], '\n') + 1
+		end := lineEnd(f.Edits[i].End)
+		j := i + 1
+		for ; j < len(f.Edits) && f.Edits[j].Start <= end; j++ {
+			end = max(end, lineEnd(f.Edits[j].End))
+		}
+		var renamed strings.Builder
+		last := start
+		for _, e := range f.Edits[i:j] {
+			renamed.Write(content[last:e.Start])
+			renamed.WriteString(e.New)
+			last = e.End
+		}
+		renamed.Write(content[last:end])
+
+		line := 1 + bytes.Count(content[:start], []byte{'\n'})
+		old, changed := previewLines(string(content[start:end]), f.Created), previewLines(renamed.String(), false)
+		for len(old) > 0 && len(changed) > 0 && old[0] == changed[0] {
+			old, changed, line = old[1:], changed[1:], line+1
+		}
+		for len(old) > 0 && len(changed) > 0 && old[len(old)-1] == changed[len(changed)-1] {
+			old, changed = old[:len(old)-1], changed[:len(changed)-1]
+		}
+		fmt.Fprintf(preview, "@@ -%s +%s @@\n", previewRange(line, len(old)), previewRange(line+delta, len(changed)))
+		for _, l := range old {
+			preview.WriteString("-" + l + "\n")
+		}
+		for _, l := range changed {
+			preview.WriteString("+" + l + "\n")
+		}
+		delta += len(changed) - len(old)
+		i = j
+	}
+}
+
+func previewLines(text string, created bool) []string {
+	if created && text == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	return lines
+}
+
+func previewRange(line, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", line-1)
+	case 1:
+		return fmt.Sprintf("%d", line)
+	}
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+// discriminatorMappings returns the value nodes of every discriminator mapping in a document.
+func discriminatorMappings(root *yaml.Node) []*yaml.Node {
+	var values []*yaml.Node
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n == nil {
+			return
+		}
+		if utils.IsNodeMap(n) {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value != "discriminator" || !utils.IsNodeMap(n.Content[i+1]) {
+					continue
+				}
+				mapping := nodeAtPointer(n.Content[i+1], "/mapping")
+				for j := 0; mapping != nil && utils.IsNodeMap(mapping) && j+1 < len(mapping.Content); j += 2 {
+					if utils.IsNodeStringValue(mapping.Content[j+1]) {
+						values = append(values, mapping.Content[j+1])
+					}
+				}
+			}
+		}
+		for _, child := range n.Content {
+			walk(child)
+		}
+	}
+	walk(root)
+	return values
+}
+
+// securityRequirementKeys returns the keys naming a security scheme in the security requirements of a document,
+// at the top level and on every operation of its paths and webhooks.
+func securityRequirementKeys(root *yaml.Node, name string) []*yaml.Node {
+	requirements := []*yaml.Node{nodeAtPointer(root, "/security")}
+	for _, section := range []string{"/paths", "/webhooks"} {
+		items := nodeAtPointer(root, section)
+		for i := 0; items != nil && utils.IsNodeMap(items) && i+1 < len(items.Content); i += 2 {
+			item := items.Content[i+1]
+			for j := 0; utils.IsNodeMap(item) && j+1 < len(item.Content); j += 2 {
+				if utils.IsHttpVerb(strings.ToLower(item.Content[j].Value)) {
+					requirements = append(requirements, nodeAtPointer(item.Content[j+1], "/security"))
+				}
+			}
+		}
+	}
+	var keys []*yaml.Node
+	for _, security := range requirements {
+		if security == nil || !utils.IsNodeArray(security) {
+			continue
+		}
+		for _, requirement := range security.Content {
+			for i := 0; utils.IsNodeMap(requirement) && i+1 < len(requirement.Content); i += 2 {
+				if requirement.Content[i].Value == name {
+					keys = append(keys, requirement.Content[i])
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// mappingEntry returns the key and value of an entry of a mapping node, or nils.
+func mappingEntry(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; mapping != nil && utils.IsNodeMap(mapping) && i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
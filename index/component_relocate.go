@@ -0,0 +1,819 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/json"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// ComponentRelocation is a planned extract, inline or move of a component: the edits to make to every file so the
+// component lives at its new location, and everything pointing at it, or into it, follows.
+type ComponentRelocation struct {
+	// From is the full definition of the schema or component being relocated.
+	From string `json:"from"`
+
+	// To is the full definition it lives at once relocated.
+	To string `json:"to"`
+
+	// Files are the files to change, ordered by path.
+	Files []*RenameFileEdits `json:"files"`
+
+	// Preview is a unified diff of every changed line.
+	Preview string `json:"preview"`
+}
+
+// Changes applies the edits to the content the relocation was planned against, and returns the changed files, ready
+// to be written out or handed to Rolodex.UpdateFiles.
+func (c *ComponentRelocation) Changes() ([]RolodexFileChange, error) {
+	return applyFileEdits(c.Files)
+}
+
+// ExtractComponent plans the extraction of an inline schema held by this index into a named component of the same
+// document (under components/schemas, or definitions for a Swagger document). The schema is replaced by a $ref to
+// the new component, and every $ref pointing into the schema is rewritten to point into the component.
+//
+// The definition is a JSON pointer to the schema (#/paths/~1pets/get/responses/200/content/application~1json/schema),
+// or a full definition. An error is returned if the schema does not exist, is already a $ref or a component, the name
+// is not a valid component name, or a component with the name already exists.
+func (index *SpecIndex) ExtractComponent(definition, name string) (*ComponentRelocation, error) {
+	return planComponentExtract(nil, []*SpecIndex{index}, definition, name)
+}
+
+// ExtractComponent plans the extraction of an inline schema held by any file in the rolodex into a named component
+// of the same file, rewriting every $ref pointing into the schema from any file. The definition is a full definition,
+// a definition relative to the BasePath of the index configuration, or a definition, looked up in the root document
+// first, then in every other file.
+func (r *Rolodex) ExtractComponent(definition, name string) (*ComponentRelocation, error) {
+	return planComponentExtract(r, append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...), definition, name)
+}
+
+// InlineComponent plans the inlining of a component held by this index into the single $ref using it. The $ref is
+// replaced with the component, the component is removed, and every $ref pointing into the component is rewritten to
+// point into the use site.
+//
+// An error is returned if the component does not exist, is not used exactly once, is used by a $ref with sibling
+// properties, references itself, or is named by a discriminator mapping.
+func (index *SpecIndex) InlineComponent(definition string) (*ComponentRelocation, error) {
+	return planComponentInline(nil, []*SpecIndex{index}, definition)
+}
+
+// InlineComponent plans the inlining of a component held by any file in the rolodex into the single $ref using it,
+// which may be in another file. $refs held by the component are rewritten to be relative to the file of the use site.
+func (r *Rolodex) InlineComponent(definition string) (*ComponentRelocation, error) {
+	return planComponentInline(r, append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...), definition)
+}
+
+// MoveComponent plans the move of a component held by any file in the rolodex into another file, rewriting every
+// $ref and discriminator mapping pointing at it, or into it, from any file, and every $ref the component holds, with
+// paths relative to the file holding them.
+//
+// The destination is a file, absolute or relative to the BasePath of the index configuration, optionally followed by
+// the JSON pointer of the component in that file (shared.yaml#/components/schemas/Pet). Without a pointer, the
+// component keeps its pointer. The destination file is created when it does not exist, as JSON when it has a .json
+// extension, otherwise as YAML.
+//
+// An error is returned if the component does not exist, is a security scheme, the destination is a remote file,
+// already holds a component at the pointer, or is the section the component is already in.
+func (r *Rolodex) MoveComponent(definition, destination string) (*ComponentRelocation, error) {
+	c := newRefactorer(r, append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...))
+	component := findComponent(r, c.indexes, definition)
+	if component == nil {
+		return nil, fmt.Errorf("unable to move component, '%s' does not exist", definition)
+	}
+	if securitySection(component.parentPointer) {
+		return nil, fmt.Errorf("unable to move component '%s', a security scheme can only be used by the document "+
+			"declaring it", component.name)
+	}
+
+	file, toPointer := splitFullDefinition(destination)
+	switch {
+	case file == "":
+		file = component.file
+	case strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://"):
+		return nil, fmt.Errorf("unable to move component '%s', '%s' is a remote file", component.name, file)
+	default:
+		file = r.absoluteFilePath(file)
+	}
+	if toPointer == "" {
+		toPointer = component.pointer
+	}
+	parentPointer, name := splitPointer(toPointer)
+	if !strings.HasPrefix(toPointer, "/") || !componentNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("unable to move component '%s', '%s' is not a valid component name",
+			component.name, name)
+	}
+	if file == component.file {
+		switch {
+		case toPointer == component.pointer:
+			return nil, fmt.Errorf("unable to move component '%s', it is already at '%s'", component.name,
+				joinFullDefinition(file, toPointer))
+		case isPointerPrefix(component.pointer, toPointer):
+			return nil, fmt.Errorf("unable to move component '%s' into itself", component.name)
+		case parentPointer == component.parentPointer:
+			return nil, fmt.Errorf("unable to move component '%s' within '%s', rename it instead", component.name,
+				parentPointer)
+		}
+	}
+
+	to, err := c.destination(file)
+	if err != nil {
+		return nil, err
+	}
+	if refactorNodeAt(to.root, toPointer) != nil {
+		return nil, fmt.Errorf("unable to move component '%s', '%s' already exists", component.name,
+			joinFullDefinition(file, toPointer))
+	}
+	from, err := c.document(component.index)
+	if err != nil {
+		return nil, err
+	}
+	rel := newRelocation(c, from, component.pointer, component.value, file, toPointer)
+	if err = rel.rebase(); err != nil {
+		return nil, err
+	}
+	if err = rel.remove(from, component); err != nil {
+		return nil, err
+	}
+	if err = rel.insert(to, toPointer); err != nil {
+		return nil, err
+	}
+	return rel.relocation()
+}
+
+func planComponentExtract(r *Rolodex, indexes []*SpecIndex, definition, name string) (*ComponentRelocation, error) {
+	if !componentNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("unable to extract component, '%s' is not a valid component name", name)
+	}
+	c := newRefactorer(r, indexes)
+	file, pointer := splitFullDefinition(definition)
+	var idx *SpecIndex
+	var node *yaml.Node
+	if strings.HasPrefix(pointer, "/") {
+		for _, candidate := range refactorCandidates(r, c.indexes, file) {
+			if node = refactorNodeAt(candidate.GetRootNode(), pointer); node != nil {
+				idx = candidate
+				break
+			}
+		}
+	}
+	if node == nil {
+		return nil, fmt.Errorf("unable to extract component, '%s' does not exist", definition)
+	}
+	if !utils.IsNodeMap(node) {
+		return nil, fmt.Errorf("unable to extract component, '%s' is not a schema", definition)
+	}
+	if key, _ := mappingEntry(node, "$ref"); key != nil {
+		return nil, fmt.Errorf("unable to extract component, '%s' is already a reference", definition)
+	}
+	if parentPointer, _ := splitPointer(pointer); componentSection(parentPointer) {
+		return nil, fmt.Errorf("unable to extract component, '%s' is already a component", definition)
+	}
+
+	section := "/components/schemas"
+	if key, _ := mappingEntry(nodeAtPointer(idx.GetRootNode(), ""), "swagger"); key != nil {
+		section = "/definitions"
+	}
+	toPointer := section + "/" + escapePointerSegment(name)
+	if refactorNodeAt(idx.GetRootNode(), toPointer) != nil {
+		return nil, fmt.Errorf("unable to extract component '%s', '%s' already exists", name,
+			joinFullDefinition(idx.specAbsolutePath, toPointer))
+	}
+
+	d, err := c.document(idx)
+	if err != nil {
+		return nil, err
+	}
+	rel := newRelocation(c, d, pointer, node, d.file, toPointer)
+	if err = rel.rebase(); err != nil {
+		return nil, err
+	}
+
+	// the schema is replaced with a $ref to the component.
+	start, end := d.start(node), d.end(node)
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("unable to extract component, the content of '%s' does not match the index", d.file)
+	}
+	ref := "$ref: " + quoteScalar(&yaml.Node{Style: yaml.SingleQuotedStyle}, "#"+toPointer)
+	if node.Style&yaml.FlowStyle != 0 || d.flow {
+		ref = `{"$ref": ` + quoteScalar(&yaml.Node{Style: yaml.DoubleQuotedStyle}, "#"+toPointer) + "}"
+	}
+	c.edit(d, start, end, ref, RenameEditReference)
+	if err = rel.insert(d, toPointer); err != nil {
+		return nil, err
+	}
+	return rel.relocation()
+}
+
+func planComponentInline(r *Rolodex, indexes []*SpecIndex, definition string) (*ComponentRelocation, error) {
+	c := newRefactorer(r, indexes)
+	component := findComponent(r, c.indexes, definition)
+	if component == nil {
+		return nil, fmt.Errorf("unable to inline component, '%s' does not exist", definition)
+	}
+	if securitySection(component.parentPointer) {
+		return nil, fmt.Errorf("unable to inline component '%s', a security scheme is not used by a $ref",
+			component.name)
+	}
+	inside := descendants(component.value)
+
+	var site *Reference
+	var siteIndex *SpecIndex
+	uses := 0
+	for _, idx := range c.indexes {
+		for _, reference := range idx.GetRawReferencesSequenced() {
+			file, fragment := splitFullDefinition(reference.FullDefinition)
+			if reference.KeyNode == nil || file != component.file || fragment != component.pointer {
+				continue
+			}
+			if inside[reference.KeyNode] {
+				return nil, fmt.Errorf("unable to inline component '%s', it references itself", component.name)
+			}
+			site, siteIndex = reference, idx
+			uses++
+		}
+		for _, value := range discriminatorMappings(idx.GetRootNode()) {
+			named := idx == component.index && component.parentPointer == "/components/schemas" &&
+				value.Value == component.name
+			if strings.Contains(value.Value, "#") {
+				fullDefinition, _ := idx.resolveReferenceTarget(value.Value)
+				named = fullDefinition == joinFullDefinition(component.file, component.pointer)
+			}
+			if named {
+				return nil, fmt.Errorf("unable to inline component '%s', it is named by the discriminator mapping "+
+					"in '%s' at line %d", component.name, idx.specAbsolutePath, value.Line)
+			}
+		}
+	}
+	switch {
+	case uses == 0:
+		return nil, fmt.Errorf("unable to inline component '%s', it is not used", component.name)
+	case uses > 1:
+		return nil, fmt.Errorf("unable to inline component '%s', it is used %d times", component.name, uses)
+	}
+	if !utils.IsNodeMap(site.Node) || len(site.Node.Content) != 2 {
+		return nil, fmt.Errorf("unable to inline component '%s', the $ref in '%s' at line %d has sibling "+
+			"properties", component.name, siteIndex.specAbsolutePath, site.KeyNode.Line)
+	}
+
+	from, err := c.document(component.index)
+	if err != nil {
+		return nil, err
+	}
+	to, err := c.document(siteIndex)
+	if err != nil {
+		return nil, err
+	}
+	sitePointer, found := pointerTo(to.root, site.Node)
+	if !found {
+		return nil, fmt.Errorf("unable to inline component '%s', the $ref in '%s' at line %d cannot be located",
+			component.name, to.file, site.KeyNode.Line)
+	}
+	rel := newRelocation(c, from, component.pointer, component.value, to.file, sitePointer)
+	rel.skip = site.KeyNode
+	if err = rel.rebase(); err != nil {
+		return nil, err
+	}
+
+	// the $ref is replaced with the component.
+	start, end := to.start(site.Node), to.end(site.Node)
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("unable to inline component, the content of '%s' does not match the index", to.file)
+	}
+	value, err := rel.value(site.Node.Style&yaml.FlowStyle != 0 || to.flow, to)
+	if err != nil {
+		return nil, err
+	}
+	c.edit(to, start, end, value.indent(to.base(site.Node)), RenameEditInsert)
+	if err = rel.remove(from, component); err != nil {
+		return nil, err
+	}
+	return rel.relocation()
+}
+
+// relocation moves a node of a document to a pointer of another (or the same) document. $refs and discriminator
+// mappings pointing into the node are rewritten to point into its new location. Those held by the node travel with
+// it, and are rewritten relative to its new file.
+type relocation struct {
+	*refactorer
+	from      *refactorDocument
+	pointer   string
+	node      *yaml.Node
+	file      string
+	toPointer string
+
+	// inside holds the node and every node below it.
+	inside map[*yaml.Node]bool
+
+	// changes are the new values of scalars held by the node.
+	changes map[*yaml.Node]string
+
+	// skip is a $ref left alone, the use site of an inlined component.
+	skip *yaml.Node
+}
+
+func newRelocation(c *refactorer, from *refactorDocument, pointer string, node *yaml.Node, file, toPointer string,
+) *relocation {
+	return &relocation{
+		refactorer: c, from: from, pointer: pointer, node: node, file: file, toPointer: toPointer,
+		inside: descendants(node), changes: make(map[*yaml.Node]string),
+	}
+}
+
+func (r *relocation) relocation() (*ComponentRelocation, error) {
+	files, preview, err := r.files()
+	if err != nil {
+		return nil, err
+	}
+	return &ComponentRelocation{
+		From:    joinFullDefinition(r.from.file, r.pointer),
+		To:      joinFullDefinition(r.file, r.toPointer),
+		Files:   files,
+		Preview: preview,
+	}, nil
+}
+
+// target returns where a file and pointer live once the node is relocated.
+func (r *relocation) target(file, pointer string) (string, string) {
+	if file == r.from.file && isPointerPrefix(r.pointer, pointer) {
+		return r.file, r.toPointer + pointer[len(r.pointer):]
+	}
+	return file, pointer
+}
+
+// rebase rewrites every $ref and discriminator mapping of every document whose target moves, or which moves with
+// the node to another file.
+func (r *relocation) rebase() error {
+	for _, idx := range r.indexes {
+		d, err := r.document(idx)
+		if err != nil {
+			return err
+		}
+		for _, reference := range idx.GetRawReferencesSequenced() {
+			if reference.KeyNode == nil || reference.KeyNode == r.skip {
+				continue
+			}
+			file, fragment := splitFullDefinition(reference.FullDefinition)
+			if err = r.rewrite(d, reference.KeyNode, file, fragment, RenameEditReference); err != nil {
+				return err
+			}
+		}
+		for _, value := range discriminatorMappings(idx.GetRootNode()) {
+			if strings.Contains(value.Value, "#") {
+				fullDefinition, _ := idx.resolveReferenceTarget(value.Value)
+				file, fragment := splitFullDefinition(fullDefinition)
+				if err = r.rewrite(d, value, file, fragment, RenameEditMapping); err != nil {
+					return err
+				}
+				continue
+			}
+			// a schema name, of the schemas in the document declaring the discriminator.
+			fragment := "/components/schemas/" + escapePointerSegment(value.Value)
+			if nodeAtPointer(d.root, fragment) == nil {
+				continue
+			}
+			from := d.file
+			if r.inside[value] {
+				from = r.file
+			}
+			file, pointer := r.target(d.file, fragment)
+			if file == d.file && pointer == fragment && from == d.file {
+				continue
+			}
+			mapping := relativeReference(from, file, pointer, "")
+			if parent, name := splitPointer(pointer); file == from && parent == "/components/schemas" {
+				mapping = name
+			}
+			if err = r.update(d, value, mapping, RenameEditMapping); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewrite rewrites a $ref (or a discriminator mapping) to a file and pointer, if its target moves, or it moves with
+// the node to another file.
+func (r *relocation) rewrite(d *refactorDocument, node *yaml.Node, file, pointer string, kind RenameEditKind) error {
+	from := d.file
+	if r.inside[node] {
+		from = r.file
+	}
+	newFile, newPointer := r.target(file, pointer)
+	if newFile == file && newPointer == pointer && from == d.file {
+		return nil
+	}
+	return r.update(d, node, relativeReference(from, newFile, newPointer, node.Value), kind)
+}
+
+// update records the new value of a scalar, as a change to the relocated text when the node holds it, otherwise
+// as an edit.
+func (r *relocation) update(d *refactorDocument, node *yaml.Node, value string, kind RenameEditKind) error {
+	if r.inside[node] {
+		r.changes[node] = value
+		return nil
+	}
+	return r.replaceScalar(d, node, value, kind)
+}
+
+// refactorValue is the text of a relocated node, its lines indented relative to the first.
+type refactorValue struct {
+	lines []string
+
+	// block is true for a block collection, written on the lines after its key.
+	block bool
+}
+
+// indent returns the text of a value, every line but the first indented by n spaces.
+func (v refactorValue) indent(n int) string {
+	pad := strings.Repeat(" ", n)
+	var b strings.Builder
+	for i, line := range v.lines {
+		if i > 0 {
+			b.WriteByte('\n')
+			if line != "" {
+				b.WriteString(pad)
+			}
+		}
+		b.WriteString(line)
+	}
+	return b.String()
+}
+
+// value returns the text of the relocated node, with its changes, for a flow (JSON) or block destination. The text
+// is copied when the style of the destination matches the document of the node, otherwise the node is rendered in
+// the style of the destination.
+func (r *relocation) value(flow bool, to *refactorDocument) (refactorValue, error) {
+	if flow == r.from.flow {
+		return r.text()
+	}
+	clone := r.clone(r.node)
+	if flow {
+		rendered, err := json.YAMLNodeToJSON(clone, to.jsonIndent())
+		if err != nil {
+			return refactorValue{}, fmt.Errorf("unable to render '%s' as JSON: %w", r.pointer, err)
+		}
+		return refactorValue{lines: strings.Split(string(rendered), "\n")}, nil
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(to.step())
+	if err := encoder.Encode(clone); err != nil {
+		return refactorValue{}, fmt.Errorf("unable to render '%s' as YAML: %w", r.pointer, err)
+	}
+	_ = encoder.Close()
+	return refactorValue{
+		lines: strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"),
+		block: (clone.Kind == yaml.MappingNode || clone.Kind == yaml.SequenceNode) && len(clone.Content) > 0,
+	}, nil
+}
+
+// text returns the text of the relocated node as written, with its changes.
+func (r *relocation) text() (refactorValue, error) {
+	d, node := r.from, r.node
+	start, end := d.start(node), d.end(node)
+	if start < 0 || end < start {
+		return refactorValue{}, fmt.Errorf("unable to relocate '%s', the content of '%s' does not match the index",
+			r.pointer, d.file)
+	}
+	type change struct {
+		start, end int
+		text       string
+	}
+	var changes []change
+	for n, value := range r.changes {
+		s, e := d.start(n), d.end(n)
+		if s < start || e > end || e < s {
+			return refactorValue{}, fmt.Errorf("unable to relocate '%s', the content of '%s' at line %d, "+
+				"column %d does not match the index", r.pointer, d.file, n.Line, n.Column)
+		}
+		changes = append(changes, change{s, e, quoteScalar(n, value)})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].start < changes[j].start })
+	var b strings.Builder
+	last := start
+	for _, ch := range changes {
+		b.Write(d.content[last:ch.start])
+		b.WriteString(ch.text)
+		last = ch.end
+	}
+	b.Write(d.content[last:end])
+
+	lines := strings.Split(b.String(), "\n")
+	base := d.base(node)
+	for i := 1; i < len(lines); i++ {
+		n := 0
+		for n < base && n < len(lines[i]) && lines[i][n] == ' ' {
+			n++
+		}
+		lines[i] = lines[i][n:]
+	}
+	return refactorValue{
+		lines: lines,
+		block: (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) &&
+			node.Style&yaml.FlowStyle == 0 && len(node.Content) > 0,
+	}, nil
+}
+
+// clone copies a node with its changes and without styles, ready to be rendered in another style.
+func (r *relocation) clone(node *yaml.Node) *yaml.Node {
+	c := *node
+	c.Style = 0
+	if value, ok := r.changes[node]; ok {
+		c.Value = value
+	}
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		c.Content[i] = r.clone(child)
+	}
+	return &c
+}
+
+// insert records an edit writing the relocated node at a pointer of a document, creating the mappings of the
+// pointer that do not exist.
+func (r *relocation) insert(d *refactorDocument, pointer string) error {
+	var keys []string
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		keys = append(keys, decodeJSONPointerToken(segment))
+	}
+	if d.root == nil || d.root.Kind != yaml.MappingNode {
+		if len(bytes.TrimSpace(d.content)) > 0 {
+			return fmt.Errorf("unable to relocate '%s', '%s' is not a mapping", r.pointer, d.file)
+		}
+		value, err := r.value(d.flow, d)
+		if err != nil {
+			return err
+		}
+		text := blockEntry(keys, value, 0, d.step()) + "\n"
+		if d.flow {
+			unit := d.jsonIndent()
+			text = "{\n" + unit + flowEntry(keys, value, len(unit), unit) + "\n}\n"
+		}
+		r.edit(d, len(d.content), len(d.content), text, RenameEditInsert)
+		return nil
+	}
+
+	// the deepest mapping of the pointer that exists.
+	container, key := d.root, (*yaml.Node)(nil)
+	for len(keys) > 1 {
+		k, v := mappingEntry(container, keys[0])
+		if k == nil {
+			break
+		}
+		if !utils.IsNodeMap(v) {
+			return fmt.Errorf("unable to relocate '%s', '%s' in '%s' at line %d is not a mapping", r.pointer,
+				keys[0], d.file, k.Line)
+		}
+		container, key, keys = v, k, keys[1:]
+	}
+
+	flow := container.Style&yaml.FlowStyle != 0
+	// an empty flow mapping of a block document becomes a block mapping.
+	blockify := flow && len(container.Content) == 0 && !d.flow && key != nil
+	value, err := r.value(flow && !blockify, d)
+	if err != nil {
+		return err
+	}
+	start, end := d.start(container), d.end(container)
+	if start < 0 || end < start {
+		return fmt.Errorf("unable to relocate '%s', the content of '%s' does not match the index", r.pointer, d.file)
+	}
+	unit := d.jsonIndent()
+	switch {
+	case !flow:
+		indent := container.Column - 1
+		r.edit(d, end, end, "\n"+strings.Repeat(" ", indent)+blockEntry(keys, value, indent, d.step()),
+			RenameEditInsert)
+	case blockify:
+		indent := key.Column - 1 + d.step()
+		r.edit(d, d.end(key), end, ":\n"+strings.Repeat(" ", indent)+blockEntry(keys, value, indent, d.step()),
+			RenameEditInsert)
+	case len(container.Content) == 0:
+		indent := d.indentation(start)
+		inner := indent + len(unit)
+		r.edit(d, start, end, "{\n"+strings.Repeat(" ", inner)+flowEntry(keys, value, inner, unit)+"\n"+
+			strings.Repeat(" ", indent)+"}", RenameEditInsert)
+	default:
+		lastKey, last := container.Content[len(container.Content)-2], container.Content[len(container.Content)-1]
+		at := d.end(last)
+		if lastKey.Line > container.Line {
+			indent := lastKey.Column - 1
+			r.edit(d, at, at, ",\n"+strings.Repeat(" ", indent)+flowEntry(keys, value, indent, unit),
+				RenameEditInsert)
+		} else {
+			r.edit(d, at, at, ", "+flowEntry(keys, value, d.indentation(start), unit), RenameEditInsert)
+		}
+	}
+	return nil
+}
+
+// remove records an edit removing a component from its section. A section left empty becomes an empty mapping.
+func (r *relocation) remove(d *refactorDocument, component *refactorComponent) error {
+	section, key := component.section, component.key
+	start, end := d.start(key), d.end(component.value)
+	if start < 0 || end < start {
+		return fmt.Errorf("unable to remove '%s', the content of '%s' does not match the index", component.name,
+			d.file)
+	}
+	i := 0
+	for i < len(section.Content) && section.Content[i] != key {
+		i += 2
+	}
+	switch {
+	case len(section.Content) == 2:
+		sectionKey := (*yaml.Node)(nil)
+		if component.parentPointer != "" {
+			grandParent, name := splitPointer(component.parentPointer)
+			sectionKey, _ = mappingEntry(refactorNodeAt(d.root, grandParent), name)
+		}
+		switch {
+		case section.Style&yaml.FlowStyle != 0 || sectionKey == nil:
+			start, end = d.start(section), d.end(section)
+			r.edit(d, start, end, "{}", RenameEditRemove)
+		default:
+			r.edit(d, d.end(sectionKey), end, ": {}", RenameEditRemove)
+		}
+	case section.Style&yaml.FlowStyle != 0 && i+2 < len(section.Content):
+		r.edit(d, start, d.start(section.Content[i+2]), "", RenameEditRemove)
+	case section.Style&yaml.FlowStyle != 0:
+		r.edit(d, d.end(section.Content[i-1]), end, "", RenameEditRemove)
+	default:
+		// the lines of the entry, from the end of the line before it.
+		if lineStart := bytes.LastIndexByte(d.content[:start], '\n'); lineStart >= 0 {
+			start = lineStart
+		} else if end < len(d.content) && d.content[end] == '\n' {
+			start, end = 0, end+1
+		}
+		r.edit(d, start, end, "", RenameEditRemove)
+	}
+	return nil
+}
+
+// destination returns the document of a file a component is moved into, read from the file when it is not
+// indexed, or created when it does not exist.
+func (c *refactorer) destination(file string) (*refactorDocument, error) {
+	if idx := c.indexFor(file); idx != nil {
+		return c.document(idx)
+	}
+	if d := c.documents[file]; d != nil {
+		return d, nil
+	}
+	content, err := os.ReadFile(file)
+	var d *refactorDocument
+	switch {
+	case err == nil:
+		var root yaml.Node
+		if err = yaml.Unmarshal(content, &root); err != nil {
+			return nil, fmt.Errorf("unable to read '%s': %w", file, err)
+		}
+		d = newRefactorDocument(file, content, &root)
+	case errors.Is(err, fs.ErrNotExist):
+		d = newRefactorDocument(file, nil, nil)
+		d.created = true
+	default:
+		return nil, fmt.Errorf("unable to read '%s': %w", file, err)
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		d.flow = strings.EqualFold(filepath.Ext(file), ".json")
+	}
+	c.documents[file] = d
+	return d, nil
+}
+
+// blockEntry renders the entry of a value in a block mapping, nested under each key, at an indentation.
+func blockEntry(keys []string, value refactorValue, indent, step int) string {
+	key := quoteScalar(&yaml.Node{}, keys[0]) + ":"
+	switch {
+	case len(keys) > 1:
+		return key + "\n" + strings.Repeat(" ", indent+step) + blockEntry(keys[1:], value, indent+step, step)
+	case value.block:
+		return key + "\n" + strings.Repeat(" ", indent+step) + value.indent(indent+step)
+	}
+	return key + " " + value.indent(indent)
+}
+
+// flowEntry renders the entry of a value in a flow (JSON) mapping, nested under each key, at an indentation.
+func flowEntry(keys []string, value refactorValue, indent int, unit string) string {
+	key := quoteScalar(&yaml.Node{Style: yaml.DoubleQuotedStyle}, keys[0]) + ": "
+	if len(keys) > 1 {
+		inner := indent + len(unit)
+		return key + "{\n" + strings.Repeat(" ", inner) + flowEntry(keys[1:], value, inner, unit) + "\n" +
+			strings.Repeat(" ", indent) + "}"
+	}
+	return key + value.indent(indent)
+}
+
+// relativeReference returns a $ref, written in a file, to a pointer of another file: a local reference for the
+// same file, otherwise the path of the file relative to the directory of the first, keeping a leading ./ when the
+// original reference had one.
+func relativeReference(from, file, pointer, original string) string {
+	if file == from {
+		return "#" + pointer
+	}
+	ref := file
+	if !strings.HasPrefix(file, "http") && !strings.HasPrefix(from, "http") {
+		if rel, err := filepath.Rel(filepath.Dir(from), file); err == nil {
+			ref = filepath.ToSlash(rel)
+			if strings.HasPrefix(original, "./") && !strings.HasPrefix(ref, "../") {
+				ref = "./" + ref
+			}
+		}
+	}
+	return joinFullDefinition(ref, pointer)
+}
+
+// componentSection returns true if a pointer is a section of components.
+func componentSection(pointer string) bool {
+	switch pointer {
+	case "/definitions", "/parameters", "/responses", "/securityDefinitions":
+		return true
+	}
+	return strings.HasPrefix(pointer, "/components/") && strings.Count(pointer, "/") == 2
+}
+
+func securitySection(pointer string) bool {
+	return pointer == "/components/securitySchemes" || pointer == "/securityDefinitions"
+}
+
+// splitPointer splits a JSON pointer into the pointer of its parent and its last (unescaped) segment.
+func splitPointer(pointer string) (string, string) {
+	i := strings.LastIndexByte(pointer, '/')
+	if i < 0 {
+		return "", decodeJSONPointerToken(pointer)
+	}
+	return pointer[:i], decodeJSONPointerToken(pointer[i+1:])
+}
+
+// refactorNodeAt returns the node at a JSON pointer, through mappings and sequences, or nil.
+func refactorNodeAt(root *yaml.Node, pointer string) *yaml.Node {
+	node := nodeAtPointer(root, "")
+	if pointer == "" || pointer == "/" {
+		return node
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		switch {
+		case node == nil:
+			return nil
+		case utils.IsNodeMap(node):
+			_, node = mappingEntry(node, decodeJSONPointerToken(segment))
+		case utils.IsNodeArray(node):
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[i]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// pointerTo returns the JSON pointer of a node below a root.
+func pointerTo(root, target *yaml.Node) (string, bool) {
+	if root == nil {
+		return "", false
+	}
+	if root == target {
+		return "", true
+	}
+	for i, child := range root.Content {
+		segment := strconv.Itoa(i)
+		if utils.IsNodeMap(root) {
+			if i%2 == 0 {
+				continue
+			}
+			segment = escapePointerSegment(root.Content[i-1].Value)
+		}
+		if pointer, found := pointerTo(child, target); found {
+			return "/" + segment + pointer, true
+		}
+	}
+	return "", false
+}
+
+// descendants returns a node and every node below it.
+func descendants(node *yaml.Node) map[*yaml.Node]bool {
+	nodes := make(map[*yaml.Node]bool)
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		nodes[n] = true
+		for _, child := range n.Content {
+			walk(child)
+		}
+	}
+	walk(node)
+	return nodes
+}
@@ -0,0 +1,267 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestRolodex_MoveComponent(t *testing.T) {
+	rolodex, dir := buildRenameRolodex(t)
+	root, pets := filepath.Join(dir, "root.yaml"), filepath.Join(dir, "models", "pets.json")
+
+	move, err := rolodex.MoveComponent("#/components/schemas/Pet", "models/pets.json")
+	require.NoError(t, err)
+	assert.Equal(t, root+"#/components/schemas/Pet", move.From)
+	assert.Equal(t, pets+"#/components/schemas/Pet", move.To)
+
+	changes, err := move.Changes()
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	// the component is written as JSON, its $refs relative to the new file.
+	assert.Equal(t, `{
+  "components": {
+    "schemas": {
+      "Pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+      "Pet": {
+        "type": "object",
+        "discriminator": {
+          "propertyName": "kind",
+          "mapping": {
+            "dog": "../root.yaml#/components/schemas/Dog",
+            "cat": "../root.yaml#/components/schemas/Cat"
+          }
+        },
+        "properties": {
+          "kind": {
+            "type": "string"
+          },
+          "name": {
+            "$ref": "#/components/schemas/Pet/properties/kind"
+          }
+        }
+      }
+    }
+  }
+}
+`, string(changes[0].Content))
+
+	content := string(changes[1].Content)
+	assert.Contains(t, content, "                $ref: 'models/pets.json#/components/schemas/Pet'\n")
+	assert.Contains(t, content, "  schemas:\n    Dog:\n      allOf:\n        - $ref: 'models/pets.json#/components/schemas/Pet'\n")
+	assert.NotContains(t, content, "every pet")
+
+	_, err = rolodex.UpdateFiles(context.Background(), changes...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, rolodex.FindReferencesTo(pets+"#/components/schemas/Pet"))
+	assert.Empty(t, rolodex.FindReferencesTo(root+"#/components/schemas/Pet"))
+}
+
+func TestRolodex_MoveComponent_NewFile(t *testing.T) {
+	rolodex, dir := buildRenameRolodex(t)
+
+	move, err := rolodex.MoveComponent("#/components/schemas/Cat", "shared/common.yaml")
+	require.NoError(t, err)
+	require.Len(t, move.Files, 2)
+	assert.False(t, move.Files[0].Created)
+	assert.True(t, move.Files[1].Created)
+	assert.Equal(t, filepath.Join(dir, "shared", "common.yaml"), move.Files[1].File)
+
+	// the discriminator mapping naming the schema becomes a reference.
+	assert.Equal(t, `--- a/root.yaml
++++ b/root.yaml
+@@ -39 +39 @@
+-          cat: Cat
++          cat: shared/common.yaml#/components/schemas/Cat
+@@ -48,2 +47,0 @@
+-    Cat:
+-      type: object
+--- /dev/null
++++ b/shared/common.yaml
+@@ -0,0 +1,4 @@
++components:
++  schemas:
++    Cat:
++      type: object
+`, move.Preview)
+}
+
+func TestRolodex_InlineComponent(t *testing.T) {
+	rolodex, dir := buildRenameRolodex(t)
+
+	inline, err := rolodex.InlineComponent("models/pets.json#/components/schemas/Pets")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "root.yaml")+"#/paths/~1pets/post/requestBody/content/application~1json/schema",
+		inline.To)
+
+	// the JSON component is written as YAML, its $ref relative to the use site.
+	assert.Equal(t, `--- a/models/pets.json
++++ b/models/pets.json
+@@ -3,3 +3 @@
+-    "schemas": {
+-      "Pets": {"type": "array", "items": {"$ref": "../root.yaml#/components/schemas/Pet"}}
+-    }
++    "schemas": {}
+--- a/root.yaml
++++ b/root.yaml
+@@ -25 +25,3 @@
+-              $ref: "models/pets.json#/components/schemas/Pets"
++              type: array
++              items:
++                $ref: '#/components/schemas/Pet'
+`, inline.Preview)
+}
+
+func TestRolodex_ExtractComponent(t *testing.T) {
+	rolodex, _ := buildRenameRolodex(t)
+
+	extract, err := rolodex.ExtractComponent("#/components/schemas/Pet/properties/kind", "Kind")
+	require.NoError(t, err)
+
+	// the $ref pointing into the schema follows it.
+	assert.Equal(t, `--- a/root.yaml
++++ b/root.yaml
+@@ -42 +42 @@
+-          type: string
++          $ref: '#/components/schemas/Kind'
+@@ -44 +44 @@
+-          $ref: '#/components/schemas/Pet/properties/kind'
++          $ref: '#/components/schemas/Kind'
+@@ -49,0 +50,2 @@
++    Kind:
++      type: string
+`, extract.Preview)
+}
+
+func TestSpecIndex_ExtractComponent(t *testing.T) {
+	index := func(src string) *SpecIndex {
+		spec := []byte(src)
+		var root yaml.Node
+		require.NoError(t, yaml.Unmarshal(spec, &root))
+		cfg := CreateClosedAPIIndexConfig()
+		cfg.SpecInfo = &datamodel.SpecInfo{SpecBytes: &spec}
+		return NewSpecIndexWithConfig(&root, cfg)
+	}
+
+	// a JSON document without components.
+	idx := index(`{
+  "openapi": "3.1.0",
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {"application/json": {"schema": {"type": "object", "properties": {"id": {"type": "integer"}}}}}
+          },
+          "404": {"$ref": "#/paths/~1pets/get/responses/200/content/application~1json/schema/properties/id"}
+        }
+      }
+    }
+  }
+}
+`)
+	extract, err := idx.ExtractComponent("#/paths/~1pets/get/responses/200/content/application~1json/schema", "Pet")
+	require.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/Pet", extract.To)
+	changes, err := extract.Changes()
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "ok",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+          },
+          "404": {"$ref": "#/components/schemas/Pet/properties/id"}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {"type": "object", "properties": {"id": {"type": "integer"}}}
+    }
+  }
+}
+`, string(changes[0].Content))
+	assert.Contains(t, extract.Preview, "--- a/root.yaml\n")
+
+	// a Swagger document, through a sequence, into an empty section.
+	idx = index(`swagger: "2.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            type: object
+            properties:
+              id: {type: integer}
+      responses:
+        '200':
+          description: ok
+definitions: {}
+`)
+	extract, err = idx.ExtractComponent("#/paths/~1pets/get/parameters/0/schema", "Pet")
+	require.NoError(t, err)
+	changes, err = extract.Changes()
+	require.NoError(t, err)
+	assert.Contains(t, string(changes[0].Content), "          schema:\n            $ref: '#/definitions/Pet'\n")
+	assert.Contains(t, string(changes[0].Content), `definitions:
+  Pet:
+    type: object
+    properties:
+      id: {type: integer}
+`)
+}
+
+func TestRolodex_RelocateComponent_Refused(t *testing.T) {
+	rolodex, dir := buildRenameRolodex(t)
+	root := filepath.Join(dir, "root.yaml")
+
+	_, err := rolodex.ExtractComponent("#/components/schemas/Dog", "Hound")
+	assert.EqualError(t, err, "unable to extract component, '#/components/schemas/Dog' is already a component")
+	_, err = rolodex.ExtractComponent("#/components/schemas/Dog/allOf/0", "Hound")
+	assert.EqualError(t, err, "unable to extract component, '#/components/schemas/Dog/allOf/0' is already a reference")
+	_, err = rolodex.ExtractComponent("#/components/schemas/Pet/properties/kind", "Cat")
+	assert.EqualError(t, err, "unable to extract component 'Cat', '"+root+"#/components/schemas/Cat' already exists")
+	_, err = rolodex.ExtractComponent("#/components/schemas/Pet/properties/kind", "a kind")
+	assert.EqualError(t, err, "unable to extract component, 'a kind' is not a valid component name")
+	_, err = rolodex.ExtractComponent("#/components/schemas/Pet/properties/nope", "Nope")
+	assert.EqualError(t, err, "unable to extract component, '#/components/schemas/Pet/properties/nope' does not exist")
+
+	_, err = rolodex.InlineComponent("#/components/schemas/Pet")
+	assert.EqualError(t, err, "unable to inline component 'Pet', it is used 3 times")
+	_, err = rolodex.InlineComponent("#/components/schemas/Cat")
+	assert.EqualError(t, err, "unable to inline component 'Cat', it is named by the discriminator mapping in '"+
+		root+"' at line 39")
+	_, err = rolodex.InlineComponent("#/components/securitySchemes/apiKey")
+	assert.EqualError(t, err, "unable to inline component 'apiKey', a security scheme is not used by a $ref")
+
+	_, err = rolodex.MoveComponent("#/components/schemas/Pet", "https://example.com/shared.yaml")
+	assert.EqualError(t, err, "unable to move component 'Pet', 'https://example.com/shared.yaml' is a remote file")
+	_, err = rolodex.MoveComponent("#/components/schemas/Pet", "#/components/schemas/Animal")
+	assert.EqualError(t, err, "unable to move component 'Pet' within '/components/schemas', rename it instead")
+	_, err = rolodex.MoveComponent("#/components/schemas/Pet", "#/components/schemas/Pet/properties/pet")
+	assert.EqualError(t, err, "unable to move component 'Pet' into itself")
+	_, err = rolodex.MoveComponent("#/components/schemas/Pet", "root.yaml")
+	assert.EqualError(t, err, "unable to move component 'Pet', it is already at '"+root+"#/components/schemas/Pet'")
+	_, err = rolodex.MoveComponent("models/pets.json#/components/schemas/Pets", "root.yaml#/components/schemas/Dog")
+	assert.EqualError(t, err, "unable to move component 'Pets', '"+root+"#/components/schemas/Dog' already exists")
+	_, err = rolodex.MoveComponent("#/components/securitySchemes/apiKey", "models/pets.json")
+	assert.ErrorContains(t, err, "a security scheme can only be used by the document declaring it")
+}
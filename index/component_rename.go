@@ -0,0 +1,232 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// RenameEditKind is what a RenameEdit changes.
+type RenameEditKind string
+
+const (
+	// RenameEditComponent is the key declaring a renamed component.
+	RenameEditComponent RenameEditKind = "component"
+
+	// RenameEditReference is a $ref pointing at a component, or into it, rewritten or written.
+	RenameEditReference RenameEditKind = "reference"
+
+	// RenameEditMapping is a discriminator mapping value, either a reference or the name of a schema.
+	RenameEditMapping RenameEditKind = "mapping"
+
+	// RenameEditSecurity is the name of a security scheme in a security requirement.
+	RenameEditSecurity RenameEditKind = "security"
+
+	// RenameEditInsert is a component written into a file, or a component inlined into its use site.
+	RenameEditInsert RenameEditKind = "insert"
+
+	// RenameEditRemove is a component removed from a file.
+	RenameEditRemove RenameEditKind = "remove"
+)
+
+// componentNamePattern is the pattern component names must match, as defined by the OpenAPI specification.
+var componentNamePattern = regexp.MustCompile(`^[a-zA-Z0-9.\-_]+$`)
+
+// RenameEdit is a change to the bytes of a file, the bytes between Start and End (Old) are replaced with New.
+// An insertion has the same Start and End.
+type RenameEdit struct {
+	// Start and End are byte offsets into the content of the file.
+	Start int `json:"start"`
+	End   int `json:"end"`
+
+	// Line and Column are the 1-based position of Start.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+
+	// Old is the text being replaced, New is the replacement.
+	Old string `json:"old"`
+	New string `json:"new"`
+
+	// Kind is what the edit changes.
+	Kind RenameEditKind `json:"kind"`
+}
+
+// RenameFileEdits are the edits of a single file, ordered by offset. Edits never overlap.
+type RenameFileEdits struct {
+	// File is the absolute path (or URL) of the file.
+	File string `json:"file"`
+
+	// Created is true when the file does not exist yet, its edits apply to empty content.
+	Created bool `json:"created,omitempty"`
+
+	// Edits are the changes to make to the file.
+	Edits []*RenameEdit `json:"edits"`
+
+	content []byte
+}
+
+// Apply applies the edits to the content of the file. An error is returned if the content does not hold the text
+// being replaced, which means the file has changed since the refactoring was planned.
+func (f *RenameFileEdits) Apply(content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	last := 0
+	for _, e := range f.Edits {
+		if e.Start < last || e.End > len(content) || string(content[e.Start:e.End]) != e.Old {
+			return nil, fmt.Errorf("unable to apply refactoring to '%s', line %d, column %d does not hold '%s'",
+				f.File, e.Line, e.Column, e.Old)
+		}
+		buf.Write(content[last:e.Start])
+		buf.WriteString(e.New)
+		last = e.End
+	}
+	buf.Write(content[last:])
+	return buf.Bytes(), nil
+}
+
+// ComponentRename is a planned rename of a component: the edits to make to every file so the component, and
+// everything that points at it, use the new name. Nothing is changed until the edits are applied.
+type ComponentRename struct {
+	// Component is the full definition of the component, for example /specs/openapi.yaml#/components/schemas/Pet.
+	Component string `json:"component"`
+
+	// Renamed is the full definition of the component once renamed.
+	Renamed string `json:"renamed"`
+
+	// Name and NewName are the current and new names of the component.
+	Name    string `json:"name"`
+	NewName string `json:"newName"`
+
+	// Files are the files to change, ordered by path.
+	Files []*RenameFileEdits `json:"files"`
+
+	// Preview is a unified diff of every changed line.
+	Preview string `json:"preview"`
+}
+
+// Changes applies the edits to the content the rename was planned against, and returns the changed files, ready to
+// be written out or handed to Rolodex.UpdateFiles.
+func (c *ComponentRename) Changes() ([]RolodexFileChange, error) {
+	return applyFileEdits(c.Files)
+}
+
+// RenameComponent plans the rename of a component held by this index, and of every $ref, discriminator mapping and
+// security requirement pointing at it. The component is a definition (#/components/schemas/Pet) or a full
+// definition. An error is returned if the component does not exist, the new name is not a valid component name, or
+// a component with the new name already exists.
+//
+// The content of the document is read from the SpecInfo of the index configuration, or from the file it was
+// loaded from.
+func (index *SpecIndex) RenameComponent(definition, newName string) (*ComponentRename, error) {
+	return planComponentRename(nil, []*SpecIndex{index}, definition, newName)
+}
+
+// RenameComponent plans the rename of a component held by any file in the rolodex, and of every $ref,
+// discriminator mapping and security requirement pointing at it from any file. The component is a full definition,
+// a definition relative to the BasePath of the index configuration (shared.yaml#/components/schemas/Pet), or a
+// definition (#/components/schemas/Pet), looked up in the root document first, then in every other file.
+//
+// An error is returned if the component does not exist, the new name is not a valid component name, a component
+// with the new name already exists, or a $ref to the component cannot be rewritten (for example a $ref that reaches
+// it through a $id).
+func (r *Rolodex) RenameComponent(definition, newName string) (*ComponentRename, error) {
+	return planComponentRename(r, append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...), definition, newName)
+}
+
+func planComponentRename(r *Rolodex, indexes []*SpecIndex, definition, newName string) (*ComponentRename, error) {
+	if !componentNamePattern.MatchString(newName) {
+		return nil, fmt.Errorf("unable to rename component, '%s' is not a valid component name", newName)
+	}
+	c := newRefactorer(r, indexes)
+	component := findComponent(r, c.indexes, definition)
+	if component == nil {
+		return nil, fmt.Errorf("unable to rename component, '%s' does not exist", definition)
+	}
+	name, parentPointer := component.name, component.parentPointer
+	if name == newName {
+		return nil, fmt.Errorf("unable to rename component, '%s' is already named '%s'", definition, newName)
+	}
+	if key, _ := mappingEntry(component.section, newName); key != nil {
+		return nil, fmt.Errorf("unable to rename component '%s', '%s' already exists", name,
+			joinFullDefinition(component.file, parentPointer+"/"+newName))
+	}
+
+	d, err := c.document(component.index)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.replaceText(d, component.key, 0, name, newName, RenameEditComponent); err != nil {
+		return nil, err
+	}
+
+	// rewrites the name segment of a reference to the component, or into it.
+	escapedName := escapePointerSegment(name)
+	rewrite := func(d *refactorDocument, node *yaml.Node, fullDefinition string, kind RenameEditKind) error {
+		target, fragment := splitFullDefinition(fullDefinition)
+		if target != component.file || !isPointerPrefix(component.pointer, fragment) {
+			return nil
+		}
+		hash := strings.IndexByte(node.Value, '#')
+		if hash < 0 || !isPointerPrefix(parentPointer+"/"+escapedName, node.Value[hash+1:]) {
+			return fmt.Errorf("unable to rename component '%s', the reference '%s' in '%s' at line %d, column %d "+
+				"cannot be rewritten", name, node.Value, d.file, node.Line, node.Column)
+		}
+		return c.replaceText(d, node, hash+1+len(parentPointer)+1, escapedName, newName, kind)
+	}
+
+	securitySchemes := parentPointer == "/components/securitySchemes" || parentPointer == "/securityDefinitions"
+	for _, idx := range c.indexes {
+		d, err := c.document(idx)
+		if err != nil {
+			return nil, err
+		}
+		for _, reference := range idx.GetRawReferencesSequenced() {
+			if reference.KeyNode == nil {
+				continue
+			}
+			if err := rewrite(d, reference.KeyNode, reference.FullDefinition, RenameEditReference); err != nil {
+				return nil, err
+			}
+		}
+		for _, value := range discriminatorMappings(idx.GetRootNode()) {
+			if strings.Contains(value.Value, "#") {
+				fullDefinition, _ := idx.resolveReferenceTarget(value.Value)
+				if err := rewrite(d, value, fullDefinition, RenameEditMapping); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			// a schema name, of the schemas in the document declaring the discriminator.
+			if idx == component.index && parentPointer == "/components/schemas" && value.Value == name {
+				if err := c.replaceText(d, value, 0, name, newName, RenameEditMapping); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if securitySchemes && idx == component.index {
+			for _, key := range securityRequirementKeys(idx.GetRootNode(), name) {
+				if err := c.replaceText(d, key, 0, name, newName, RenameEditSecurity); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	files, preview, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	return &ComponentRename{
+		Component: joinFullDefinition(component.file, component.pointer),
+		Renamed:   joinFullDefinition(component.file, parentPointer+"/"+newName),
+		Name:      name,
+		NewName:   newName,
+		Files:     files,
+		Preview:   preview,
+	}, nil
+}
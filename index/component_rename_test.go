@@ -0,0 +1,248 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const renameRootSpec = `openapi: 3.1.0
+info:
+  title: rename
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      security:
+        - apiKey: []
+          oauth: []
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "models/pets.json#/components/schemas/Pets"
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: key
+      in: header
+  schemas:
+    Pet: # every pet
+      type: object
+      discriminator:
+        propertyName: kind
+        mapping:
+          dog: '#/components/schemas/Dog'
+          cat: Cat
+      properties:
+        kind:
+          type: string
+        name:
+          $ref: '#/components/schemas/Pet/properties/kind'
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+    Cat:
+      type: object
+`
+
+const renamePetsSpec = `{
+  "components": {
+    "schemas": {
+      "Pets": {"type": "array", "items": {"$ref": "../root.yaml#/components/schemas/Pet"}}
+    }
+  }
+}
+`
+
+func buildRenameRolodex(t *testing.T) (*Rolodex, string) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "models"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "root.yaml"), []byte(renameRootSpec), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models", "pets.json"), []byte(renamePetsSpec), 0o644))
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(renameRootSpec), &root))
+	cfg := CreateOpenAPIIndexConfig()
+	cfg.BasePath = dir
+	cfg.SpecFilePath = filepath.Join(dir, "root.yaml")
+	cfg.AllowFileLookup = true
+	rolodex := NewRolodex(cfg)
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: cfg})
+	require.NoError(t, err)
+	rolodex.AddLocalFS(dir, localFS)
+	rolodex.SetRootNode(&root)
+	require.NoError(t, rolodex.IndexTheRolodex(context.Background()))
+	return rolodex, dir
+}
+
+func TestRolodex_RenameComponent(t *testing.T) {
+	rolodex, dir := buildRenameRolodex(t)
+	root, pets := filepath.Join(dir, "root.yaml"), filepath.Join(dir, "models", "pets.json")
+
+	rename, err := rolodex.RenameComponent("#/components/schemas/Pet", "Animal")
+	require.NoError(t, err)
+	assert.Equal(t, root+"#/components/schemas/Pet", rename.Component)
+	assert.Equal(t, root+"#/components/schemas/Animal", rename.Renamed)
+	assert.Equal(t, "Pet", rename.Name)
+	require.Len(t, rename.Files, 2)
+	assert.Equal(t, pets, rename.Files[0].File)
+	assert.Equal(t, root, rename.Files[1].File)
+
+	var kinds []RenameEditKind
+	var lines []int
+	for _, e := range rename.Files[1].Edits {
+		kinds = append(kinds, e.Kind)
+		lines = append(lines, e.Line)
+		assert.Equal(t, "Pet", e.Old)
+		assert.Equal(t, "Animal", e.New)
+	}
+	assert.Equal(t, []int{19, 33, 44, 47}, lines)
+	assert.Equal(t, []RenameEditKind{RenameEditReference, RenameEditComponent, RenameEditReference,
+		RenameEditReference}, kinds)
+	assert.Equal(t, RenameEdit{Start: 121, End: 124, Line: 4, Column: 85, Old: "Pet", New: "Animal",
+		Kind: RenameEditReference}, *rename.Files[0].Edits[0])
+
+	assert.Equal(t, `--- a/models/pets.json
++++ b/models/pets.json
+@@ -4 +4 @@
+-      "Pets": {"type": "array", "items": {"$ref": "../root.yaml#/components/schemas/Pet"}}
++      "Pets": {"type": "array", "items": {"$ref": "../root.yaml#/components/schemas/Animal"}}
+--- a/root.yaml
++++ b/root.yaml
+@@ -19 +19 @@
+-                $ref: '#/components/schemas/Pet'
++                $ref: '#/components/schemas/Animal'
+@@ -33 +33 @@
+-    Pet: # every pet
++    Animal: # every pet
+@@ -44 +44 @@
+-          $ref: '#/components/schemas/Pet/properties/kind'
++          $ref: '#/components/schemas/Animal/properties/kind'
+@@ -47 +47 @@
+-        - $ref: '#/components/schemas/Pet'
++        - $ref: '#/components/schemas/Animal'
+`, rename.Preview)
+
+	// the renamed files are indexed again, and nothing points at the old name.
+	changes, err := rename.Changes()
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Contains(t, string(changes[1].Content), "    Animal: # every pet\n")
+	_, err = rolodex.UpdateFiles(context.Background(), changes...)
+	require.NoError(t, err)
+	assert.NotEmpty(t, rolodex.FindReferencesTo(root+"#/components/schemas/Animal"))
+	assert.Empty(t, rolodex.FindReferencesTo(root+"#/components/schemas/Pet"))
+}
+
+func TestRolodex_RenameComponent_Mappings(t *testing.T) {
+	rolodex, _ := buildRenameRolodex(t)
+
+	// a mapping to a schema by reference, and by name.
+	rename, err := rolodex.RenameComponent("#/components/schemas/Dog", "Hound")
+	require.NoError(t, err)
+	edits := rename.Files[0].Edits
+	require.Len(t, edits, 2)
+	assert.Equal(t, RenameEditMapping, edits[0].Kind)
+	assert.Equal(t, 38, edits[0].Line)
+	assert.Equal(t, RenameEditComponent, edits[1].Kind)
+
+	rename, err = rolodex.RenameComponent("#/components/schemas/Cat", "Feline")
+	require.NoError(t, err)
+	edits = rename.Files[0].Edits
+	require.Len(t, edits, 2)
+	assert.Equal(t, RenameEdit{Start: 765, End: 768, Line: 39, Column: 16, Old: "Cat", New: "Feline",
+		Kind: RenameEditMapping}, *edits[0])
+}
+
+func TestRolodex_RenameComponent_SecurityScheme(t *testing.T) {
+	rolodex, _ := buildRenameRolodex(t)
+
+	rename, err := rolodex.RenameComponent("#/components/securitySchemes/apiKey", "key_auth")
+	require.NoError(t, err)
+	require.Len(t, rename.Files, 1)
+	var kinds []RenameEditKind
+	for _, e := range rename.Files[0].Edits {
+		kinds = append(kinds, e.Kind)
+	}
+	assert.Equal(t, []RenameEditKind{RenameEditSecurity, RenameEditSecurity, RenameEditComponent}, kinds)
+	changes, err := rename.Changes()
+	require.NoError(t, err)
+	assert.Contains(t, string(changes[0].Content), "security:\n  - key_auth: []\n")
+	assert.Contains(t, string(changes[0].Content), "        - key_auth: []\n          oauth: []\n")
+}
+
+func TestRolodex_RenameComponent_Refused(t *testing.T) {
+	rolodex, dir := buildRenameRolodex(t)
+
+	_, err := rolodex.RenameComponent("#/components/schemas/Pet", "Dog")
+	assert.EqualError(t, err, "unable to rename component 'Pet', '"+filepath.Join(dir, "root.yaml")+
+		"#/components/schemas/Dog' already exists")
+
+	_, err = rolodex.RenameComponent("#/components/schemas/Pet", "Pet Shop")
+	assert.EqualError(t, err, "unable to rename component, 'Pet Shop' is not a valid component name")
+
+	_, err = rolodex.RenameComponent("#/components/schemas/Nope", "Yes")
+	assert.EqualError(t, err, "unable to rename component, '#/components/schemas/Nope' does not exist")
+
+	_, err = rolodex.RenameComponent("models/pets.json#/components/schemas/Pets", "Pets")
+	assert.Error(t, err)
+
+	// a component of another file, by a definition relative to the base path.
+	rename, err := rolodex.RenameComponent("models/pets.json#/components/schemas/Pets", "PetList")
+	require.NoError(t, err)
+	require.Len(t, rename.Files, 2)
+	assert.Equal(t, RenameEditComponent, rename.Files[0].Edits[0].Kind)
+	assert.Equal(t, 4, rename.Files[0].Edits[0].Line)
+	changes, err := rename.Changes()
+	require.NoError(t, err)
+	assert.Contains(t, string(changes[0].Content), `      "PetList": {"type": "array"`)
+	assert.Contains(t, string(changes[1].Content), `$ref: "models/pets.json#/components/schemas/PetList"`)
+}
+
+func TestSpecIndex_RenameComponent(t *testing.T) {
+	spec := []byte(renameRootSpec)
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal(spec, &root))
+	cfg := CreateClosedAPIIndexConfig()
+	cfg.SpecInfo = &datamodel.SpecInfo{SpecBytes: &spec}
+	idx := NewSpecIndexWithConfig(&root, cfg)
+
+	rename, err := idx.RenameComponent("#/components/schemas/Cat", "Feline")
+	require.NoError(t, err)
+	require.Len(t, rename.Files, 1)
+	changes, err := rename.Changes()
+	require.NoError(t, err)
+	assert.Contains(t, string(changes[0].Content), "          cat: Feline\n")
+	assert.Contains(t, string(changes[0].Content), "    Feline:\n      type: object\n")
+
+	// the content has changed since the rename was planned.
+	_, err = rename.Files[0].Apply([]byte("openapi: 3.1.0\n"))
+	assert.Error(t, err)
+
+	// without the content of the document, nothing can be planned.
+	idx = NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+	_, err = idx.RenameComponent("#/components/schemas/Cat", "Feline")
+	assert.ErrorContains(t, err, "is not available")
+}
@@ -0,0 +1,110 @@
+// Copyright 2023-2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// registerSchemaAnchorAt registers a $anchor or $dynamicAnchor declared in the mapping node against the
+// schema resource currently in scope (the nearest $id, or the document itself).
+func (index *SpecIndex) registerSchemaAnchorAt(node *yaml.Node, keyIndex int, seenPath []string, scope *SchemaIdScope) {
+	if underOpenAPIExamplePath(seenPath) || utils.IsNodeArray(node) {
+		return
+	}
+	if len(node.Content) <= keyIndex+1 || !utils.IsNodeStringValue(node.Content[keyIndex+1]) {
+		return
+	}
+
+	anchorNode := node.Content[keyIndex+1]
+	definitionPath := "#"
+	if len(seenPath) > 0 {
+		definitionPath = "#/" + strings.Join(seenPath, "/")
+	}
+
+	if err := ValidateSchemaAnchor(anchorNode.Value); err != nil {
+		index.errorLock.Lock()
+		index.refErrors = append(index.refErrors, &IndexingError{
+			Err:     fmt.Errorf("invalid %s value '%s': %w", node.Content[keyIndex].Value, anchorNode.Value, err),
+			Node:    anchorNode,
+			KeyNode: node.Content[keyIndex],
+			Path:    definitionPath,
+		})
+		index.errorLock.Unlock()
+		return
+	}
+
+	resourceUri := index.specAbsolutePath
+	if scope != nil && scope.BaseUri != "" {
+		resourceUri = scope.BaseUri
+	}
+
+	_ = index.RegisterSchemaAnchor(&SchemaAnchorEntry{
+		Name:           anchorNode.Value,
+		ResourceUri:    resourceUri,
+		Dynamic:        node.Content[keyIndex].Value == "$dynamicAnchor",
+		SchemaNode:     node,
+		Index:          index,
+		DefinitionPath: definitionPath,
+		Line:           anchorNode.Line,
+		Column:         anchorNode.Column,
+	})
+}
+
+// extractDynamicReferenceAt records a $dynamicRef. Dynamic references are kept apart from $ref because
+// their target depends on the dynamic scope at evaluation time, they are resolved on demand by the
+// resolver and the schema model via ResolveDynamicRef.
+func (index *SpecIndex) extractDynamicReferenceAt(node, parent *yaml.Node, keyIndex int, seenPath []string, scope *SchemaIdScope) {
+	if underOpenAPIExamplePayloadPath(seenPath) || utils.IsNodeArray(node) {
+		return
+	}
+	if len(node.Content) <= keyIndex+1 || !utils.IsNodeStringValue(node.Content[keyIndex+1]) {
+		return
+	}
+
+	keyNode := node.Content[keyIndex]
+	valueNode := node.Content[keyIndex+1]
+	value := valueNode.Value
+	if value == "" {
+		index.errorLock.Lock()
+		index.refErrors = append(index.refErrors, &IndexingError{
+			Err:     errors.New("dynamic schema reference is empty and cannot be processed"),
+			Node:    valueNode,
+			KeyNode: keyNode,
+			Path:    fmt.Sprintf("$.%s", strings.Join(seenPath, ".")),
+		})
+		index.errorLock.Unlock()
+		return
+	}
+
+	base := index.specAbsolutePath
+	schemaIdBase := ""
+	if scope != nil && scope.BaseUri != "" {
+		base = scope.BaseUri
+		if len(scope.Chain) > 0 {
+			schemaIdBase = scope.BaseUri
+		}
+	}
+
+	_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(value)
+	index.dynamicRefs = append(index.dynamicRefs, &Reference{
+		ParentNode:     parent,
+		FullDefinition: resolveRefWithSchemaBase(value, base),
+		Definition:     value,
+		RawRef:         value,
+		SchemaIdBase:   schemaIdBase,
+		Name:           value[strings.LastIndexAny(value, "/#")+1:],
+		Node:           node,
+		KeyNode:        valueNode,
+		Path:           path,
+		SourcePath:     append([]string(nil), seenPath...),
+		Index:          index,
+		IsDynamic:      true,
+	})
+}
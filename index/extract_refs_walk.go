@@ -144,6 +144,14 @@ func (index *SpecIndex) handleExtractRefsKey(
 		index.registerSchemaIDAt(node, keyIndex, state.seenPath, state.parentBaseURI)
 	}
 
+	if keyNode.Value == "$anchor" || keyNode.Value == "$dynamicAnchor" {
+		index.registerSchemaAnchorAt(node, keyIndex, state.seenPath, state.scope)
+	}
+
+	if keyNode.Value == "$dynamicRef" {
+		index.extractDynamicReferenceAt(node, parent, keyIndex, state.seenPath, state.scope)
+	}
+
 	if keyNode.Value != "$ref" && keyNode.Value != "$id" && keyNode.Value != "" {
 		action := index.extractNodeMetadata(node, parent, state.seenPath, keyIndex)
 		state.lastAppended = action.appendSegment
@@ -5,6 +5,7 @@ package index
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -45,6 +46,12 @@ func (index *SpecIndex) lookupRolodex(ctx context.Context, uri []string) *Refere
 		if rError != nil {
 			index.logger.Error("unable to open the rolodex file, check specification references and base path",
 				"file", absoluteFileLocation, "error", rError)
+			// a document refused by the remote policy is reported, not only logged.
+			if errors.Is(rError, ErrRemotePolicyViolation) {
+				index.errorLock.Lock()
+				index.refErrors = append(index.refErrors, &IndexingError{Err: rError, Path: absoluteFileLocation})
+				index.errorLock.Unlock()
+			}
 			return nil
 		}
 		if rFile == nil {
@@ -46,6 +46,7 @@ type Reference struct {
 	SiblingProperties     map[string]*yaml.Node `json:"-"`                            // stores sibling property nodes
 	SiblingKeys           []*yaml.Node          `json:"-"`                            // stores sibling key nodes
 	In                    string                `json:"-"`                            // parameter location (path, query, header, cookie) - cached for performance
+	IsDynamic             bool                  `json:"isDynamic,omitempty"`          // true if this reference was declared with $dynamicRef
 }
 
 // ReferenceMapped is a helper struct that pairs a mapped reference with its original definition key,
@@ -241,6 +242,16 @@ type SpecIndexConfig struct {
 	// values must NOT enable this. Defaults to false (everything is collected).
 	SkipMetadataCollection bool
 
+	// ResourceBudget sets hard limits on the resources used by the rolodex to index the specification and
+	// everything it references. The first limit exceeded aborts indexing, and the rolodex reports a
+	// *ResourceBudgetError naming it. Defaults to nil (no limits).
+	ResourceBudget *datamodel.ResourceBudget
+
+	// ReferenceCatalog maps canonical URLs to local files, directories or in-memory documents. The rolodex serves
+	// mapped documents from the catalog before any remote lookup, even when AllowRemoteLookup is false, and indexes
+	// them under their canonical URL. Defaults to nil (no catalog).
+	ReferenceCatalog *datamodel.ReferenceCatalog
+
 	// private fields
 	uri []string
 	id  string
@@ -294,6 +305,8 @@ func (s *SpecIndexConfig) ToDocumentConfiguration() *datamodel.DocumentConfigura
 		PropertyMergeStrategy:                 strategy,
 		SkipExternalRefResolution:             s.SkipExternalRefResolution,
 		SkipMetadataCollection:                s.SkipMetadataCollection,
+		ResourceBudget:                        s.ResourceBudget,
+		ReferenceCatalog:                      s.ReferenceCatalog,
 		Logger:                                s.Logger,
 	}
 }
@@ -436,8 +449,11 @@ type SpecIndex struct {
 	nodeMapCompleted                    chan struct{}
 	pendingResolve                      []refMap
 	highModelCache                      Cache
-	schemaIdRegistry                    map[string]*SchemaIdEntry // registry of $id declarations for JSON Schema 2020-12
-	schemaIdRegistryLock                sync.RWMutex              // lock for concurrent access to schemaIdRegistry
+	schemaIdRegistry                    map[string]*SchemaIdEntry     // registry of $id declarations for JSON Schema 2020-12
+	schemaIdRegistryLock                sync.RWMutex                  // lock for concurrent access to schemaIdRegistry
+	schemaAnchorRegistry                map[string]*SchemaAnchorEntry // registry of $anchor and $dynamicAnchor declarations
+	schemaAnchorRegistryLock            sync.RWMutex                  // lock for concurrent access to schemaAnchorRegistry
+	dynamicRefs                         []*Reference                  // every $dynamicRef found in the spec, in document order.
 }
 
 // GetResolver returns the resolver for this index.
@@ -600,6 +616,8 @@ func (index *SpecIndex) releaseDerivedState() {
 	index.cache = nil
 	index.highModelCache = nil
 	index.schemaIdRegistry = nil
+	index.schemaAnchorRegistry = nil
+	index.dynamicRefs = nil
 	index.pendingResolve = nil
 	index.uri = nil
 	index.logger = nil
@@ -0,0 +1,549 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// PositionObjectKind is the kind of OpenAPI object enclosing a position.
+type PositionObjectKind string
+
+const (
+	PositionDocument       PositionObjectKind = "document"
+	PositionPathItem       PositionObjectKind = "pathItem"
+	PositionOperation      PositionObjectKind = "operation"
+	PositionParameter      PositionObjectKind = "parameter"
+	PositionRequestBody    PositionObjectKind = "requestBody"
+	PositionResponse       PositionObjectKind = "response"
+	PositionMediaType      PositionObjectKind = "mediaType"
+	PositionHeader         PositionObjectKind = "header"
+	PositionEncoding       PositionObjectKind = "encoding"
+	PositionSchema         PositionObjectKind = "schema"
+	PositionProperty       PositionObjectKind = "property"
+	PositionExample        PositionObjectKind = "example"
+	PositionLink           PositionObjectKind = "link"
+	PositionCallback       PositionObjectKind = "callback"
+	PositionSecurityScheme PositionObjectKind = "securityScheme"
+)
+
+// PositionObject is an OpenAPI object enclosing a position.
+type PositionObject struct {
+	// Kind is the kind of object. A property is a schema declared under properties.
+	Kind PositionObjectKind `json:"kind"`
+
+	// Name identifies the object within its parent: a path, an HTTP method, a parameter, property or component
+	// name, a response code or a media type.
+	Name string `json:"name,omitempty"`
+
+	// Pointer is the JSON pointer of the object within its file.
+	Pointer string `json:"pointer"`
+
+	// KeyNode is the key the object is declared under, nil for array items and the document.
+	KeyNode *yaml.Node `json:"-"`
+
+	// Node is the object itself.
+	Node *yaml.Node `json:"-"`
+}
+
+// ReferenceTarget is the location a $ref resolves to.
+type ReferenceTarget struct {
+	// File is the absolute path or URL of the file holding the target, empty for an in-memory document.
+	File    string `json:"file,omitempty"`
+	Pointer string `json:"pointer"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+
+	// Node is the target node.
+	Node *yaml.Node `json:"-"`
+
+	// Index is the index holding the target.
+	Index *SpecIndex `json:"-"`
+}
+
+// PositionLookup describes what is under a position in a file, as returned by SpecIndex.LookupPosition and
+// Rolodex.LookupPosition. Lines and columns are 1-based, like those of yaml.Node. It works the same way for YAML
+// and JSON documents.
+type PositionLookup struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+
+	// Pointer is the JSON pointer of the innermost node enclosing the position.
+	Pointer string `json:"pointer"`
+
+	// Node is the innermost node enclosing the position. When the position is on a key, it is the value of that
+	// key.
+	Node *yaml.Node `json:"-"`
+
+	// KeyNode is the key of Node, nil for array items and the document.
+	KeyNode *yaml.Node `json:"-"`
+
+	// OnKey is true when the position is on KeyNode rather than within its value.
+	OnKey bool `json:"onKey,omitempty"`
+
+	// Objects are the OpenAPI objects enclosing the position, outermost first.
+	Objects []*PositionObject `json:"objects"`
+
+	// Reference is the $ref the position is on, nil if the position is not on a $ref key or value.
+	Reference *Reference `json:"-"`
+
+	// Target is where Reference resolves to, nil if it is not on a $ref or the $ref cannot be resolved.
+	Target *ReferenceTarget `json:"target,omitempty"`
+
+	// Index is the index of the file.
+	Index *SpecIndex `json:"-"`
+}
+
+// Object returns the innermost OpenAPI object enclosing the position, or nil if there is none.
+func (p *PositionLookup) Object() *PositionObject {
+	if len(p.Objects) == 0 {
+		return nil
+	}
+	return p.Objects[len(p.Objects)-1]
+}
+
+// LookupPosition returns what is under a line and column of the document held by this index. The document is
+// treated as an OpenAPI document. Returns nil if the index has no document.
+func (index *SpecIndex) LookupPosition(line, column int) *PositionLookup {
+	if index == nil || index.GetRootNode() == nil {
+		return nil
+	}
+	l := &positionLocator{pointers: make(map[*SpecIndex]map[*yaml.Node]string)}
+	return l.lookup(index, line, column)
+}
+
+// LookupPosition returns what is under a line and column of a file in the rolodex. A relative file is resolved
+// against the BasePath of the index configuration. The objects of a file other than the root document are
+// classified by the references pointing into it, so the properties of a schema held by its own file are found as
+// properties, not as the keys of a document.
+func (r *Rolodex) LookupPosition(file string, line, column int) (*PositionLookup, error) {
+	idx := r.indexForFile(file)
+	if idx == nil {
+		return nil, fmt.Errorf("unable to lookup position, file '%s' is not indexed by the rolodex", file)
+	}
+	l := &positionLocator{
+		rolodex:  r,
+		pointers: make(map[*SpecIndex]map[*yaml.Node]string),
+	}
+	return l.lookup(idx, line, column), nil
+}
+
+// indexForFile returns the index of a file in the rolodex, or nil if the file has not been indexed.
+func (r *Rolodex) indexForFile(file string) *SpecIndex {
+	abs := r.absoluteFilePath(file)
+	for _, idx := range append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...) {
+		if idx != nil && (idx.specAbsolutePath == file || idx.specAbsolutePath == abs) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// positionLocator looks up positions, caching the JSON pointers of the files it visits.
+type positionLocator struct {
+	rolodex  *Rolodex
+	pointers map[*SpecIndex]map[*yaml.Node]string
+}
+
+func (l *positionLocator) lookup(idx *SpecIndex, line, column int) *PositionLookup {
+	p := &PositionLookup{
+		File:    idx.GetSpecAbsolutePath(),
+		Line:    line,
+		Column:  column,
+		Objects: []*PositionObject{},
+		Index:   idx,
+	}
+	path, nodes, keys := enclosingNodes(idx.GetRootNode(), line, column)
+	last := len(nodes) - 1
+	p.Pointer = positionPointer(path)
+	p.Node, p.KeyNode = nodes[last], keys[last]
+	p.OnKey = p.KeyNode != nil && onScalar(p.KeyNode, line, column)
+	p.Objects = l.classify(idx, path, nodes, keys, make(map[*SpecIndex]bool))
+
+	if last > 0 && path[last-1] == "$ref" {
+		p.Reference = refDeclaredBy(idx, nodes[last-1])
+		p.Target = resolveReferenceTarget(idx, p.Reference)
+	}
+	return p
+}
+
+// classify returns the OpenAPI objects along a path. The root of the root document is the OpenAPI document; the
+// root of any other file is whatever the most specific reference pointing into it refers to.
+func (l *positionLocator) classify(idx *SpecIndex, path []string, nodes, keys []*yaml.Node,
+	visited map[*SpecIndex]bool,
+) []*PositionObject {
+	objects := []*PositionObject{}
+	kind, start := PositionDocument, 0
+	if l.rolodex != nil && idx != l.rolodex.GetRootIndex() {
+		if k, depth := l.referencedKind(idx, path, visited); k != "" {
+			kind, start = k, depth
+		}
+	}
+	if kind == PositionDocument && start == 0 {
+		objects = append(objects, &PositionObject{Kind: PositionDocument, Pointer: "", Node: nodes[0]})
+	} else {
+		objects = append(objects, newPositionObject(kind, path, nodes, keys, start))
+	}
+	for i := start; i < len(path); {
+		next, consumed := positionChildKind(kind, path[i:])
+		if next == "" {
+			break
+		}
+		i += consumed
+		kind = next
+		objects = append(objects, newPositionObject(kind, path, nodes, keys, i))
+	}
+	return objects
+}
+
+// referencedKind finds the most specific reference pointing at the path, or at one of its parents, and returns the
+// kind of object it refers to, along with the depth of the path the reference points to.
+func (l *positionLocator) referencedKind(idx *SpecIndex, path []string, visited map[*SpecIndex]bool) (PositionObjectKind, int) {
+	if visited[idx] {
+		return "", 0
+	}
+	visited[idx] = true
+	defer delete(visited, idx)
+
+	file := idx.GetSpecAbsolutePath()
+	bestKind, bestDepth := PositionObjectKind(""), -1
+	for _, source := range append([]*SpecIndex{l.rolodex.GetRootIndex()}, l.rolodex.GetIndexes()...) {
+		if source == nil || source == idx {
+			continue
+		}
+		for _, ref := range source.GetRawReferencesSequenced() {
+			target, pointer := splitFullDefinition(ref.FullDefinition)
+			if target != file {
+				continue
+			}
+			segments := pointerSegments(pointer)
+			if len(segments) <= bestDepth || !hasPathPrefix(path, segments) {
+				continue
+			}
+			sitePointer, ok := l.pointersFor(source)[ref.Node]
+			if !ok {
+				continue
+			}
+			sitePath := pointerSegments(sitePointer)
+			siteNodes, siteKeys := nodesAlong(source.GetRootNode(), sitePath)
+			objects := l.classify(source, sitePath, siteNodes, siteKeys, visited)
+			if site := objects[len(objects)-1]; site.Pointer == sitePointer {
+				bestKind, bestDepth = site.Kind, len(segments)
+			}
+		}
+	}
+	return bestKind, max(bestDepth, 0)
+}
+
+func (l *positionLocator) pointersFor(idx *SpecIndex) map[*yaml.Node]string {
+	if p, ok := l.pointers[idx]; ok {
+		return p
+	}
+	p := jsonPointers(idx.GetRootNode())
+	l.pointers[idx] = p
+	return p
+}
+
+// positionChildKind returns the kind of OpenAPI object found by following path from an object of the given kind,
+// along with the number of path segments it takes. An empty kind is returned if path does not lead to an object.
+func positionChildKind(kind PositionObjectKind, path []string) (PositionObjectKind, int) {
+	segment := path[0]
+	named := func(k PositionObjectKind) (PositionObjectKind, int) {
+		if len(path) < 2 {
+			return "", 0
+		}
+		return k, 2
+	}
+	switch kind {
+	case PositionDocument:
+		switch segment {
+		case "paths", "webhooks":
+			return named(PositionPathItem)
+		case "definitions":
+			return named(PositionSchema)
+		case "parameters":
+			return named(PositionParameter)
+		case "responses":
+			return named(PositionResponse)
+		case "components":
+			if len(path) < 3 {
+				return "", 0
+			}
+			if k, ok := componentPositionKinds[path[1]]; ok {
+				return k, 3
+			}
+		}
+	case PositionPathItem:
+		switch {
+		case segment == "parameters":
+			return named(PositionParameter)
+		case segment == "additionalOperations":
+			return named(PositionOperation)
+		case utils.IsHttpVerb(segment) || segment == "query":
+			return PositionOperation, 1
+		}
+	case PositionOperation:
+		switch segment {
+		case "parameters":
+			return named(PositionParameter)
+		case "requestBody":
+			return PositionRequestBody, 1
+		case "responses":
+			return named(PositionResponse)
+		case "callbacks":
+			return named(PositionCallback)
+		}
+	case PositionCallback:
+		return PositionPathItem, 1
+	case PositionParameter, PositionHeader:
+		switch segment {
+		case "schema":
+			return PositionSchema, 1
+		case "content":
+			return named(PositionMediaType)
+		case "examples":
+			return named(PositionExample)
+		}
+	case PositionRequestBody:
+		if segment == "content" {
+			return named(PositionMediaType)
+		}
+	case PositionResponse:
+		switch segment {
+		case "schema":
+			return PositionSchema, 1
+		case "headers":
+			return named(PositionHeader)
+		case "content":
+			return named(PositionMediaType)
+		case "links":
+			return named(PositionLink)
+		}
+	case PositionMediaType:
+		switch segment {
+		case "schema", "itemSchema":
+			return PositionSchema, 1
+		case "examples":
+			return named(PositionExample)
+		case "encoding":
+			return named(PositionEncoding)
+		}
+	case PositionEncoding:
+		if segment == "headers" {
+			return named(PositionHeader)
+		}
+	case PositionSchema, PositionProperty:
+		switch segment {
+		case "properties":
+			return named(PositionProperty)
+		case "patternProperties", "dependentSchemas", "$defs", "definitions",
+			"allOf", "oneOf", "anyOf", "prefixItems":
+			return named(PositionSchema)
+		case "items", "not", "additionalProperties", "additionalItems", "contains", "if", "then", "else",
+			"propertyNames", "unevaluatedItems", "unevaluatedProperties", "contentSchema":
+			return PositionSchema, 1
+		}
+	}
+	return "", 0
+}
+
+var componentPositionKinds = map[string]PositionObjectKind{
+	"schemas":         PositionSchema,
+	"parameters":      PositionParameter,
+	"requestBodies":   PositionRequestBody,
+	"responses":       PositionResponse,
+	"headers":         PositionHeader,
+	"examples":        PositionExample,
+	"links":           PositionLink,
+	"callbacks":       PositionCallback,
+	"securitySchemes": PositionSecurityScheme,
+	"pathItems":       PositionPathItem,
+	"mediaTypes":      PositionMediaType,
+}
+
+// newPositionObject creates the object found at the given depth of a path.
+func newPositionObject(kind PositionObjectKind, path []string, nodes, keys []*yaml.Node, depth int) *PositionObject {
+	o := &PositionObject{
+		Kind:    kind,
+		Pointer: positionPointer(path[:depth]),
+		KeyNode: keys[depth],
+		Node:    nodes[depth],
+	}
+	if depth > 0 {
+		o.Name = path[depth-1]
+	}
+	if kind == PositionParameter && keys[depth] == nil {
+		if _, name := utils.FindKeyNodeTop("name", contentOf(o.Node)); name != nil {
+			o.Name = name.Value
+		}
+	}
+	return o
+}
+
+// enclosingNodes walks down from the root to the innermost node enclosing a position. It returns the path to that
+// node, the nodes along it, starting with the root, and the key of each node.
+func enclosingNodes(root *yaml.Node, line, column int) ([]string, []*yaml.Node, []*yaml.Node) {
+	node := rootMapNode(root)
+	path, nodes, keys := []string{}, []*yaml.Node{node}, []*yaml.Node{nil}
+	for node != nil {
+		switch node.Kind {
+		case yaml.MappingNode:
+			i := lastBefore(node.Content, 2, line, column)
+			if i < 0 {
+				return path, nodes, keys
+			}
+			key, value := node.Content[i], node.Content[i+1]
+			path, nodes, keys = append(path, key.Value), append(nodes, value), append(keys, key)
+			if onScalar(key, line, column) || positionBefore(line, column, value) {
+				return path, nodes, keys
+			}
+			node = value
+		case yaml.SequenceNode:
+			i := lastBefore(node.Content, 1, line, column)
+			if i < 0 {
+				return path, nodes, keys
+			}
+			path, nodes, keys = append(path, strconv.Itoa(i)), append(nodes, node.Content[i]), append(keys, nil)
+			node = node.Content[i]
+		default:
+			return path, nodes, keys
+		}
+	}
+	return path, nodes, keys
+}
+
+// nodesAlong returns the nodes along a path, and the key of each node.
+func nodesAlong(root *yaml.Node, path []string) ([]*yaml.Node, []*yaml.Node) {
+	node := rootMapNode(root)
+	nodes, keys := []*yaml.Node{node}, []*yaml.Node{nil}
+	for _, segment := range path {
+		var key, next *yaml.Node
+		switch {
+		case node == nil:
+		case node.Kind == yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					key, next = node.Content[i], node.Content[i+1]
+					break
+				}
+			}
+		case node.Kind == yaml.SequenceNode:
+			if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		node = next
+		nodes, keys = append(nodes, node), append(keys, key)
+	}
+	return nodes, keys
+}
+
+// lastBefore returns the index of the last of every step-th node of content starting at or before a position, or
+// -1 if they all start after it.
+func lastBefore(content []*yaml.Node, step, line, column int) int {
+	found := -1
+	for i := 0; i < len(content); i += step {
+		if positionBefore(line, column, content[i]) {
+			break
+		}
+		found = i
+	}
+	return found
+}
+
+// positionBefore returns true if a position comes before the start of a node.
+func positionBefore(line, column int, node *yaml.Node) bool {
+	return line < node.Line || (line == node.Line && column < node.Column)
+}
+
+// onScalar returns true if a position is within the text of a single line scalar, including any quotes.
+func onScalar(node *yaml.Node, line, column int) bool {
+	if node.Kind != yaml.ScalarNode || line != node.Line {
+		return false
+	}
+	width := len(node.Value)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		width += 2
+	}
+	return column >= node.Column && column < node.Column+max(width, 1)
+}
+
+// refDeclaredBy returns the reference declared by a $ref object.
+func refDeclaredBy(idx *SpecIndex, node *yaml.Node) *Reference {
+	for _, ref := range idx.GetRawReferencesSequenced() {
+		if ref.Node == node {
+			return ref
+		}
+	}
+	return nil
+}
+
+// resolveReferenceTarget returns where a reference resolves to, or nil if it cannot be resolved.
+func resolveReferenceTarget(idx *SpecIndex, ref *Reference) *ReferenceTarget {
+	if ref == nil {
+		return nil
+	}
+	found, foundIdx := idx.SearchIndexForReferenceByReference(ref)
+	if found == nil || found.Node == nil {
+		return nil
+	}
+	file, pointer := splitFullDefinition(found.FullDefinition)
+	if foundIdx == nil {
+		foundIdx = found.Index
+	}
+	if foundIdx != nil && foundIdx.GetSpecAbsolutePath() != "" {
+		file = foundIdx.GetSpecAbsolutePath()
+	}
+	node := found.Node
+	if node.Kind == yaml.DocumentNode {
+		node = rootMapNode(node)
+	}
+	return &ReferenceTarget{
+		File:    file,
+		Pointer: pointer,
+		Line:    node.Line,
+		Column:  node.Column,
+		Node:    node,
+		Index:   foundIdx,
+	}
+}
+
+func positionPointer(path []string) string {
+	var b strings.Builder
+	for _, segment := range path {
+		b.WriteByte('/')
+		b.WriteString(escapePointerSegment(segment))
+	}
+	return b.String()
+}
+
+func pointerSegments(pointer string) []string {
+	pointer = strings.TrimPrefix(pointer, "/")
+	if pointer == "" {
+		return nil
+	}
+	segments := strings.Split(pointer, "/")
+	for i, s := range segments {
+		segments[i] = strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	return segments
+}
+
+func hasPathPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+const positionSpec = `openapi: 3.1.0
+info:
+  title: position
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+const positionJSONSpec = `{
+  "openapi": "3.1.0",
+  "paths": {
+    "/pets": {
+      "post": {
+        "parameters": [{"name": "limit", "in": "query"}],
+        "responses": {"200": {"$ref": "#/components/responses/Ok"}}
+      }
+    }
+  },
+  "components": {"responses": {"Ok": {"description": "ok"}}}
+}`
+
+func positionKinds(p *PositionLookup) []string {
+	var kinds []string
+	for _, o := range p.Objects {
+		kinds = append(kinds, string(o.Kind)+" "+o.Name)
+	}
+	return kinds
+}
+
+func TestSpecIndex_LookupPosition(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(positionSpec), &root))
+	idx := NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+
+	// the value of the parameter schema type.
+	p := idx.LookupPosition(12, 19)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/parameters/0/schema/type", p.Pointer)
+	assert.Equal(t, "string", p.Node.Value)
+	assert.False(t, p.OnKey)
+	assert.Equal(t, []string{"document ", "pathItem /pets/{id}", "operation get", "parameter id", "schema schema"},
+		positionKinds(p))
+	assert.Nil(t, p.Reference)
+
+	// the key of a schema property.
+	p = idx.LookupPosition(25, 11)
+	assert.True(t, p.OnKey)
+	assert.Equal(t, "/components/schemas/Pet/properties/name", p.Pointer)
+	assert.Equal(t, PositionProperty, p.Object().Kind)
+	assert.Equal(t, "name", p.Object().Name)
+	assert.Equal(t, 26, p.Object().Node.Line)
+
+	// the value of a $ref resolves to its target.
+	p = idx.LookupPosition(19, 26)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/$ref", p.Pointer)
+	assert.Equal(t, PositionSchema, p.Object().Kind)
+	assert.NotNil(t, p.Reference)
+	assert.Equal(t, "/components/schemas/Pet", p.Target.Pointer)
+	assert.Equal(t, 23, p.Target.Line)
+
+	// before anything, the document itself.
+	p = idx.LookupPosition(0, 0)
+	assert.Equal(t, "", p.Pointer)
+	assert.Equal(t, []string{"document "}, positionKinds(p))
+}
+
+func TestSpecIndex_LookupPosition_JSON(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(positionJSONSpec), &root))
+	idx := NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+
+	p := idx.LookupPosition(6, 33)
+	assert.Equal(t, "/paths/~1pets/post/parameters/0/name", p.Pointer)
+	assert.Equal(t, "limit", p.Node.Value)
+	assert.Equal(t, "parameter limit", positionKinds(p)[3])
+
+	p = idx.LookupPosition(6, 26)
+	assert.True(t, p.OnKey)
+	assert.Equal(t, "/paths/~1pets/post/parameters/0/name", p.Pointer)
+
+	p = idx.LookupPosition(7, 32)
+	assert.True(t, p.OnKey)
+	assert.Equal(t, "/paths/~1pets/post/responses/200/$ref", p.Pointer)
+	assert.Equal(t, "response 200", positionKinds(p)[3])
+	assert.Equal(t, "/components/responses/Ok", p.Target.Pointer)
+	assert.Equal(t, 11, p.Target.Line)
+}
+
+func TestRolodex_LookupPosition(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+
+	// pet.yaml is a schema referenced as the items of Pets, its owner is a property.
+	p, err := rolodex.LookupPosition("pet.yaml", 3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "pet.yaml"), p.File)
+	assert.Equal(t, []string{"schema ", "property owner"}, positionKinds(p))
+
+	p, err = rolodex.LookupPosition(filepath.Join(dir, "pet.yaml"), 4, 15)
+	assert.NoError(t, err)
+	assert.NotNil(t, p.Reference)
+	assert.Equal(t, filepath.Join(dir, "shared.yaml"), p.Target.File)
+	assert.Equal(t, "/components/schemas/Owner", p.Target.Pointer)
+	assert.Equal(t, 4, p.Target.Line)
+
+	// shared.yaml is only referenced by component, it classifies as the referenced schema.
+	p, err = rolodex.LookupPosition("shared.yaml", 7, 17)
+	assert.NoError(t, err)
+	assert.Equal(t, "/components/schemas/Owner/properties/name/type", p.Pointer)
+	assert.Equal(t, []string{"schema Owner", "property name"}, positionKinds(p))
+
+	_, err = rolodex.LookupPosition("nope.yaml", 1, 1)
+	assert.Error(t, err)
+}
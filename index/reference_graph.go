@@ -0,0 +1,405 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// ErrUnknownReferenceGraphFocus is returned when the Focus of ReferenceGraphOptions matches no node of the graph.
+var ErrUnknownReferenceGraphFocus = errors.New("reference graph focus matches no node")
+
+// ReferenceGraphNodeKind is the kind of object a ReferenceGraphNode represents.
+type ReferenceGraphNodeKind string
+
+const (
+	// ReferenceGraphComponent is a reusable component, for example #/components/schemas/Pet, #/definitions/Pet
+	// or #/$defs/Pet.
+	ReferenceGraphComponent ReferenceGraphNodeKind = "component"
+
+	// ReferenceGraphOperation is an operation under paths or webhooks.
+	ReferenceGraphOperation ReferenceGraphNodeKind = "operation"
+
+	// ReferenceGraphPathItem is a path item, used for references that sit on the path item itself, for example
+	// path level parameters, or a path item that is a $ref.
+	ReferenceGraphPathItem ReferenceGraphNodeKind = "pathItem"
+
+	// ReferenceGraphFile is a whole file, used for references to a file without a fragment, and for references
+	// that sit outside any component or operation.
+	ReferenceGraphFile ReferenceGraphNodeKind = "file"
+)
+
+// ReferenceGraphNode is a component, operation, path item or file in a ReferenceGraph.
+type ReferenceGraphNode struct {
+	// Id is the full definition of the node, for example /specs/openapi.yaml#/components/schemas/Pet, or the path
+	// of the file for file nodes.
+	Id string `json:"id"`
+
+	// Kind is the kind of object the node represents.
+	Kind ReferenceGraphNodeKind `json:"kind"`
+
+	// Label is a short, human-readable name: the component name, the method and path of an operation, or the
+	// file name.
+	Label string `json:"label"`
+
+	// File is the path of the file the node lives in.
+	File string `json:"file"`
+
+	// Pointer is the JSON pointer of the node within its file, empty for file nodes.
+	Pointer string `json:"pointer,omitempty"`
+
+	// Method is the lower case HTTP method of an operation.
+	Method string `json:"method,omitempty"`
+
+	// Path is the path (or webhook name) of an operation or path item.
+	Path string `json:"path,omitempty"`
+
+	// Webhook is true for operations and path items under webhooks.
+	Webhook bool `json:"webhook,omitempty"`
+
+	// Circular is true when the node is part of a circular reference and cycles are highlighted.
+	Circular bool `json:"circular,omitempty"`
+}
+
+// ReferenceGraphEdge is a $ref (or $dynamicRef) from one node to another. Every reference between the same two
+// nodes, with the same polymorphic keyword, is folded into a single edge.
+type ReferenceGraphEdge struct {
+	// From is the Id of the node containing the references.
+	From string `json:"from"`
+
+	// To is the Id of the node being referenced.
+	To string `json:"to"`
+
+	// Polymorphic is the keyword (allOf, oneOf or anyOf) the references sit under, empty for plain references.
+	Polymorphic string `json:"polymorphic,omitempty"`
+
+	// Dynamic is true when the references were declared with $dynamicRef.
+	Dynamic bool `json:"dynamic,omitempty"`
+
+	// Circular is true when the edge is part of a circular reference and cycles are highlighted.
+	Circular bool `json:"circular,omitempty"`
+
+	// Count is the number of references folded into the edge.
+	Count int `json:"count"`
+
+	// References are the references folded into the edge, in the order they were found.
+	References []*Reference `json:"-"`
+}
+
+// ReferenceGraphOptions filters and decorates a ReferenceGraph.
+type ReferenceGraphOptions struct {
+	// Focus limits the graph to the neighbourhood of a single node. It can be a full definition
+	// (/specs/openapi.yaml#/components/schemas/Pet), a definition in any file (#/components/schemas/Pet), or the
+	// label of a node (Pet, GET /pets). Building the graph fails with ErrUnknownReferenceGraphFocus when no node
+	// matches, including a node removed by CyclesOnly.
+	Focus string
+
+	// Depth is the number of edges, followed in either direction, that are kept around the Focus node.
+	// Defaults to 1, a negative value keeps everything reachable.
+	Depth int
+
+	// HighlightCycles marks the nodes and edges that are part of circular references found by the resolver.
+	HighlightCycles bool
+
+	// CyclesOnly keeps only the nodes and edges that are part of circular references. Implies HighlightCycles.
+	CyclesOnly bool
+}
+
+// ReferenceGraph is a directed graph of the references between the components, operations and files of a
+// specification. Nodes and edges are sorted by Id, so the graph and its renderings are deterministic.
+type ReferenceGraph struct {
+	Nodes []*ReferenceGraphNode `json:"nodes"`
+	Edges []*ReferenceGraphEdge `json:"edges"`
+
+	nodes map[string]*ReferenceGraphNode
+}
+
+// GetNode returns the node with the given Id, or nil.
+func (g *ReferenceGraph) GetNode(id string) *ReferenceGraphNode {
+	if g == nil {
+		return nil
+	}
+	return g.nodes[id]
+}
+
+// FindNode returns the node matching a full definition, a definition in any file or a label, or nil. When
+// several nodes match a definition or label, nodes in the first file (in sorted order) win.
+func (g *ReferenceGraph) FindNode(query string) *ReferenceGraphNode {
+	if g == nil || query == "" {
+		return nil
+	}
+	if n := g.nodes[query]; n != nil {
+		return n
+	}
+	if pointer, ok := strings.CutPrefix(query, "#"); ok {
+		for _, n := range g.Nodes {
+			if n.Pointer == pointer {
+				return n
+			}
+		}
+	}
+	for _, n := range g.Nodes {
+		if n.Label == query {
+			return n
+		}
+	}
+	return nil
+}
+
+// BuildReferenceGraph builds a graph of every reference held by this index, and the references leaving it into
+// other files. An error is returned when the Focus of the options matches no node.
+func (index *SpecIndex) BuildReferenceGraph(options *ReferenceGraphOptions) (*ReferenceGraph, error) {
+	var circular []*CircularReferenceResult
+	circular = append(circular, index.GetCircularReferences()...)
+	circular = append(circular, index.GetIgnoredPolymorphicCircularReferences()...)
+	circular = append(circular, index.GetIgnoredArrayCircularReferences()...)
+	if index.resolver != nil {
+		circular = append(circular, index.resolver.GetSafeCircularReferences()...)
+	}
+	return focusReferenceGraph(buildReferenceGraph([]*SpecIndex{index}, circular, options), options)
+}
+
+// BuildReferenceGraph builds a graph of every reference held by the root index and every file in the rolodex. An
+// error is returned when the Focus of the options matches no node.
+func (r *Rolodex) BuildReferenceGraph(options *ReferenceGraphOptions) (*ReferenceGraph, error) {
+	indexes := []*SpecIndex{r.GetRootIndex()}
+	indexes = append(indexes, r.GetIndexes()...)
+	var circular []*CircularReferenceResult
+	for _, idx := range indexes {
+		if idx == nil {
+			continue
+		}
+		circular = append(circular, idx.GetCircularReferences()...)
+		circular = append(circular, idx.GetIgnoredPolymorphicCircularReferences()...)
+		circular = append(circular, idx.GetIgnoredArrayCircularReferences()...)
+	}
+	circular = append(circular, r.GetIgnoredCircularReferences()...)
+	circular = append(circular, r.GetSafeCircularReferences()...)
+	circular = append(circular, r.infiniteCircularReferences...)
+	return focusReferenceGraph(buildReferenceGraph(indexes, circular, options), options)
+}
+
+func buildReferenceGraph(indexes []*SpecIndex, circular []*CircularReferenceResult,
+	options *ReferenceGraphOptions,
+) *ReferenceGraph {
+	if options == nil {
+		options = &ReferenceGraphOptions{}
+	}
+	g := &ReferenceGraph{
+		Nodes: []*ReferenceGraphNode{},
+		Edges: []*ReferenceGraphEdge{},
+		nodes: make(map[string]*ReferenceGraphNode),
+	}
+	edges := make(map[string]*ReferenceGraphEdge)
+
+	for _, idx := range indexes {
+		if idx == nil {
+			continue
+		}
+		refs := append([]*Reference(nil), idx.GetRawReferencesSequenced()...)
+		refs = append(refs, idx.GetAllDynamicReferences()...)
+		for _, ref := range refs {
+			if ref == nil || ref.FullDefinition == "" {
+				continue
+			}
+			from := g.addNode(idx.specAbsolutePath, "/"+strings.Join(ref.SourcePath, "/"))
+			to := g.addNode(splitFullDefinition(ref.FullDefinition))
+			poly := polymorphicKeyword(ref.SourcePath)
+			key := from.Id + "\x00" + to.Id + "\x00" + poly
+			if ref.IsDynamic {
+				key += "\x00dynamic"
+			}
+			e := edges[key]
+			if e == nil {
+				e = &ReferenceGraphEdge{From: from.Id, To: to.Id, Polymorphic: poly, Dynamic: ref.IsDynamic}
+				edges[key] = e
+			}
+			e.Count++
+			e.References = append(e.References, ref)
+		}
+	}
+	for _, k := range sortedKeys(edges) {
+		g.Edges = append(g.Edges, edges[k])
+	}
+
+	if options.HighlightCycles || options.CyclesOnly {
+		g.markCycles(circular)
+	}
+	if options.CyclesOnly {
+		g.retain(func(n *ReferenceGraphNode) bool { return n.Circular }, func(e *ReferenceGraphEdge) bool { return e.Circular })
+	}
+	g.sortNodes()
+	return g
+}
+
+// focusReferenceGraph limits a graph to the neighbourhood of the Focus node of the options, if there is one.
+func focusReferenceGraph(g *ReferenceGraph, options *ReferenceGraphOptions) (*ReferenceGraph, error) {
+	if options == nil || options.Focus == "" {
+		return g, nil
+	}
+	focus := g.FindNode(options.Focus)
+	if focus == nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownReferenceGraphFocus, options.Focus)
+	}
+	keep := g.neighbourhood(focus.Id, options.Depth)
+	g.retain(func(n *ReferenceGraphNode) bool { return keep[n.Id] },
+		func(e *ReferenceGraphEdge) bool { return keep[e.From] && keep[e.To] })
+	return g, nil
+}
+
+// addNode returns the node that owns a JSON pointer in a file, creating it when needed. References inside a
+// component or operation belong to it, anything else belongs to the file.
+func (g *ReferenceGraph) addNode(file, pointer string) *ReferenceGraphNode {
+	n := referenceGraphNodeFor(file, pointer)
+	if existing := g.nodes[n.Id]; existing != nil {
+		return existing
+	}
+	g.nodes[n.Id] = n
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+func referenceGraphNodeFor(file, pointer string) *ReferenceGraphNode {
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	unescape := func(s string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+	}
+	node := func(kind ReferenceGraphNodeKind, depth int) *ReferenceGraphNode {
+		p := "/" + strings.Join(segments[:depth], "/")
+		return &ReferenceGraphNode{
+			Id:      joinFullDefinition(file, p),
+			Kind:    kind,
+			Label:   unescape(segments[depth-1]),
+			File:    file,
+			Pointer: p,
+		}
+	}
+	switch {
+	case len(segments) >= 3 && segments[0] == "components":
+		return node(ReferenceGraphComponent, 3)
+	case len(segments) >= 2 && (segments[0] == "paths" || segments[0] == "webhooks"):
+		path := unescape(segments[1])
+		webhook := segments[0] == "webhooks"
+		if len(segments) >= 3 && utils.IsHttpVerb(strings.ToLower(segments[2])) {
+			n := node(ReferenceGraphOperation, 3)
+			n.Method, n.Path, n.Webhook = strings.ToLower(segments[2]), path, webhook
+			n.Label = strings.ToUpper(n.Method) + " " + path
+			return n
+		}
+		n := node(ReferenceGraphPathItem, 2)
+		n.Path, n.Webhook = path, webhook
+		return n
+	case len(segments) >= 2:
+		switch segments[0] {
+		case "definitions", "parameters", "responses", "securityDefinitions", "$defs":
+			return node(ReferenceGraphComponent, 2)
+		}
+	}
+	label := file
+	if i := strings.LastIndexAny(file, `/\`); i >= 0 && i < len(file)-1 {
+		label = file[i+1:]
+	}
+	return &ReferenceGraphNode{Id: file, Kind: ReferenceGraphFile, Label: label, File: file}
+}
+
+// polymorphicKeyword returns allOf, oneOf or anyOf when a reference is a direct member of that keyword.
+func polymorphicKeyword(sourcePath []string) string {
+	n := len(sourcePath)
+	if n == 0 {
+		return ""
+	}
+	if n > 1 && (sourcePath[n-2] == "properties" || sourcePath[n-2] == "patternProperties") {
+		return ""
+	}
+	switch sourcePath[n-1] {
+	case "allOf", "oneOf", "anyOf":
+		return sourcePath[n-1]
+	}
+	return ""
+}
+
+// markCycles marks every node and edge that makes up the loop of a circular reference result.
+func (g *ReferenceGraph) markCycles(circular []*CircularReferenceResult) {
+	byEnds := make(map[string][]*ReferenceGraphEdge)
+	for _, e := range g.Edges {
+		byEnds[e.From+"\x00"+e.To] = append(byEnds[e.From+"\x00"+e.To], e)
+	}
+	for _, c := range circular {
+		if c == nil || c.LoopIndex < 0 || c.LoopIndex >= len(c.Journey) {
+			continue
+		}
+		loop := c.Journey[c.LoopIndex:]
+		for i, ref := range loop {
+			if ref == nil {
+				continue
+			}
+			id := referenceGraphNodeFor(splitFullDefinition(ref.FullDefinition)).Id
+			if n := g.nodes[id]; n != nil {
+				n.Circular = true
+			}
+			if i+1 >= len(loop) || loop[i+1] == nil {
+				continue
+			}
+			next := referenceGraphNodeFor(splitFullDefinition(loop[i+1].FullDefinition)).Id
+			for _, e := range byEnds[id+"\x00"+next] {
+				e.Circular = true
+			}
+		}
+	}
+}
+
+// neighbourhood returns the Ids of the nodes within depth edges of a node, following edges in both directions.
+func (g *ReferenceGraph) neighbourhood(id string, depth int) map[string]bool {
+	if depth == 0 {
+		depth = 1
+	}
+	adjacent := make(map[string][]string)
+	for _, e := range g.Edges {
+		adjacent[e.From] = append(adjacent[e.From], e.To)
+		adjacent[e.To] = append(adjacent[e.To], e.From)
+	}
+	seen := map[string]bool{id: true}
+	frontier := []string{id}
+	for level := 0; len(frontier) > 0 && (depth < 0 || level < depth); level++ {
+		var next []string
+		for _, n := range frontier {
+			for _, a := range adjacent[n] {
+				if !seen[a] {
+					seen[a] = true
+					next = append(next, a)
+				}
+			}
+		}
+		frontier = next
+	}
+	return seen
+}
+
+func (g *ReferenceGraph) retain(keepNode func(*ReferenceGraphNode) bool, keepEdge func(*ReferenceGraphEdge) bool) {
+	nodes := g.Nodes[:0]
+	for _, n := range g.Nodes {
+		if keepNode(n) {
+			nodes = append(nodes, n)
+		} else {
+			delete(g.nodes, n.Id)
+		}
+	}
+	g.Nodes = nodes
+	edges := g.Edges[:0]
+	for _, e := range g.Edges {
+		if keepEdge(e) {
+			edges = append(edges, e)
+		}
+	}
+	g.Edges = edges
+}
+
+func (g *ReferenceGraph) sortNodes() {
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].Id < g.Nodes[j].Id })
+}
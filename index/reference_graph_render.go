@@ -0,0 +1,170 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RenderJSON renders the graph as indented JSON.
+func (g *ReferenceGraph) RenderJSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
+
+// RenderDOT renders the graph in the Graphviz DOT language. Nodes are clustered by file, operations are drawn as
+// ellipses, files as notes and components as boxes. Polymorphic edges are dashed and labelled with their keyword,
+// circular nodes and edges are drawn in red.
+func (g *ReferenceGraph) RenderDOT() string {
+	ids, labels := g.renderIds(), g.fileLabels()
+	var b strings.Builder
+	b.WriteString("digraph references {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"Helvetica\"];\n")
+	for i, file := range g.files() {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(labels[file]))
+		for _, n := range g.Nodes {
+			if n.File != file {
+				continue
+			}
+			attrs := []string{"label=" + dotQuote(n.Label)}
+			switch n.Kind {
+			case ReferenceGraphOperation:
+				attrs = append(attrs, "shape=ellipse")
+			case ReferenceGraphPathItem:
+				attrs = append(attrs, "shape=box", "style=rounded")
+			case ReferenceGraphFile:
+				attrs = append(attrs, "shape=note")
+			}
+			if n.Circular {
+				attrs = append(attrs, "color=red", "fontcolor=red")
+			}
+			fmt.Fprintf(&b, "    %s [%s];\n", ids[n.Id], strings.Join(attrs, ", "))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		if e.Polymorphic != "" {
+			attrs = append(attrs, "label="+dotQuote(e.Polymorphic), "style=dashed")
+		}
+		if e.Dynamic {
+			attrs = append(attrs, "arrowhead=empty")
+		}
+		if e.Circular {
+			attrs = append(attrs, "color=red")
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, "  %s -> %s [%s];\n", ids[e.From], ids[e.To], strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", ids[e.From], ids[e.To])
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart. Nodes are grouped into a subgraph per file,
+// polymorphic edges are dotted and labelled with their keyword, circular nodes and edges are styled in red.
+func (g *ReferenceGraph) RenderMermaid() string {
+	ids, labels := g.renderIds(), g.fileLabels()
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	var circularNodes []string
+	for i, file := range g.files() {
+		fmt.Fprintf(&b, "  subgraph f%d[%s]\n", i, mermaidQuote(labels[file]))
+		for _, n := range g.Nodes {
+			if n.File != file {
+				continue
+			}
+			label := mermaidQuote(n.Label)
+			switch n.Kind {
+			case ReferenceGraphOperation:
+				fmt.Fprintf(&b, "    %s([%s])\n", ids[n.Id], label)
+			case ReferenceGraphPathItem:
+				fmt.Fprintf(&b, "    %s(%s)\n", ids[n.Id], label)
+			case ReferenceGraphFile:
+				fmt.Fprintf(&b, "    %s[/%s/]\n", ids[n.Id], label)
+			default:
+				fmt.Fprintf(&b, "    %s[%s]\n", ids[n.Id], label)
+			}
+			if n.Circular {
+				circularNodes = append(circularNodes, ids[n.Id])
+			}
+		}
+		b.WriteString("  end\n")
+	}
+	var circularEdges []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		if e.Polymorphic != "" {
+			arrow = "-.->|" + e.Polymorphic + "|"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+		if e.Circular {
+			circularEdges = append(circularEdges, fmt.Sprint(i))
+		}
+	}
+	if len(circularNodes) > 0 {
+		b.WriteString("  classDef circular stroke:#d00,stroke-width:2px,color:#d00\n")
+		fmt.Fprintf(&b, "  class %s circular\n", strings.Join(circularNodes, ","))
+	}
+	if len(circularEdges) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:#d00,stroke-width:2px\n", strings.Join(circularEdges, ","))
+	}
+	return b.String()
+}
+
+// renderIds maps every node Id to a short identifier that is safe to use in DOT and Mermaid.
+func (g *ReferenceGraph) renderIds() map[string]string {
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n.Id] = fmt.Sprintf("n%d", i)
+	}
+	return ids
+}
+
+// files returns the files holding the nodes of the graph, in order of first appearance.
+func (g *ReferenceGraph) files() []string {
+	seen := make(map[string]bool)
+	var files []string
+	for _, n := range g.Nodes {
+		if !seen[n.File] {
+			seen[n.File] = true
+			files = append(files, n.File)
+		}
+	}
+	return files
+}
+
+// fileLabels maps every file to its path relative to the deepest directory shared by all files.
+func (g *ReferenceGraph) fileLabels() map[string]string {
+	files := g.files()
+	common := ""
+	for i, file := range files {
+		dir := file[:strings.LastIndexAny(file, `/\`)+1]
+		if i == 0 {
+			common = dir
+			continue
+		}
+		for !strings.HasPrefix(dir, common) {
+			common = common[:strings.LastIndexAny(strings.TrimRight(common, `/\`), `/\`)+1]
+		}
+	}
+	labels := make(map[string]string, len(files))
+	for _, file := range files {
+		labels[file] = strings.TrimPrefix(file, common)
+	}
+	return labels
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const graphSpec = `openapi: 3.1.0
+info:
+  title: graph
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Limit'
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Animal:
+      type: object
+      properties:
+        name:
+          type: string
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Animal'
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+`
+
+func buildGraphIndex(t *testing.T) *SpecIndex {
+	t.Helper()
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(graphSpec), &root))
+	idx := NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+	resolver := NewResolver(idx)
+	resolver.CheckForCircularReferences()
+	return idx
+}
+
+func TestSpecIndex_BuildReferenceGraph(t *testing.T) {
+	idx := buildGraphIndex(t)
+	g, err := idx.BuildReferenceGraph(nil)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, n := range g.Nodes {
+		ids = append(ids, n.Id)
+	}
+	assert.Equal(t, []string{
+		"#/components/parameters/Limit",
+		"#/components/schemas/Animal",
+		"#/components/schemas/Owner",
+		"#/components/schemas/Pet",
+		"#/paths/~1pets",
+		"#/paths/~1pets/get",
+		"#/webhooks/newPet/post",
+	}, ids)
+
+	get := g.GetNode("#/paths/~1pets/get")
+	assert.Equal(t, ReferenceGraphOperation, get.Kind)
+	assert.Equal(t, "GET /pets", get.Label)
+	assert.Equal(t, "get", get.Method)
+	assert.Equal(t, "/pets", get.Path)
+
+	hook := g.GetNode("#/webhooks/newPet/post")
+	assert.True(t, hook.Webhook)
+	assert.Equal(t, "POST newPet", hook.Label)
+	assert.Equal(t, ReferenceGraphPathItem, g.GetNode("#/paths/~1pets").Kind)
+
+	assert.Len(t, g.Edges, 6)
+	for _, e := range g.Edges {
+		assert.False(t, e.Circular)
+		if e.To == "#/components/schemas/Animal" {
+			assert.Equal(t, "allOf", e.Polymorphic)
+			assert.Equal(t, "#/components/schemas/Pet", e.From)
+		} else {
+			assert.Empty(t, e.Polymorphic)
+		}
+		assert.Equal(t, 1, e.Count)
+		assert.Len(t, e.References, 1)
+	}
+}
+
+func TestSpecIndex_BuildReferenceGraph_Cycles(t *testing.T) {
+	idx := buildGraphIndex(t)
+	assert.NotEmpty(t, idx.GetCircularReferences())
+
+	g, err := idx.BuildReferenceGraph(&ReferenceGraphOptions{HighlightCycles: true})
+	require.NoError(t, err)
+	assert.True(t, g.GetNode("#/components/schemas/Pet").Circular)
+	assert.True(t, g.GetNode("#/components/schemas/Owner").Circular)
+	assert.False(t, g.GetNode("#/components/schemas/Animal").Circular)
+
+	g, err = idx.BuildReferenceGraph(&ReferenceGraphOptions{CyclesOnly: true})
+	require.NoError(t, err)
+	assert.Len(t, g.Nodes, 2)
+	assert.Len(t, g.Edges, 2)
+	for _, e := range g.Edges {
+		assert.True(t, e.Circular)
+	}
+}
+
+func TestSpecIndex_BuildReferenceGraph_Focus(t *testing.T) {
+	idx := buildGraphIndex(t)
+
+	g, err := idx.BuildReferenceGraph(&ReferenceGraphOptions{Focus: "#/components/schemas/Animal"})
+	require.NoError(t, err)
+	assert.Len(t, g.Nodes, 2)
+	assert.Len(t, g.Edges, 1)
+
+	g, err = idx.BuildReferenceGraph(&ReferenceGraphOptions{Focus: "Animal", Depth: 2})
+	require.NoError(t, err)
+	assert.Len(t, g.Nodes, 5)
+	assert.NotNil(t, g.GetNode("#/paths/~1pets/get"))
+	assert.Nil(t, g.GetNode("#/components/parameters/Limit"))
+
+	g, err = idx.BuildReferenceGraph(&ReferenceGraphOptions{Focus: "Animal", Depth: -1})
+	require.NoError(t, err)
+	assert.Len(t, g.Nodes, 5)
+
+	g, err = idx.BuildReferenceGraph(&ReferenceGraphOptions{Focus: "Nope"})
+	assert.ErrorIs(t, err, ErrUnknownReferenceGraphFocus)
+	assert.ErrorContains(t, err, "'Nope'")
+	assert.Nil(t, g)
+
+	// the focus must survive the other filters.
+	_, err = idx.BuildReferenceGraph(&ReferenceGraphOptions{Focus: "Limit", CyclesOnly: true})
+	assert.ErrorIs(t, err, ErrUnknownReferenceGraphFocus)
+}
+
+func TestReferenceGraph_Render(t *testing.T) {
+	idx := buildGraphIndex(t)
+	g, err := idx.BuildReferenceGraph(&ReferenceGraphOptions{HighlightCycles: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, `digraph references {
+  rankdir=LR;
+  node [shape=box, fontname="Helvetica"];
+  subgraph cluster_0 {
+    label="";
+    n0 [label="Limit"];
+    n1 [label="Animal"];
+    n2 [label="Owner", color=red, fontcolor=red];
+    n3 [label="Pet", color=red, fontcolor=red];
+    n4 [label="/pets", shape=box, style=rounded];
+    n5 [label="GET /pets", shape=ellipse];
+    n6 [label="POST newPet", shape=ellipse];
+  }
+  n2 -> n3 [color=red];
+  n3 -> n1 [label="allOf", style=dashed];
+  n3 -> n2 [color=red];
+  n4 -> n0;
+  n5 -> n3;
+  n6 -> n3;
+}
+`, g.RenderDOT())
+
+	assert.Equal(t, `flowchart LR
+  subgraph f0[""]
+    n0["Limit"]
+    n1["Animal"]
+    n2["Owner"]
+    n3["Pet"]
+    n4("/pets")
+    n5(["GET /pets"])
+    n6(["POST newPet"])
+  end
+  n2 --> n3
+  n3 -.->|allOf| n1
+  n3 --> n2
+  n4 --> n0
+  n5 --> n3
+  n6 --> n3
+  classDef circular stroke:#d00,stroke-width:2px,color:#d00
+  class n2,n3 circular
+  linkStyle 0,2 stroke:#d00,stroke-width:2px
+`, g.RenderMermaid())
+
+	b, err := g.RenderJSON()
+	assert.NoError(t, err)
+	var decoded ReferenceGraph
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Len(t, decoded.Nodes, 7)
+	assert.Len(t, decoded.Edges, 6)
+	assert.True(t, decoded.Edges[0].Circular)
+
+	empty, err := (&ReferenceGraph{Nodes: []*ReferenceGraphNode{}, Edges: []*ReferenceGraphEdge{}}).RenderJSON()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"nodes":[],"edges":[]}`, string(empty))
+}
+
+func TestRolodex_BuildReferenceGraph(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	g, err := rolodex.BuildReferenceGraph(nil)
+	require.NoError(t, err)
+	root, pet, shared := filepath.Join(dir, "root.yaml"), filepath.Join(dir, "pet.yaml"), filepath.Join(dir, "shared.yaml")
+
+	petFile := g.GetNode(pet)
+	assert.NotNil(t, petFile)
+	assert.Equal(t, ReferenceGraphFile, petFile.Kind)
+	assert.Equal(t, "pet.yaml", petFile.Label)
+
+	var edges []string
+	for _, e := range g.Edges {
+		edges = append(edges, e.From+" -> "+e.To)
+	}
+	assert.Equal(t, []string{
+		pet + " -> " + shared + "#/components/schemas/Owner",
+		root + "#/components/schemas/Other -> " + shared + "#/components/schemas/Owner",
+		root + "#/components/schemas/Pets -> " + pet,
+		root + "#/paths/~1pets/get -> " + root + "#/components/schemas/Pets",
+	}, edges)
+
+	assert.Equal(t, shared+"#/components/schemas/Owner", g.FindNode("#/components/schemas/Owner").Id)
+	assert.Contains(t, g.RenderDOT(), `label="shared.yaml"`)
+}
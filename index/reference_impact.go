@@ -0,0 +1,285 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// ReferenceSite is a $ref that depends on a component, either by pointing at it directly, or by pointing at
+// something that depends on it.
+type ReferenceSite struct {
+	// File is the path of the file holding the $ref.
+	File string `json:"file"`
+
+	// Line and Column are the position of the $ref value.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+
+	// Pointer is the JSON pointer of the object holding the $ref.
+	Pointer string `json:"pointer"`
+
+	// RawRef is the value of the $ref, as written.
+	RawRef string `json:"rawRef"`
+
+	// Target is the full definition the $ref points at.
+	Target string `json:"target"`
+
+	// Owner is the Id of the ReferenceGraphNode (component, operation, path item or file) holding the $ref.
+	Owner string `json:"owner"`
+
+	// Depth is 1 for a $ref that points at the component, 2 for a $ref that points at something with a direct
+	// reference to the component, and so on.
+	Depth int `json:"depth"`
+
+	// Reference is the indexed reference.
+	Reference *Reference `json:"-"`
+}
+
+// ImpactedOperation is an operation whose request or response could be affected by a change to a component.
+type ImpactedOperation struct {
+	// Method is the lower case HTTP method.
+	Method string `json:"method"`
+
+	// Path is the path of the operation, or the name of the webhook.
+	Path string `json:"path"`
+
+	// Webhook is true when the operation is a webhook.
+	Webhook bool `json:"webhook,omitempty"`
+
+	// File is the path of the file declaring the path (or webhook).
+	File string `json:"file"`
+
+	// Pointer is the JSON pointer of the operation within File.
+	Pointer string `json:"pointer"`
+}
+
+// ImpactAnalysis describes everything that depends on a component.
+type ImpactAnalysis struct {
+	// Component is the full definition of the component, empty when it is not referenced anywhere.
+	Component string `json:"component"`
+
+	// References are every reference site depending on the component, ordered by depth, file and line.
+	References []*ReferenceSite `json:"references"`
+
+	// Dependents are the Ids of every component, operation, path item and file depending on the component.
+	Dependents []string `json:"dependents"`
+
+	// Operations are the operations and webhooks depending on the component, ordered by path and method. When a
+	// path item itself depends on the component (for example through a path level parameter), every operation of
+	// the path item is included.
+	Operations []*ImpactedOperation `json:"operations"`
+}
+
+// FindReferencesTo returns every reference site in this index that points at a component, directly or
+// transitively. The component can be a full definition, a definition (#/components/schemas/Pet) or a component
+// name, as accepted by ReferenceGraphOptions.Focus.
+func (index *SpecIndex) FindReferencesTo(definition string) []*ReferenceSite {
+	return index.AnalyzeImpact(definition).References
+}
+
+// AnalyzeImpact returns every reference site, component and operation in this index that depends on a component.
+func (index *SpecIndex) AnalyzeImpact(definition string) *ImpactAnalysis {
+	g, _ := index.BuildReferenceGraph(nil)
+	return analyzeReferenceImpact(g, []*SpecIndex{index}, definition)
+}
+
+// FindReferencesTo returns every reference site in the rolodex that points at a component, directly or
+// transitively. The component can be a full definition, a definition (#/components/schemas/Pet) or a component
+// name, as accepted by ReferenceGraphOptions.Focus.
+func (r *Rolodex) FindReferencesTo(definition string) []*ReferenceSite {
+	return r.AnalyzeImpact(definition).References
+}
+
+// AnalyzeImpact returns every reference site, component and operation in the rolodex that depends on a component.
+func (r *Rolodex) AnalyzeImpact(definition string) *ImpactAnalysis {
+	indexes := append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...)
+	g, _ := r.BuildReferenceGraph(nil)
+	return analyzeReferenceImpact(g, indexes, definition)
+}
+
+func analyzeReferenceImpact(g *ReferenceGraph, indexes []*SpecIndex, definition string) *ImpactAnalysis {
+	analysis := &ImpactAnalysis{
+		References: []*ReferenceSite{},
+		Dependents: []string{},
+		Operations: []*ImpactedOperation{},
+	}
+	target := g.FindNode(definition)
+	if target == nil {
+		return analysis
+	}
+	analysis.Component = target.Id
+
+	incoming := make(map[string][]*ReferenceGraphEdge)
+	for _, e := range g.Edges {
+		incoming[e.To] = append(incoming[e.To], e)
+	}
+
+	// walk the graph backwards, breadth first, so every site is reported at its shortest depth.
+	seen := map[string]bool{target.Id: true}
+	frontier := []string{target.Id}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []string
+		for _, id := range frontier {
+			for _, e := range incoming[id] {
+				for _, ref := range e.References {
+					analysis.References = append(analysis.References, newReferenceSite(ref, e, depth))
+				}
+				if !seen[e.From] {
+					seen[e.From] = true
+					next = append(next, e.From)
+				}
+			}
+		}
+		frontier = next
+	}
+	sort.SliceStable(analysis.References, func(i, j int) bool {
+		a, b := analysis.References[i], analysis.References[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	byFile := make(map[string]*SpecIndex)
+	for _, idx := range indexes {
+		if idx != nil {
+			byFile[idx.specAbsolutePath] = idx
+		}
+	}
+	sites := make(map[string][]*ReferenceSite)
+	for _, site := range analysis.References {
+		sites[site.Owner] = append(sites[site.Owner], site)
+	}
+	operations := make(map[string]*ImpactedOperation)
+	for _, id := range sortedKeys(seen) {
+		if id == target.Id {
+			continue
+		}
+		analysis.Dependents = append(analysis.Dependents, id)
+		n := g.GetNode(id)
+		switch n.Kind {
+		case ReferenceGraphOperation:
+			operations[n.Id] = &ImpactedOperation{
+				Method: n.Method, Path: n.Path, Webhook: n.Webhook, File: n.File, Pointer: n.Pointer,
+			}
+		case ReferenceGraphPathItem:
+			for _, method := range pathItemMethods(g, byFile, n, sites, map[string]bool{}) {
+				op := &ImpactedOperation{
+					Method: method, Path: n.Path, Webhook: n.Webhook, File: n.File, Pointer: n.Pointer + "/" + method,
+				}
+				operations[joinFullDefinition(op.File, op.Pointer)] = op
+			}
+		}
+	}
+	for _, op := range operations {
+		analysis.Operations = append(analysis.Operations, op)
+	}
+	sort.Slice(analysis.Operations, func(i, j int) bool {
+		a, b := analysis.Operations[i], analysis.Operations[j]
+		if a.Webhook != b.Webhook {
+			return !a.Webhook
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.File < b.File
+	})
+	return analysis
+}
+
+func newReferenceSite(ref *Reference, e *ReferenceGraphEdge, depth int) *ReferenceSite {
+	site := &ReferenceSite{
+		Pointer:   "/" + strings.Join(ref.SourcePath, "/"),
+		RawRef:    ref.RawRef,
+		Target:    ref.FullDefinition,
+		Owner:     e.From,
+		Depth:     depth,
+		Reference: ref,
+	}
+	if ref.Index != nil {
+		site.File = ref.Index.specAbsolutePath
+	}
+	if ref.KeyNode != nil {
+		site.Line, site.Column = ref.KeyNode.Line, ref.KeyNode.Column
+	}
+	return site
+}
+
+// pathItemMethods returns the methods of a path item that depend on the component, given the dependent sites
+// owned by each node. A site on an operation of the path item impacts that operation, a site on the path item itself
+// (for example a path level parameter) impacts every operation, and a path item that is a $ref defers to its target.
+func pathItemMethods(g *ReferenceGraph, byFile map[string]*SpecIndex, n *ReferenceGraphNode,
+	sites map[string][]*ReferenceSite, visited map[string]bool,
+) []string {
+	if n == nil || visited[n.Id] {
+		return nil
+	}
+	visited[n.Id] = true
+
+	methods := make(map[string]bool)
+	prefix := strings.TrimSuffix(n.Pointer, "/") + "/"
+	for _, site := range sites[n.Id] {
+		if site.Pointer == n.Pointer || (n.Pointer == "" && site.Pointer == "/") {
+			target := referenceGraphNodeFor(splitFullDefinition(site.Target))
+			for _, m := range pathItemMethods(g, byFile, g.GetNode(target.Id), sites, visited) {
+				methods[m] = true
+			}
+			continue
+		}
+		segment, _, _ := strings.Cut(strings.TrimPrefix(site.Pointer, prefix), "/")
+		if utils.IsHttpVerb(strings.ToLower(segment)) {
+			methods[strings.ToLower(segment)] = true
+			continue
+		}
+		if idx := byFile[n.File]; idx != nil {
+			item := nodeAtPointer(idx.GetRootNode(), n.Pointer)
+			for i := 0; item != nil && i+1 < len(item.Content); i += 2 {
+				if key := strings.ToLower(item.Content[i].Value); utils.IsHttpVerb(key) {
+					methods[key] = true
+				}
+			}
+		}
+	}
+	return sortedKeys(methods)
+}
+
+// nodeAtPointer returns the node at a JSON pointer, or nil. An empty pointer returns the root of the document.
+func nodeAtPointer(root *yaml.Node, pointer string) *yaml.Node {
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if pointer == "" || pointer == "/" {
+		return node
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if node == nil || !utils.IsNodeMap(node) {
+			return nil
+		}
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+	return node
+}
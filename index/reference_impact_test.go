@@ -0,0 +1,145 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestSpecIndex_FindReferencesTo(t *testing.T) {
+	idx := buildGraphIndex(t)
+
+	sites := idx.FindReferencesTo("#/components/schemas/Pet")
+	var found []string
+	for _, s := range sites {
+		found = append(found, s.Owner+" "+s.Pointer)
+		assert.NotZero(t, s.Line)
+		assert.NotNil(t, s.Reference)
+	}
+	assert.Equal(t, []string{
+		"#/paths/~1pets/get /paths/~1pets/get/responses/200/content/application~1json/schema",
+		"#/webhooks/newPet/post /webhooks/newPet/post/requestBody/content/application~1json/schema",
+		"#/components/schemas/Owner /components/schemas/Owner/properties/pet",
+		"#/components/schemas/Pet /components/schemas/Pet/properties/owner",
+	}, found)
+	assert.Equal(t, 1, sites[0].Depth)
+	assert.Equal(t, 16, sites[0].Line)
+	assert.Equal(t, "#/components/schemas/Pet", sites[0].RawRef)
+	assert.Equal(t, 2, sites[3].Depth)
+
+	assert.Empty(t, idx.FindReferencesTo("#/components/schemas/Nope"))
+}
+
+func TestSpecIndex_AnalyzeImpact(t *testing.T) {
+	idx := buildGraphIndex(t)
+
+	impact := idx.AnalyzeImpact("Animal")
+	assert.Equal(t, "#/components/schemas/Animal", impact.Component)
+	assert.Equal(t, []string{
+		"#/components/schemas/Owner",
+		"#/components/schemas/Pet",
+		"#/paths/~1pets/get",
+		"#/webhooks/newPet/post",
+	}, impact.Dependents)
+	assert.Len(t, impact.Operations, 2)
+	assert.Equal(t, ImpactedOperation{Method: "get", Path: "/pets", Pointer: "/paths/~1pets/get"}, *impact.Operations[0])
+	assert.Equal(t, ImpactedOperation{Method: "post", Path: "newPet", Webhook: true, Pointer: "/webhooks/newPet/post"},
+		*impact.Operations[1])
+
+	impact = idx.AnalyzeImpact("#/components/parameters/Limit")
+	assert.Equal(t, []string{"#/paths/~1pets"}, impact.Dependents)
+	assert.Len(t, impact.Operations, 1)
+	assert.Equal(t, "/paths/~1pets/get", impact.Operations[0].Pointer)
+
+	impact = idx.AnalyzeImpact("#/components/schemas/Nope")
+	assert.Empty(t, impact.Component)
+	assert.Empty(t, impact.References)
+	assert.Empty(t, impact.Operations)
+}
+
+func TestRolodex_AnalyzeImpact(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	root, pet, shared := filepath.Join(dir, "root.yaml"), filepath.Join(dir, "pet.yaml"), filepath.Join(dir, "shared.yaml")
+
+	impact := rolodex.AnalyzeImpact("#/components/schemas/Owner")
+	assert.Equal(t, shared+"#/components/schemas/Owner", impact.Component)
+	assert.Equal(t, []string{
+		pet,
+		root + "#/components/schemas/Other",
+		root + "#/components/schemas/Pets",
+		root + "#/paths/~1pets/get",
+	}, impact.Dependents)
+
+	var depths []int
+	for _, s := range rolodex.FindReferencesTo(shared + "#/components/schemas/Owner") {
+		depths = append(depths, s.Depth)
+	}
+	assert.Equal(t, []int{1, 1, 2, 3}, depths)
+
+	assert.Len(t, impact.Operations, 1)
+	assert.Equal(t, root, impact.Operations[0].File)
+	assert.Equal(t, "get", impact.Operations[0].Method)
+}
+
+func TestRolodex_AnalyzeImpact_PathItemRef(t *testing.T) {
+	dir := t.TempDir()
+	rootSpec := `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: 'pets.yaml'
+`
+	for name, content := range map[string]string{
+		"root.yaml": rootSpec,
+		"pets.yaml": `get:
+  responses:
+    '200':
+      description: ok
+post:
+  parameters:
+    - $ref: 'params.yaml#/components/parameters/Limit'
+  responses:
+    '200':
+      description: ok
+`,
+		"params.yaml": `components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+`,
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(rootSpec), &rootNode))
+
+	cfg := CreateOpenAPIIndexConfig()
+	cfg.BasePath = dir
+	cfg.SpecFilePath = filepath.Join(dir, "root.yaml")
+	rolodex := NewRolodex(cfg)
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: cfg})
+	assert.NoError(t, err)
+	rolodex.AddLocalFS(dir, localFS)
+	rolodex.SetRootNode(&rootNode)
+	assert.NoError(t, rolodex.IndexTheRolodex(context.Background()))
+
+	impact := rolodex.AnalyzeImpact("Limit")
+	assert.Equal(t, []string{filepath.Join(dir, "pets.yaml"), filepath.Join(dir, "root.yaml") + "#/paths/~1pets"},
+		impact.Dependents)
+	var methods []string
+	for _, op := range impact.Operations {
+		assert.Equal(t, "/pets", op.Path)
+		methods = append(methods, op.Method)
+	}
+	assert.Equal(t, []string{"post"}, methods)
+}
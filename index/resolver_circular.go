@@ -9,7 +9,12 @@ func (resolver *Resolver) handleCircularJourneyRelative(ref, relative *Reference
 			continue
 		}
 
-		foundDup, _, _ := resolver.searchReferenceWithContext(ref, relative)
+		// dynamic targets are located through the dynamic scope, searching the index again would
+		// lose that context, so they stand in for themselves.
+		foundDup := relative
+		if !relative.IsDynamic {
+			foundDup, _, _ = resolver.searchReferenceWithContext(ref, relative)
+		}
 		if foundDup == nil {
 			return true
 		}
@@ -0,0 +1,94 @@
+// Copyright 2022-2026 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// extractDynamicRelativeReference resolves a $dynamicRef found while walking a reference, using the journey
+// taken to get here as the dynamic scope. The located target is returned as a relative so circular checks
+// and visiting treat it exactly like a $ref dependency.
+func (resolver *Resolver) extractDynamicRelativeReference(
+	ref *Reference,
+	node, parent, keyNode *yaml.Node,
+	keyIndex int,
+	state relativeWalkState,
+) *Reference {
+	if !utils.IsNodeStringValue(node.Content[keyIndex+1]) || utils.IsNodeArray(node) {
+		return nil
+	}
+
+	value := node.Content[keyIndex+1].Value
+	searchIndex := resolver.specIndex
+	if ref.Index != nil {
+		searchIndex = ref.Index
+	}
+
+	base := state.schemaIDBase
+	if base == "" {
+		base = referenceDocumentLocation(ref)
+	}
+
+	located := searchIndex.ResolveDynamicRef(value, base, resolver.dynamicScopeForJourney(state.journey, base))
+	if located == nil {
+		// unlike $ref, a $dynamicRef that can't be located is not reported as a resolving error. Anchors are
+		// often declared in a different resource than the one the ref is written in, and the rest of the
+		// spec remains usable, the ref just can't contribute to the journey.
+		if searchIndex.logger != nil {
+			searchIndex.logger.Debug("[resolver] unable to resolve $dynamicRef", "ref", value,
+				"line", keyNode.Line, "column", keyNode.Column)
+		}
+		return nil
+	}
+
+	if ref.ParentNodeSchemaType != "" {
+		located.ParentNodeTypes = append(located.ParentNodeTypes, ref.ParentNodeSchemaType)
+	}
+	located.ParentNodeSchemaType = parentArraySchemaType(parent)
+	state.foundRelatives[value] = true
+	return located
+}
+
+// dynamicScopeForJourney builds the JSON Schema dynamic scope (outermost first) from the references
+// visited so far, ending with the resource currently being walked.
+func (resolver *Resolver) dynamicScopeForJourney(journey []*Reference, current string) []string {
+	var scope []string
+	push := func(uri string) {
+		if uri != "" && (len(scope) == 0 || scope[len(scope)-1] != uri) {
+			scope = append(scope, uri)
+		}
+	}
+	for _, j := range journey {
+		if j == nil {
+			continue
+		}
+		location := referenceDocumentLocation(j)
+		push(location)
+		base := j.SchemaIdBase
+		if base == "" {
+			base = location
+		}
+		push(resolver.resolveSchemaIdBase(base, j.Node))
+	}
+	push(current)
+	return scope
+}
+
+// referenceDocumentLocation returns the file or URL of the document a reference target lives in.
+func referenceDocumentLocation(ref *Reference) string {
+	if ref.RemoteLocation != "" {
+		return ref.RemoteLocation
+	}
+	if location, _, found := strings.Cut(ref.FullDefinition, "#"); found || location != "" {
+		return location
+	}
+	if ref.Index != nil {
+		return ref.Index.specAbsolutePath
+	}
+	return ""
+}
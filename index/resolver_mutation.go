@@ -58,9 +58,11 @@ func (resolver *Resolver) resolveRelativeReference(
 	resolve bool,
 ) {
 	original := relative
-	foundRef, _, _ := resolver.searchReferenceWithContext(ref, relative)
-	if foundRef != nil {
-		original = foundRef
+	if !relative.IsDynamic {
+		foundRef, _, _ := resolver.searchReferenceWithContext(ref, relative)
+		if foundRef != nil {
+			original = foundRef
+		}
 	}
 
 	resolved := resolver.VisitReference(original, seen, journey, resolve)
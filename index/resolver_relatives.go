@@ -66,6 +66,13 @@ func (resolver *Resolver) extractRelativesWithState(
 				}
 			}
 
+			if i%2 == 0 && n.Value == "$dynamicRef" && len(node.Content) > i+1 {
+				if relative := resolver.extractDynamicRelativeReference(ref, node, parent, n, i, state); relative != nil {
+					found = append(found, relative)
+				}
+				continue
+			}
+
 			if i%2 == 0 && shouldExtractPolymorphicRelatives(parent, n) {
 				found = append(found, resolver.extractPolymorphicRelatives(ref, node, n, state, i)...)
 				skip = true
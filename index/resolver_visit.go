@@ -157,6 +157,12 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 	if content, done := resolver.visitReferenceShortCircuit(ref, resolve); done {
 		return content
 	}
+	if resolver.exceedsResourceBudget(ref, len(journey)+1) {
+		if ref.Node != nil {
+			return ref.Node.Content
+		}
+		return nil
+	}
 
 	journey = append(journey, ref)
 	relatives := resolver.collectReferenceRelatives(ref, seen, journey, resolve)
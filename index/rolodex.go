@@ -89,6 +89,11 @@ type Rolodex struct {
 	id                         string // unique ID for the rolodex, can be used to identify it in logs or other contexts.
 	globalSchemaIdRegistry     map[string]*SchemaIdEntry
 	schemaIdRegistryLock       sync.RWMutex
+	globalAnchorRegistry       map[string]*SchemaAnchorEntry
+	anchorRegistryLock         sync.RWMutex
+	updateLock                 sync.Mutex // serializes incremental updates, see UpdateFiles
+	budget                     *resourceBudget
+	catalog                    *referenceCatalog
 }
 
 // Release nils all fields that can pin YAML node trees, SpecIndex objects, or
@@ -117,7 +122,10 @@ func (r *Rolodex) Release() {
 	r.infiniteCircularReferences = nil
 	r.ignoredCircularReferences = nil
 	r.globalSchemaIdRegistry = nil
+	r.globalAnchorRegistry = nil
 	r.indexConfig = nil
+	r.budget = nil
+	r.catalog = nil
 	r.indexingDuration = 0
 	r.indexed = false
 	r.built = false
@@ -148,6 +156,8 @@ func NewRolodex(indexConfig *SpecIndexConfig) *Rolodex {
 		remoteFS:    make(map[string]fs.FS),
 		logger:      logger,
 		indexMap:    make(map[string]*SpecIndex),
+		budget:      newResourceBudget(indexConfig.ResourceBudget),
+		catalog:     newReferenceCatalog(indexConfig.ReferenceCatalog),
 	}
 	indexConfig.Rolodex = r
 	return r
@@ -337,6 +347,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 	var caughtErrors []error
 
 	var indexBuildQueue []*SpecIndex
+	r.budget.start()
 
 	indexRolodexFile := func(
 		location string, fs fs.FS,
@@ -346,8 +357,11 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 	) {
 		var wg sync.WaitGroup
 
-		indexFileFunc := func(idxFile CanBeIndexed, fullPath string) {
+		indexFileFunc := func(idxFile CanBeIndexed, fullPath string, size int64) {
 			defer wg.Done()
+			if r.budget.chargeFile(fullPath, size) != nil {
+				return
+			}
 
 			// copy config and set the
 			copiedConfig := *r.indexConfig
@@ -378,7 +392,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 				if idxFile, ko := f.(CanBeIndexed); ko {
 					wg.Add(1)
 					wait = true
-					go indexFileFunc(idxFile, f.GetFullPath())
+					go indexFileFunc(idxFile, f.GetFullPath(), f.Size())
 				}
 			}
 			if wait {
@@ -509,43 +523,13 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 			}
 		}
 
-		// Here we take the root node and also build the index for it.
-		// This involves extracting references.
-		index := NewSpecIndexWithConfigAndContext(ctx, r.rootNode, r.indexConfig)
-		resolver := NewResolver(index)
-
-		if r.indexConfig.IgnoreArrayCircularReferences {
-			resolver.IgnoreArrayCircularReferences()
-		}
-		if r.indexConfig.IgnorePolymorphicCircularReferences {
-			resolver.IgnorePolymorphicCircularReferences()
-		}
-		r.rootIndex = index
-		r.logger.Debug("[rolodex] starting root index build")
-		index.BuildIndex()
-		r.logger.Debug("[rolodex] root index build completed")
-
-		if !r.indexConfig.AvoidCircularReferenceCheck {
-			resolvingErrors := resolver.CheckForCircularReferences()
-			r.circChecked = true
-			for e := range resolvingErrors {
-				caughtErrors = append(caughtErrors, resolvingErrors[e])
-			}
-			if len(resolver.GetIgnoredCircularPolyReferences()) > 0 {
-				r.ignoredCircularReferences = append(
-					r.ignoredCircularReferences, resolver.GetIgnoredCircularPolyReferences()...,
-				)
-			}
-			if len(resolver.GetIgnoredCircularArrayReferences()) > 0 {
-				r.ignoredCircularReferences = append(
-					r.ignoredCircularReferences, resolver.GetIgnoredCircularArrayReferences()...,
-				)
-			}
-		}
-
-		if len(index.refErrors) > 0 {
-			caughtErrors = append(caughtErrors, index.refErrors...)
+		if info := r.indexConfig.SpecInfo; info != nil && info.SpecBytes != nil {
+			_ = r.budget.chargeFile(r.indexConfig.SpecAbsolutePath, int64(len(*info.SpecBytes)))
 		}
+		caughtErrors = append(caughtErrors, r.indexRootNode(ctx)...)
+	}
+	if err := r.budget.report(); err != nil {
+		caughtErrors = append(caughtErrors, err)
 	}
 	r.indexingDuration = time.Since(started)
 	r.indexed = true
@@ -554,6 +538,50 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 	return errors.Join(caughtErrors...)
 }
 
+// indexRootNode builds the root index from the root node, and checks it for circular references. Any errors
+// caught are returned.
+func (r *Rolodex) indexRootNode(ctx context.Context) []error {
+	var caughtErrors []error
+	// Here we take the root node and also build the index for it.
+	// This involves extracting references.
+	index := NewSpecIndexWithConfigAndContext(ctx, r.rootNode, r.indexConfig)
+	resolver := NewResolver(index)
+
+	if r.indexConfig.IgnoreArrayCircularReferences {
+		resolver.IgnoreArrayCircularReferences()
+	}
+	if r.indexConfig.IgnorePolymorphicCircularReferences {
+		resolver.IgnorePolymorphicCircularReferences()
+	}
+	r.rootIndex = index
+	r.logger.Debug("[rolodex] starting root index build")
+	index.BuildIndex()
+	r.logger.Debug("[rolodex] root index build completed")
+
+	if !r.indexConfig.AvoidCircularReferenceCheck {
+		resolvingErrors := resolver.CheckForCircularReferences()
+		r.circChecked = true
+		for e := range resolvingErrors {
+			caughtErrors = append(caughtErrors, resolvingErrors[e])
+		}
+		if len(resolver.GetIgnoredCircularPolyReferences()) > 0 {
+			r.ignoredCircularReferences = append(
+				r.ignoredCircularReferences, resolver.GetIgnoredCircularPolyReferences()...,
+			)
+		}
+		if len(resolver.GetIgnoredCircularArrayReferences()) > 0 {
+			r.ignoredCircularReferences = append(
+				r.ignoredCircularReferences, resolver.GetIgnoredCircularArrayReferences()...,
+			)
+		}
+	}
+
+	if len(index.refErrors) > 0 {
+		caughtErrors = append(caughtErrors, index.refErrors...)
+	}
+	return caughtErrors
+}
+
 // CheckForCircularReferences checks for circular references in the rolodex.
 func (r *Rolodex) CheckForCircularReferences() {
 	if !r.circChecked {
@@ -682,6 +710,17 @@ func (r *Rolodex) OpenWithContext(ctx context.Context, location string) (Rolodex
 		return nil, fmt.Errorf("rolodex has not been initialized, cannot open file '%s'", location)
 	}
 
+	// the reference catalog is checked before any file system, a mapped location is never fetched.
+	if strings.HasPrefix(location, "http") {
+		catalogFile, err := r.openCatalogLocation(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		if catalogFile != nil {
+			return r.wrapRemoteRolodexFile(catalogFile)
+		}
+	}
+
 	if len(r.localFS) <= 0 && len(r.remoteFS) <= 0 {
 		return nil, fmt.Errorf(
 			"rolodex has no file systems configured, cannot open '%s'. Add a BaseURL or BasePath to your configuration so the rolodex knows how to resolve references",
@@ -1064,4 +1103,39 @@ func (r *Rolodex) RegisterIdsFromIndex(idx *SpecIndex) {
 	for _, entry := range entries {
 		_ = r.RegisterGlobalSchemaId(entry)
 	}
+	for _, anchor := range idx.GetAllSchemaAnchors() {
+		_ = r.RegisterGlobalSchemaAnchor(anchor)
+	}
+}
+
+// RegisterGlobalSchemaAnchor registers a $anchor or $dynamicAnchor in the Rolodex global registry.
+// Returns an error if the anchor name is invalid.
+func (r *Rolodex) RegisterGlobalSchemaAnchor(entry *SchemaAnchorEntry) error {
+	if r == nil {
+		return fmt.Errorf("cannot register anchor on nil Rolodex")
+	}
+
+	r.anchorRegistryLock.Lock()
+	defer r.anchorRegistryLock.Unlock()
+
+	if r.globalAnchorRegistry == nil {
+		r.globalAnchorRegistry = make(map[string]*SchemaAnchorEntry)
+	}
+	return registerSchemaAnchorToRegistry(r.globalAnchorRegistry, entry, r.logger, "global registry")
+}
+
+// LookupSchemaAnchor looks up an anchor declared in the schema resource identified by resourceUri,
+// across all indexes.
+func (r *Rolodex) LookupSchemaAnchor(resourceUri, name string) *SchemaAnchorEntry {
+	if r == nil {
+		return nil
+	}
+
+	r.anchorRegistryLock.RLock()
+	defer r.anchorRegistryLock.RUnlock()
+
+	if r.globalAnchorRegistry == nil {
+		return nil
+	}
+	return r.globalAnchorRegistry[schemaAnchorKey(resourceUri, name)]
 }
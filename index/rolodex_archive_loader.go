@@ -0,0 +1,475 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	defaultArchiveMaxFileSize  int64 = 32 << 20
+	defaultArchiveMaxTotalSize int64 = 256 << 20
+	defaultArchiveMaxFiles           = 10000
+)
+
+var defaultArchiveManifestNames = []string{"manifest.yaml", "manifest.yml", "manifest.json"}
+
+// ArchiveFSConfig is the configuration for an ArchiveFS.
+type ArchiveFSConfig struct {
+	// BaseDirectory is the absolute directory the archive is mounted at. Nothing is read from or written to this
+	// location, it only gives archive entries stable absolute paths. Defaults to the archive path when loading
+	// from a file, and to the current working directory otherwise.
+	BaseDirectory string
+
+	// RootDocument is the slash separated path of the root document inside the archive. When empty, the root is
+	// read from a manifest, or detected by looking for documents with a top-level openapi, swagger or arazzo key.
+	RootDocument string
+
+	// ManifestNames are the file names checked for a manifest with a `root` key pointing at the root document,
+	// relative to the manifest. Defaults to manifest.yaml, manifest.yml and manifest.json.
+	ManifestNames []string
+
+	// MaxFileSize is the largest uncompressed size allowed for a single entry. Defaults to 32MB.
+	MaxFileSize int64
+
+	// MaxTotalSize is the largest uncompressed size allowed for all entries combined. Defaults to 256MB.
+	MaxTotalSize int64
+
+	// MaxFiles is the largest number of entries allowed in the archive. Defaults to 10,000.
+	MaxFiles int
+
+	// supply your own logger
+	Logger *slog.Logger
+}
+
+// ArchiveFS is a RolodexFS that serves the YAML and JSON files of a zip, tar or tar.gz archive from memory.
+// Relative paths are resolved against the directory of the root document, so relative references inside the
+// archive resolve the same way they would once the archive is extracted.
+//
+// Entries that would escape the archive (absolute paths, `..` elements, drive letters or backslashes) are
+// rejected, symbolic links are skipped, and the number and uncompressed size of entries are capped while reading
+// so a decompression bomb fails fast instead of exhausting memory.
+type ArchiveFS struct {
+	files         map[string]*LocalFile
+	baseDirectory string
+	rootDocument  string
+	rootDirectory string
+	logger        *slog.Logger
+}
+
+type archiveReader struct {
+	config  *ArchiveFSConfig
+	entries map[string][]byte
+	total   int64
+	count   int
+}
+
+// NewArchiveFSFromFile reads a zip, tar or tar.gz archive from disk and mounts it as an ArchiveFS.
+func NewArchiveFSFromFile(archivePath string, config *ArchiveFSConfig) (*ArchiveFS, error) {
+	data, err := os.ReadFile(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	cfg := ArchiveFSConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.BaseDirectory == "" {
+		cfg.BaseDirectory = archivePath
+	}
+	return NewArchiveFS(data, &cfg)
+}
+
+// NewArchiveFS mounts the supplied zip, tar or tar.gz archive bytes as an ArchiveFS. The format is detected from
+// the content.
+func NewArchiveFS(archive []byte, config *ArchiveFSConfig) (*ArchiveFS, error) {
+	cfg := ArchiveFSConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.MaxFileSize <= 0 {
+		cfg.MaxFileSize = defaultArchiveMaxFileSize
+	}
+	if cfg.MaxTotalSize <= 0 {
+		cfg.MaxTotalSize = defaultArchiveMaxTotalSize
+	}
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = defaultArchiveMaxFiles
+	}
+	if len(cfg.ManifestNames) == 0 {
+		cfg.ManifestNames = defaultArchiveManifestNames
+	}
+	log := cfg.Logger
+	if log == nil {
+		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelError,
+		}))
+	}
+	base, err := filepath.Abs(cfg.BaseDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &archiveReader{config: &cfg, entries: make(map[string][]byte)}
+	switch {
+	case bytes.HasPrefix(archive, []byte("PK\x03\x04")), bytes.HasPrefix(archive, []byte("PK\x05\x06")):
+		err = reader.readZip(archive, log)
+	case bytes.HasPrefix(archive, []byte{0x1f, 0x8b}):
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(bytes.NewReader(archive))
+		if err == nil {
+			err = reader.readTar(gz, log)
+			gz.Close()
+		}
+	case len(archive) > 262 && string(archive[257:262]) == "ustar":
+		err = reader.readTar(bytes.NewReader(archive), log)
+	default:
+		err = errors.New("unable to read archive: format is not zip, tar or tar.gz")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := reader.rootDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	modTime := time.Now()
+	archiveFS := &ArchiveFS{
+		files:         make(map[string]*LocalFile, len(reader.entries)),
+		baseDirectory: base,
+		rootDocument:  root,
+		rootDirectory: filepath.Dir(filepath.Join(base, filepath.FromSlash(root))),
+		logger:        log,
+	}
+	for name, data := range reader.entries {
+		abs := filepath.Join(base, filepath.FromSlash(name))
+		lf := &LocalFile{
+			filename:         name,
+			name:             path.Base(name),
+			extension:        ExtractFileType(name),
+			data:             data,
+			fullPath:         abs,
+			lastModified:     modTime,
+			indexingComplete: make(chan struct{}),
+		}
+		// archive files are indexed by IndexTheRolodex, so they are ready as soon as they are read.
+		lf.signalIndexingComplete()
+		archiveFS.files[abs] = lf
+	}
+	return archiveFS, nil
+}
+
+// Open opens a file from the archive. Relative names are resolved against the directory of the root document.
+func (a *ArchiveFS) Open(name string) (fs.File, error) {
+	p := name
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(a.rootDirectory, filepath.FromSlash(p))
+	}
+	if f, ok := a.files[filepath.Clean(p)]; ok {
+		return f, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// GetFiles returns every YAML and JSON file in the archive, keyed by its absolute path under the base directory.
+func (a *ArchiveFS) GetFiles() map[string]RolodexFile {
+	files := make(map[string]RolodexFile, len(a.files))
+	for k, v := range a.files {
+		files[k] = v
+	}
+	return files
+}
+
+// GetBaseDirectory returns the absolute directory the archive is mounted at.
+func (a *ArchiveFS) GetBaseDirectory() string {
+	return a.baseDirectory
+}
+
+// GetRootDocument returns the slash separated path of the root document inside the archive.
+func (a *ArchiveFS) GetRootDocument() string {
+	return a.rootDocument
+}
+
+// GetRootDocumentPath returns the absolute path of the root document under the base directory.
+func (a *ArchiveFS) GetRootDocumentPath() string {
+	return filepath.Join(a.baseDirectory, filepath.FromSlash(a.rootDocument))
+}
+
+// GetRootDocumentBytes returns the content of the root document.
+func (a *ArchiveFS) GetRootDocumentBytes() []byte {
+	return a.files[a.GetRootDocumentPath()].data
+}
+
+// DocumentConfiguration returns a copy of the supplied configuration (or a new one) that reads local references
+// from the archive, with the base path set to the directory of the root document. Pass the result to
+// libopenapi.NewDocumentWithConfiguration together with GetRootDocumentBytes.
+func (a *ArchiveFS) DocumentConfiguration(config *datamodel.DocumentConfiguration) *datamodel.DocumentConfiguration {
+	cfg := datamodel.NewDocumentConfiguration()
+	if config != nil {
+		copied := *config
+		cfg = &copied
+	}
+	cfg.LocalFS = a
+	cfg.BasePath = a.rootDirectory
+	cfg.SpecFilePath = a.GetRootDocumentPath()
+	cfg.AllowFileReferences = true
+	if cfg.Logger == nil {
+		cfg.Logger = a.logger
+	}
+	return cfg
+}
+
+func (r *archiveReader) readZip(archive []byte, log *slog.Logger) error {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return fmt.Errorf("unable to read zip archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if f.Mode()&fs.ModeSymlink != 0 {
+			log.Warn("[rolodex archive loader]: skipping symbolic link", "file", f.Name)
+			continue
+		}
+		name, err := r.accept(f.Name, int64(f.UncompressedSize64))
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("unable to read archive entry '%s': %w", f.Name, err)
+		}
+		err = r.store(name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *archiveReader) readTar(in io.Reader, log *slog.Logger) error {
+	tr := tar.NewReader(in)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read tar archive: %w", err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+		case tar.TypeSymlink, tar.TypeLink:
+			log.Warn("[rolodex archive loader]: skipping link", "file", hdr.Name)
+			continue
+		default:
+			continue
+		}
+		name, err := r.accept(hdr.Name, hdr.Size)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			continue
+		}
+		if err := r.store(name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// accept checks an entry against the path rules and limits before it is read. It returns an empty name for
+// entries that are skipped, which still count towards the limits.
+func (r *archiveReader) accept(entry string, size int64) (string, error) {
+	name, err := cleanArchivePath(entry)
+	if err != nil {
+		return "", err
+	}
+	r.count++
+	if r.count > r.config.MaxFiles {
+		return "", fmt.Errorf("archive contains more than %d files", r.config.MaxFiles)
+	}
+	if size > r.config.MaxFileSize {
+		return "", fmt.Errorf("archive entry '%s' is larger than the %d byte limit", entry, r.config.MaxFileSize)
+	}
+	r.total += size
+	if r.total > r.config.MaxTotalSize {
+		return "", fmt.Errorf("archive content is larger than the %d byte limit", r.config.MaxTotalSize)
+	}
+	if isHiddenArchivePath(name) {
+		return "", nil
+	}
+	if ext := ExtractFileType(name); ext != YAML && ext != JSON {
+		return "", nil
+	}
+	if _, ok := r.entries[name]; ok {
+		return "", fmt.Errorf("archive contains duplicate entry '%s'", entry)
+	}
+	return name, nil
+}
+
+// store reads an accepted entry. Declared sizes can lie, so the read itself is capped as well.
+func (r *archiveReader) store(name string, in io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(in, r.config.MaxFileSize+1))
+	if err != nil {
+		return fmt.Errorf("unable to read archive entry '%s': %w", name, err)
+	}
+	if int64(len(data)) > r.config.MaxFileSize {
+		return fmt.Errorf("archive entry '%s' is larger than the %d byte limit", name, r.config.MaxFileSize)
+	}
+	r.entries[name] = data
+	return nil
+}
+
+// cleanArchivePath normalizes an entry name and rejects any name that could escape the archive root.
+func cleanArchivePath(entry string) (string, error) {
+	if strings.ContainsAny(entry, "\\\x00") || (len(entry) > 1 && entry[1] == ':') || strings.HasPrefix(entry, "/") {
+		return "", fmt.Errorf("archive entry '%s' has an unsafe path", entry)
+	}
+	name := path.Clean(entry)
+	if !fs.ValidPath(name) || name == "." {
+		return "", fmt.Errorf("archive entry '%s' has an unsafe path", entry)
+	}
+	return name, nil
+}
+
+func isHiddenArchivePath(name string) bool {
+	for _, element := range strings.Split(name, "/") {
+		if strings.HasPrefix(element, ".") || element == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// rootDocument picks the root document: the configured one, then one named by a manifest, then the shallowest
+// document with a top-level openapi, swagger or arazzo key. Documents named openapi, swagger or arazzo win ties.
+func (r *archiveReader) rootDocument() (string, error) {
+	if r.config.RootDocument != "" {
+		root, err := cleanArchivePath(r.config.RootDocument)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := r.entries[root]; !ok {
+			return "", fmt.Errorf("root document '%s' is not in the archive", r.config.RootDocument)
+		}
+		return root, nil
+	}
+
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if di, dj := strings.Count(names[i], "/"), strings.Count(names[j], "/"); di != dj {
+			return di < dj
+		}
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		for _, manifest := range r.config.ManifestNames {
+			if path.Base(name) != manifest {
+				continue
+			}
+			root, err := r.manifestRoot(name)
+			if err != nil {
+				return "", err
+			}
+			if root != "" {
+				return root, nil
+			}
+		}
+	}
+
+	var candidates []string
+	for _, name := range names {
+		if isArchiveRootDocument(r.entries[name]) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", errors.New("archive does not contain an openapi, swagger or arazzo document")
+	}
+	depth := strings.Count(candidates[0], "/")
+	var shallowest, preferred []string
+	for _, name := range candidates {
+		if strings.Count(name, "/") != depth {
+			break
+		}
+		shallowest = append(shallowest, name)
+		stem := strings.TrimSuffix(path.Base(name), path.Ext(name))
+		if stem == "openapi" || stem == "swagger" || stem == "arazzo" {
+			preferred = append(preferred, name)
+		}
+	}
+	if len(shallowest) == 1 {
+		return shallowest[0], nil
+	}
+	if len(preferred) == 1 {
+		return preferred[0], nil
+	}
+	return "", fmt.Errorf("archive contains more than one possible root document (%s), set the RootDocument",
+		strings.Join(shallowest, ", "))
+}
+
+// manifestRoot returns the root document named by a manifest, or an empty string when the manifest has no root.
+func (r *archiveReader) manifestRoot(manifest string) (string, error) {
+	var content struct {
+		Root string `yaml:"root"`
+	}
+	if err := yaml.Unmarshal(r.entries[manifest], &content); err != nil || content.Root == "" {
+		return "", nil
+	}
+	root, err := cleanArchivePath(path.Join(path.Dir(manifest), content.Root))
+	if err != nil {
+		return "", fmt.Errorf("manifest '%s': %w", manifest, err)
+	}
+	if _, ok := r.entries[root]; !ok {
+		return "", fmt.Errorf("manifest '%s' names root document '%s', which is not in the archive", manifest, content.Root)
+	}
+	return root, nil
+}
+
+func isArchiveRootDocument(data []byte) bool {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return false
+	}
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		switch mapping.Content[i].Value {
+		case "openapi", "swagger", "arazzo":
+			return true
+		}
+	}
+	return false
+}
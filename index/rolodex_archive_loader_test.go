@@ -0,0 +1,325 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+)
+
+type archiveTestEntry struct {
+	name    string
+	content string
+	link    bool
+}
+
+func buildTestZip(t *testing.T, entries ...archiveTestEntry) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		if e.link {
+			hdr.SetMode(fs.ModeSymlink | 0o777)
+		}
+		w, err := zw.CreateHeader(hdr)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(e.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func buildTestTar(t *testing.T, gzipped bool, entries ...archiveTestEntry) []byte {
+	var buf bytes.Buffer
+	var out io.Writer = &buf
+	var gz *gzip.Writer
+	if gzipped {
+		gz = gzip.NewWriter(&buf)
+		out = gz
+	}
+	tw := tar.NewWriter(out)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.link {
+			hdr = &tar.Header{Name: e.name, Linkname: e.content, Typeflag: tar.TypeSymlink}
+		}
+		assert.NoError(t, tw.WriteHeader(hdr))
+		if !e.link {
+			_, err := tw.Write([]byte(e.content))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, tw.Close())
+	if gz != nil {
+		assert.NoError(t, gz.Close())
+	}
+	return buf.Bytes()
+}
+
+var archiveTestSpec = []archiveTestEntry{
+	{name: "petstore-1.0/README.md", content: "# pets"},
+	{name: "petstore-1.0/spec/openapi.yaml", content: "openapi: 3.1.0\ninfo:\n  title: pets\n  version: 1.0.0\n" +
+		"paths: {}\ncomponents:\n  schemas:\n    Pet:\n      $ref: 'schemas/pet.yaml'\n"},
+	{name: "petstore-1.0/spec/schemas/pet.yaml", content: "type: object\nproperties:\n  error:\n" +
+		"    $ref: '../../shared/error.yaml'\n"},
+	{name: "petstore-1.0/shared/error.yaml", content: "type: string\n"},
+	{name: "__MACOSX/petstore-1.0/spec/._openapi.yaml", content: "openapi: junk"},
+}
+
+func TestNewArchiveFS_Formats(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "bundle")
+	for name, archive := range map[string][]byte{
+		"zip":    buildTestZip(t, archiveTestSpec...),
+		"tar":    buildTestTar(t, false, archiveTestSpec...),
+		"tar.gz": buildTestTar(t, true, archiveTestSpec...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			archiveFS, err := NewArchiveFS(archive, &ArchiveFSConfig{BaseDirectory: base})
+			assert.NoError(t, err)
+			assert.Equal(t, "petstore-1.0/spec/openapi.yaml", archiveFS.GetRootDocument())
+			assert.Equal(t, filepath.Join(base, "petstore-1.0", "spec", "openapi.yaml"), archiveFS.GetRootDocumentPath())
+			assert.Equal(t, base, archiveFS.GetBaseDirectory())
+			assert.Contains(t, string(archiveFS.GetRootDocumentBytes()), "openapi: 3.1.0")
+			assert.Len(t, archiveFS.GetFiles(), 3)
+
+			f, err := archiveFS.Open("schemas/pet.yaml")
+			assert.NoError(t, err)
+			data, err := io.ReadAll(f)
+			assert.NoError(t, err)
+			assert.Contains(t, string(data), "../../shared/error.yaml")
+
+			_, err = archiveFS.Open("../shared/error.yaml")
+			assert.NoError(t, err)
+			_, err = archiveFS.Open(filepath.Join(base, "petstore-1.0", "shared", "error.yaml"))
+			assert.NoError(t, err)
+			_, err = archiveFS.Open("README.md")
+			assert.ErrorIs(t, err, fs.ErrNotExist)
+		})
+	}
+}
+
+func TestNewArchiveFS_IndexesWithRolodex(t *testing.T) {
+	archiveFS, err := NewArchiveFS(buildTestZip(t, archiveTestSpec...), &ArchiveFSConfig{BaseDirectory: t.TempDir()})
+	assert.NoError(t, err)
+
+	docConfig := archiveFS.DocumentConfiguration(nil)
+	assert.Equal(t, archiveFS, docConfig.LocalFS)
+	assert.True(t, docConfig.AllowFileReferences)
+	assert.Equal(t, filepath.Dir(archiveFS.GetRootDocumentPath()), docConfig.BasePath)
+
+	cfg := CreateOpenAPIIndexConfig()
+	cfg.BasePath = docConfig.BasePath
+	cfg.SpecFilePath = docConfig.SpecFilePath
+	cfg.AllowFileLookup = true
+	rolodex := NewRolodex(cfg)
+	rolodex.AddLocalFS(cfg.BasePath, archiveFS)
+
+	f, err := rolodex.Open("schemas/pet.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cfg.BasePath, "schemas", "pet.yaml"), f.GetFullPath())
+	f, err = rolodex.Open("../shared/error.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "type: string\n", f.GetContent())
+}
+
+func TestNewArchiveFSFromFile(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "specs.tar.gz")
+	assert.NoError(t, os.WriteFile(archivePath, buildTestTar(t, true, archiveTestSpec...), 0o600))
+
+	archiveFS, err := NewArchiveFSFromFile(archivePath, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(archivePath, "petstore-1.0", "spec", "openapi.yaml"), archiveFS.GetRootDocumentPath())
+
+	_, err = NewArchiveFSFromFile(filepath.Join(dir, "missing.zip"), nil)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestNewArchiveFS_RootDetection(t *testing.T) {
+	spec := "openapi: 3.1.0\n"
+	tests := []struct {
+		name    string
+		config  *ArchiveFSConfig
+		entries []archiveTestEntry
+		root    string
+		err     string
+	}{
+		{
+			name:    "swagger",
+			entries: []archiveTestEntry{{name: "api/v2.json", content: `{"swagger": "2.0"}`}},
+			root:    "api/v2.json",
+		},
+		{
+			name: "arazzo",
+			entries: []archiveTestEntry{
+				{name: "workflows/flow.yaml", content: "arazzo: 1.0.1\n"},
+				{name: "workflows/data.yaml", content: "name: data\n"},
+			},
+			root: "workflows/flow.yaml",
+		},
+		{
+			name: "shallowest wins",
+			entries: []archiveTestEntry{
+				{name: "a/b/openapi.yaml", content: spec},
+				{name: "a/api.yaml", content: spec},
+			},
+			root: "a/api.yaml",
+		},
+		{
+			name: "preferred name wins ties",
+			entries: []archiveTestEntry{
+				{name: "other.yaml", content: spec},
+				{name: "openapi.yaml", content: spec},
+			},
+			root: "openapi.yaml",
+		},
+		{
+			name: "ambiguous",
+			entries: []archiveTestEntry{
+				{name: "one.yaml", content: spec},
+				{name: "two.yaml", content: spec},
+			},
+			err: "more than one possible root document (one.yaml, two.yaml)",
+		},
+		{
+			name:    "none",
+			entries: []archiveTestEntry{{name: "schema.yaml", content: "type: string\n"}},
+			err:     "does not contain an openapi, swagger or arazzo document",
+		},
+		{
+			name: "manifest",
+			entries: []archiveTestEntry{
+				{name: "bundle/manifest.yaml", content: "root: specs/internal.yaml\n"},
+				{name: "bundle/openapi.yaml", content: spec},
+				{name: "bundle/specs/internal.yaml", content: spec},
+			},
+			root: "bundle/specs/internal.yaml",
+		},
+		{
+			name: "manifest without root is ignored",
+			entries: []archiveTestEntry{
+				{name: "manifest.json", content: `{"name": "pets"}`},
+				{name: "openapi.yaml", content: spec},
+			},
+			root: "openapi.yaml",
+		},
+		{
+			name:    "manifest root missing",
+			entries: []archiveTestEntry{{name: "manifest.yml", content: "root: nope.yaml\n"}},
+			err:     "names root document 'nope.yaml', which is not in the archive",
+		},
+		{
+			name:    "manifest root escapes",
+			entries: []archiveTestEntry{{name: "manifest.yml", content: "root: ../../etc/passwd.yaml\n"}},
+			err:     "has an unsafe path",
+		},
+		{
+			name:   "configured",
+			config: &ArchiveFSConfig{RootDocument: "./two.yaml"},
+			entries: []archiveTestEntry{
+				{name: "one.yaml", content: spec},
+				{name: "two.yaml", content: spec},
+			},
+			root: "two.yaml",
+		},
+		{
+			name:    "configured missing",
+			config:  &ArchiveFSConfig{RootDocument: "three.yaml"},
+			entries: []archiveTestEntry{{name: "one.yaml", content: spec}},
+			err:     "root document 'three.yaml' is not in the archive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archiveFS, err := NewArchiveFS(buildTestZip(t, tt.entries...), tt.config)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.root, archiveFS.GetRootDocument())
+		})
+	}
+}
+
+func TestNewArchiveFS_UnsafePaths(t *testing.T) {
+	for _, name := range []string{"../evil.yaml", "a/../../evil.yaml", "/etc/evil.yaml", `a\..\evil.yaml`, "C:/evil.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			entries := []archiveTestEntry{{name: "openapi.yaml", content: "openapi: 3.1.0\n"}, {name: name, content: "x: y\n"}}
+			_, err := NewArchiveFS(buildTestZip(t, entries...), nil)
+			assert.ErrorContains(t, err, "has an unsafe path")
+			_, err = NewArchiveFS(buildTestTar(t, true, entries...), nil)
+			assert.ErrorContains(t, err, "has an unsafe path")
+		})
+	}
+}
+
+func TestNewArchiveFS_SkipsLinks(t *testing.T) {
+	entries := []archiveTestEntry{
+		{name: "openapi.yaml", content: "openapi: 3.1.0\n"},
+		{name: "secret.yaml", content: "/etc/passwd", link: true},
+	}
+	for _, archive := range [][]byte{buildTestZip(t, entries...), buildTestTar(t, false, entries...)} {
+		archiveFS, err := NewArchiveFS(archive, nil)
+		assert.NoError(t, err)
+		_, err = archiveFS.Open("secret.yaml")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+	}
+}
+
+func TestNewArchiveFS_Limits(t *testing.T) {
+	bomb := archiveTestEntry{name: "bomb.yaml", content: "a: " + strings.Repeat("b", 4096) + "\n"}
+	spec := archiveTestEntry{name: "openapi.yaml", content: "openapi: 3.1.0\n"}
+
+	_, err := NewArchiveFS(buildTestZip(t, spec, bomb), &ArchiveFSConfig{MaxFileSize: 1024})
+	assert.ErrorContains(t, err, "archive entry 'bomb.yaml' is larger than the 1024 byte limit")
+
+	_, err = NewArchiveFS(buildTestTar(t, true, spec, bomb), &ArchiveFSConfig{MaxTotalSize: 2048})
+	assert.ErrorContains(t, err, "archive content is larger than the 2048 byte limit")
+
+	_, err = NewArchiveFS(buildTestTar(t, false, spec, bomb, bomb), nil)
+	assert.ErrorContains(t, err, "archive contains duplicate entry 'bomb.yaml'")
+
+	_, err = NewArchiveFS(buildTestZip(t, spec, bomb, spec), &ArchiveFSConfig{MaxFiles: 2})
+	assert.ErrorContains(t, err, "archive contains more than 2 files")
+}
+
+func TestNewArchiveFS_LyingZipHeader(t *testing.T) {
+	archive := buildTestZip(t, archiveTestEntry{name: "openapi.yaml", content: "openapi: 3.1.0\n" + strings.Repeat("#", 4096)})
+
+	// understate the uncompressed size in the central directory, the entry must still be rejected.
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(t, err)
+	declared := zr.File[0].UncompressedSize64
+	central := bytes.LastIndex(archive, []byte("PK\x01\x02"))
+	sizeOffset := central + 24
+	assert.Equal(t, uint32(declared), uint32(archive[sizeOffset])|uint32(archive[sizeOffset+1])<<8|
+		uint32(archive[sizeOffset+2])<<16|uint32(archive[sizeOffset+3])<<24)
+	archive[sizeOffset], archive[sizeOffset+1], archive[sizeOffset+2], archive[sizeOffset+3] = 10, 0, 0, 0
+
+	_, err = NewArchiveFS(archive, &ArchiveFSConfig{MaxFileSize: 1024})
+	assert.Error(t, err)
+}
+
+func TestNewArchiveFS_UnknownFormat(t *testing.T) {
+	_, err := NewArchiveFS([]byte("openapi: 3.1.0"), nil)
+	assert.ErrorContains(t, err, "format is not zip, tar or tar.gz")
+
+	_, err = NewArchiveFS([]byte("PK\x03\x04broken"), nil)
+	assert.ErrorContains(t, err, "unable to read zip archive")
+
+	_, err = NewArchiveFS([]byte{0x1f, 0x8b, 0x00}, nil)
+	assert.Error(t, err)
+}
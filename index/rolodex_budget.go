@@ -0,0 +1,318 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"go.yaml.in/yaml/v4"
+)
+
+// ErrResourceBudgetExceeded is returned (wrapped by a *ResourceBudgetError) when indexing exceeds a limit of a
+// datamodel.ResourceBudget.
+var ErrResourceBudgetExceeded = errors.New("resource budget exceeded")
+
+// ResourceLimit is the limit of a datamodel.ResourceBudget that was exceeded.
+type ResourceLimit string
+
+const (
+	// ResourceLimitFiles is the maximum number of documents indexed.
+	ResourceLimitFiles ResourceLimit = "files"
+
+	// ResourceLimitBytes is the maximum number of bytes read.
+	ResourceLimitBytes ResourceLimit = "bytes"
+
+	// ResourceLimitReferenceDepth is the maximum number of references followed in a chain.
+	ResourceLimitReferenceDepth ResourceLimit = "reference-depth"
+
+	// ResourceLimitYAMLNodes is the maximum number of YAML nodes parsed.
+	ResourceLimitYAMLNodes ResourceLimit = "yaml-nodes"
+
+	// ResourceLimitAliasExpansions is the maximum number of YAML aliases expanded.
+	ResourceLimitAliasExpansions ResourceLimit = "alias-expansions"
+
+	// ResourceLimitIndexingTime is the maximum wall-clock time spent indexing.
+	ResourceLimitIndexingTime ResourceLimit = "indexing-time"
+)
+
+// ResourceBudgetError describes the limit of a datamodel.ResourceBudget exceeded while indexing.
+type ResourceBudgetError struct {
+	// Limit is the limit that was exceeded.
+	Limit ResourceLimit
+
+	// Max is the value of the limit, a time.Duration for ResourceLimitIndexingTime.
+	Max int64
+
+	// Used is the amount used when the limit was exceeded, a time.Duration for ResourceLimitIndexingTime.
+	Used int64
+
+	// Location is the document or reference being indexed when the limit was exceeded.
+	Location string
+}
+
+func (e *ResourceBudgetError) Error() string {
+	if e == nil {
+		return ErrResourceBudgetExceeded.Error()
+	}
+	max, used := fmt.Sprint(e.Max), fmt.Sprint(e.Used)
+	if e.Limit == ResourceLimitIndexingTime {
+		max, used = time.Duration(e.Max).String(), time.Duration(e.Used).String()
+	}
+	return fmt.Sprintf("%s: %s limit of %s exceeded (%s) at '%s'", ErrResourceBudgetExceeded, e.Limit, max, used,
+		e.Location)
+}
+
+// Unwrap returns ErrResourceBudgetExceeded for errors.Is checks.
+func (e *ResourceBudgetError) Unwrap() error {
+	return ErrResourceBudgetExceeded
+}
+
+// resourceBudget tracks the resources used by a rolodex against a datamodel.ResourceBudget. The first limit exceeded
+// is kept, every later check fails with it, so indexing stops. All methods are safe on a nil budget (no limits).
+type resourceBudget struct {
+	limits    datamodel.ResourceBudget
+	lock      sync.Mutex
+	files     map[string]bool
+	documents map[string]bool
+	bytes     int64
+	nodes     int64
+	aliases   int64
+	started   atomic.Int64
+	exceeded  atomic.Pointer[ResourceBudgetError]
+	reported  atomic.Bool
+}
+
+func newResourceBudget(limits *datamodel.ResourceBudget) *resourceBudget {
+	if limits == nil {
+		return nil
+	}
+	return &resourceBudget{
+		limits:    *limits,
+		files:     make(map[string]bool),
+		documents: make(map[string]bool),
+	}
+}
+
+// start starts the indexing clock, if it has not been started.
+func (b *resourceBudget) start() {
+	if b == nil {
+		return
+	}
+	b.started.CompareAndSwap(0, time.Now().UnixNano())
+}
+
+// fail keeps the first limit exceeded, and returns it.
+func (b *resourceBudget) fail(err *ResourceBudgetError) error {
+	b.exceeded.CompareAndSwap(nil, err)
+	return b.exceeded.Load()
+}
+
+// check returns the limit exceeded so far, checking the indexing time at a location.
+func (b *resourceBudget) check(location string) error {
+	if b == nil {
+		return nil
+	}
+	if err := b.exceeded.Load(); err != nil {
+		return err
+	}
+	started := b.started.Load()
+	if b.limits.MaxIndexingTime > 0 && started > 0 {
+		if elapsed := time.Since(time.Unix(0, started)); elapsed > b.limits.MaxIndexingTime {
+			return b.fail(&ResourceBudgetError{Limit: ResourceLimitIndexingTime,
+				Max: int64(b.limits.MaxIndexingTime), Used: int64(elapsed), Location: location})
+		}
+	}
+	return nil
+}
+
+// budgetLocation returns the key a location is charged under. Local paths are cleaned and made absolute, so a file
+// opened by a file system and indexed by the rolodex under different spellings of its path is only charged once.
+func budgetLocation(location string) string {
+	if location == "" || strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return location
+	}
+	if abs, err := filepath.Abs(location); err == nil {
+		return abs
+	}
+	return filepath.Clean(location)
+}
+
+// chargeFile charges a document, and the bytes read from it. Each location is only charged once.
+func (b *resourceBudget) chargeFile(location string, size int64) error {
+	if err := b.check(location); err != nil || b == nil {
+		return err
+	}
+	location = budgetLocation(location)
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.files[location] {
+		return nil
+	}
+	b.files[location] = true
+	b.bytes += size
+	if b.limits.MaxFiles > 0 && len(b.files) > b.limits.MaxFiles {
+		return b.fail(&ResourceBudgetError{Limit: ResourceLimitFiles, Max: int64(b.limits.MaxFiles),
+			Used: int64(len(b.files)), Location: location})
+	}
+	if b.limits.MaxBytes > 0 && b.bytes > b.limits.MaxBytes {
+		return b.fail(&ResourceBudgetError{Limit: ResourceLimitBytes, Max: b.limits.MaxBytes, Used: b.bytes,
+			Location: location})
+	}
+	return nil
+}
+
+// chargeDocument charges the YAML nodes of a parsed document, and the aliases expanded walking it. Each location is
+// only charged once.
+func (b *resourceBudget) chargeDocument(location string, root *yaml.Node) error {
+	if err := b.check(location); err != nil || b == nil {
+		return err
+	}
+	if b.limits.MaxYAMLNodes <= 0 && b.limits.MaxAliasExpansions <= 0 {
+		return nil
+	}
+	location = budgetLocation(location)
+	b.lock.Lock()
+	if b.documents[location] {
+		b.lock.Unlock()
+		return nil
+	}
+	b.documents[location] = true
+	b.lock.Unlock()
+
+	nodes, aliases := countYAMLNodes(root)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.nodes = addSaturated(b.nodes, nodes)
+	b.aliases = addSaturated(b.aliases, aliases)
+	if b.limits.MaxYAMLNodes > 0 && b.nodes > int64(b.limits.MaxYAMLNodes) {
+		return b.fail(&ResourceBudgetError{Limit: ResourceLimitYAMLNodes, Max: int64(b.limits.MaxYAMLNodes),
+			Used: b.nodes, Location: location})
+	}
+	if b.limits.MaxAliasExpansions > 0 && b.aliases > int64(b.limits.MaxAliasExpansions) {
+		return b.fail(&ResourceBudgetError{Limit: ResourceLimitAliasExpansions,
+			Max: int64(b.limits.MaxAliasExpansions), Used: b.aliases, Location: location})
+	}
+	return nil
+}
+
+// checkDepth checks the number of references followed in a chain, at a reference.
+func (b *resourceBudget) checkDepth(location string, depth int) error {
+	if err := b.check(location); err != nil || b == nil {
+		return err
+	}
+	if b.limits.MaxReferenceDepth > 0 && depth > b.limits.MaxReferenceDepth {
+		return b.fail(&ResourceBudgetError{Limit: ResourceLimitReferenceDepth,
+			Max: int64(b.limits.MaxReferenceDepth), Used: int64(depth), Location: location})
+	}
+	return nil
+}
+
+// limitReader limits a reader to one byte past the remaining bytes, so a document exceeding the budget is never
+// read in full, but is still charged past it.
+func (b *resourceBudget) limitReader(r io.Reader) io.Reader {
+	if b == nil || b.limits.MaxBytes <= 0 {
+		return r
+	}
+	b.lock.Lock()
+	remaining := b.limits.MaxBytes - b.bytes
+	b.lock.Unlock()
+	return io.LimitReader(r, max(remaining, 0)+1)
+}
+
+// report returns the limit exceeded, only the first time it is called once a limit has been exceeded.
+func (b *resourceBudget) report() error {
+	if b == nil {
+		return nil
+	}
+	if err := b.exceeded.Load(); err != nil && b.reported.CompareAndSwap(false, true) {
+		return err
+	}
+	return nil
+}
+
+// countYAMLNodes returns the number of nodes of a YAML tree, and the number of aliases expanded by a walk following
+// every alias. Aliases are counted once per node, so an alias bomb is counted without being expanded.
+func countYAMLNodes(root *yaml.Node) (nodes, aliases int64) {
+	expansions := make(map[*yaml.Node]int64)
+	var walk func(n *yaml.Node) int64
+	walk = func(n *yaml.Node) int64 {
+		if n == nil {
+			return 0
+		}
+		if count, ok := expansions[n]; ok {
+			return count
+		}
+		expansions[n] = 0 // an alias to one of its own ancestors is not expanded.
+		var count int64
+		if n.Kind == yaml.AliasNode {
+			count = addSaturated(1, walk(n.Alias))
+		} else {
+			for _, c := range n.Content {
+				count = addSaturated(count, walk(c))
+			}
+		}
+		expansions[n] = count
+		return count
+	}
+	aliases = walk(root)
+
+	seen := make(map[*yaml.Node]bool)
+	stack := []*yaml.Node{root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		nodes++
+		stack = append(stack, n.Content...)
+	}
+	return nodes, aliases
+}
+
+func addSaturated(a, b int64) int64 {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
+// resourceBudget returns the resource budget of the rolodex, nil if there is none.
+func (r *Rolodex) resourceBudget() *resourceBudget {
+	if r == nil {
+		return nil
+	}
+	return r.budget
+}
+
+// exceedsResourceBudget checks the resource budget before visiting a reference, at a depth in a chain. The limit
+// exceeded is added to the resolving errors, unless it was already reported.
+func (resolver *Resolver) exceedsResourceBudget(ref *Reference, depth int) bool {
+	if resolver.specIndex == nil {
+		return false
+	}
+	budget := resolver.specIndex.rolodex.resourceBudget()
+	if budget.checkDepth(ref.FullDefinition, depth) == nil {
+		return false
+	}
+	if err := budget.report(); err != nil {
+		resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
+			ErrorRef: err,
+			Node:     ref.Node,
+			Path:     ref.FullDefinition,
+		})
+	}
+	return true
+}
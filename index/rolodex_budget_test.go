@@ -0,0 +1,267 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// indexWithBudget indexes a root document in a directory of files with a resource budget.
+func indexWithBudget(t *testing.T, budget *datamodel.ResourceBudget, root string, files map[string]string) (*Rolodex, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	info, err := datamodel.ExtractSpecInfo([]byte(root))
+	require.NoError(t, err)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	cf.SpecFilePath = filepath.Join(dir, "root.yaml")
+	cf.SpecInfo = info
+	cf.ResourceBudget = budget
+	rolodex := NewRolodex(cf)
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: cf})
+	require.NoError(t, err)
+	rolodex.AddLocalFS(dir, localFS)
+	rolodex.SetRootNode(info.RootNode)
+	return rolodex, rolodex.IndexTheRolodex(context.Background())
+}
+
+func requireBudgetError(t *testing.T, errs []error, limit ResourceLimit) *ResourceBudgetError {
+	t.Helper()
+	var budgetErrs []*ResourceBudgetError
+	for _, err := range errs {
+		var resolvingErr *ResolvingError
+		if errors.As(err, &resolvingErr) {
+			err = resolvingErr.ErrorRef
+		}
+		var budgetErr *ResourceBudgetError
+		if errors.As(err, &budgetErr) {
+			assert.ErrorIs(t, err, ErrResourceBudgetExceeded)
+			budgetErrs = append(budgetErrs, budgetErr)
+		}
+	}
+	require.Len(t, budgetErrs, 1, "a budget is only reported once")
+	assert.Equal(t, limit, budgetErrs[0].Limit)
+	return budgetErrs[0]
+}
+
+const budgetRoot = `openapi: 3.1.0
+components:
+  schemas:
+    A:
+      $ref: 'a.yaml'
+    B:
+      $ref: 'b.yaml'
+    C:
+      $ref: 'c.yaml'`
+
+var budgetFiles = map[string]string{
+	"a.yaml": "type: string",
+	"b.yaml": "type: integer",
+	"c.yaml": "type: boolean",
+}
+
+func TestRolodex_ResourceBudget_Files(t *testing.T) {
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxFiles: 4}, budgetRoot, budgetFiles)
+	require.NoError(t, err)
+
+	rolodex, err = indexWithBudget(t, &datamodel.ResourceBudget{MaxFiles: 2}, budgetRoot, budgetFiles)
+	require.Error(t, err)
+	budgetErr := requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitFiles)
+	assert.Equal(t, int64(2), budgetErr.Max)
+	assert.Equal(t, int64(3), budgetErr.Used)
+	assert.Contains(t, budgetErr.Error(), "resource budget exceeded: files limit of 2 exceeded (3)")
+
+	// indexing stopped, no more files were opened.
+	assert.Len(t, rolodex.GetIndexes(), 1)
+}
+
+func TestRolodex_ResourceBudget_FilesChargedOnce(t *testing.T) {
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxFiles: 4}, budgetRoot, budgetFiles)
+	require.NoError(t, err)
+
+	// the file was charged when it was indexed, opening it again under another spelling of its path is free.
+	dir := filepath.Dir(rolodex.indexConfig.SpecFilePath)
+	f, err := rolodex.Open(dir + string(filepath.Separator) + "." + string(filepath.Separator) + "a.yaml")
+	require.NoError(t, err)
+	assert.NotNil(t, f)
+	assert.NoError(t, rolodex.resourceBudget().check("a.yaml"))
+}
+
+func TestResourceBudget_ChargeFileByAbsolutePath(t *testing.T) {
+	budget := newResourceBudget(&datamodel.ResourceBudget{MaxFiles: 1})
+	abs, err := filepath.Abs("a.yaml")
+	require.NoError(t, err)
+	assert.NoError(t, budget.chargeFile(abs, 1))
+	assert.NoError(t, budget.chargeFile("a.yaml", 1))
+	assert.NoError(t, budget.chargeFile(filepath.Join("sub", "..", "a.yaml"), 1))
+	assert.NoError(t, budget.chargeFile(filepath.Dir(abs)+string(filepath.Separator)+"."+string(filepath.Separator)+"a.yaml", 1))
+
+	// urls are charged as they are.
+	budget = newResourceBudget(&datamodel.ResourceBudget{MaxFiles: 1})
+	assert.NoError(t, budget.chargeFile("https://pb33f.io/a.yaml", 1))
+	assert.Error(t, budget.chargeFile("https://pb33f.io/b.yaml", 1))
+}
+
+func TestRolodex_ResourceBudget_Bytes(t *testing.T) {
+	// the root (120 bytes) and one file fit, the second file does not.
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxBytes: 140}, budgetRoot, budgetFiles)
+	require.Error(t, err)
+	budgetErr := requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitBytes)
+	assert.Equal(t, int64(140), budgetErr.Max)
+	assert.Greater(t, budgetErr.Used, int64(140))
+}
+
+func TestRolodex_ResourceBudget_RemoteBytes(t *testing.T) {
+	written := make(chan int64, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var n int64
+		for i := 0; i < 1024; i++ {
+			w, err := rw.Write([]byte(strings.Repeat("# padding\n", 100)))
+			n += int64(w)
+			if err != nil {
+				break
+			}
+		}
+		written <- n
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.ResourceBudget = &datamodel.ResourceBudget{MaxBytes: 4096}
+	rolodex := NewRolodex(cf)
+	remoteFS, err := NewRemoteFSWithConfig(cf)
+	require.NoError(t, err)
+	rolodex.AddRemoteFS(server.URL, remoteFS)
+
+	_, err = remoteFS.Open(server.URL + "/huge.yaml")
+	var budgetErr *ResourceBudgetError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, ResourceLimitBytes, budgetErr.Limit)
+	assert.Equal(t, int64(4097), budgetErr.Used, "the payload is never read past the budget")
+	assert.Equal(t, server.URL+"/huge.yaml", budgetErr.Location)
+
+	// every later fetch is refused.
+	_, err = remoteFS.Open(server.URL + "/small.yaml")
+	var laterErr *ResourceBudgetError
+	require.ErrorAs(t, err, &laterErr)
+	assert.Same(t, budgetErr, laterErr)
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+	}
+}
+
+func TestRolodex_ResourceBudget_ReferenceDepth(t *testing.T) {
+	chain := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/A'
+components:
+  schemas:
+    A:
+      $ref: '#/components/schemas/B'
+    B:
+      $ref: '#/components/schemas/C'
+    C:
+      $ref: '#/components/schemas/D'
+    D:
+      type: string`
+
+	_, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxReferenceDepth: 4}, chain, nil)
+	require.NoError(t, err)
+
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxReferenceDepth: 2}, chain, nil)
+	require.Error(t, err)
+	budgetErr := requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitReferenceDepth)
+	assert.Equal(t, int64(3), budgetErr.Used)
+	assert.True(t, strings.HasSuffix(budgetErr.Location, "#/components/schemas/C"))
+}
+
+func TestRolodex_ResourceBudget_YAMLNodes(t *testing.T) {
+	// the root fits, a referenced file pushes it past the limit.
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxYAMLNodes: 20}, budgetRoot, budgetFiles)
+	require.Error(t, err)
+	budgetErr := requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitYAMLNodes)
+	assert.Greater(t, budgetErr.Used, int64(20))
+	assert.NotEmpty(t, rolodex.GetRootIndex().GetAllReferences())
+
+	rolodex, err = indexWithBudget(t, &datamodel.ResourceBudget{MaxYAMLNodes: 10}, budgetRoot, budgetFiles)
+	require.Error(t, err)
+	requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitYAMLNodes)
+	assert.Empty(t, rolodex.GetRootIndex().GetAllReferences(), "the document was not indexed")
+}
+
+func TestRolodex_ResourceBudget_AliasExpansions(t *testing.T) {
+	var bomb strings.Builder
+	bomb.WriteString("openapi: 3.1.0\nx-bomb:\n  a: &a [lol, lol, lol, lol, lol, lol, lol, lol, lol]\n")
+	for i, prev := range "abcdefgh" {
+		name := string(rune('b' + i))
+		fmt.Fprintf(&bomb, "  %s: &%s [*%c, *%c, *%c, *%c, *%c, *%c, *%c, *%c, *%c]\n", name, name,
+			prev, prev, prev, prev, prev, prev, prev, prev, prev)
+	}
+
+	start := time.Now()
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxAliasExpansions: 10000}, bomb.String(), nil)
+	require.Error(t, err)
+	budgetErr := requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitAliasExpansions)
+	assert.Greater(t, budgetErr.Used, int64(10000))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRolodex_ResourceBudget_IndexingTime(t *testing.T) {
+	rolodex, err := indexWithBudget(t, &datamodel.ResourceBudget{MaxIndexingTime: time.Nanosecond}, budgetRoot,
+		budgetFiles)
+	require.Error(t, err)
+	budgetErr := requireBudgetError(t, rolodex.GetCaughtErrors(), ResourceLimitIndexingTime)
+	assert.Contains(t, budgetErr.Error(), "indexing-time limit of 1ns exceeded")
+}
+
+func TestCountYAMLNodes(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("a: &x [1, 2]\nb: *x\nc: [*x, *x]\nd: &y {e: *x}\nf: *y"), &root))
+	nodes, aliases := countYAMLNodes(&root)
+	assert.Equal(t, int64(18), nodes)
+	assert.Equal(t, int64(6), aliases) // b, c twice, e, f and the e reached through f.
+	nodes, aliases = countYAMLNodes(nil)
+	assert.Zero(t, nodes)
+	assert.Zero(t, aliases)
+}
+
+func TestResourceBudget_Nil(t *testing.T) {
+	var budget *resourceBudget
+	budget.start()
+	assert.NoError(t, budget.check("a"))
+	assert.NoError(t, budget.chargeFile("a", 1))
+	assert.NoError(t, budget.chargeDocument("a", &yaml.Node{}))
+	assert.NoError(t, budget.checkDepth("a", 1000))
+	assert.NoError(t, budget.report())
+	r := strings.NewReader("abc")
+	assert.Same(t, r, budget.limitReader(r))
+	assert.Nil(t, newResourceBudget(nil))
+	assert.Equal(t, ErrResourceBudgetExceeded.Error(), (*ResourceBudgetError)(nil).Error())
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// referenceCatalog resolves canonical URLs against a datamodel.ReferenceCatalog, and keeps the files it has served,
+// so each mapped document is only read and indexed once. All methods are safe on a nil catalog (nothing is mapped).
+type referenceCatalog struct {
+	documents map[string][]byte
+	urls      map[string]string
+	prefixes  []catalogPrefix
+	files     map[string]*RemoteFile
+	lock      sync.Mutex
+}
+
+type catalogPrefix struct {
+	prefix    string
+	directory string
+}
+
+// catalogSource is where the catalog serves a canonical URL from, either a local file or in-memory bytes.
+type catalogSource struct {
+	canonical *url.URL
+	file      string
+	data      []byte
+}
+
+func newReferenceCatalog(catalog *datamodel.ReferenceCatalog) *referenceCatalog {
+	if catalog == nil || (len(catalog.Documents) == 0 && len(catalog.URLs) == 0 && len(catalog.Prefixes) == 0) {
+		return nil
+	}
+	c := &referenceCatalog{
+		documents: make(map[string][]byte, len(catalog.Documents)),
+		urls:      make(map[string]string, len(catalog.URLs)),
+		files:     make(map[string]*RemoteFile),
+	}
+	for u, data := range catalog.Documents {
+		c.documents[catalogKey(u)] = data
+	}
+	for u, file := range catalog.URLs {
+		c.urls[catalogKey(u)] = catalogPath(file)
+	}
+	for prefix, dir := range catalog.Prefixes {
+		c.prefixes = append(c.prefixes, catalogPrefix{prefix: catalogKey(prefix), directory: catalogPath(dir)})
+	}
+	sort.Slice(c.prefixes, func(i, j int) bool {
+		if len(c.prefixes[i].prefix) != len(c.prefixes[j].prefix) {
+			return len(c.prefixes[i].prefix) > len(c.prefixes[j].prefix)
+		}
+		return c.prefixes[i].prefix < c.prefixes[j].prefix
+	})
+	return c
+}
+
+// catalogKey removes the fragment of a URL, catalog entries match whole documents.
+func catalogKey(location string) string {
+	key, _, _ := strings.Cut(location, "#")
+	return key
+}
+
+func catalogPath(location string) string {
+	if abs, err := filepath.Abs(location); err == nil {
+		return abs
+	}
+	return location
+}
+
+// lookup returns where a location is served from, or nil when the catalog does not map it. A prefix mapping that
+// would escape its directory is an error.
+func (c *referenceCatalog) lookup(location string) (*catalogSource, error) {
+	if c == nil {
+		return nil, nil
+	}
+	key := catalogKey(location)
+	var source *catalogSource
+	if data, ok := c.documents[key]; ok {
+		source = &catalogSource{data: data}
+	} else if file, ok := c.urls[key]; ok {
+		source = &catalogSource{file: file}
+	} else {
+		for _, p := range c.prefixes {
+			if !strings.HasPrefix(key, p.prefix) {
+				continue
+			}
+			remainder, _, _ := strings.Cut(strings.TrimPrefix(key, p.prefix), "?")
+			file := filepath.Join(p.directory, filepath.FromSlash(remainder))
+			if rel, err := filepath.Rel(p.directory, file); err != nil || rel == ".." ||
+				strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return nil, fmt.Errorf("reference catalog cannot map '%s', it escapes the directory '%s'",
+					location, p.directory)
+			}
+			source = &catalogSource{file: file}
+			break
+		}
+	}
+	if source == nil {
+		return nil, nil
+	}
+	canonical, err := url.Parse(key)
+	if err != nil {
+		return nil, err
+	}
+	source.canonical = canonical
+	return source, nil
+}
+
+// openCatalogLocation serves a location mapped by the reference catalog, indexing it under its canonical URL the
+// first time it is opened. No remote lookup is made for a mapped location, a mapped file that cannot be read is an
+// error. It returns nil, with no error, when the location is not mapped.
+func (r *Rolodex) openCatalogLocation(ctx context.Context, location string) (*RemoteFile, error) {
+	c := r.catalog
+	source, err := c.lookup(location)
+	if source == nil || err != nil {
+		return nil, err
+	}
+	canonical := source.canonical.String()
+
+	c.lock.Lock()
+	if existing, ok := c.files[canonical]; ok {
+		c.lock.Unlock()
+		return existing, nil
+	}
+	remoteFile, err := r.readCatalogSource(source)
+	if err == nil {
+		err = r.resourceBudget().chargeFile(canonical, remoteFile.Size())
+	}
+	if err != nil {
+		c.lock.Unlock()
+		return nil, err
+	}
+	c.files[canonical] = remoteFile
+	c.lock.Unlock()
+
+	r.logger.Debug("[rolodex] serving reference from catalog", "location", canonical, "file", source.file)
+
+	// index the document after releasing the lock, references back to it wait for the indexing to complete.
+	copiedCfg := *r.indexConfig
+	copiedCfg.Rolodex = r
+	copiedCfg.SpecAbsolutePath = canonical
+	copiedCfg.SpecInfo = nil
+	copiedCfg.ExtractRefsSequentially = true
+	if baseURL, parseErr := url.Parse(fmt.Sprintf("%s://%s%s", source.canonical.Scheme, source.canonical.Host,
+		path.Dir(source.canonical.Path))); parseErr == nil {
+		copiedCfg.BaseURL = baseURL
+	}
+
+	indexingCtx := AddIndexingFile(ctx, canonical)
+	idx, idxErr := remoteFile.Index(indexingCtx, &copiedCfg)
+	if idxErr != nil && idx == nil {
+		remoteFile.seekingErrors = append(remoteFile.seekingErrors, idxErr)
+		remoteFile.signalIndexingComplete()
+		return remoteFile, nil
+	}
+	NewResolver(idx)
+	idx.BuildIndex()
+	r.AddExternalIndex(idx, canonical)
+	remoteFile.signalIndexingComplete()
+	return remoteFile, nil
+}
+
+// readCatalogSource reads a catalog source into a RemoteFile named after its canonical URL.
+func (r *Rolodex) readCatalogSource(source *catalogSource) (*RemoteFile, error) {
+	data := source.data
+	lastModified := time.Now()
+	if source.file != "" {
+		var err error
+		if data, err = os.ReadFile(source.file); err != nil {
+			return nil, &fs.PathError{Op: "open", Path: source.canonical.String(),
+				Err: fmt.Errorf("reference catalog file '%s': %w", source.file, err)}
+		}
+		if stat, statErr := os.Stat(source.file); statErr == nil {
+			lastModified = stat.ModTime()
+		}
+	}
+	extension := ExtractFileType(source.canonical.Path)
+	if extension == UNSUPPORTED && source.file != "" {
+		extension = ExtractFileType(source.file)
+	}
+	if extension == UNSUPPORTED {
+		extension = detectContentType(data)
+	}
+	return &RemoteFile{
+		filename:         path.Base(source.canonical.Path),
+		name:             source.canonical.Path,
+		extension:        extension,
+		data:             data,
+		fullPath:         source.canonical.String(),
+		URL:              source.canonical,
+		lastModified:     lastModified,
+		indexingComplete: make(chan struct{}),
+	}, nil
+}
+
+// GetCatalogFiles returns the files served by the reference catalog, keyed by their canonical URL.
+func (r *Rolodex) GetCatalogFiles() map[string]RolodexFile {
+	files := make(map[string]RolodexFile)
+	if r == nil || r.catalog == nil {
+		return files
+	}
+	r.catalog.lock.Lock()
+	defer r.catalog.lock.Unlock()
+	for location, f := range r.catalog.files {
+		files[location] = f
+	}
+	return files
+}
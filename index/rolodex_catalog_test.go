@@ -0,0 +1,117 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+// indexWithCatalog indexes a root document with a reference catalog, and no file systems.
+func indexWithCatalog(t *testing.T, catalog *datamodel.ReferenceCatalog, root string) (*Rolodex, error) {
+	t.Helper()
+	info, err := datamodel.ExtractSpecInfo([]byte(root))
+	require.NoError(t, err)
+
+	cf := CreateClosedAPIIndexConfig()
+	cf.SpecInfo = info
+	cf.ReferenceCatalog = catalog
+	rolodex := NewRolodex(cf)
+	rolodex.SetRootNode(info.RootNode)
+	err = rolodex.IndexTheRolodex(context.Background())
+	rolodex.Resolve()
+	return rolodex, err
+}
+
+func TestRolodex_ReferenceCatalog(t *testing.T) {
+	vendor := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(vendor, "common", "v1"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(vendor, "common", "v1", "money.yaml"), []byte(`Money:
+  type: object
+  properties:
+    currency:
+      $ref: 'currency.yaml'
+    amount:
+      type: number`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(vendor, "common", "v1", "currency.yaml"),
+		[]byte("type: string\nenum: [EUR, USD]"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(vendor, "pinned.yaml"), []byte("type: integer"), 0o644))
+
+	catalog := &datamodel.ReferenceCatalog{
+		Prefixes: map[string]string{
+			"https://schemas.example.com/":           filepath.Join(vendor, "missing"),
+			"https://schemas.example.com/common/":    filepath.Join(vendor, "common"),
+			"https://schemas.example.com/elsewhere/": vendor,
+		},
+		URLs: map[string]string{
+			"https://schemas.example.com/common/v2/pinned.yaml": filepath.Join(vendor, "pinned.yaml"),
+		},
+		Documents: map[string][]byte{
+			"https://schemas.example.com/errors.json": []byte(`{"Error": {"type": "object"}}`),
+		},
+	}
+	rolodex, err := indexWithCatalog(t, catalog, `openapi: 3.1.0
+components:
+  schemas:
+    Price:
+      $ref: 'https://schemas.example.com/common/v1/money.yaml#/Money'
+    Pinned:
+      $ref: 'https://schemas.example.com/common/v2/pinned.yaml'
+    Error:
+      $ref: 'https://schemas.example.com/errors.json#/Error'`)
+	require.NoError(t, err)
+	assert.Empty(t, rolodex.GetRootIndex().GetResolver().GetResolvingErrors())
+
+	// references report their canonical URL, never the local copy.
+	refs := rolodex.GetRootIndex().GetMappedReferences()
+	require.Contains(t, refs, "https://schemas.example.com/common/v1/money.yaml#/Money")
+	assert.Equal(t, "https://schemas.example.com/errors.json#/Error",
+		refs["https://schemas.example.com/errors.json#/Error"].FullDefinition)
+
+	files := rolodex.GetCatalogFiles()
+	assert.Len(t, files, 4)
+	require.Contains(t, files, "https://schemas.example.com/common/v1/currency.yaml")
+	assert.Equal(t, "type: integer", files["https://schemas.example.com/common/v2/pinned.yaml"].GetContent())
+
+	// relative references inside a mapped document resolve against its canonical URL.
+	money := files["https://schemas.example.com/common/v1/money.yaml"].GetIndex()
+	require.NotNil(t, money)
+	assert.Contains(t, money.GetMappedReferences(), "https://schemas.example.com/common/v1/currency.yaml")
+
+	// each document is served once.
+	again, err := rolodex.Open("https://schemas.example.com/common/v1/money.yaml#/Money")
+	require.NoError(t, err)
+	assert.Same(t, money, again.GetIndex())
+}
+
+func TestRolodex_ReferenceCatalog_Errors(t *testing.T) {
+	vendor := t.TempDir()
+	catalog := &datamodel.ReferenceCatalog{
+		Prefixes: map[string]string{"https://schemas.example.com/common/": vendor},
+	}
+	rolodex, _ := indexWithCatalog(t, catalog, "openapi: 3.1.0")
+
+	// a mapped document is never fetched, a missing local copy is an error.
+	_, err := rolodex.Open("https://schemas.example.com/common/money.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Contains(t, err.Error(), "reference catalog file")
+
+	_, err = rolodex.Open("https://schemas.example.com/common/../../secrets.yaml")
+	assert.ErrorContains(t, err, "escapes the directory")
+
+	// unmapped locations fall through to the file systems.
+	_, err = rolodex.Open("https://schemas.example.com/other.yaml")
+	assert.ErrorContains(t, err, "rolodex has no file systems configured")
+	assert.Empty(t, rolodex.GetCatalogFiles())
+
+	assert.Nil(t, newReferenceCatalog(&datamodel.ReferenceCatalog{}))
+	assert.Empty(t, (*Rolodex)(nil).GetCatalogFiles())
+}
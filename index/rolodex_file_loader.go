@@ -40,6 +40,13 @@ type LocalFS struct {
 	readingErrors       []error
 	rolodex             *Rolodex
 	processingFiles     sync.Map
+
+	// openSource opens files in place of the OS, when set. Used by file systems built on a LocalFS, like GitFS.
+	openSource func(path string) (fs.File, error)
+
+	// allowFileLookup opens files even when the index configuration does not AllowFileLookup. Set by file systems
+	// that only exist to read files, like GitFS.
+	allowFileLookup bool
 }
 
 // GetFiles returns the files that have been indexed. A map of RolodexFile objects keyed by the full path of the file.
@@ -77,7 +84,7 @@ type waiterLocal struct {
 }
 
 func (l *LocalFS) OpenWithContext(ctx context.Context, name string) (fs.File, error) {
-	if l.indexConfig != nil && !l.indexConfig.AllowFileLookup {
+	if !l.allowFileLookup && l.indexConfig != nil && !l.indexConfig.AllowFileLookup {
 		return nil, &fs.PathError{
 			Op: "open", Path: name,
 			Err: fmt.Errorf("file lookup for '%s' not allowed, set the index configuration "+
@@ -123,6 +130,10 @@ func (l *LocalFS) OpenWithContext(ctx context.Context, name string) (fs.File, er
 		l.logger.Debug("[rolodex file loader]: extracting file from OS", "file", name)
 		extractedFile, extErr = l.extractFile(name)
 
+		if extErr == nil && extractedFile != nil {
+			extErr = l.rolodex.resourceBudget().chargeFile(name, int64(len(extractedFile.data)))
+		}
+
 		if extErr != nil {
 			processingWaiter.error = extErr
 			processingWaiter.done = true
@@ -499,6 +510,13 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 			if fileError != nil {
 				return nil, fileError
 			}
+		} else if l.openSource != nil {
+			l.logger.Debug("[rolodex file loader]: reading local file from source", "file", extension, "location", abs)
+			var fileError error
+			file, fileError = l.openSource(abs)
+			if fileError != nil {
+				return nil, fileError
+			}
 		} else {
 			l.logger.Debug("[rolodex file loader]: reading local file from OS", "file", extension, "location", abs)
 			var fileError error
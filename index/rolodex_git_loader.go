@@ -0,0 +1,295 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// GitFSConfig is the configuration for a GitFS.
+type GitFSConfig struct {
+	// Repository is the working tree of a local git repository, or any directory inside it.
+	Repository string
+
+	// Revision is the commit to read files from, anything git can resolve to a commit: a branch, a tag, a commit
+	// hash or an expression like HEAD~2. Defaults to HEAD.
+	Revision string
+
+	// BaseDirectory is the directory relative references are resolved from, like LocalFSConfig.BaseDirectory. It is
+	// resolved against the current working directory, and must be inside the repository. Defaults to the root of
+	// the repository. Use the same directory as the BasePath of the document configuration.
+	BaseDirectory string
+
+	// GitBinary is the git executable to run. Defaults to "git", looked up in the PATH.
+	GitBinary string
+
+	// supply your own logger
+	Logger *slog.Logger
+
+	// supply an index configuration to use, defaults to the configuration of the rolodex the GitFS is added to.
+	IndexConfig *SpecIndexConfig
+}
+
+// GitFS is a RolodexFS that reads files as they existed at a commit of a local git repository, without checking the
+// commit out. Files keep the path they have in the working tree, so documents loaded from different revisions of
+// the same repository report the same locations, and can be compared with each other.
+//
+// The tree of the commit is listed once, when the GitFS is created. Files are read from the git object database
+// when they are first opened, and are indexed the same way a LocalFS indexes them. Nothing is fetched, the commit
+// must exist in the local repository. Symbolic links and submodules are not followed. A GitFS reads files whether or
+// not the index configuration has AllowFileLookup set, reading them is all it is for.
+type GitFS struct {
+	*LocalFS
+	git        string
+	repository string
+	commit     string
+	committed  time.Time
+	tree       map[string]gitBlob
+}
+
+// gitBlob is a file in the tree of a commit.
+type gitBlob struct {
+	object string
+	size   int64
+	mode   fs.FileMode
+}
+
+// NewGitFS creates a new GitFS, reading files at the configured revision of a local git repository.
+func NewGitFS(config *GitFSConfig) (*GitFS, error) {
+	if config == nil || config.Repository == "" {
+		return nil, errors.New("git file system requires a repository")
+	}
+	revision := config.Revision
+	if revision == "" {
+		revision = "HEAD"
+	}
+	if strings.HasPrefix(revision, "-") {
+		return nil, fmt.Errorf("invalid git revision '%s'", revision)
+	}
+	g := &GitFS{git: config.GitBinary}
+	if g.git == "" {
+		g.git = "git"
+	}
+
+	repository, _ := filepath.Abs(config.Repository)
+	cdup, err := g.run(repository, "rev-parse", "--show-cdup")
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a git working tree: %w", config.Repository, err)
+	}
+	g.repository = filepath.Clean(filepath.Join(repository, strings.TrimSpace(string(cdup))))
+
+	commit, err := g.run(g.repository, "rev-parse", "--verify", "--quiet", revision+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve git revision '%s': %w", revision, err)
+	}
+	g.commit = strings.TrimSpace(string(commit))
+
+	committed, err := g.run(g.repository, "show", "--no-patch", "--format=%ct", g.commit)
+	if err != nil {
+		return nil, err
+	}
+	if seconds, parseErr := strconv.ParseInt(strings.TrimSpace(string(committed)), 10, 64); parseErr == nil {
+		g.committed = time.Unix(seconds, 0)
+	}
+
+	listing, err := g.run(g.repository, "ls-tree", "-r", "-z", "-l", "--full-tree", g.commit)
+	if err != nil {
+		return nil, err
+	}
+	if g.tree, err = parseGitTree(listing); err != nil {
+		return nil, err
+	}
+
+	baseDirectory := g.repository
+	if config.BaseDirectory != "" {
+		baseDirectory, _ = filepath.Abs(config.BaseDirectory)
+	}
+	if _, ok := g.treePath(baseDirectory); !ok {
+		return nil, fmt.Errorf("base directory '%s' is not inside the repository '%s'", baseDirectory, g.repository)
+	}
+
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: baseDirectory,
+		Logger:        config.Logger,
+		IndexConfig:   config.IndexConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	localFS.openSource = g.openBlob
+	localFS.allowFileLookup = true
+	g.LocalFS = localFS
+	return g, nil
+}
+
+// parseGitTree parses the output of 'git ls-tree -r -z -l', keeping regular files only.
+func parseGitTree(listing []byte) (map[string]gitBlob, error) {
+	tree := make(map[string]gitBlob)
+	for _, entry := range bytes.Split(listing, []byte{0}) {
+		if len(entry) == 0 {
+			continue
+		}
+		meta, name, found := strings.Cut(string(entry), "\t")
+		fields := strings.Fields(meta)
+		if !found || len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected git tree entry '%s'", entry)
+		}
+		if fields[1] != "blob" || (fields[0] != "100644" && fields[0] != "100755") {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[3], 10, 64)
+		mode := fs.FileMode(0o644)
+		if fields[0] == "100755" {
+			mode = 0o755
+		}
+		tree[name] = gitBlob{object: fields[2], size: size, mode: mode}
+	}
+	return tree, nil
+}
+
+// run runs a git command in a directory, and returns its output. The error includes what git reported.
+func (g *GitFS) run(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command(g.git, append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// treePath returns the path of a file in the tree of the commit. Relative names are resolved from the base directory.
+func (g *GitFS) treePath(name string) (string, bool) {
+	abs := name
+	if !filepath.IsAbs(abs) {
+		base := g.repository
+		if g.LocalFS != nil {
+			base = g.baseDirectory
+		}
+		abs, _ = filepath.Abs(utils.CheckPathOverlap(base, filepath.FromSlash(name), string(os.PathSeparator)))
+	}
+	rel, err := filepath.Rel(g.repository, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func (g *GitFS) lookup(op, name string) (string, gitBlob, error) {
+	p, ok := g.treePath(name)
+	if !ok {
+		return "", gitBlob{}, &fs.PathError{Op: op, Path: name,
+			Err: fmt.Errorf("%w: outside of the repository '%s'", fs.ErrNotExist, g.repository)}
+	}
+	blob, ok := g.tree[p]
+	if !ok {
+		return "", gitBlob{}, &fs.PathError{Op: op, Path: name,
+			Err: fmt.Errorf("%w: not found at git revision %s", fs.ErrNotExist, g.commit)}
+	}
+	return p, blob, nil
+}
+
+// openBlob reads a file from the git object database, used by the LocalFS in place of the OS.
+func (g *GitFS) openBlob(name string) (fs.File, error) {
+	p, blob, err := g.lookup("open", name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.run(g.repository, "cat-file", "blob", blob.object)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &gitFile{Reader: bytes.NewReader(data), info: g.fileInfo(p, blob)}, nil
+}
+
+func (g *GitFS) fileInfo(p string, blob gitBlob) *gitFileInfo {
+	return &gitFileInfo{name: path.Base(p), size: blob.size, mode: blob.mode, modTime: g.committed}
+}
+
+// SetRolodex sets the rolodex, and adopts its index configuration if the GitFS was created without one.
+func (g *GitFS) SetRolodex(rolodex *Rolodex) {
+	g.LocalFS.SetRolodex(rolodex)
+	if g.indexConfig == nil && rolodex != nil {
+		g.indexConfig = rolodex.indexConfig
+	}
+}
+
+// ReadFile returns the content of a file at the revision, by its path in the working tree, or a path relative to the
+// base directory. Use it to read the root document.
+func (g *GitFS) ReadFile(name string) ([]byte, error) {
+	_, blob, err := g.lookup("read", name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.run(g.repository, "cat-file", "blob", blob.object)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	return data, nil
+}
+
+// Stat returns a FileInfo describing a file at the revision, without reading it.
+func (g *GitFS) Stat(name string) (fs.FileInfo, error) {
+	p, blob, err := g.lookup("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return g.fileInfo(p, blob), nil
+}
+
+// GetCommit returns the hash of the commit the revision resolved to.
+func (g *GitFS) GetCommit() string {
+	return g.commit
+}
+
+// GetRepository returns the root of the working tree of the repository.
+func (g *GitFS) GetRepository() string {
+	return g.repository
+}
+
+// gitFile is a file read from the git object database.
+type gitFile struct {
+	*bytes.Reader
+	info *gitFileInfo
+}
+
+func (f *gitFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *gitFile) Close() error {
+	return nil
+}
+
+// gitFileInfo describes a file in the tree of a commit, its modification time is the time of the commit.
+type gitFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *gitFileInfo) Name() string       { return i.name }
+func (i *gitFileInfo) Size() int64        { return i.size }
+func (i *gitFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *gitFileInfo) ModTime() time.Time { return i.modTime }
+func (i *gitFileInfo) IsDir() bool        { return false }
+func (i *gitFileInfo) Sys() interface{}   { return nil }
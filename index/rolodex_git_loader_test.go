@@ -0,0 +1,127 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+// gitRepository creates a git repository, committing each revision of files in turn, and tagging it v1, v2 and so on.
+func gitRepository(t *testing.T, revisions ...map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+			"-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	for i, files := range revisions {
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", "revision")
+		git("tag", "v"+string(rune('1'+i)))
+	}
+	return dir
+}
+
+func TestGitFS(t *testing.T) {
+	repo := gitRepository(t, map[string]string{
+		"specs/openapi.yaml": `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'schemas/pet.yaml'`,
+		"specs/schemas/pet.yaml": "type: object",
+	}, map[string]string{
+		"specs/schemas/pet.yaml": "type: string",
+	})
+	// the working tree has moved on, the revision has not.
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "specs", "schemas", "pet.yaml"), []byte("type: number"), 0o644))
+
+	specs := filepath.Join(repo, "specs")
+	gitFS, err := NewGitFS(&GitFSConfig{Repository: specs, Revision: "v1", BaseDirectory: specs})
+	require.NoError(t, err)
+	assert.Equal(t, repo, gitFS.GetRepository())
+	assert.Len(t, gitFS.GetCommit(), 40)
+
+	root, err := gitFS.ReadFile("openapi.yaml")
+	require.NoError(t, err)
+	info, err := datamodel.ExtractSpecInfo(root)
+	require.NoError(t, err)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = specs
+	cf.SpecFilePath = filepath.Join(specs, "openapi.yaml")
+	cf.SpecInfo = info
+	rolodex := NewRolodex(cf)
+	rolodex.AddLocalFS(specs, gitFS)
+	rolodex.SetRootNode(info.RootNode)
+	require.NoError(t, rolodex.IndexTheRolodex(context.Background()))
+	rolodex.Resolve()
+	assert.Empty(t, rolodex.GetCaughtErrors())
+
+	// files keep their working tree path, with the content of the revision.
+	pet, err := rolodex.Open(filepath.Join(specs, "schemas", "pet.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "type: object", pet.GetContent())
+	assert.NotNil(t, pet.GetIndex())
+	assert.Contains(t, gitFS.GetFiles(), filepath.Join(specs, "schemas", "pet.yaml"))
+	assert.Contains(t, rolodex.GetRootIndex().GetMappedReferences(), filepath.Join(specs, "schemas", "pet.yaml"))
+
+	stat, err := gitFS.Stat("schemas/pet.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("type: object")), stat.Size())
+	assert.Equal(t, "pet.yaml", stat.Name())
+
+	later, err := NewGitFS(&GitFSConfig{Repository: repo, Revision: "v2", BaseDirectory: specs})
+	require.NoError(t, err)
+	content, err := later.ReadFile(filepath.Join(specs, "schemas", "pet.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "type: string", string(content))
+	assert.NotEqual(t, gitFS.GetCommit(), later.GetCommit())
+}
+
+func TestGitFS_Errors(t *testing.T) {
+	repo := gitRepository(t, map[string]string{"openapi.yaml": "openapi: 3.1.0"})
+
+	_, err := NewGitFS(nil)
+	assert.Error(t, err)
+	_, err = NewGitFS(&GitFSConfig{Repository: t.TempDir()})
+	assert.ErrorContains(t, err, "is not a git working tree")
+	_, err = NewGitFS(&GitFSConfig{Repository: repo, Revision: "no-such-branch"})
+	assert.ErrorContains(t, err, "unable to resolve git revision 'no-such-branch'")
+	_, err = NewGitFS(&GitFSConfig{Repository: repo, Revision: "--output=/tmp/x"})
+	assert.ErrorContains(t, err, "invalid git revision")
+	_, err = NewGitFS(&GitFSConfig{Repository: repo, BaseDirectory: t.TempDir()})
+	assert.ErrorContains(t, err, "is not inside the repository")
+
+	gitFS, err := NewGitFS(&GitFSConfig{Repository: repo})
+	require.NoError(t, err)
+	_, err = gitFS.ReadFile("missing.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = gitFS.Stat("../outside.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = gitFS.Open(filepath.Join(repo, "missing.yaml"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = parseGitTree([]byte("100644 blob abc\x00"))
+	assert.ErrorContains(t, err, "unexpected git tree entry")
+}
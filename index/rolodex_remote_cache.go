@@ -0,0 +1,408 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// RemoteCacheConfig is the configuration for a RemoteCache.
+type RemoteCacheConfig struct {
+	// Directory is where fetched documents are stored between runs. It is created if it does not exist. When empty,
+	// nothing is written and only the VendorDirectory is used.
+	Directory string
+
+	// VendorDirectory is a read-only directory of pre-seeded documents laid out as <host>/<path>, for example
+	// vendor/api.example.com/schemas/pet.yaml. It is used in offline mode, and when a fetch fails.
+	VendorDirectory string
+
+	// Offline serves documents only from the Directory and the VendorDirectory. A document found in neither is an
+	// error that wraps fs.ErrNotExist, and no network request is ever made.
+	Offline bool
+
+	// Client is used to fetch and revalidate documents. A RemoteCache sends conditional requests, so it replaces the
+	// RemoteHandlerFunc of the RemoteFS it is attached to; customize the client transport for authentication or
+	// proxies, or set a Handler. Defaults to a client with a 120 second timeout. A RemoteFS with a remote policy only
+	// fetches through a client created by NewRemotePolicyClient.
+	Client *http.Client
+
+	// Handler fetches documents in place of the Client when set, for example a RemoteURLHandler that adds
+	// credentials. A handler only receives the URL, so stale documents cannot be revalidated with conditional
+	// requests, they are fetched again in full. A RemoteFS with a remote policy never fetches through a Handler.
+	Handler utils.RemoteURLHandler
+
+	// supply your own logger
+	Logger *slog.Logger
+}
+
+// RemoteCache is a persistent HTTP cache for remote references, keyed by URL (without the fragment). Fresh entries
+// are served without a request, following the max-age, no-cache and no-store directives of Cache-Control and the
+// Expires header. Stale entries are revalidated with If-None-Match and If-Modified-Since when the server supplied
+// an ETag or Last-Modified header, and a 304 response serves the stored copy. If a request fails, a stored or
+// vendored copy is served instead of the error.
+//
+// Attach a RemoteCache to a RemoteFS with SetRemoteCache, or set the RemoteCacheDirectory,
+// RemoteVendorDirectory and OfflineRemoteReferences properties of the DocumentConfiguration.
+type RemoteCache struct {
+	directory       string
+	vendorDirectory string
+	offline         bool
+	client          *http.Client
+	handler         utils.RemoteURLHandler
+	logger          *slog.Logger
+	now             func() time.Time
+}
+
+// remoteCacheEntry is the metadata stored next to each cached document body.
+type remoteCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	StoredAt     time.Time `json:"storedAt"`
+	Expires      time.Time `json:"expires,omitempty"`
+}
+
+// NewRemoteCache creates a new RemoteCache using the supplied configuration.
+func NewRemoteCache(config *RemoteCacheConfig) (*RemoteCache, error) {
+	if config == nil {
+		return nil, errors.New("no remote cache config provided")
+	}
+	if config.Directory == "" && config.VendorDirectory == "" {
+		return nil, errors.New("remote cache requires a directory or a vendor directory")
+	}
+	if config.Directory != "" {
+		if err := os.MkdirAll(config.Directory, 0o755); err != nil {
+			return nil, fmt.Errorf("unable to create remote cache directory: %w", err)
+		}
+	}
+	log := config.Logger
+	if log == nil {
+		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelError,
+		}))
+	}
+	client := config.Client
+	if client == nil {
+		client = &http.Client{
+			Timeout: time.Second * 120,
+		}
+	}
+	return &RemoteCache{
+		directory:       config.Directory,
+		vendorDirectory: config.VendorDirectory,
+		offline:         config.Offline,
+		client:          client,
+		handler:         config.Handler,
+		logger:          log,
+		now:             time.Now,
+	}, nil
+}
+
+// NewRemoteCacheWithDocumentConfig creates a RemoteCache from the remote cache properties of a
+// DocumentConfiguration, fetching with a client enforcing its RemotePolicy when set. It returns nil when none of
+// the remote cache properties are set, and ErrRemotePolicyHandler when a RemotePolicy is set with a RemoteURLHandler.
+func NewRemoteCacheWithDocumentConfig(config *datamodel.DocumentConfiguration) (*RemoteCache, error) {
+	if config == nil || (config.RemoteCacheDirectory == "" && config.RemoteVendorDirectory == "" &&
+		!config.OfflineRemoteReferences) {
+		return nil, nil
+	}
+	if config.RemoteCacheDirectory == "" && config.RemoteVendorDirectory == "" {
+		return nil, errors.New("offline remote references require a RemoteCacheDirectory or RemoteVendorDirectory")
+	}
+	var client *http.Client
+	if config.RemotePolicy != nil {
+		if config.RemoteURLHandler != nil {
+			return nil, ErrRemotePolicyHandler
+		}
+		var err error
+		if client, err = NewRemotePolicyClient(config.RemotePolicy, time.Second*120); err != nil {
+			return nil, err
+		}
+	}
+	return NewRemoteCache(&RemoteCacheConfig{
+		Directory:       config.RemoteCacheDirectory,
+		VendorDirectory: config.RemoteVendorDirectory,
+		Offline:         config.OfflineRemoteReferences,
+		Client:          client,
+		Handler:         config.RemoteURLHandler,
+		Logger:          config.Logger,
+	})
+}
+
+// IsOffline returns true if the cache never makes network requests.
+func (c *RemoteCache) IsOffline() bool {
+	return c.offline
+}
+
+// Fetch returns the response for remoteURL, served from the cache when possible. Responses served from the cache
+// have a 200 status and the stored Last-Modified and ETag headers. A server error (5xx) is a failed request, the
+// stored or vendored copy is served when there is one. Other non-200 responses from the server are returned as-is
+// and are not stored.
+func (c *RemoteCache) Fetch(ctx context.Context, remoteURL string) (*http.Response, error) {
+	key := remoteCacheKey(remoteURL)
+	entry, body := c.load(key)
+
+	if c.offline {
+		if body != nil {
+			c.logger.Debug("[rolodex remote cache] offline, serving cached document", "url", key)
+			return cachedResponse(entry, body), nil
+		}
+		if body = c.loadVendored(key); body != nil {
+			c.logger.Debug("[rolodex remote cache] offline, serving vendored document", "url", key)
+			return cachedResponse(nil, body), nil
+		}
+		return nil, fmt.Errorf("offline mode: no cached or vendored copy of '%s': %w", key, fs.ErrNotExist)
+	}
+
+	if body != nil && c.now().Before(entry.Expires) {
+		c.logger.Debug("[rolodex remote cache] serving fresh cached document", "url", key)
+		return cachedResponse(entry, body), nil
+	}
+
+	resp, err := c.request(ctx, key, entry, body != nil)
+	if err == nil && resp.StatusCode >= http.StatusInternalServerError {
+		// a server error is a failed fetch, the stored or vendored copy is better than an error page.
+		if body != nil || c.loadVendored(key) != nil {
+			_ = resp.Body.Close()
+			err = fmt.Errorf("remote server returned status %d", resp.StatusCode)
+		}
+	}
+	if err != nil {
+		if body != nil {
+			c.logger.Warn("[rolodex remote cache] fetch failed, serving cached document", "url", key, "error", err)
+			return cachedResponse(entry, body), nil
+		}
+		if vendored := c.loadVendored(key); vendored != nil {
+			c.logger.Warn("[rolodex remote cache] fetch failed, serving vendored document", "url", key, "error", err)
+			return cachedResponse(nil, vendored), nil
+		}
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && body != nil:
+		_ = resp.Body.Close()
+		c.logger.Debug("[rolodex remote cache] cached document revalidated", "url", key)
+		c.refresh(entry, resp.Header)
+		c.store(key, entry, nil)
+		return cachedResponse(entry, body), nil
+	case resp.StatusCode == http.StatusOK:
+		data, readErr := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		if !hasCacheDirective(resp.Header, "no-store") {
+			fresh := &remoteCacheEntry{URL: key}
+			c.refresh(fresh, resp.Header)
+			c.store(key, fresh, data)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		return resp, nil
+	}
+	return resp, nil
+}
+
+// request fetches a document with the handler when one is set, otherwise with the client, sending a conditional
+// request when a stored copy can be revalidated.
+func (c *RemoteCache) request(ctx context.Context, key string, entry *remoteCacheEntry, stored bool) (*http.Response, error) {
+	if c.handler != nil {
+		return c.handler(key)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	if stored {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+	return c.client.Do(req)
+}
+
+// refresh updates the validators and expiry of an entry from response headers.
+func (c *RemoteCache) refresh(entry *remoteCacheEntry, header http.Header) {
+	now := c.now()
+	entry.StoredAt = now
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		entry.LastModified = lastModified
+	}
+	entry.Expires = time.Time{}
+	if hasCacheDirective(header, "no-cache") || hasCacheDirective(header, "no-store") {
+		return
+	}
+	if maxAge, ok := cacheMaxAge(header); ok {
+		entry.Expires = now.Add(time.Duration(maxAge) * time.Second)
+		return
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		entry.Expires = expires
+	}
+}
+
+func (c *RemoteCache) load(key string) (*remoteCacheEntry, []byte) {
+	if c.directory == "" {
+		return nil, nil
+	}
+	base := filepath.Join(c.directory, remoteCacheFileName(key))
+	meta, err := os.ReadFile(base + ".json")
+	if err != nil {
+		return nil, nil
+	}
+	var entry remoteCacheEntry
+	if json.Unmarshal(meta, &entry) != nil || entry.URL != key {
+		return nil, nil
+	}
+	body, err := os.ReadFile(base + ".body")
+	if err != nil {
+		return nil, nil
+	}
+	return &entry, body
+}
+
+// store writes the entry metadata, and the body when supplied. Files are written to a temporary file first and
+// renamed, so concurrent processes never read a partial document. Failures are logged, a cache that cannot be
+// written is not fatal.
+func (c *RemoteCache) store(key string, entry *remoteCacheEntry, body []byte) {
+	if c.directory == "" {
+		return
+	}
+	base := filepath.Join(c.directory, remoteCacheFileName(key))
+	if body != nil {
+		if err := writeFileAtomic(base+".body", body); err != nil {
+			c.logger.Warn("[rolodex remote cache] unable to store document", "url", key, "error", err)
+			return
+		}
+	}
+	meta, _ := json.Marshal(entry)
+	if err := writeFileAtomic(base+".json", meta); err != nil {
+		c.logger.Warn("[rolodex remote cache] unable to store document metadata", "url", key, "error", err)
+	}
+}
+
+func (c *RemoteCache) loadVendored(key string) []byte {
+	if c.vendorDirectory == "" {
+		return nil
+	}
+	u, err := url.Parse(key)
+	if err != nil || u.Host == "" || u.Host == "." || u.Host == ".." || strings.ContainsAny(u.Host, `/\`) {
+		return nil
+	}
+	// the URL path is cleaned as a rooted path first, so it can never climb out of the host directory.
+	p := filepath.Join(c.vendorDirectory, u.Host, filepath.FromSlash(path.Clean("/"+u.Path)))
+	if rel, relErr := filepath.Rel(c.vendorDirectory, p); relErr != nil || rel == ".." ||
+		strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		c.logger.Warn("[rolodex remote cache] vendored location escapes the vendor directory", "url", key)
+		return nil
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func cachedResponse(entry *remoteCacheEntry, body []byte) *http.Response {
+	header := make(http.Header)
+	if entry != nil {
+		if entry.ETag != "" {
+			header.Set("ETag", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			header.Set("Last-Modified", entry.LastModified)
+		}
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+func remoteCacheKey(remoteURL string) string {
+	if u, err := url.Parse(remoteURL); err == nil {
+		return remoteLookupCacheKey(u)
+	}
+	return remoteURL
+}
+
+func remoteCacheFileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func hasCacheDirective(header http.Header, directive string) bool {
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cacheMaxAge(header http.Header) (int64, bool) {
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, found := strings.Cut(strings.TrimSpace(part), "=")
+			if !found || !strings.EqualFold(name, "max-age") {
+				continue
+			}
+			seconds, err := strconv.ParseInt(strings.Trim(arg, `"`), 10, 64)
+			if err == nil && seconds >= 0 {
+				return seconds, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func writeFileAtomic(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Close()
+	} else {
+		_ = tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
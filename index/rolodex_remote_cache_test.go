@@ -0,0 +1,311 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+)
+
+func readCachedResponse(t *testing.T, cache *RemoteCache, url string) (int, string) {
+	t.Helper()
+	resp, err := cache.Fetch(context.Background(), url)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestRemoteCache_RevalidatesWithETag(t *testing.T) {
+	var requests, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		rw.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		_, _ = rw.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+	assert.NoError(t, err)
+
+	code, body := readCachedResponse(t, cache, server.URL+"/spec.yaml#/components")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "openapi: 3.1.0", body)
+
+	// a new cache over the same directory behaves like a new process.
+	cache, err = NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+	assert.NoError(t, err)
+	resp, err := cache.Fetch(context.Background(), server.URL+"/spec.yaml")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "openapi: 3.1.0", string(data))
+	assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", resp.Header.Get("Last-Modified"))
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func TestRemoteCache_MaxAge(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		rw.Header().Set("Cache-Control", "public, max-age=60")
+		_, _ = rw.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: t.TempDir()})
+	assert.NoError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	assert.Equal(t, int32(1), requests.Load())
+
+	now = now.Add(2 * time.Minute)
+	readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestRemoteCache_NoStore(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		rw.Header().Set("Cache-Control", "no-store")
+		_, _ = rw.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+	assert.NoError(t, err)
+	readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	assert.Equal(t, int32(2), requests.Load())
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 0)
+}
+
+func TestRemoteCache_ErrorStatusNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+	assert.NoError(t, err)
+	code, _ := readCachedResponse(t, cache, server.URL+"/missing.yaml")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 0)
+}
+
+func TestRemoteCache_StaleOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("openapi: 3.1.0"))
+	}))
+	url := server.URL + "/spec.yaml"
+
+	dir := t.TempDir()
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+	assert.NoError(t, err)
+	readCachedResponse(t, cache, url)
+	server.Close()
+
+	code, body := readCachedResponse(t, cache, url)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "openapi: 3.1.0", body)
+}
+
+func TestRemoteCache_StaleOnServerError(t *testing.T) {
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if failing.Load() {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = rw.Write([]byte("maintenance"))
+			return
+		}
+		_, _ = rw.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+	host := server.Listener.Addr().String()
+
+	vendor := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(vendor, host), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, host, "vendored.yaml"), []byte("type: object"), 0o644))
+
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: t.TempDir(), VendorDirectory: vendor})
+	assert.NoError(t, err)
+	readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	failing.Store(true)
+
+	code, body := readCachedResponse(t, cache, server.URL+"/spec.yaml")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "openapi: 3.1.0", body)
+
+	code, body = readCachedResponse(t, cache, server.URL+"/vendored.yaml")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "type: object", body)
+
+	// with nothing to fall back on, the server error is returned.
+	code, body = readCachedResponse(t, cache, server.URL+"/missing.yaml")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "maintenance", body)
+}
+
+func TestRemoteCache_Offline(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		rw.Header().Set("Cache-Control", "no-cache")
+		_, _ = rw.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	online, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+	assert.NoError(t, err)
+	readCachedResponse(t, online, server.URL+"/spec.yaml")
+
+	offline, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir, Offline: true})
+	assert.NoError(t, err)
+	assert.True(t, offline.IsOffline())
+	_, body := readCachedResponse(t, offline, server.URL+"/spec.yaml")
+	assert.Equal(t, "openapi: 3.1.0", body)
+
+	_, err = offline.Fetch(context.Background(), server.URL+"/other.yaml")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	assert.Contains(t, err.Error(), "offline mode: no cached or vendored copy of")
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRemoteCache_Vendor(t *testing.T) {
+	vendor := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(vendor, "api.example.com", "schemas"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, "api.example.com", "schemas", "pet.yaml"),
+		[]byte("type: object"), 0o644))
+
+	cache, err := NewRemoteCache(&RemoteCacheConfig{VendorDirectory: vendor, Offline: true})
+	assert.NoError(t, err)
+	_, body := readCachedResponse(t, cache, "https://api.example.com/schemas/pet.yaml#/properties")
+	assert.Equal(t, "type: object", body)
+
+	_, err = cache.Fetch(context.Background(), "https://api.example.com/../../etc/passwd")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestRemoteCache_VendorHostTraversal(t *testing.T) {
+	root := t.TempDir()
+	vendor := filepath.Join(root, "vendor")
+	assert.NoError(t, os.MkdirAll(vendor, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "secret.yaml"), []byte("secret: true"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(vendor, "secret.yaml"), []byte("secret: true"), 0o644))
+
+	cache, err := NewRemoteCache(&RemoteCacheConfig{VendorDirectory: vendor, Offline: true})
+	assert.NoError(t, err)
+	for _, location := range []string{"http://../secret.yaml", "http://./secret.yaml"} {
+		_, err = cache.Fetch(context.Background(), location)
+		assert.True(t, errors.Is(err, fs.ErrNotExist), location)
+	}
+}
+
+func TestNewRemoteCache_Errors(t *testing.T) {
+	_, err := NewRemoteCache(nil)
+	assert.Error(t, err)
+	_, err = NewRemoteCache(&RemoteCacheConfig{})
+	assert.Error(t, err)
+
+	cache, err := NewRemoteCacheWithDocumentConfig(&datamodel.DocumentConfiguration{})
+	assert.NoError(t, err)
+	assert.Nil(t, cache)
+	_, err = NewRemoteCacheWithDocumentConfig(&datamodel.DocumentConfiguration{OfflineRemoteReferences: true})
+	assert.Error(t, err)
+}
+
+func TestRemoteFS_RemoteCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		rw.Header().Set("Cache-Control", "max-age=300")
+		_, _ = rw.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for range 2 {
+		cfg := CreateOpenAPIIndexConfig()
+		cfg.AllowRemoteLookup = true
+		remoteFS, err := NewRemoteFSWithConfig(cfg)
+		assert.NoError(t, err)
+		remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+			t.Fatal("remote handler should not be used with a cache")
+			return nil, nil
+		}
+		cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: dir})
+		assert.NoError(t, err)
+		remoteFS.SetRemoteCache(cache)
+
+		f, err := remoteFS.Open(server.URL + "/schema.yaml")
+		assert.NoError(t, err)
+		assert.Equal(t, "type: string", string(f.(*RemoteFile).GetContent()))
+	}
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRemoteCache_Handler(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rw.Header().Set("Cache-Control", "no-cache")
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+
+	handler := func(url string) (*http.Response, error) {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		return http.DefaultClient.Do(req)
+	}
+	cache, err := NewRemoteCacheWithDocumentConfig(&datamodel.DocumentConfiguration{
+		RemoteCacheDirectory: t.TempDir(),
+		RemoteURLHandler:     handler,
+	})
+	assert.NoError(t, err)
+
+	// stale documents are fetched again in full through the handler, which cannot send conditional requests.
+	for range 2 {
+		status, body := readCachedResponse(t, cache, server.URL+"/schema.yaml")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "type: string", body)
+	}
+	assert.Equal(t, int32(2), requests.Load())
+}
@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"reflect"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -258,6 +259,9 @@ type RemoteFS struct {
 	logger            *slog.Logger
 	extractedFiles    map[string]RolodexFile
 	rolodex           *Rolodex
+	cache             *RemoteCache
+	policy            *remotePolicy
+	policyHandler     utils.RemoteURLHandler
 	errMutex          sync.Mutex
 }
 
@@ -509,6 +513,46 @@ func (i *RemoteFS) SetRemoteHandlerFunc(handlerFunc utils.RemoteURLHandler) {
 	i.RemoteHandlerFunc = handlerFunc
 }
 
+// SetRemotePolicy restricts the remote documents that can be fetched to those allowed by a policy. The
+// RemoteHandlerFunc is replaced with a client enforcing the policy at every connection and redirect. Refused
+// documents fail to open with a *RemotePolicyError.
+//
+// A policy cannot be enforced by a custom handler: ErrRemotePolicyHandler is returned when the index configuration
+// has a RemoteURLHandler, and documents fail to open with it when the RemoteHandlerFunc is replaced afterwards, or
+// a RemoteCache fetches with a Handler or a Client not created by NewRemotePolicyClient.
+func (i *RemoteFS) SetRemotePolicy(policy *datamodel.RemotePolicy) error {
+	if i.indexConfig != nil && i.indexConfig.RemoteURLHandler != nil {
+		return ErrRemotePolicyHandler
+	}
+	p, err := newRemotePolicy(policy)
+	if err != nil {
+		return err
+	}
+	i.policy = p
+	client := p.client(time.Second * 120)
+	i.policyHandler = func(url string) (*http.Response, error) {
+		return client.Get(url)
+	}
+	i.RemoteHandlerFunc = i.policyHandler
+	return nil
+}
+
+// SetRemoteCache sets a persistent cache for remote documents. When set, all fetches are served by the cache,
+// and RemoteHandlerFunc is no longer used; set the Handler of the RemoteCacheConfig to fetch with a custom handler.
+func (i *RemoteFS) SetRemoteCache(cache *RemoteCache) {
+	i.cache = cache
+}
+
+// remoteHandler returns the handler used to fetch remote documents, the cache when one is set.
+func (i *RemoteFS) remoteHandler(ctx context.Context) utils.RemoteURLHandler {
+	if i.cache != nil {
+		return func(url string) (*http.Response, error) {
+			return i.cache.Fetch(ctx, url)
+		}
+	}
+	return i.RemoteHandlerFunc
+}
+
 // SetIndexConfig sets the index configuration.
 func (i *RemoteFS) SetIndexConfig(config *SpecIndexConfig) {
 	i.indexConfig = config
@@ -575,7 +619,19 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 		return cached, nil
 	}
 
-	fileExt, err := i.detectRemoteFileType(remoteURL, remoteParsedURL)
+	budget := i.rolodex.resourceBudget()
+	if err = budget.check(remoteParsedURL.String()); err != nil {
+		return nil, err
+	}
+
+	if err = i.checkRemotePolicy(ctx, remoteParsedURL); err != nil {
+		i.appendRemoteError(err)
+		i.logger.Warn("[rolodex remote loader] remote document refused by policy", "file", remoteURL,
+			"error", err.Error())
+		return nil, err
+	}
+
+	fileExt, err := i.detectRemoteFileType(ctx, remoteURL, remoteParsedURL)
 	if err != nil {
 		return nil, err
 	}
@@ -592,7 +648,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 
 	i.logger.Debug("[rolodex remote loader] loading remote file", "file", remoteURL, "remoteURL", remoteParsedURL.String())
 
-	response, clientErr := i.RemoteHandlerFunc(remoteParsedURL.String())
+	response, clientErr := i.remoteHandler(ctx)(remoteParsedURL.String())
 	if clientErr != nil {
 		i.appendRemoteError(clientErr)
 		i.releaseRemoteProcessingWaiter(processingWaiter, cacheKey, nil, nil)
@@ -615,7 +671,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 			_ = response.Body.Close()
 		}
 	}()
-	responseBytes, readError := io.ReadAll(response.Body)
+	responseBytes, readError := io.ReadAll(budget.limitReader(response.Body))
 	if readError != nil {
 		i.releaseRemoteProcessingWaiter(processingWaiter, cacheKey, nil, readError)
 		return nil, fmt.Errorf("error reading bytes from remote file '%s': [%s]",
@@ -631,6 +687,13 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 			response.StatusCode)
 	}
 
+	if budgetErr := budget.chargeFile(remoteParsedURL.String(), int64(len(responseBytes))); budgetErr != nil {
+		i.releaseRemoteProcessingWaiter(processingWaiter, cacheKey, nil, budgetErr)
+		i.logger.Warn("[rolodex remote loader] resource budget exceeded, remote document not indexed",
+			"file", remoteURL, "error", budgetErr.Error())
+		return nil, budgetErr
+	}
+
 	remoteFile := i.createRemoteFile(remoteParsedURL, fileExt, responseBytes, response.Header)
 	copiedCfg := i.createRemoteIndexConfig(remoteParsedURL, remoteParsedURLOriginal)
 
@@ -646,6 +709,25 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	return remoteFile, errors.Join(i.remoteErrors...)
 }
 
+// checkRemotePolicy checks a URL against the remote policy, if one is set, and that the document will be fetched by
+// a client enforcing it. Hosts are not resolved when the cache is offline, as no request is made.
+func (i *RemoteFS) checkRemotePolicy(ctx context.Context, remoteParsedURL *url.URL) error {
+	if i.policy == nil {
+		return nil
+	}
+	if i.cache != nil && i.cache.IsOffline() {
+		return i.policy.checkURL(remoteParsedURL)
+	}
+	if i.cache != nil && (i.cache.handler != nil || !enforcedBy(i.cache.client)) {
+		return ErrRemotePolicyHandler
+	}
+	if i.cache == nil && reflect.ValueOf(i.RemoteHandlerFunc).Pointer() != reflect.ValueOf(i.policyHandler).Pointer() {
+		// the handler set by SetRemotePolicy has been replaced.
+		return ErrRemotePolicyHandler
+	}
+	return i.policy.check(ctx, remoteParsedURL)
+}
+
 func (i *RemoteFS) normalizeRemoteURL(remoteParsedURL *url.URL) {
 	if i.rootURLParsed == nil || remoteParsedURL == nil {
 		return
@@ -668,7 +750,7 @@ func (i *RemoteFS) loadCachedRemoteFile(cacheKey, legacyPath string) *RemoteFile
 	return nil
 }
 
-func (i *RemoteFS) detectRemoteFileType(remoteURL string, remoteParsedURL *url.URL) (FileExtension, error) {
+func (i *RemoteFS) detectRemoteFileType(ctx context.Context, remoteURL string, remoteParsedURL *url.URL) (FileExtension, error) {
 	fileExt := ExtractFileType(remoteParsedURL.Path)
 	if fileExt != UNSUPPORTED {
 		return fileExt, nil
@@ -677,7 +759,7 @@ func (i *RemoteFS) detectRemoteFileType(remoteURL string, remoteParsedURL *url.U
 		if i.logger != nil {
 			i.logger.Debug("[rolodex remote loader] attempting content detection for unknown file extension", "url", remoteParsedURL.String())
 		}
-		fileExt = detectRemoteContentType(remoteParsedURL.String(), i.RemoteHandlerFunc, i.logger)
+		fileExt = detectRemoteContentType(remoteParsedURL.String(), i.remoteHandler(ctx), i.logger)
 		if fileExt == UNSUPPORTED {
 			defer func() {
 				contentDetectionMutex.Lock()
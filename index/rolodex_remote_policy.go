@@ -0,0 +1,319 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// ErrRemotePolicyViolation is returned (wrapped by a *RemotePolicyError) when a remote reference is refused by a
+// datamodel.RemotePolicy.
+var ErrRemotePolicyViolation = errors.New("remote reference refused by policy")
+
+// ErrRemotePolicyHandler is returned when a datamodel.RemotePolicy is set together with a custom remote handler. A
+// handler makes its own connections and follows its own redirects, which the policy cannot check; build the handler
+// (or the Client of a RemoteCacheConfig) with NewRemotePolicyClient instead.
+var ErrRemotePolicyHandler = errors.New("a remote policy cannot be enforced by a custom remote handler")
+
+// RemotePolicyRule is the rule of a datamodel.RemotePolicy a remote reference broke.
+type RemotePolicyRule string
+
+const (
+	// RemotePolicyScheme is a URL scheme that is not allowed.
+	RemotePolicyScheme RemotePolicyRule = "scheme"
+
+	// RemotePolicyDeniedHost is a host matching a denied host pattern.
+	RemotePolicyDeniedHost RemotePolicyRule = "denied-host"
+
+	// RemotePolicyHostNotAllowed is a host matching none of the allowed host patterns.
+	RemotePolicyHostNotAllowed RemotePolicyRule = "host-not-allowed"
+
+	// RemotePolicyBlockedAddress is a host that is, or resolves to, a blocked loopback, private or link-local address.
+	RemotePolicyBlockedAddress RemotePolicyRule = "blocked-address"
+
+	// RemotePolicyRedirects is a redirect beyond the maximum number of redirects.
+	RemotePolicyRedirects RemotePolicyRule = "redirects"
+)
+
+// RemotePolicyError describes a remote reference refused by a datamodel.RemotePolicy.
+type RemotePolicyError struct {
+	// Rule is the rule that was broken.
+	Rule RemotePolicyRule
+
+	// URL is the URL being fetched, empty when the address was refused while connecting.
+	URL string
+
+	// Host is the host of the URL, or the address being connected to.
+	Host string
+
+	// Address is the blocked address the host resolved to.
+	Address string
+
+	// Limit is the maximum number of redirects.
+	Limit int
+}
+
+func (e *RemotePolicyError) Error() string {
+	if e == nil {
+		return ErrRemotePolicyViolation.Error()
+	}
+	target := e.URL
+	if target == "" {
+		target = e.Host
+	}
+	switch e.Rule {
+	case RemotePolicyScheme:
+		return fmt.Sprintf("%s: '%s' uses a scheme that is not allowed", ErrRemotePolicyViolation, target)
+	case RemotePolicyDeniedHost:
+		return fmt.Sprintf("%s: '%s', host '%s' is denied", ErrRemotePolicyViolation, target, e.Host)
+	case RemotePolicyHostNotAllowed:
+		return fmt.Sprintf("%s: '%s', host '%s' is not allowed", ErrRemotePolicyViolation, target, e.Host)
+	case RemotePolicyBlockedAddress:
+		if e.Address != e.Host {
+			return fmt.Sprintf("%s: '%s', host '%s' resolves to blocked address %s", ErrRemotePolicyViolation,
+				target, e.Host, e.Address)
+		}
+		return fmt.Sprintf("%s: '%s', address %s is blocked", ErrRemotePolicyViolation, target, e.Address)
+	case RemotePolicyRedirects:
+		return fmt.Sprintf("%s: '%s', more than %d redirects", ErrRemotePolicyViolation, target, e.Limit)
+	}
+	return fmt.Sprintf("%s: '%s'", ErrRemotePolicyViolation, target)
+}
+
+// Unwrap returns ErrRemotePolicyViolation for errors.Is checks.
+func (e *RemotePolicyError) Unwrap() error {
+	return ErrRemotePolicyViolation
+}
+
+// blockedNetworks are the ranges refused unless a policy allows private networks, on top of the loopback, private,
+// link-local, multicast and unspecified addresses known to net/netip.
+var blockedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // this network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+}
+
+// remotePolicy enforces a datamodel.RemotePolicy.
+type remotePolicy struct {
+	policy   *datamodel.RemotePolicy
+	schemes  map[string]bool
+	networks []netip.Prefix
+	lookup   func(ctx context.Context, host string) ([]netip.Addr, error)
+}
+
+func newRemotePolicy(policy *datamodel.RemotePolicy) (*remotePolicy, error) {
+	if policy == nil {
+		return nil, errors.New("no remote policy provided")
+	}
+	p := &remotePolicy{
+		policy:  policy,
+		schemes: map[string]bool{"http": true, "https": true},
+		lookup: func(ctx context.Context, host string) ([]netip.Addr, error) {
+			return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		},
+	}
+	if len(policy.AllowedSchemes) > 0 {
+		p.schemes = make(map[string]bool)
+		for _, scheme := range policy.AllowedSchemes {
+			p.schemes[strings.ToLower(scheme)] = true
+		}
+	}
+	for _, network := range policy.AllowedNetworks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed network '%s' in remote policy: %w", network, err)
+		}
+		p.networks = append(p.networks, prefix.Masked())
+	}
+	return p, nil
+}
+
+// NewRemotePolicyClient creates an HTTP client enforcing a policy: every request (including redirects) is checked
+// against the allowed schemes and hosts, every connection against the blocked addresses, and the redirects followed
+// are capped. Headers of the policy are added to the requests of matching hosts. The client does not use a proxy,
+// so the address connected to is always the address of the host.
+//
+// Use it to build a RemoteURLHandler, or as the Client of a RemoteCacheConfig.
+func NewRemotePolicyClient(policy *datamodel.RemotePolicy, timeout time.Duration) (*http.Client, error) {
+	p, err := newRemotePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	return p.client(timeout), nil
+}
+
+func (p *remotePolicy) client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				host = address
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			return p.checkAddress("", host, addr)
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &remotePolicyTransport{policy: p, base: transport},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > p.policy.MaxRedirects {
+				return &RemotePolicyError{Rule: RemotePolicyRedirects, URL: req.URL.String(), Host: req.URL.Hostname(),
+					Limit: p.policy.MaxRedirects}
+			}
+			return nil
+		},
+	}
+}
+
+// enforcedBy returns true if a client enforces a policy at every connection and redirect, it was created by
+// NewRemotePolicyClient.
+func enforcedBy(client *http.Client) bool {
+	if client == nil {
+		return false
+	}
+	_, ok := client.Transport.(*remotePolicyTransport)
+	return ok
+}
+
+// check checks a URL against the policy, resolving its host to check the addresses it points at.
+func (p *remotePolicy) check(ctx context.Context, u *url.URL) error {
+	if err := p.checkURL(u); err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if _, err := netip.ParseAddr(host); err == nil || p.policy.AllowPrivateNetworks {
+		return nil
+	}
+	addrs, err := p.lookup(ctx, host)
+	if err != nil {
+		return fmt.Errorf("unable to resolve remote host '%s': %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err = p.checkAddress(u.String(), host, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkURL checks the scheme and host of a URL against the policy, and the address of a host that is an IP address.
+func (p *remotePolicy) checkURL(u *url.URL) error {
+	host := strings.ToLower(u.Hostname())
+	if !p.schemes[strings.ToLower(u.Scheme)] {
+		return &RemotePolicyError{Rule: RemotePolicyScheme, URL: u.String(), Host: host}
+	}
+	if matchHostPatterns(p.policy.DeniedHosts, host) {
+		return &RemotePolicyError{Rule: RemotePolicyDeniedHost, URL: u.String(), Host: host}
+	}
+	if len(p.policy.AllowedHosts) > 0 && !matchHostPatterns(p.policy.AllowedHosts, host) {
+		return &RemotePolicyError{Rule: RemotePolicyHostNotAllowed, URL: u.String(), Host: host}
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return p.checkAddress(u.String(), host, addr)
+	}
+	return nil
+}
+
+// checkAddress checks an address a host resolves to against the blocked ranges.
+func (p *remotePolicy) checkAddress(rawURL, host string, addr netip.Addr) error {
+	if p.policy.AllowPrivateNetworks {
+		return nil
+	}
+	addr = addr.Unmap()
+	for _, network := range p.networks {
+		if network.Contains(addr) {
+			return nil
+		}
+	}
+	blocked := addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified()
+	for _, network := range blockedNetworks {
+		blocked = blocked || network.Contains(addr)
+	}
+	if blocked {
+		return &RemotePolicyError{Rule: RemotePolicyBlockedAddress, URL: rawURL, Host: host, Address: addr.String()}
+	}
+	return nil
+}
+
+// headers returns the headers of the policy for a host.
+func (p *remotePolicy) headers(host string) http.Header {
+	var headers http.Header
+	for pattern, h := range p.policy.HostHeaders {
+		if matchHostPattern(pattern, host) {
+			if headers == nil {
+				headers = make(http.Header)
+			}
+			for k, v := range h {
+				headers[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+			}
+		}
+	}
+	return headers
+}
+
+// remotePolicyTransport checks every request against the policy, and adds the headers of its host. Headers are
+// added to a copy of the request, so the client never copies them onto a redirect.
+type remotePolicyTransport struct {
+	policy *remotePolicy
+	base   http.RoundTripper
+}
+
+func (t *remotePolicyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.policy.checkURL(req.URL); err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	if headers := t.policy.headers(strings.ToLower(req.URL.Hostname())); headers != nil {
+		req = req.Clone(req.Context())
+		for k, v := range headers {
+			req.Header[k] = v
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+// matchHostPatterns returns true if a host matches any of the patterns.
+func matchHostPatterns(patterns []string, host string) bool {
+	for _, pattern := range patterns {
+		if matchHostPattern(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHostPattern returns true if a (lower case) host matches a pattern, an exact host or *.domain for any
+// subdomain of domain.
+func matchHostPattern(pattern, host string) bool {
+	pattern = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(pattern)), ".")
+	host = strings.TrimSuffix(host, ".")
+	if domain, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+domain)
+	}
+	return strings.Trim(pattern, "[]") == host
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func newPolicyRemoteFS(t *testing.T, policy *datamodel.RemotePolicy) *RemoteFS {
+	t.Helper()
+	cfg := CreateOpenAPIIndexConfig()
+	cfg.AllowRemoteLookup = true
+	remoteFS, err := NewRemoteFSWithConfig(cfg)
+	require.NoError(t, err)
+	require.NoError(t, remoteFS.SetRemotePolicy(policy))
+	return remoteFS
+}
+
+func requirePolicyError(t *testing.T, err error, rule RemotePolicyRule) *RemotePolicyError {
+	t.Helper()
+	var policyErr *RemotePolicyError
+	require.ErrorAs(t, err, &policyErr)
+	assert.ErrorIs(t, err, ErrRemotePolicyViolation)
+	assert.Equal(t, rule, policyErr.Rule)
+	return policyErr
+}
+
+func TestRemoteFS_RemotePolicy_BlockedAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+	remoteFS := newPolicyRemoteFS(t, &datamodel.RemotePolicy{})
+
+	_, err := remoteFS.Open(server.URL + "/schema.yaml")
+	policyErr := requirePolicyError(t, err, RemotePolicyBlockedAddress)
+	assert.Equal(t, "127.0.0.1", policyErr.Address)
+	assert.Equal(t, server.URL+"/schema.yaml", policyErr.URL)
+
+	// a host name is checked once resolved.
+	_, err = remoteFS.Open(strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/schema.yaml")
+	policyErr = requirePolicyError(t, err, RemotePolicyBlockedAddress)
+	assert.Equal(t, "localhost", policyErr.Host)
+	assert.True(t, netip.MustParseAddr(policyErr.Address).IsLoopback())
+	assert.Contains(t, policyErr.Error(), "host 'localhost' resolves to blocked address")
+
+	// cloud metadata, carrier-grade NAT and IPv4 mapped addresses, refused without a connection.
+	for _, u := range []string{
+		"http://169.254.169.254/latest/meta-data.yaml",
+		"http://100.64.0.1/schema.yaml",
+		"http://[::ffff:10.0.0.1]/schema.yaml",
+		"http://[fe80::1]/schema.yaml",
+	} {
+		_, err = remoteFS.Open(u)
+		requirePolicyError(t, err, RemotePolicyBlockedAddress)
+	}
+	assert.Len(t, remoteFS.GetErrors(), 6)
+}
+
+func TestRemoteFS_RemotePolicy_AllowedNetworksAndHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer registry" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = rw.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+
+	remoteFS := newPolicyRemoteFS(t, &datamodel.RemotePolicy{
+		AllowedNetworks: []string{"127.0.0.0/8"},
+		HostHeaders: map[string]http.Header{
+			"127.0.0.1": {"authorization": []string{"Bearer registry"}},
+		},
+	})
+	f, err := remoteFS.Open(server.URL + "/schema.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "type: string", f.(*RemoteFile).GetContent())
+}
+
+func TestRemoteFS_RemotePolicy_Redirects(t *testing.T) {
+	var mu sync.Mutex
+	tokens := make(map[string]string)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/twice.yaml":
+			http.Redirect(rw, req, "/once.yaml", http.StatusFound)
+		case "/once.yaml":
+			http.Redirect(rw, req, "/schema.yaml", http.StatusFound)
+		case "/away.yaml":
+			http.Redirect(rw, req, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)+"/schema.yaml",
+				http.StatusFound)
+		default:
+			mu.Lock()
+			tokens[req.Host] = req.Header.Get("X-Token")
+			mu.Unlock()
+			_, _ = rw.Write([]byte("type: string"))
+		}
+	}))
+	defer server.Close()
+	policy := &datamodel.RemotePolicy{
+		AllowPrivateNetworks: true,
+		MaxRedirects:         1,
+		HostHeaders:          map[string]http.Header{"127.0.0.1": {"X-Token": []string{"secret"}}},
+	}
+
+	remoteFS := newPolicyRemoteFS(t, policy)
+	_, err := remoteFS.Open(server.URL + "/once.yaml")
+	require.NoError(t, err)
+	_, err = remoteFS.Open(server.URL + "/twice.yaml")
+	policyErr := requirePolicyError(t, err, RemotePolicyRedirects)
+	assert.Equal(t, 1, policyErr.Limit)
+
+	// the headers of a host never follow a redirect to another host.
+	remoteFS = newPolicyRemoteFS(t, policy)
+	_, err = remoteFS.Open(server.URL + "/away.yaml")
+	require.NoError(t, err)
+	host := strings.TrimPrefix(server.URL, "http://")
+	assert.Equal(t, "secret", tokens[host])
+	assert.Equal(t, "", tokens[strings.Replace(host, "127.0.0.1", "localhost", 1)])
+
+	// every redirect is checked again.
+	policy.DeniedHosts = []string{"LOCALHOST"}
+	remoteFS = newPolicyRemoteFS(t, policy)
+	_, err = remoteFS.Open(server.URL + "/away.yaml")
+	requirePolicyError(t, err, RemotePolicyDeniedHost)
+}
+
+func TestRemoteFS_RemotePolicy_SchemesAndHosts(t *testing.T) {
+	remoteFS := newPolicyRemoteFS(t, &datamodel.RemotePolicy{AllowedSchemes: []string{"HTTPS"}})
+	_, err := remoteFS.Open("http://schemas.example.com/pet.yaml")
+	requirePolicyError(t, err, RemotePolicyScheme)
+
+	remoteFS = newPolicyRemoteFS(t, &datamodel.RemotePolicy{
+		AllowedHosts: []string{"*.example.com"},
+		DeniedHosts:  []string{"internal.example.com"},
+	})
+	_, err = remoteFS.Open("https://example.com/pet.yaml")
+	policyErr := requirePolicyError(t, err, RemotePolicyHostNotAllowed)
+	assert.Equal(t, "example.com", policyErr.Host)
+	_, err = remoteFS.Open("https://internal.example.com/pet.yaml")
+	requirePolicyError(t, err, RemotePolicyDeniedHost)
+}
+
+func TestRemoteFS_RemotePolicy_CustomHandler(t *testing.T) {
+	handler := func(url string) (*http.Response, error) {
+		t.Fatal("remote handler should not be called")
+		return nil, nil
+	}
+	policy := &datamodel.RemotePolicy{AllowPrivateNetworks: true}
+
+	cfg := CreateOpenAPIIndexConfig()
+	cfg.RemoteURLHandler = handler
+	remoteFS, err := NewRemoteFSWithConfig(cfg)
+	require.NoError(t, err)
+	assert.ErrorIs(t, remoteFS.SetRemotePolicy(policy), ErrRemotePolicyHandler)
+
+	// a handler set after the policy is never called.
+	remoteFS = newPolicyRemoteFS(t, policy)
+	remoteFS.SetRemoteHandlerFunc(handler)
+	_, err = remoteFS.Open("https://schemas.example.com/pet.yaml")
+	assert.ErrorIs(t, err, ErrRemotePolicyHandler)
+
+	// nor is the handler of a cache, or its client when it does not enforce the policy.
+	remoteFS = newPolicyRemoteFS(t, policy)
+	cache, err := NewRemoteCache(&RemoteCacheConfig{Directory: t.TempDir(), Handler: handler})
+	require.NoError(t, err)
+	remoteFS.SetRemoteCache(cache)
+	_, err = remoteFS.Open("https://schemas.example.com/pet.yaml")
+	assert.ErrorIs(t, err, ErrRemotePolicyHandler)
+
+	cache, err = NewRemoteCache(&RemoteCacheConfig{Directory: t.TempDir(), Client: http.DefaultClient})
+	require.NoError(t, err)
+	remoteFS.SetRemoteCache(cache)
+	_, err = remoteFS.Open("https://schemas.example.com/pet.yaml")
+	assert.ErrorIs(t, err, ErrRemotePolicyHandler)
+
+	// a cache fetching with a policy client is allowed.
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+	client, err := NewRemotePolicyClient(policy, time.Second)
+	require.NoError(t, err)
+	cache, err = NewRemoteCache(&RemoteCacheConfig{Directory: t.TempDir(), Client: client})
+	require.NoError(t, err)
+	remoteFS = newPolicyRemoteFS(t, policy)
+	remoteFS.SetRemoteCache(cache)
+	_, err = remoteFS.Open(server.URL + "/schema.yaml")
+	assert.NoError(t, err)
+
+	_, err = NewRemoteCacheWithDocumentConfig(&datamodel.DocumentConfiguration{
+		RemoteCacheDirectory: t.TempDir(),
+		RemotePolicy:         policy,
+		RemoteURLHandler:     handler,
+	})
+	assert.ErrorIs(t, err, ErrRemotePolicyHandler)
+}
+
+func TestNewRemotePolicyClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+
+	// the address is checked when connecting, whatever the host name resolved to before.
+	client, err := NewRemotePolicyClient(&datamodel.RemotePolicy{}, time.Second)
+	require.NoError(t, err)
+	_, err = client.Get(strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/schema.yaml")
+	policyErr := requirePolicyError(t, err, RemotePolicyBlockedAddress)
+	assert.Empty(t, policyErr.URL)
+
+	_, err = NewRemotePolicyClient(&datamodel.RemotePolicy{AllowedNetworks: []string{"10.0.0.0"}}, time.Second)
+	assert.ErrorContains(t, err, "invalid allowed network '10.0.0.0'")
+	_, err = NewRemotePolicyClient(nil, time.Second)
+	assert.Error(t, err)
+}
+
+func TestMatchHostPattern(t *testing.T) {
+	assert.True(t, matchHostPattern("api.example.com", "api.example.com"))
+	assert.True(t, matchHostPattern("API.Example.com.", "api.example.com"))
+	assert.True(t, matchHostPattern("*.example.com", "a.b.example.com"))
+	assert.False(t, matchHostPattern("*.example.com", "example.com"))
+	assert.False(t, matchHostPattern("*.example.com", "badexample.com"))
+	assert.True(t, matchHostPattern("[::1]", "::1"))
+}
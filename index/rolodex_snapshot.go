@@ -0,0 +1,865 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	snapshotMagic   = "LIBOPENAPI-ROLODEX"
+	snapshotVersion = 1
+)
+
+// ErrInvalidSnapshot is returned when a snapshot is corrupt, truncated, or was written by an unsupported version.
+var ErrInvalidSnapshot = errors.New("invalid rolodex snapshot")
+
+// ErrStaleSnapshot is returned when the documents a snapshot was built from have changed since it was written.
+var ErrStaleSnapshot = errors.New("rolodex snapshot is stale")
+
+const (
+	snapshotFileLocal = iota
+	snapshotFileRemote
+)
+
+// WriteSnapshot writes a compact binary snapshot of the indexed rolodex: every document with its content and YAML
+// nodes (with line and column information), and the references, mapped references, circular reference results,
+// schema ids and anchors of every index. RestoreRolodex turns the snapshot back into a rolodex without parsing or
+// indexing anything.
+//
+// The snapshot records a SHA-256 hash of the root document and of every local file, so a snapshot is only
+// restored for the documents it was built from. The rolodex must have been indexed, circular references are
+// captured as they stand, so check for them first if they are needed.
+func (r *Rolodex) WriteSnapshot(w io.Writer) error {
+	if r == nil || !r.indexed || r.rootIndex == nil {
+		return errors.New("cannot snapshot a rolodex that has not been indexed")
+	}
+	var rootSpec []byte
+	if r.indexConfig.SpecInfo != nil && r.indexConfig.SpecInfo.SpecBytes != nil {
+		rootSpec = *r.indexConfig.SpecInfo.SpecBytes
+	}
+	rootHash := sha256.Sum256(rootSpec)
+
+	e := newSnapshotEncoder()
+	e.addIndex(r.rootIndex)
+	for _, idx := range r.indexes {
+		e.addIndex(idx)
+	}
+	for _, k := range sortedKeys(r.indexMap) {
+		e.addIndex(r.indexMap[k])
+	}
+	files := r.snapshotFiles()
+	for _, f := range files {
+		e.addIndex(f.index)
+	}
+
+	r.writeSnapshotBody(e, files)
+	for _, idx := range e.indexList {
+		idx.writeSnapshot(e)
+	}
+	body := e.section()
+	nodes, refs, circulars := e.tables()
+
+	e.buf = append(e.buf, snapshotMagic...)
+	e.uint(snapshotVersion)
+	e.buf = append(e.buf, rootHash[:]...)
+	e.uint(uint64(len(e.stringList)))
+	for _, s := range e.stringList {
+		e.bytes([]byte(s))
+	}
+	e.uint(uint64(len(e.nodeList)))
+	e.uint(uint64(len(e.refList)))
+	e.uint(uint64(len(e.circularList)))
+	e.uint(uint64(len(e.indexList)))
+	for _, section := range [][]byte{e.section(), nodes, refs, circulars, body} {
+		if _, err := w.Write(section); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshot returns a snapshot of the indexed rolodex, see WriteSnapshot.
+func (r *Rolodex) Snapshot() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.WriteSnapshot(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// snapshotFile is a document of the rolodex, as written to a snapshot.
+type snapshotFile struct {
+	kind         uint64
+	onDisk       bool
+	fullPath     string
+	filename     string
+	name         string
+	extension    FileExtension
+	lastModified time.Time
+	data         []byte
+	hash         []byte
+	parsed       *yaml.Node
+	index        *SpecIndex
+}
+
+// snapshotFiles returns every document of the rolodex, sorted by location. Local files read from disk by a LocalFS
+// are revalidated when the snapshot is restored.
+func (r *Rolodex) snapshotFiles() []*snapshotFile {
+	var files []*snapshotFile
+	seen := make(map[string]bool)
+	add := func(f RolodexFile, kind uint64, onDisk bool) {
+		if seen[f.GetFullPath()] {
+			return
+		}
+		seen[f.GetFullPath()] = true
+		data := []byte(f.GetContent())
+		hash := sha256.Sum256(data)
+		sf := &snapshotFile{
+			kind:         kind,
+			onDisk:       onDisk,
+			fullPath:     f.GetFullPath(),
+			name:         f.Name(),
+			extension:    f.GetFileExtension(),
+			lastModified: f.ModTime(),
+			data:         data,
+			hash:         hash[:],
+			index:        f.GetIndex(),
+		}
+		switch typed := f.(type) {
+		case *LocalFile:
+			sf.filename, sf.parsed = typed.filename, typed.parsed
+		case *RemoteFile:
+			sf.filename, sf.parsed = typed.filename, typed.parsed
+		default:
+			sf.filename = filepath.Base(f.GetFullPath())
+		}
+		if sf.index != nil && sf.index.root != nil {
+			sf.parsed = sf.index.root
+		}
+		files = append(files, sf)
+	}
+	for _, k := range sortedKeys(r.localFS) {
+		lfs, ok := r.localFS[k].(RolodexFS)
+		if !ok {
+			continue
+		}
+		local, isLocal := lfs.(*LocalFS)
+		onDisk := isLocal && (local.fsConfig == nil || local.fsConfig.DirFS == nil)
+		if restored, ok := lfs.(*snapshotFS); ok {
+			onDisk = restored.onDisk
+		}
+		filesInFS := lfs.GetFiles()
+		for _, p := range sortedKeys(filesInFS) {
+			add(filesInFS[p], snapshotFileLocal, onDisk && !isRemoteSnapshotFile(filesInFS[p]))
+		}
+	}
+	for _, k := range sortedKeys(r.remoteFS) {
+		if rfs, ok := r.remoteFS[k].(RolodexFS); ok {
+			filesInFS := rfs.GetFiles()
+			for _, p := range sortedKeys(filesInFS) {
+				add(filesInFS[p], snapshotFileRemote, false)
+			}
+		}
+	}
+	catalogFiles := r.GetCatalogFiles()
+	for _, p := range sortedKeys(catalogFiles) {
+		add(catalogFiles[p], snapshotFileRemote, false)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].fullPath < files[j].fullPath
+	})
+	return files
+}
+
+func isRemoteSnapshotFile(f RolodexFile) bool {
+	_, ok := f.(*RemoteFile)
+	return ok
+}
+
+func (r *Rolodex) writeSnapshotBody(e *snapshotEncoder, files []*snapshotFile) {
+	e.str(r.indexConfig.SpecAbsolutePath)
+	e.node(r.rootNode)
+	info := r.indexConfig.SpecInfo
+	e.bool(info != nil)
+	if info != nil {
+		e.str(info.SpecType)
+		e.int(int64(info.NumLines))
+		e.str(info.Version)
+		e.uint(uint64(math.Float32bits(info.VersionNumeric)))
+		e.str(info.SpecFormat)
+		e.str(info.SpecFileType)
+		e.str(info.APISchema)
+		e.int(int64(info.OriginalIndentation))
+		e.str(info.Self)
+	}
+	e.index(r.rootIndex)
+	e.uint(uint64(len(r.indexes)))
+	for _, idx := range r.indexes {
+		e.index(idx)
+	}
+	e.uint(uint64(len(r.indexMap)))
+	for _, k := range sortedKeys(r.indexMap) {
+		e.str(k)
+		e.index(r.indexMap[k])
+	}
+
+	e.uint(uint64(len(files)))
+	for _, f := range files {
+		e.uint(f.kind)
+		e.bool(f.onDisk)
+		e.str(f.fullPath)
+		e.str(f.filename)
+		e.str(f.name)
+		e.uint(uint64(f.extension))
+		e.int(f.lastModified.UnixNano())
+		e.bytes(f.hash)
+		e.bytes(f.data)
+		e.node(f.parsed)
+		e.index(f.index)
+	}
+
+	r.schemaIdRegistryLock.RLock()
+	writeSnapshotSchemaIds(e, r.globalSchemaIdRegistry)
+	r.schemaIdRegistryLock.RUnlock()
+	r.anchorRegistryLock.RLock()
+	writeSnapshotSchemaAnchors(e, r.globalAnchorRegistry)
+	r.anchorRegistryLock.RUnlock()
+
+	e.circularSlice(r.safeCircularReferences)
+	e.circularSlice(r.infiniteCircularReferences)
+	e.circularSlice(r.ignoredCircularReferences)
+	e.errSlice(r.caughtErrors)
+	e.bool(r.circChecked)
+	e.bool(r.resolved)
+	e.int(int64(r.indexingDuration))
+}
+
+func writeSnapshotSchemaIds(e *snapshotEncoder, registry map[string]*SchemaIdEntry) {
+	e.uint(uint64(len(registry)))
+	for _, k := range sortedKeys(registry) {
+		entry := registry[k]
+		e.str(k)
+		e.str(entry.Id)
+		e.str(entry.ResolvedUri)
+		e.node(entry.SchemaNode)
+		e.str(entry.ParentId)
+		e.index(entry.Index)
+		e.str(entry.DefinitionPath)
+		e.int(int64(entry.Line))
+		e.int(int64(entry.Column))
+	}
+}
+
+func readSnapshotSchemaIds(d *snapshotDecoder) map[string]*SchemaIdEntry {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	registry := make(map[string]*SchemaIdEntry, n)
+	for i := 0; i < n; i++ {
+		k := d.str()
+		registry[k] = &SchemaIdEntry{
+			Id:             d.str(),
+			ResolvedUri:    d.str(),
+			SchemaNode:     d.node(),
+			ParentId:       d.str(),
+			Index:          d.index(),
+			DefinitionPath: d.str(),
+			Line:           int(d.int()),
+			Column:         int(d.int()),
+		}
+	}
+	return registry
+}
+
+func writeSnapshotSchemaAnchors(e *snapshotEncoder, registry map[string]*SchemaAnchorEntry) {
+	e.uint(uint64(len(registry)))
+	for _, k := range sortedKeys(registry) {
+		entry := registry[k]
+		e.str(k)
+		e.str(entry.Name)
+		e.str(entry.ResourceUri)
+		e.bool(entry.Dynamic)
+		e.node(entry.SchemaNode)
+		e.index(entry.Index)
+		e.str(entry.DefinitionPath)
+		e.int(int64(entry.Line))
+		e.int(int64(entry.Column))
+	}
+}
+
+func readSnapshotSchemaAnchors(d *snapshotDecoder) map[string]*SchemaAnchorEntry {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	registry := make(map[string]*SchemaAnchorEntry, n)
+	for i := 0; i < n; i++ {
+		k := d.str()
+		registry[k] = &SchemaAnchorEntry{
+			Name:           d.str(),
+			ResourceUri:    d.str(),
+			Dynamic:        d.bool(),
+			SchemaNode:     d.node(),
+			Index:          d.index(),
+			DefinitionPath: d.str(),
+			Line:           int(d.int()),
+			Column:         int(d.int()),
+		}
+	}
+	return registry
+}
+
+// writeSnapshot writes everything the index collected while extracting and resolving references. Everything else
+// is collected again from the restored nodes, without extracting any references.
+func (index *SpecIndex) writeSnapshot(e *snapshotEncoder) {
+	e.str(index.specAbsolutePath)
+	baseURL := ""
+	if index.config != nil && index.config.BaseURL != nil {
+		baseURL = index.config.BaseURL.String()
+	}
+	e.str(baseURL)
+	e.node(index.root)
+	e.bool(index.allowCircularReferences)
+
+	index.refLock.RLock()
+	e.refMap(index.allRefs)
+	e.refSlice(index.rawSequencedRefs)
+	e.uint(uint64(len(index.linesWithRefs)))
+	for _, line := range sortedLines(index.linesWithRefs) {
+		e.int(int64(line))
+	}
+	e.refMap(index.allMappedRefs)
+	e.uint(uint64(len(index.allMappedRefsSequenced)))
+	for _, mapped := range index.allMappedRefsSequenced {
+		e.ref(mapped.OriginalReference)
+		e.ref(mapped.Reference)
+		e.str(mapped.Definition)
+		e.str(mapped.FullDefinition)
+		e.bool(mapped.IsPolymorphic)
+	}
+	e.uint(uint64(len(index.refsByLine)))
+	for _, k := range sortedKeys(index.refsByLine) {
+		e.str(k)
+		lines := sortedLines(index.refsByLine[k])
+		e.uint(uint64(len(lines)))
+		for _, line := range lines {
+			e.int(int64(line))
+		}
+	}
+	e.refMap(index.polymorphicRefs)
+	e.refSlice(index.polymorphicAllOfRefs)
+	e.refSlice(index.polymorphicOneOfRefs)
+	e.refSlice(index.polymorphicAnyOfRefs)
+	e.uint(uint64(len(index.refsWithSiblings)))
+	for _, k := range sortedKeys(index.refsWithSiblings) {
+		ref := index.refsWithSiblings[k]
+		e.str(k)
+		e.reference(&ref)
+	}
+	e.refSlice(index.allRefSchemaDefinitions)
+	e.refSlice(index.allInlineSchemaDefinitions)
+	e.refSlice(index.allInlineSchemaObjectDefinitions)
+	e.uint(uint64(len(index.securityRequirementRefs)))
+	for _, k := range sortedKeys(index.securityRequirementRefs) {
+		e.str(k)
+		e.uint(uint64(len(index.securityRequirementRefs[k])))
+		for _, name := range sortedKeys(index.securityRequirementRefs[k]) {
+			e.str(name)
+			e.refSlice(index.securityRequirementRefs[k][name])
+		}
+	}
+	e.refSlice(index.dynamicRefs)
+	e.int(int64(index.refCount))
+	index.refLock.RUnlock()
+
+	for _, descriptions := range [][]*DescriptionReference{index.allDescriptions, index.allSummaries} {
+		e.uint(uint64(len(descriptions)))
+		for _, desc := range descriptions {
+			e.str(desc.Content)
+			e.str(desc.Path)
+			e.node(desc.KeyNode)
+			e.node(desc.Node)
+			e.node(desc.ParentNode)
+			e.bool(desc.IsSummary)
+		}
+	}
+	e.uint(uint64(len(index.allEnums)))
+	for _, enum := range index.allEnums {
+		e.node(enum.Node)
+		e.node(enum.KeyNode)
+		e.node(enum.Type)
+		e.str(enum.Path)
+		e.node(enum.SchemaNode)
+		e.node(enum.ParentNode)
+	}
+	e.uint(uint64(len(index.allObjectsWithProperties)))
+	for _, object := range index.allObjectsWithProperties {
+		e.node(object.Node)
+		e.node(object.KeyNode)
+		e.str(object.Path)
+		e.node(object.ParentNode)
+	}
+	e.int(int64(index.descriptionCount))
+	e.int(int64(index.enumCount))
+	e.int(int64(index.summaryCount))
+
+	index.errorLock.RLock()
+	e.errSlice(index.refErrors)
+	index.errorLock.RUnlock()
+
+	index.schemaIdRegistryLock.RLock()
+	writeSnapshotSchemaIds(e, index.schemaIdRegistry)
+	index.schemaIdRegistryLock.RUnlock()
+	index.schemaAnchorRegistryLock.RLock()
+	writeSnapshotSchemaAnchors(e, index.schemaAnchorRegistry)
+	index.schemaAnchorRegistryLock.RUnlock()
+
+	e.circularSlice(index.circularReferences)
+	e.circularSlice(index.polyCircularReferences)
+	e.circularSlice(index.arrayCircularReferences)
+	e.circularSlice(index.tagCircularReferences)
+
+	resolver := index.GetResolver()
+	e.bool(resolver != nil)
+	if resolver != nil {
+		e.uint(uint64(len(resolver.resolvingErrors)))
+		for _, err := range resolver.resolvingErrors {
+			e.resolvingError(err)
+		}
+		e.circularSlice(resolver.circularReferences)
+		e.circularSlice(resolver.ignoredPolyReferences)
+		e.circularSlice(resolver.ignoredArrayReferences)
+		e.bool(resolver.IgnorePoly)
+		e.bool(resolver.IgnoreArray)
+		e.bool(resolver.circChecked)
+		e.int(int64(resolver.referencesVisited))
+		e.int(int64(resolver.indexesVisited))
+		e.int(int64(resolver.journeysTaken))
+		e.int(int64(resolver.relativesSeen))
+	}
+}
+
+// readSnapshot restores everything written by writeSnapshot.
+func (index *SpecIndex) readSnapshot(d *snapshotDecoder) (baseURL string) {
+	index.specAbsolutePath = d.str()
+	baseURL = d.str()
+	index.root = d.node()
+	index.allowCircularReferences = d.bool()
+
+	index.allRefs = d.refMap(index.allRefs)
+	index.rawSequencedRefs = d.refSlice()
+	for n := d.count(); n > 0; n-- {
+		index.linesWithRefs[int(d.int())] = true
+	}
+	index.allMappedRefs = d.refMap(index.allMappedRefs)
+	if n := d.count(); n > 0 {
+		index.allMappedRefsSequenced = make([]*ReferenceMapped, n)
+		for i := range index.allMappedRefsSequenced {
+			index.allMappedRefsSequenced[i] = &ReferenceMapped{
+				OriginalReference: d.ref(),
+				Reference:         d.ref(),
+				Definition:        d.str(),
+				FullDefinition:    d.str(),
+				IsPolymorphic:     d.bool(),
+			}
+		}
+	}
+	for n := d.count(); n > 0; n-- {
+		k := d.str()
+		lines := make(map[int]bool)
+		for l := d.count(); l > 0; l-- {
+			lines[int(d.int())] = true
+		}
+		index.refsByLine[k] = lines
+	}
+	index.polymorphicRefs = d.refMap(index.polymorphicRefs)
+	index.polymorphicAllOfRefs = d.refSlice()
+	index.polymorphicOneOfRefs = d.refSlice()
+	index.polymorphicAnyOfRefs = d.refSlice()
+	for n := d.count(); n > 0; n-- {
+		k := d.str()
+		var ref Reference
+		d.reference(&ref)
+		index.refsWithSiblings[k] = ref
+	}
+	index.allRefSchemaDefinitions = d.refSlice()
+	index.allInlineSchemaDefinitions = d.refSlice()
+	index.allInlineSchemaObjectDefinitions = d.refSlice()
+	for n := d.count(); n > 0; n-- {
+		k := d.str()
+		names := make(map[string][]*Reference)
+		for m := d.count(); m > 0; m-- {
+			name := d.str()
+			names[name] = d.refSlice()
+		}
+		index.securityRequirementRefs[k] = names
+	}
+	index.dynamicRefs = d.refSlice()
+	index.refCount = int(d.int())
+
+	for _, descriptions := range []*[]*DescriptionReference{&index.allDescriptions, &index.allSummaries} {
+		for n := d.count(); n > 0; n-- {
+			*descriptions = append(*descriptions, &DescriptionReference{
+				Content:    d.str(),
+				Path:       d.str(),
+				KeyNode:    d.node(),
+				Node:       d.node(),
+				ParentNode: d.node(),
+				IsSummary:  d.bool(),
+			})
+		}
+	}
+	for n := d.count(); n > 0; n-- {
+		index.allEnums = append(index.allEnums, &EnumReference{
+			Node:       d.node(),
+			KeyNode:    d.node(),
+			Type:       d.node(),
+			Path:       d.str(),
+			SchemaNode: d.node(),
+			ParentNode: d.node(),
+		})
+	}
+	for n := d.count(); n > 0; n-- {
+		index.allObjectsWithProperties = append(index.allObjectsWithProperties, &ObjectReference{
+			Node:       d.node(),
+			KeyNode:    d.node(),
+			Path:       d.str(),
+			ParentNode: d.node(),
+		})
+	}
+	index.descriptionCount = int(d.int())
+	index.enumCount = int(d.int())
+	index.summaryCount = int(d.int())
+	index.refErrors = d.errSlice()
+	index.schemaIdRegistry = readSnapshotSchemaIds(d)
+	index.schemaAnchorRegistry = readSnapshotSchemaAnchors(d)
+
+	index.circularReferences = d.circularSlice()
+	index.polyCircularReferences = d.circularSlice()
+	index.arrayCircularReferences = d.circularSlice()
+	index.tagCircularReferences = d.circularSlice()
+
+	if d.bool() {
+		resolver := &Resolver{specIndex: index, resolvedRoot: index.root}
+		if n := d.count(); n > 0 {
+			resolver.resolvingErrors = make([]*ResolvingError, n)
+			for i := range resolver.resolvingErrors {
+				resolver.resolvingErrors[i] = d.resolvingError()
+			}
+		}
+		resolver.circularReferences = d.circularSlice()
+		resolver.ignoredPolyReferences = d.circularSlice()
+		resolver.ignoredArrayReferences = d.circularSlice()
+		resolver.IgnorePoly = d.bool()
+		resolver.IgnoreArray = d.bool()
+		resolver.circChecked = d.bool()
+		resolver.referencesVisited = int(d.int())
+		resolver.indexesVisited = int(d.int())
+		resolver.journeysTaken = int(d.int())
+		resolver.relativesSeen = int(d.int())
+		index.resolver = resolver
+	}
+	return baseURL
+}
+
+func sortedLines(lines map[int]bool) []int {
+	sorted := make([]int, 0, len(lines))
+	for line := range lines {
+		sorted = append(sorted, line)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+// RestoreRolodex restores a rolodex from a snapshot written by WriteSnapshot, without parsing or indexing the
+// documents again. The rootSpec is the root document the snapshot was built from, and the config is used the same
+// way NewRolodex uses it. When the config has no SpecInfo, one is restored from the snapshot.
+//
+// The snapshot is validated against a content hash of its inputs: the root document, and every local file that was
+// read from disk, which is read again. ErrStaleSnapshot is returned if any of them changed, index the documents again
+// and write a new snapshot. Remote documents, and files read from a custom fs.FS, are restored from the snapshot
+// without being fetched or read again.
+//
+// The restored rolodex serves the documents recorded in the snapshot only, it does not read or fetch any others.
+func RestoreRolodex(snapshot, rootSpec []byte, config *SpecIndexConfig) (*Rolodex, error) {
+	if config == nil {
+		return nil, errors.New("no spec index config provided")
+	}
+	d := &snapshotDecoder{data: snapshot}
+	if !bytes.HasPrefix(snapshot, []byte(snapshotMagic)) {
+		return nil, fmt.Errorf("%w: not a rolodex snapshot", ErrInvalidSnapshot)
+	}
+	d.pos = len(snapshotMagic)
+	if version := d.uint(); d.err == nil && version != snapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+	rootHash := sha256.Sum256(rootSpec)
+	if recorded := d.fixed(sha256.Size); d.err == nil && !bytes.Equal(recorded, rootHash[:]) {
+		return nil, fmt.Errorf("%w: the root document has changed", ErrStaleSnapshot)
+	}
+
+	d.strings = make([]string, d.count())
+	for i := range d.strings {
+		d.strings[i] = string(d.bytes())
+	}
+	d.nodes = make([]yaml.Node, d.count())
+	d.refs = make([]Reference, d.count())
+	d.circulars = make([]CircularReferenceResult, d.count())
+	d.indexes = make([]*SpecIndex, d.count())
+	for i := range d.indexes {
+		d.indexes[i] = new(SpecIndex)
+		bootstrapIndexCollections(d.indexes[i])
+	}
+	d.tables()
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	r := NewRolodex(config)
+	files, err := r.readSnapshotBody(d, rootSpec)
+	if err != nil {
+		return nil, err
+	}
+	baseURLs := make([]string, len(d.indexes))
+	for i, idx := range d.indexes {
+		baseURLs[i] = idx.readSnapshot(d)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("%w: %d unexpected trailing bytes", ErrInvalidSnapshot, len(d.data)-d.pos)
+	}
+	if err = validateSnapshotFiles(files); err != nil {
+		return nil, err
+	}
+
+	r.indexConfig.Rolodex = r
+	var wg sync.WaitGroup
+	for i, idx := range d.indexes {
+		wg.Add(1)
+		go func(idx *SpecIndex, baseURL string) {
+			defer wg.Done()
+			r.restoreSnapshotIndex(idx, baseURL)
+		}(idx, baseURLs[i])
+	}
+	wg.Wait()
+	r.mountSnapshotFiles(files)
+	r.indexed = true
+	r.built = true
+	return r, nil
+}
+
+func (r *Rolodex) readSnapshotBody(d *snapshotDecoder, rootSpec []byte) ([]*snapshotFile, error) {
+	r.indexConfig.SpecAbsolutePath = d.str()
+	r.rootNode = d.node()
+	if d.bool() {
+		info := &datamodel.SpecInfo{
+			SpecType:            d.str(),
+			NumLines:            int(d.int()),
+			Version:             d.str(),
+			VersionNumeric:      math.Float32frombits(uint32(d.uint())),
+			SpecFormat:          d.str(),
+			SpecFileType:        d.str(),
+			APISchema:           d.str(),
+			OriginalIndentation: int(d.int()),
+			Self:                d.str(),
+			SpecBytes:           &rootSpec,
+			RootNode:            r.rootNode,
+		}
+		if r.indexConfig.SpecInfo == nil {
+			r.indexConfig.SpecInfo = info
+		}
+	}
+	r.rootIndex = d.index()
+	if n := d.count(); n > 0 {
+		r.indexes = make([]*SpecIndex, n)
+		for i := range r.indexes {
+			r.indexes[i] = d.index()
+		}
+	}
+	for n := d.count(); n > 0; n-- {
+		k := d.str()
+		r.indexMap[k] = d.index()
+	}
+
+	files := make([]*snapshotFile, d.count())
+	for i := range files {
+		files[i] = &snapshotFile{
+			kind:         d.uint(),
+			onDisk:       d.bool(),
+			fullPath:     d.str(),
+			filename:     d.str(),
+			name:         d.str(),
+			extension:    FileExtension(d.uint()),
+			lastModified: time.Unix(0, d.int()),
+			hash:         d.bytes(),
+			data:         d.bytes(),
+			parsed:       d.node(),
+			index:        d.index(),
+		}
+	}
+
+	r.globalSchemaIdRegistry = readSnapshotSchemaIds(d)
+	r.globalAnchorRegistry = readSnapshotSchemaAnchors(d)
+	r.safeCircularReferences = d.circularSlice()
+	r.infiniteCircularReferences = d.circularSlice()
+	r.ignoredCircularReferences = d.circularSlice()
+	r.caughtErrors = d.errSlice()
+	r.circChecked = d.bool()
+	r.resolved = d.bool()
+	r.indexingDuration = time.Duration(d.int())
+	return files, d.err
+}
+
+// validateSnapshotFiles reads the local files of a snapshot from disk again, and checks their content hash.
+func validateSnapshotFiles(files []*snapshotFile) error {
+	for _, f := range files {
+		if !f.onDisk {
+			continue
+		}
+		data, err := os.ReadFile(f.fullPath)
+		if err != nil {
+			return fmt.Errorf("%w: unable to read '%s': %w", ErrStaleSnapshot, f.fullPath, err)
+		}
+		if hash := sha256.Sum256(data); !bytes.Equal(hash[:], f.hash) {
+			return fmt.Errorf("%w: '%s' has changed", ErrStaleSnapshot, f.fullPath)
+		}
+	}
+	return nil
+}
+
+// restoreSnapshotIndex links a restored index to the rolodex, and collects everything that was not written to the
+// snapshot from its nodes.
+func (r *Rolodex) restoreSnapshotIndex(idx *SpecIndex, baseURL string) {
+	config := r.indexConfig
+	if idx != r.rootIndex {
+		copiedConfig := *r.indexConfig
+		copiedConfig.SpecAbsolutePath = idx.specAbsolutePath
+		copiedConfig.SpecInfo = nil
+		copiedConfig.AvoidBuildIndex = true
+		if u, err := url.Parse(baseURL); err == nil && baseURL != "" {
+			copiedConfig.BaseURL = u
+		}
+		config = &copiedConfig
+	}
+	idx.config = config
+	idx.rolodex = r
+	idx.uri = config.uri
+	idx.logger = r.logger
+	idx.cache = new(sync.Map)
+	idx.InitHighCache()
+	if idx.root == nil || len(idx.root.Content) == 0 {
+		return
+	}
+	idx.nodeMapCompleted = make(chan struct{})
+	go idx.MapNodes(idx.root)
+	idx.ExtractExternalDocuments(idx.root)
+	idx.GetPathCount()
+	idx.BuildIndex()
+	<-idx.nodeMapCompleted
+}
+
+// mountSnapshotFiles serves the restored documents from read-only file systems.
+func (r *Rolodex) mountSnapshotFiles(files []*snapshotFile) {
+	baseDir := r.indexConfig.BasePath
+	if baseDir == "" && r.indexConfig.SpecAbsolutePath != "" {
+		baseDir = filepath.Dir(r.indexConfig.SpecAbsolutePath)
+	}
+	baseDir, _ = filepath.Abs(baseDir)
+	local := &snapshotFS{baseDirectory: baseDir, files: make(map[string]RolodexFile), onDisk: true}
+	remote := &snapshotFS{files: make(map[string]RolodexFile)}
+	for _, f := range files {
+		var atm atomic.Value
+		if f.index != nil {
+			atm.Store(f.index)
+		}
+		if f.kind == snapshotFileRemote {
+			u, _ := url.Parse(f.fullPath)
+			remote.files[catalogKey(f.fullPath)] = &RemoteFile{
+				filename:     f.filename,
+				name:         f.name,
+				extension:    f.extension,
+				data:         f.data,
+				fullPath:     f.fullPath,
+				URL:          u,
+				lastModified: f.lastModified,
+				index:        atm,
+				parsed:       f.parsed,
+			}
+			continue
+		}
+		local.onDisk = local.onDisk && f.onDisk
+		local.files[f.fullPath] = &LocalFile{
+			filename:     f.filename,
+			name:         f.name,
+			extension:    f.extension,
+			data:         f.data,
+			fullPath:     f.fullPath,
+			lastModified: f.lastModified,
+			index:        atm,
+			parsed:       f.parsed,
+		}
+	}
+	if len(local.files) > 0 {
+		r.AddLocalFS(baseDir, local)
+	}
+	if len(remote.files) > 0 {
+		r.AddRemoteFS("snapshot", remote)
+	}
+}
+
+// snapshotFS is a read-only RolodexFS serving the documents restored from a snapshot.
+type snapshotFS struct {
+	baseDirectory string
+	files         map[string]RolodexFile
+	onDisk        bool
+}
+
+// OpenWithContext opens a restored document by its absolute path or URL, or by a path relative to the base directory.
+func (s *snapshotFS) OpenWithContext(_ context.Context, name string) (fs.File, error) {
+	if f, ok := s.files[catalogKey(name)]; ok {
+		return f.(fs.File), nil
+	}
+	if s.baseDirectory != "" && !filepath.IsAbs(name) {
+		if f, ok := s.files[filepath.Join(s.baseDirectory, filepath.FromSlash(path.Clean(name)))]; ok {
+			return f.(fs.File), nil
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// Open opens a restored document, see OpenWithContext.
+func (s *snapshotFS) Open(name string) (fs.File, error) {
+	return s.OpenWithContext(context.Background(), name)
+}
+
+// GetFiles returns the restored documents, keyed by their absolute path or URL.
+func (s *snapshotFS) GetFiles() map[string]RolodexFile {
+	files := make(map[string]RolodexFile, len(s.files))
+	for k, f := range s.files {
+		files[k] = f
+	}
+	return files
+}
@@ -0,0 +1,613 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// snapshotEncoder writes the sections of a snapshot. Strings are interned, and YAML nodes, references and circular
+// reference results are written once to a table and referred to by id everywhere else, so nodes shared between
+// documents and references shared between indexes keep their identity when restored. An id of zero is nil.
+type snapshotEncoder struct {
+	buf          []byte
+	strings      map[string]uint64
+	stringList   []string
+	nodes        map[*yaml.Node]uint64
+	nodeList     []*yaml.Node
+	refs         map[*Reference]uint64
+	refList      []*Reference
+	circulars    map[*CircularReferenceResult]uint64
+	circularList []*CircularReferenceResult
+	indexes      map[*SpecIndex]uint64
+	indexList    []*SpecIndex
+}
+
+func newSnapshotEncoder() *snapshotEncoder {
+	return &snapshotEncoder{
+		strings:   make(map[string]uint64),
+		nodes:     make(map[*yaml.Node]uint64),
+		refs:      make(map[*Reference]uint64),
+		circulars: make(map[*CircularReferenceResult]uint64),
+		indexes:   make(map[*SpecIndex]uint64),
+	}
+}
+
+// section returns the bytes written since the last section, and starts a new one.
+func (e *snapshotEncoder) section() []byte {
+	b := e.buf
+	e.buf = nil
+	return b
+}
+
+func (e *snapshotEncoder) uint(v uint64) {
+	e.buf = binary.AppendUvarint(e.buf, v)
+}
+
+func (e *snapshotEncoder) int(v int64) {
+	e.buf = binary.AppendVarint(e.buf, v)
+}
+
+func (e *snapshotEncoder) bool(v bool) {
+	if v {
+		e.buf = append(e.buf, 1)
+		return
+	}
+	e.buf = append(e.buf, 0)
+}
+
+func (e *snapshotEncoder) bytes(b []byte) {
+	e.uint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *snapshotEncoder) str(s string) {
+	id, ok := e.strings[s]
+	if !ok {
+		id = uint64(len(e.stringList))
+		e.strings[s] = id
+		e.stringList = append(e.stringList, s)
+	}
+	e.uint(id)
+}
+
+func (e *snapshotEncoder) strs(s []string) {
+	e.uint(uint64(len(s)))
+	for _, v := range s {
+		e.str(v)
+	}
+}
+
+func (e *snapshotEncoder) node(n *yaml.Node) {
+	if n == nil {
+		e.uint(0)
+		return
+	}
+	id, ok := e.nodes[n]
+	if !ok {
+		e.nodeList = append(e.nodeList, n)
+		id = uint64(len(e.nodeList))
+		e.nodes[n] = id
+	}
+	e.uint(id)
+}
+
+func (e *snapshotEncoder) nodeSlice(nodes []*yaml.Node) {
+	e.uint(uint64(len(nodes)))
+	for _, n := range nodes {
+		e.node(n)
+	}
+}
+
+func (e *snapshotEncoder) ref(r *Reference) {
+	if r == nil {
+		e.uint(0)
+		return
+	}
+	id, ok := e.refs[r]
+	if !ok {
+		e.refList = append(e.refList, r)
+		id = uint64(len(e.refList))
+		e.refs[r] = id
+	}
+	e.uint(id)
+}
+
+func (e *snapshotEncoder) refSlice(refs []*Reference) {
+	e.uint(uint64(len(refs)))
+	for _, r := range refs {
+		e.ref(r)
+	}
+}
+
+func (e *snapshotEncoder) refMap(refs map[string]*Reference) {
+	e.uint(uint64(len(refs)))
+	for _, k := range sortedKeys(refs) {
+		e.str(k)
+		e.ref(refs[k])
+	}
+}
+
+func (e *snapshotEncoder) circular(c *CircularReferenceResult) {
+	if c == nil {
+		e.uint(0)
+		return
+	}
+	id, ok := e.circulars[c]
+	if !ok {
+		e.circularList = append(e.circularList, c)
+		id = uint64(len(e.circularList))
+		e.circulars[c] = id
+	}
+	e.uint(id)
+}
+
+func (e *snapshotEncoder) circularSlice(results []*CircularReferenceResult) {
+	e.uint(uint64(len(results)))
+	for _, c := range results {
+		e.circular(c)
+	}
+}
+
+// addIndex registers an index, indexes must all be registered before any reference is written.
+func (e *snapshotEncoder) addIndex(idx *SpecIndex) {
+	if idx == nil {
+		return
+	}
+	if _, ok := e.indexes[idx]; !ok {
+		e.indexList = append(e.indexList, idx)
+		e.indexes[idx] = uint64(len(e.indexList))
+	}
+}
+
+// index writes a registered index, an index that is not part of the rolodex is written as nil.
+func (e *snapshotEncoder) index(idx *SpecIndex) {
+	e.uint(e.indexes[idx])
+}
+
+const (
+	snapshotErrorNil = iota
+	snapshotErrorPlain
+	snapshotErrorIndexing
+	snapshotErrorResolving
+)
+
+// err writes an error. Indexing and resolving errors keep their nodes and paths, any other error is restored
+// with its message only.
+func (e *snapshotEncoder) err(err error) {
+	switch typed := err.(type) {
+	case nil:
+		e.uint(snapshotErrorNil)
+	case *IndexingError:
+		e.uint(snapshotErrorIndexing)
+		e.err(typed.Err)
+		e.node(typed.Node)
+		e.node(typed.KeyNode)
+		e.str(typed.Path)
+	case *ResolvingError:
+		e.uint(snapshotErrorResolving)
+		e.resolvingError(typed)
+	default:
+		e.uint(snapshotErrorPlain)
+		e.str(err.Error())
+	}
+}
+
+func (e *snapshotEncoder) errSlice(errs []error) {
+	e.uint(uint64(len(errs)))
+	for _, err := range errs {
+		e.err(err)
+	}
+}
+
+func (e *snapshotEncoder) resolvingError(err *ResolvingError) {
+	e.err(err.ErrorRef)
+	e.node(err.Node)
+	e.str(err.Path)
+	e.circular(err.CircularReference)
+}
+
+// the flags of a reference, in the order they are passed to snapshotFlags.
+const (
+	snapshotRefResolved = 1 << iota
+	snapshotRefCircular
+	snapshotRefSeen
+	snapshotRefRemote
+	snapshotRefExtension
+	snapshotRefSiblings
+	snapshotRefDynamic
+)
+
+// reference writes every field of a reference.
+func (e *snapshotEncoder) reference(r *Reference) {
+	flags := snapshotFlags(r.Resolved, r.Circular, r.Seen, r.IsRemote, r.IsExtensionRef, r.HasSiblingProperties,
+		r.IsDynamic)
+	e.uint(flags)
+	e.str(r.FullDefinition)
+	e.str(r.Definition)
+	e.str(r.RawRef)
+	e.str(r.SchemaIdBase)
+	e.str(r.Name)
+	e.node(r.Node)
+	e.node(r.KeyNode)
+	e.node(r.ParentNode)
+	e.str(r.ParentNodeSchemaType)
+	e.strs(r.ParentNodeTypes)
+	e.index(r.Index)
+	e.str(r.RemoteLocation)
+	e.str(r.Path)
+	e.strs(r.SourcePath)
+	e.bool(r.RequiredRefProperties != nil)
+	e.uint(uint64(len(r.RequiredRefProperties)))
+	for _, k := range sortedKeys(r.RequiredRefProperties) {
+		e.str(k)
+		e.strs(r.RequiredRefProperties[k])
+	}
+	e.bool(r.SiblingProperties != nil)
+	e.uint(uint64(len(r.SiblingProperties)))
+	for _, k := range sortedKeys(r.SiblingProperties) {
+		e.str(k)
+		e.node(r.SiblingProperties[k])
+	}
+	e.nodeSlice(r.SiblingKeys)
+	e.str(r.In)
+}
+
+// tables writes the circular reference results, references and nodes registered so far, in that order, as each
+// table registers entries in the next one.
+func (e *snapshotEncoder) tables() (nodes, refs, circulars []byte) {
+	for i := 0; i < len(e.circularList); i++ {
+		c := e.circularList[i]
+		e.refSlice(c.Journey)
+		e.node(c.ParentNode)
+		e.ref(c.Start)
+		e.int(int64(c.LoopIndex))
+		e.ref(c.LoopPoint)
+		e.bool(c.IsArrayResult)
+		e.str(c.PolymorphicType)
+		e.bool(c.IsPolymorphicResult)
+		e.bool(c.IsInfiniteLoop)
+	}
+	circulars = e.section()
+	for i := 0; i < len(e.refList); i++ {
+		e.reference(e.refList[i])
+	}
+	refs = e.section()
+	for i := 0; i < len(e.nodeList); i++ {
+		n := e.nodeList[i]
+		e.uint(uint64(n.Kind))
+		e.uint(uint64(n.Style))
+		e.str(n.Tag)
+		e.str(n.Value)
+		e.str(n.Anchor)
+		e.node(n.Alias)
+		e.int(int64(n.Line))
+		e.int(int64(n.Column))
+		e.str(n.HeadComment)
+		e.str(n.LineComment)
+		e.str(n.FootComment)
+		e.nodeSlice(n.Content)
+	}
+	return e.section(), refs, circulars
+}
+
+// snapshotDecoder reads the sections of a snapshot written by a snapshotEncoder. The first error stops decoding,
+// every later read returns a zero value.
+type snapshotDecoder struct {
+	data      []byte
+	pos       int
+	err       error
+	strings   []string
+	nodes     []yaml.Node
+	refs      []Reference
+	circulars []CircularReferenceResult
+	indexes   []*SpecIndex
+}
+
+func (d *snapshotDecoder) fail(format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: %s at byte %d", ErrInvalidSnapshot, fmt.Sprintf(format, args...), d.pos)
+	}
+}
+
+func (d *snapshotDecoder) uint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		d.fail("malformed integer")
+		return 0
+	}
+	d.pos += n
+	return v
+}
+
+func (d *snapshotDecoder) int() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data[d.pos:])
+	if n <= 0 {
+		d.fail("malformed integer")
+		return 0
+	}
+	d.pos += n
+	return v
+}
+
+func (d *snapshotDecoder) bool() bool {
+	return d.uint() != 0
+}
+
+// count reads the length of a collection, every entry takes at least one byte.
+func (d *snapshotDecoder) count() int {
+	n := d.uint()
+	if n > uint64(len(d.data)-d.pos) {
+		d.fail("collection of %d entries exceeds the snapshot", n)
+		return 0
+	}
+	return int(n)
+}
+
+func (d *snapshotDecoder) bytes() []byte {
+	n := d.count()
+	if d.err != nil {
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *snapshotDecoder) fixed(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n > len(d.data)-d.pos {
+		d.fail("truncated")
+		return nil
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *snapshotDecoder) str() string {
+	id := d.uint()
+	if id >= uint64(len(d.strings)) {
+		d.fail("unknown string %d", id)
+		return ""
+	}
+	return d.strings[id]
+}
+
+func (d *snapshotDecoder) strs() []string {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	s := make([]string, n)
+	for i := range s {
+		s[i] = d.str()
+	}
+	return s
+}
+
+func (d *snapshotDecoder) node() *yaml.Node {
+	id := d.uint()
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(d.nodes)) {
+		d.fail("unknown node %d", id)
+		return nil
+	}
+	return &d.nodes[id-1]
+}
+
+func (d *snapshotDecoder) nodeSlice() []*yaml.Node {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]*yaml.Node, n)
+	for i := range nodes {
+		nodes[i] = d.node()
+	}
+	return nodes
+}
+
+func (d *snapshotDecoder) ref() *Reference {
+	id := d.uint()
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(d.refs)) {
+		d.fail("unknown reference %d", id)
+		return nil
+	}
+	return &d.refs[id-1]
+}
+
+func (d *snapshotDecoder) refSlice() []*Reference {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	refs := make([]*Reference, n)
+	for i := range refs {
+		refs[i] = d.ref()
+	}
+	return refs
+}
+
+func (d *snapshotDecoder) refMap(into map[string]*Reference) map[string]*Reference {
+	n := d.count()
+	if into == nil {
+		into = make(map[string]*Reference, n)
+	}
+	for i := 0; i < n; i++ {
+		k := d.str()
+		into[k] = d.ref()
+	}
+	return into
+}
+
+func (d *snapshotDecoder) circular() *CircularReferenceResult {
+	id := d.uint()
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(d.circulars)) {
+		d.fail("unknown circular reference %d", id)
+		return nil
+	}
+	return &d.circulars[id-1]
+}
+
+func (d *snapshotDecoder) circularSlice() []*CircularReferenceResult {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	results := make([]*CircularReferenceResult, n)
+	for i := range results {
+		results[i] = d.circular()
+	}
+	return results
+}
+
+func (d *snapshotDecoder) index() *SpecIndex {
+	id := d.uint()
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(d.indexes)) {
+		d.fail("unknown index %d", id)
+		return nil
+	}
+	return d.indexes[id-1]
+}
+
+func (d *snapshotDecoder) error() error {
+	switch kind := d.uint(); kind {
+	case snapshotErrorNil:
+		return nil
+	case snapshotErrorPlain:
+		return errors.New(d.str())
+	case snapshotErrorIndexing:
+		return &IndexingError{Err: d.error(), Node: d.node(), KeyNode: d.node(), Path: d.str()}
+	case snapshotErrorResolving:
+		return d.resolvingError()
+	default:
+		d.fail("unknown error kind %d", kind)
+		return nil
+	}
+}
+
+func (d *snapshotDecoder) errSlice() []error {
+	n := d.count()
+	if n == 0 {
+		return nil
+	}
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = d.error()
+	}
+	return errs
+}
+
+func (d *snapshotDecoder) resolvingError() *ResolvingError {
+	return &ResolvingError{ErrorRef: d.error(), Node: d.node(), Path: d.str(), CircularReference: d.circular()}
+}
+
+func (d *snapshotDecoder) reference(r *Reference) {
+	flags := d.uint()
+	r.Resolved = flags&snapshotRefResolved != 0
+	r.Circular = flags&snapshotRefCircular != 0
+	r.Seen = flags&snapshotRefSeen != 0
+	r.IsRemote = flags&snapshotRefRemote != 0
+	r.IsExtensionRef = flags&snapshotRefExtension != 0
+	r.HasSiblingProperties = flags&snapshotRefSiblings != 0
+	r.IsDynamic = flags&snapshotRefDynamic != 0
+	r.FullDefinition = d.str()
+	r.Definition = d.str()
+	r.RawRef = d.str()
+	r.SchemaIdBase = d.str()
+	r.Name = d.str()
+	r.Node = d.node()
+	r.KeyNode = d.node()
+	r.ParentNode = d.node()
+	r.ParentNodeSchemaType = d.str()
+	r.ParentNodeTypes = d.strs()
+	r.Index = d.index()
+	r.RemoteLocation = d.str()
+	r.Path = d.str()
+	r.SourcePath = d.strs()
+	if present, n := d.bool(), d.count(); present {
+		r.RequiredRefProperties = make(map[string][]string, n)
+		for i := 0; i < n; i++ {
+			k := d.str()
+			r.RequiredRefProperties[k] = d.strs()
+		}
+	}
+	if present, n := d.bool(), d.count(); present {
+		r.SiblingProperties = make(map[string]*yaml.Node, n)
+		for i := 0; i < n; i++ {
+			k := d.str()
+			r.SiblingProperties[k] = d.node()
+		}
+	}
+	r.SiblingKeys = d.nodeSlice()
+	r.In = d.str()
+}
+
+// tables reads the node, reference and circular reference tables, allocated up front so ids can point forward.
+func (d *snapshotDecoder) tables() {
+	for i := range d.nodes {
+		n := &d.nodes[i]
+		n.Kind = yaml.Kind(d.uint())
+		n.Style = yaml.Style(d.uint())
+		n.Tag = d.str()
+		n.Value = d.str()
+		n.Anchor = d.str()
+		n.Alias = d.node()
+		n.Line = int(d.int())
+		n.Column = int(d.int())
+		n.HeadComment = d.str()
+		n.LineComment = d.str()
+		n.FootComment = d.str()
+		n.Content = d.nodeSlice()
+	}
+	for i := range d.refs {
+		d.reference(&d.refs[i])
+	}
+	for i := range d.circulars {
+		c := &d.circulars[i]
+		c.Journey = d.refSlice()
+		c.ParentNode = d.node()
+		c.Start = d.ref()
+		c.LoopIndex = int(d.int())
+		c.LoopPoint = d.ref()
+		c.IsArrayResult = d.bool()
+		c.PolymorphicType = d.str()
+		c.IsPolymorphicResult = d.bool()
+		c.IsInfiniteLoop = d.bool()
+	}
+}
+
+func snapshotFlags(set ...bool) uint64 {
+	var flags uint64
+	for i, v := range set {
+		if v {
+			flags |= 1 << i
+		}
+	}
+	return flags
+}
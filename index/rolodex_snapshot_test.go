@@ -0,0 +1,182 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+const snapshotRootSpec = `openapi: 3.1.0
+info:
+  title: snapshot
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list pets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'pets.yaml#/Pets'
+components:
+  schemas:
+    Node:
+      $id: https://example.com/node
+      type: object
+      properties:
+        next:
+          $ref: '#/components/schemas/Node'
+    Owner:
+      $ref: 'pets.yaml#/Owner'`
+
+const snapshotPetsSpec = `Pets:
+  type: array
+  items:
+    $ref: '#/Pet'
+Pet:
+  type: object
+  properties:
+    name:
+      type: string
+      enum: [rex, fido]
+    owner:
+      $ref: '#/Owner'
+Owner:
+  type: object
+  properties:
+    pet:
+      $ref: '#/Pet'`
+
+// indexForSnapshot indexes the snapshot documents from a temporary directory.
+func indexForSnapshot(t *testing.T) (*Rolodex, string) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "root.yaml"), []byte(snapshotRootSpec), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte(snapshotPetsSpec), 0o644))
+	rolodex := NewRolodex(snapshotConfig(t, dir))
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: rolodex.indexConfig})
+	require.NoError(t, err)
+	rolodex.AddLocalFS(dir, localFS)
+	rolodex.SetRootNode(rolodex.indexConfig.SpecInfo.RootNode)
+	require.NoError(t, rolodex.IndexTheRolodex(context.Background()))
+	rolodex.CheckForCircularReferences()
+	return rolodex, dir
+}
+
+func snapshotConfig(t *testing.T, dir string) *SpecIndexConfig {
+	t.Helper()
+	info, err := datamodel.ExtractSpecInfo([]byte(snapshotRootSpec))
+	require.NoError(t, err)
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	cf.SpecFilePath = filepath.Join(dir, "root.yaml")
+	cf.SpecInfo = info
+	return cf
+}
+
+func TestRolodex_Snapshot(t *testing.T) {
+	rolodex, dir := indexForSnapshot(t)
+	snapshot, err := rolodex.Snapshot()
+	require.NoError(t, err)
+
+	restored, err := RestoreRolodex(snapshot, []byte(snapshotRootSpec), CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	original, root := rolodex.GetRootIndex(), restored.GetRootIndex()
+	require.NotNil(t, root)
+
+	// references, mapped references and their nodes, with line information.
+	assert.Equal(t, len(original.GetRawReferencesSequenced()), len(root.GetRawReferencesSequenced()))
+	require.Equal(t, len(original.GetMappedReferencesSequenced()), len(root.GetMappedReferencesSequenced()))
+	for i, mapped := range original.GetMappedReferencesSequenced() {
+		got := root.GetMappedReferencesSequenced()[i]
+		assert.Equal(t, mapped.FullDefinition, got.FullDefinition)
+		assert.Equal(t, mapped.Reference.Node.Line, got.Reference.Node.Line)
+		assert.Equal(t, mapped.Reference.Node.Column, got.Reference.Node.Column)
+	}
+	assert.Equal(t, rolodex.GetIndexingDuration(), restored.GetIndexingDuration())
+	assert.Equal(t, "https://example.com/node", root.GetAllSchemaIds()["https://example.com/node"].Id)
+
+	// circular references, including those found in other files.
+	require.NotEmpty(t, original.GetCircularReferences())
+	require.Len(t, root.GetCircularReferences(), len(original.GetCircularReferences()))
+	for i, circ := range original.GetCircularReferences() {
+		got := root.GetCircularReferences()[i]
+		assert.Equal(t, circ.LoopPoint.FullDefinition, got.LoopPoint.FullDefinition)
+		assert.Len(t, got.Journey, len(circ.Journey))
+		assert.Same(t, got.LoopPoint, got.Journey[got.LoopIndex])
+	}
+	assert.Equal(t, len(rolodex.GetSafeCircularReferences()), len(restored.GetSafeCircularReferences()))
+
+	// components collected again from the restored nodes.
+	assert.Equal(t, len(original.GetAllComponentSchemas()), len(root.GetAllComponentSchemas()))
+	assert.Equal(t, original.GetPathCount(), root.GetPathCount())
+	assert.Same(t, restored, root.GetRolodex())
+
+	// files are served from the snapshot, with their content and index.
+	pets, err := restored.Open(filepath.Join(dir, "pets.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, snapshotPetsSpec, pets.GetContent())
+	require.NotNil(t, pets.GetIndex())
+	assert.NotNil(t, pets.GetIndex().FindComponent(context.Background(), "#/Pet"))
+	relative, err := restored.Open("pets.yaml")
+	require.NoError(t, err)
+	assert.Same(t, pets.GetIndex(), relative.GetIndex())
+
+	// a restored rolodex can be snapshotted again.
+	again, err := restored.Snapshot()
+	require.NoError(t, err)
+	_, err = RestoreRolodex(again, []byte(snapshotRootSpec), CreateOpenAPIIndexConfig())
+	assert.NoError(t, err)
+}
+
+func TestRolodex_Snapshot_Stale(t *testing.T) {
+	rolodex, dir := indexForSnapshot(t)
+	snapshot, err := rolodex.Snapshot()
+	require.NoError(t, err)
+
+	_, err = RestoreRolodex(snapshot, []byte(snapshotRootSpec+"\n"), CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrStaleSnapshot)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte("Pets: {}"), 0o644))
+	_, err = RestoreRolodex(snapshot, []byte(snapshotRootSpec), CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrStaleSnapshot)
+	assert.ErrorContains(t, err, "pets.yaml")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "pets.yaml")))
+	_, err = RestoreRolodex(snapshot, []byte(snapshotRootSpec), CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrStaleSnapshot)
+}
+
+func TestRolodex_Snapshot_Invalid(t *testing.T) {
+	rolodex, _ := indexForSnapshot(t)
+	snapshot, err := rolodex.Snapshot()
+	require.NoError(t, err)
+	spec := []byte(snapshotRootSpec)
+
+	_, err = RestoreRolodex([]byte("not a snapshot"), spec, CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+
+	// every truncation is detected, never a panic.
+	for i := len(snapshotMagic); i < len(snapshot); i += 7 {
+		_, err = RestoreRolodex(snapshot[:i], spec, CreateOpenAPIIndexConfig())
+		require.Error(t, err, "truncated at %d", i)
+	}
+	_, err = RestoreRolodex(append(snapshot, 0), spec, CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrInvalidSnapshot)
+
+	_, err = RestoreRolodex(snapshot, spec, nil)
+	assert.Error(t, err)
+	_, err = NewRolodex(CreateOpenAPIIndexConfig()).Snapshot()
+	assert.Error(t, err)
+}
@@ -0,0 +1,675 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// RolodexFileChange is a change to a local file held by the rolodex. Changes are applied with Rolodex.UpdateFiles.
+type RolodexFileChange struct {
+	// Path of the changed file, absolute or relative to the BasePath of the index configuration.
+	Path string
+
+	// Content is the new content of the file.
+	Content []byte
+
+	// Deleted marks the file as removed, Content is ignored.
+	Deleted bool
+}
+
+// RolodexChangeEvent describes what changed in the rolodex after applying file changes.
+type RolodexChangeEvent struct {
+	// ChangedFiles are the absolute paths of the files that changed.
+	ChangedFiles []string
+
+	// ReindexedFiles are the absolute paths of the files that were re-indexed, the changed files and every file
+	// that references them, directly or transitively.
+	ReindexedFiles []string
+
+	// ImpactedComponents are the full definitions (for example /specs/openapi.yaml#/components/schemas/Pet) of the
+	// components and path items that were added, removed or modified, and of everything that references them. A
+	// file without components is reported by its path.
+	ImpactedComponents []string
+
+	// Errors are the errors caught while re-indexing.
+	Errors []error
+
+	// Duration is how long the update took.
+	Duration time.Duration
+}
+
+// RolodexWatchConfig is the configuration for Rolodex.Watch.
+type RolodexWatchConfig struct {
+	// BaseDirectory is the absolute directory the FS is rooted at. Defaults to the BasePath of the index
+	// configuration.
+	BaseDirectory string
+
+	// FS is polled for changes to the files held by the rolodex. Defaults to os.DirFS(BaseDirectory).
+	FS fs.FS
+
+	// Interval is the time between polls. Defaults to 500 milliseconds.
+	Interval time.Duration
+}
+
+// UpdateFiles applies changes to local files held by the rolodex and re-indexes only what they affect: the changed
+// files, and every file that references them. Resolved references, circular reference results and errors held by
+// the rolodex that belong to the re-indexed files are replaced. Files that are not affected keep their indexes.
+//
+// The rolodex must have been indexed with IndexTheRolodex, and must not have been resolved, because resolving
+// mutates the node trees that the unaffected indexes hold. When the root document changes, it is re-parsed from
+// the new content and the root index is rebuilt; models built from the old root node are not updated.
+//
+// The returned error joins any errors caught while re-indexing, the event is returned regardless.
+func (r *Rolodex) UpdateFiles(ctx context.Context, changes ...RolodexFileChange) (*RolodexChangeEvent, error) {
+	if r == nil {
+		return nil, errors.New("rolodex has not been initialized, cannot update files")
+	}
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	if !r.indexed {
+		return nil, errors.New("rolodex has not been indexed, call IndexTheRolodex before updating files")
+	}
+	if r.resolved {
+		return nil, errors.New("rolodex has been resolved, resolved node trees cannot be updated incrementally")
+	}
+	started := time.Now()
+
+	rootPath := ""
+	if r.rootIndex != nil {
+		rootPath = r.rootIndex.specAbsolutePath
+	}
+	current := make(map[string]*SpecIndex)
+	for _, idx := range r.GetIndexes() {
+		current[idx.specAbsolutePath] = idx
+	}
+
+	// validate every change before anything is modified.
+	changed := make(map[string]RolodexFileChange)
+	owners := make(map[string]*LocalFS)
+	var rootNode *yaml.Node
+	for _, change := range changes {
+		p := r.absoluteFilePath(change.Path)
+		owner := r.localFSForFile(p)
+		if owner == nil && p != rootPath {
+			return nil, fmt.Errorf("unable to update '%s', no local file system holds it", p)
+		}
+		if p == rootPath {
+			if change.Deleted {
+				return nil, fmt.Errorf("unable to delete the root document '%s'", p)
+			}
+			var node yaml.Node
+			if err := yaml.Unmarshal(change.Content, &node); err != nil {
+				return nil, fmt.Errorf("unable to parse the root document '%s': %w", p, err)
+			}
+			rootNode = &node
+		}
+		changed[p] = change
+		owners[p] = owner
+	}
+
+	event := &RolodexChangeEvent{ChangedFiles: sortedKeys(changed)}
+	if len(changed) == 0 {
+		return event, nil
+	}
+
+	before := make(map[string]map[string]string)
+	for p := range changed {
+		if p == rootPath {
+			before[p] = rolodexComponentHashes(p, r.rootNode)
+		} else if idx := current[p]; idx != nil {
+			before[p] = rolodexComponentHashes(p, idx.GetRootNode())
+		}
+	}
+
+	// everything that depends on a changed file, directly or transitively, has to be re-indexed.
+	dependents := r.fileDependents(current)
+	affected := make(map[string]bool)
+	queue := slices.Clone(event.ChangedFiles)
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if affected[p] {
+			continue
+		}
+		affected[p] = true
+		queue = append(queue, dependents[p]...)
+	}
+
+	// apply the changes.
+	for p, change := range changed {
+		if owner := owners[p]; owner != nil {
+			if change.Deleted {
+				owner.Files.Delete(p)
+			} else {
+				owner.Files.Store(p, owner.refreshFile(p, change.Content))
+			}
+		}
+	}
+	if rootNode != nil {
+		r.rootNode = rootNode
+	}
+
+	// collect everything the replaced indexes contributed, so it can be removed from the rolodex.
+	var replaced []*SpecIndex
+	for p := range affected {
+		if idx := current[p]; idx != nil {
+			replaced = append(replaced, idx)
+		}
+	}
+	if affected[rootPath] && r.rootIndex != nil {
+		replaced = append(replaced, r.rootIndex)
+	}
+	r.removeIndexes(replaced)
+
+	// re-index the affected files, then build them in two steps, like IndexTheRolodex.
+	var rebuilt []*SpecIndex
+	for _, p := range sortedKeys(affected) {
+		owner := r.localFSForFile(p)
+		if owner == nil {
+			continue
+		}
+		f, ok := owner.Files.Load(p)
+		if !ok {
+			continue
+		}
+		lf := f.(*LocalFile)
+		if _, isChanged := changed[p]; !isChanged {
+			lf = owner.refreshFile(p, lf.data)
+			owner.Files.Store(p, lf)
+		}
+		copiedCfg := *r.indexConfig
+		copiedCfg.Rolodex = r
+		copiedCfg.SpecAbsolutePath = p
+		copiedCfg.AvoidBuildIndex = true
+		copiedCfg.SpecInfo = nil
+		idx, _ := lf.IndexWithContext(ctx, &copiedCfg)
+		if idx == nil {
+			continue
+		}
+		idx.rolodex = r
+		resolver := NewResolver(idx)
+		if copiedCfg.IgnoreArrayCircularReferences {
+			resolver.IgnoreArrayCircularReferences()
+		}
+		if copiedCfg.IgnorePolymorphicCircularReferences {
+			resolver.IgnorePolymorphicCircularReferences()
+		}
+		rebuilt = append(rebuilt, idx)
+	}
+
+	var caughtErrors []error
+	for _, idx := range rebuilt {
+		idx.BuildIndex()
+		r.AddIndex(idx)
+		if r.indexConfig.AvoidCircularReferenceCheck {
+			continue
+		}
+		for _, e := range idx.resolver.CheckForCircularReferences() {
+			caughtErrors = append(caughtErrors, e)
+		}
+		r.ignoredCircularReferences = append(r.ignoredCircularReferences,
+			idx.resolver.GetIgnoredCircularPolyReferences()...)
+		r.ignoredCircularReferences = append(r.ignoredCircularReferences,
+			idx.resolver.GetIgnoredCircularArrayReferences()...)
+		r.safeCircularReferences = append(r.safeCircularReferences, idx.resolver.GetSafeCircularReferences()...)
+		r.infiniteCircularReferences = append(r.infiniteCircularReferences,
+			idx.resolver.GetInfiniteCircularReferences()...)
+	}
+	if affected[rootPath] && r.rootNode != nil {
+		caughtErrors = append(caughtErrors, r.indexRootNode(ctx)...)
+	}
+	r.caughtErrors = append(r.caughtErrors, caughtErrors...)
+
+	r.circRefCacheLock.Lock()
+	r.debouncedSafeCircRefs = nil
+	r.debouncedIgnoredCircRefs = nil
+	r.circRefCacheLock.Unlock()
+
+	event.ReindexedFiles = sortedKeys(affected)
+	event.ImpactedComponents = r.impactedComponents(changed, before, rootPath)
+	event.Errors = caughtErrors
+	event.Duration = time.Since(started)
+	r.logger.Debug("[rolodex] files updated", "changed", len(event.ChangedFiles),
+		"reindexed", len(event.ReindexedFiles), "duration", event.Duration)
+	return event, errors.Join(caughtErrors...)
+}
+
+// Watch polls the files held by the rolodex for changes, applies them with UpdateFiles, and sends an event for
+// every update on the returned channel. Errors that prevent an update are reported in the Errors of the event.
+// The channel is closed when the context is done.
+func (r *Rolodex) Watch(ctx context.Context, config *RolodexWatchConfig) (<-chan *RolodexChangeEvent, error) {
+	if r == nil {
+		return nil, errors.New("rolodex has not been initialized, cannot watch files")
+	}
+	if config == nil {
+		config = &RolodexWatchConfig{}
+	}
+	baseDirectory := config.BaseDirectory
+	if baseDirectory == "" && r.indexConfig != nil {
+		baseDirectory = r.indexConfig.BasePath
+	}
+	if baseDirectory == "" {
+		return nil, errors.New("no base directory to watch, set the BaseDirectory of the watch configuration")
+	}
+	baseDirectory, _ = filepath.Abs(baseDirectory)
+	dirFS := config.FS
+	if dirFS == nil {
+		dirFS = os.DirFS(baseDirectory)
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+
+	type stamp struct {
+		modTime time.Time
+		size    int64
+	}
+	stat := func(p string) (stamp, bool, error) {
+		rel, err := filepath.Rel(baseDirectory, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return stamp{}, false, nil
+		}
+		info, err := fs.Stat(dirFS, filepath.ToSlash(rel))
+		if err != nil {
+			return stamp{}, true, err
+		}
+		return stamp{modTime: info.ModTime(), size: info.Size()}, true, nil
+	}
+	seen := make(map[string]stamp)
+	for _, p := range r.watchedFiles() {
+		if s, ok, err := stat(p); ok && err == nil {
+			seen[p] = s
+		}
+	}
+
+	events := make(chan *RolodexChangeEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			var changes []RolodexFileChange
+			updated := make(map[string]stamp)
+			for _, p := range r.watchedFiles() {
+				s, ok, err := stat(p)
+				if !ok {
+					continue
+				}
+				previous, known := seen[p]
+				if err != nil {
+					if known && errors.Is(err, fs.ErrNotExist) {
+						delete(seen, p)
+						changes = append(changes, RolodexFileChange{Path: p, Deleted: true})
+					}
+					continue
+				}
+				if !known {
+					seen[p] = s
+					continue
+				}
+				if previous.modTime.Equal(s.modTime) && previous.size == s.size {
+					continue
+				}
+				rel, _ := filepath.Rel(baseDirectory, p)
+				content, readErr := fs.ReadFile(dirFS, filepath.ToSlash(rel))
+				if readErr != nil {
+					// keep the previous stat, so the change is picked up again on the next tick.
+					continue
+				}
+				updated[p] = s
+				changes = append(changes, RolodexFileChange{Path: p, Content: content})
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			event, err := r.UpdateFiles(ctx, changes...)
+			for p, s := range updated {
+				seen[p] = s
+			}
+			if event == nil {
+				event = &RolodexChangeEvent{Errors: []error{err}}
+				for _, change := range changes {
+					event.ChangedFiles = append(event.ChangedFiles, change.Path)
+				}
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// watchedFiles returns the root document and every file held by the local file systems of the rolodex.
+func (r *Rolodex) watchedFiles() []string {
+	files := make(map[string]bool)
+	r.indexLock.Lock()
+	if r.rootIndex != nil && r.rootIndex.specAbsolutePath != "" {
+		files[r.rootIndex.specAbsolutePath] = true
+	}
+	localFS := slices.Collect(maps.Values(r.localFS))
+	r.indexLock.Unlock()
+	for _, v := range localFS {
+		if lfs, ok := v.(*LocalFS); ok {
+			lfs.Files.Range(func(key, _ any) bool {
+				files[key.(string)] = true
+				return true
+			})
+		}
+	}
+	return sortedKeys(files)
+}
+
+// absoluteFilePath resolves a path relative to the BasePath of the index configuration.
+func (r *Rolodex) absoluteFilePath(p string) string {
+	if !filepath.IsAbs(p) && r.indexConfig != nil && r.indexConfig.BasePath != "" {
+		p = filepath.Join(r.indexConfig.BasePath, p)
+	}
+	abs, _ := filepath.Abs(p)
+	return abs
+}
+
+// localFSForFile returns the LocalFS that holds the file, or the LocalFS the file would be loaded from. When the
+// base directories of several file systems contain the file, the most specific (longest) one wins.
+func (r *Rolodex) localFSForFile(p string) *LocalFS {
+	r.indexLock.Lock()
+	localFS := slices.Collect(maps.Values(r.localFS))
+	r.indexLock.Unlock()
+
+	var holder, candidate *LocalFS
+	for _, v := range localFS {
+		lfs, ok := v.(*LocalFS)
+		if !ok {
+			continue
+		}
+		if _, found := lfs.Files.Load(p); found {
+			if holder == nil || len(lfs.baseDirectory) > len(holder.baseDirectory) {
+				holder = lfs
+			}
+			continue
+		}
+		rel, err := filepath.Rel(lfs.baseDirectory, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if candidate == nil || len(lfs.baseDirectory) > len(candidate.baseDirectory) {
+			candidate = lfs
+		}
+	}
+	if holder != nil {
+		return holder
+	}
+	return candidate
+}
+
+// fileDependents maps every file to the files that reference it.
+func (r *Rolodex) fileDependents(current map[string]*SpecIndex) map[string][]string {
+	dependents := make(map[string][]string)
+	indexes := make([]*SpecIndex, 0, len(current)+1)
+	for _, idx := range current {
+		indexes = append(indexes, idx)
+	}
+	if r.rootIndex != nil {
+		indexes = append(indexes, r.rootIndex)
+	}
+	for _, idx := range indexes {
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			target, _ := splitFullDefinition(ref.FullDefinition)
+			if target == "" || target == idx.specAbsolutePath || slices.Contains(dependents[target], idx.specAbsolutePath) {
+				continue
+			}
+			dependents[target] = append(dependents[target], idx.specAbsolutePath)
+		}
+	}
+	return dependents
+}
+
+// removeIndexes removes indexes from the rolodex, along with the schema ids, anchors, circular reference results
+// and errors they contributed.
+func (r *Rolodex) removeIndexes(indexes []*SpecIndex) {
+	if len(indexes) == 0 {
+		return
+	}
+	removed := make(map[*SpecIndex]bool)
+	staleResults := make(map[*CircularReferenceResult]bool)
+	staleResolving := make(map[*ResolvingError]bool)
+	staleIndexing := make(map[*IndexingError]bool)
+	for _, idx := range indexes {
+		removed[idx] = true
+		if res := idx.GetResolver(); res != nil {
+			for _, c := range slices.Concat(res.circularReferences, res.ignoredPolyReferences, res.ignoredArrayReferences) {
+				staleResults[c] = true
+			}
+			for _, e := range res.resolvingErrors {
+				staleResolving[e] = true
+			}
+		}
+		for _, e := range idx.refErrors {
+			if ie, ok := e.(*IndexingError); ok {
+				staleIndexing[ie] = true
+			}
+		}
+	}
+
+	r.indexLock.Lock()
+	r.indexes = slices.DeleteFunc(r.indexes, func(idx *SpecIndex) bool { return removed[idx] })
+	for k, idx := range r.indexMap {
+		if removed[idx] {
+			delete(r.indexMap, k)
+		}
+	}
+	r.indexLock.Unlock()
+
+	r.schemaIdRegistryLock.Lock()
+	for k, entry := range r.globalSchemaIdRegistry {
+		if removed[entry.Index] {
+			delete(r.globalSchemaIdRegistry, k)
+		}
+	}
+	r.schemaIdRegistryLock.Unlock()
+	r.anchorRegistryLock.Lock()
+	for k, entry := range r.globalAnchorRegistry {
+		if removed[entry.Index] {
+			delete(r.globalAnchorRegistry, k)
+		}
+	}
+	r.anchorRegistryLock.Unlock()
+
+	stale := func(c *CircularReferenceResult) bool { return staleResults[c] }
+	r.safeCircularReferences = slices.DeleteFunc(r.safeCircularReferences, stale)
+	r.infiniteCircularReferences = slices.DeleteFunc(r.infiniteCircularReferences, stale)
+	r.ignoredCircularReferences = slices.DeleteFunc(r.ignoredCircularReferences, stale)
+	r.caughtErrors = slices.DeleteFunc(r.caughtErrors, func(e error) bool {
+		switch err := e.(type) {
+		case *ResolvingError:
+			return staleResolving[err]
+		case *IndexingError:
+			return staleIndexing[err]
+		}
+		return false
+	})
+}
+
+// impactedComponents compares the components of the changed files before and after the change, and follows
+// references back from every modified component to everything that depends on it.
+func (r *Rolodex) impactedComponents(changed map[string]RolodexFileChange, before map[string]map[string]string,
+	rootPath string,
+) []string {
+	impacted := make(map[string]bool)
+	for p, change := range changed {
+		after := map[string]string{}
+		if !change.Deleted {
+			if p == rootPath {
+				after = rolodexComponentHashes(p, r.rootNode)
+			} else {
+				var node yaml.Node
+				_ = yaml.Unmarshal(change.Content, &node)
+				after = rolodexComponentHashes(p, &node)
+			}
+		}
+		for k, h := range before[p] {
+			if after[k] != h {
+				impacted[k] = true
+			}
+		}
+		for k, h := range after {
+			if before[p][k] != h {
+				impacted[k] = true
+			}
+		}
+	}
+
+	type edge struct {
+		owner  string
+		target string
+	}
+	var edges []edge
+	for _, idx := range append(r.GetIndexes(), r.rootIndex) {
+		if idx == nil {
+			continue
+		}
+		pointers := make([]string, 0)
+		for k := range rolodexComponentHashes("", idx.GetRootNode()) {
+			pointers = append(pointers, strings.TrimPrefix(k, "#"))
+		}
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			source := "/" + strings.Join(ref.SourcePath, "/")
+			owner := ""
+			for _, p := range pointers {
+				if isPointerPrefix(p, source) && len(p) > len(owner) {
+					owner = p
+				}
+			}
+			edges = append(edges, edge{owner: joinFullDefinition(idx.specAbsolutePath, owner), target: ref.FullDefinition})
+		}
+	}
+
+	isImpacted := func(target string) bool {
+		file, fragment := splitFullDefinition(target)
+		for k := range impacted {
+			kFile, kFragment := splitFullDefinition(k)
+			if kFile == file && (isPointerPrefix(kFragment, fragment) || isPointerPrefix(fragment, kFragment)) {
+				return true
+			}
+		}
+		return false
+	}
+	for grew := true; grew; {
+		grew = false
+		for _, e := range edges {
+			if !impacted[e.owner] && isImpacted(e.target) {
+				impacted[e.owner] = true
+				grew = true
+			}
+		}
+	}
+	return sortedKeys(impacted)
+}
+
+// refreshFile creates a new, un-indexed LocalFile for a path held by the LocalFS. Like files loaded in a batch, it
+// is signalled as ready immediately, its index is created by the caller.
+func (l *LocalFS) refreshFile(p string, data []byte) *LocalFile {
+	lf := &LocalFile{
+		filename:         p,
+		name:             filepath.Base(p),
+		extension:        ExtractFileType(p),
+		data:             data,
+		fullPath:         p,
+		lastModified:     time.Now(),
+		indexingComplete: make(chan struct{}),
+	}
+	if existing, ok := l.Files.Load(p); ok {
+		lf.filename = existing.(*LocalFile).filename
+	}
+	lf.signalIndexingComplete()
+	return lf
+}
+
+// rolodexComponentHashes returns a hash of every component and path item in a document, keyed by full definition.
+// A document without any is hashed as a whole, keyed by its path.
+func rolodexComponentHashes(p string, root *yaml.Node) map[string]string {
+	hashes := make(map[string]string)
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			switch key {
+			case "components":
+				if value.Kind != yaml.MappingNode {
+					continue
+				}
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					addComponentHashes(hashes, p, "/components/"+value.Content[j].Value, value.Content[j+1])
+				}
+			case "paths", "webhooks", "definitions", "parameters", "responses", "securityDefinitions", "$defs":
+				addComponentHashes(hashes, p, "/"+key, value)
+			}
+		}
+	}
+	if len(hashes) == 0 {
+		hashes[p] = HashNode(root)
+	}
+	return hashes
+}
+
+func addComponentHashes(hashes map[string]string, p, pointer string, section *yaml.Node) {
+	if section == nil || section.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(section.Content); i += 2 {
+		name := escapePointerSegment(section.Content[i].Value)
+		hashes[joinFullDefinition(p, pointer+"/"+name)] = HashNode(section.Content[i+1])
+	}
+}
+
+func splitFullDefinition(fullDefinition string) (string, string) {
+	file, fragment, _ := strings.Cut(fullDefinition, "#")
+	return file, fragment
+}
+
+func joinFullDefinition(file, pointer string) string {
+	if pointer == "" {
+		return file
+	}
+	return file + "#" + pointer
+}
+
+// isPointerPrefix returns true if the JSON pointer parent is, or contains, child.
+func isPointerPrefix(parent, child string) bool {
+	return parent == "" || parent == child || strings.HasPrefix(child, parent+"/")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+const watchRootSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+  /tags:
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: 'pet.yaml'
+    Other:
+      $ref: 'shared.yaml#/components/schemas/Owner'
+    Lonely:
+      type: string
+`
+
+const watchPetSpec = `type: object
+properties:
+  owner:
+    $ref: 'shared.yaml#/components/schemas/Owner'
+`
+
+const watchSharedSpec = `components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+    Tag:
+      type: string
+`
+
+func buildWatchRolodex(t *testing.T) (*Rolodex, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"root.yaml":   watchRootSpec,
+		"pet.yaml":    watchPetSpec,
+		"shared.yaml": watchSharedSpec,
+		"unused.yaml": "type: string\n",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(watchRootSpec), &root))
+
+	cfg := CreateOpenAPIIndexConfig()
+	cfg.BasePath = dir
+	cfg.SpecFilePath = filepath.Join(dir, "root.yaml")
+	cfg.AllowFileLookup = true
+	rolodex := NewRolodex(cfg)
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: cfg})
+	assert.NoError(t, err)
+	rolodex.AddLocalFS(dir, localFS)
+	rolodex.SetRootNode(&root)
+	assert.NoError(t, rolodex.IndexTheRolodex(context.Background()))
+	assert.Len(t, rolodex.GetIndexes(), 2)
+	return rolodex, dir
+}
+
+func TestRolodex_UpdateFiles_Component(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	oldRoot := rolodex.GetRootIndex()
+
+	event, err := rolodex.UpdateFiles(context.Background(), RolodexFileChange{
+		Path: "shared.yaml",
+		Content: []byte(`components:
+  schemas:
+    Owner:
+      type: object
+      properties:
+        name:
+          type: integer
+    Tag:
+      type: string
+`),
+	})
+	assert.NoError(t, err)
+	root, pet, shared := filepath.Join(dir, "root.yaml"), filepath.Join(dir, "pet.yaml"), filepath.Join(dir, "shared.yaml")
+	assert.Equal(t, []string{shared}, event.ChangedFiles)
+	assert.Equal(t, []string{pet, root, shared}, event.ReindexedFiles)
+	assert.Equal(t, []string{
+		pet,
+		root + "#/components/schemas/Other",
+		root + "#/components/schemas/Pets",
+		root + "#/paths/~1pets",
+		shared + "#/components/schemas/Owner",
+	}, event.ImpactedComponents)
+	assert.NotSame(t, oldRoot, rolodex.GetRootIndex())
+	assert.Len(t, rolodex.GetIndexes(), 2)
+
+	owner := rolodex.GetRootIndex().GetMappedReferences()[shared+"#/components/schemas/Owner"]
+	assert.NotNil(t, owner)
+	assert.Equal(t, "integer", owner.Node.Content[3].Content[1].Content[1].Value)
+}
+
+func TestRolodex_UpdateFiles_UnreferencedComponent(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+
+	event, err := rolodex.UpdateFiles(context.Background(), RolodexFileChange{
+		Path:    filepath.Join(dir, "shared.yaml"),
+		Content: []byte(watchSharedSpec + "    Added:\n      type: boolean\n"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "shared.yaml") + "#/components/schemas/Added"}, event.ImpactedComponents)
+}
+
+func TestRolodex_UpdateFiles_EscapedComponentName(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+
+	event, err := rolodex.UpdateFiles(context.Background(), RolodexFileChange{
+		Path:    filepath.Join(dir, "shared.yaml"),
+		Content: []byte(watchSharedSpec + "    a~b/c:\n      type: boolean\n"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "shared.yaml") + "#/components/schemas/a~0b~1c"}, event.ImpactedComponents)
+}
+
+func TestRolodex_UpdateFiles_Root(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	pet := rolodex.GetIndexes()[0]
+
+	updated := watchRootSpec + "    Tags:\n      type: array\n"
+	event, err := rolodex.UpdateFiles(context.Background(), RolodexFileChange{
+		Path:    filepath.Join(dir, "root.yaml"),
+		Content: []byte(updated),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "root.yaml")}, event.ReindexedFiles)
+	assert.Equal(t, []string{filepath.Join(dir, "root.yaml") + "#/components/schemas/Tags"}, event.ImpactedComponents)
+	assert.Same(t, pet, rolodex.GetIndexes()[0])
+	assert.Len(t, rolodex.GetRootIndex().GetAllComponentSchemas(), 4)
+}
+
+func TestRolodex_UpdateFiles_Circular(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	assert.Empty(t, rolodex.GetCaughtErrors())
+
+	circular := []byte(`components:
+  schemas:
+    Owner:
+      type: object
+      required: [parent]
+      properties:
+        parent:
+          $ref: '#/components/schemas/Owner'
+`)
+	event, err := rolodex.UpdateFiles(context.Background(), RolodexFileChange{Path: filepath.Join(dir, "shared.yaml"), Content: circular})
+	assert.Error(t, err)
+	assert.NotEmpty(t, event.Errors)
+	assert.NotEmpty(t, rolodex.GetCaughtErrors())
+
+	event, err = rolodex.UpdateFiles(context.Background(), RolodexFileChange{
+		Path: filepath.Join(dir, "shared.yaml"), Content: []byte(watchSharedSpec),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, event.Errors)
+	assert.Empty(t, rolodex.GetCaughtErrors())
+	assert.Empty(t, rolodex.GetSafeCircularReferences())
+}
+
+func TestRolodex_UpdateFiles_Deleted(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	assert.NoError(t, os.Remove(filepath.Join(dir, "pet.yaml")))
+
+	event, err := rolodex.UpdateFiles(context.Background(), RolodexFileChange{Path: filepath.Join(dir, "pet.yaml"), Deleted: true})
+	assert.Error(t, err)
+	assert.Contains(t, event.ImpactedComponents, filepath.Join(dir, "pet.yaml"))
+	assert.Contains(t, event.ImpactedComponents, filepath.Join(dir, "root.yaml")+"#/paths/~1pets")
+	assert.Len(t, rolodex.GetIndexes(), 1)
+}
+
+func TestRolodex_UpdateFiles_Errors(t *testing.T) {
+	var nilRolodex *Rolodex
+	_, err := nilRolodex.UpdateFiles(context.Background())
+	assert.Error(t, err)
+
+	cfg := CreateOpenAPIIndexConfig()
+	_, err = NewRolodex(cfg).UpdateFiles(context.Background())
+	assert.ErrorContains(t, err, "has not been indexed")
+
+	rolodex, dir := buildWatchRolodex(t)
+	_, err = rolodex.UpdateFiles(context.Background(), RolodexFileChange{Path: "/elsewhere/spec.yaml"})
+	assert.ErrorContains(t, err, "no local file system holds it")
+	_, err = rolodex.UpdateFiles(context.Background(), RolodexFileChange{Path: filepath.Join(dir, "root.yaml"), Deleted: true})
+	assert.ErrorContains(t, err, "unable to delete the root document")
+	_, err = rolodex.UpdateFiles(context.Background(), RolodexFileChange{Path: filepath.Join(dir, "root.yaml"), Content: []byte("a: [")})
+	assert.ErrorContains(t, err, "unable to parse the root document")
+
+	event, err := rolodex.UpdateFiles(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, event.ChangedFiles)
+
+	rolodex.Resolve()
+	_, err = rolodex.UpdateFiles(context.Background(), RolodexFileChange{Path: filepath.Join(dir, "pet.yaml")})
+	assert.ErrorContains(t, err, "has been resolved")
+}
+
+func TestRolodex_Watch(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := rolodex.Watch(ctx, &RolodexWatchConfig{Interval: 10 * time.Millisecond})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(watchPetSpec+"  name:\n    type: string\n"), 0o644))
+	select {
+	case event := <-events:
+		assert.Equal(t, []string{filepath.Join(dir, "pet.yaml")}, event.ChangedFiles)
+		assert.Equal(t, []string{filepath.Join(dir, "pet.yaml"), filepath.Join(dir, "root.yaml")}, event.ReindexedFiles)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change event")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+// unreadableFS stats files normally, but fails to open them while unreadable is set, like a half-written file.
+type unreadableFS struct {
+	dir        fs.StatFS
+	unreadable atomic.Bool
+}
+
+func (u *unreadableFS) Open(name string) (fs.File, error) {
+	if u.unreadable.Load() {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("file is being written")}
+	}
+	return u.dir.Open(name)
+}
+
+func (u *unreadableFS) Stat(name string) (fs.FileInfo, error) {
+	return u.dir.Stat(name)
+}
+
+func TestRolodex_Watch_RetriesUnreadableFiles(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dirFS := &unreadableFS{dir: os.DirFS(dir).(fs.StatFS)}
+	dirFS.unreadable.Store(true)
+	events, err := rolodex.Watch(ctx, &RolodexWatchConfig{Interval: 10 * time.Millisecond, FS: dirFS})
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(watchPetSpec+"  name:\n    type: string\n"), 0o644))
+	time.Sleep(50 * time.Millisecond)
+	dirFS.unreadable.Store(false)
+	select {
+	case event := <-events:
+		assert.Equal(t, []string{filepath.Join(dir, "pet.yaml")}, event.ChangedFiles)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change was dropped after a failed read")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestRolodex_Watch_NoBaseDirectory(t *testing.T) {
+	_, err := NewRolodex(CreateOpenAPIIndexConfig()).Watch(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestRolodex_LocalFSForFile(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "nested")
+	rolodex := NewRolodex(CreateOpenAPIIndexConfig())
+	outer, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir})
+	assert.NoError(t, err)
+	inner, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: nested})
+	assert.NoError(t, err)
+	rolodex.AddLocalFS(dir, outer)
+	rolodex.AddLocalFS(nested, inner)
+
+	// map iteration order is random, the most specific base directory always wins.
+	for range 20 {
+		assert.Same(t, inner, rolodex.localFSForFile(filepath.Join(nested, "pet.yaml")))
+	}
+	assert.Same(t, outer, rolodex.localFSForFile(filepath.Join(dir, "..shared.yaml")))
+	assert.Nil(t, rolodex.localFSForFile(filepath.Join(filepath.Dir(dir), "elsewhere.yaml")))
+
+	// a file system already holding the file wins.
+	held := filepath.Join(nested, "held.yaml")
+	outer.Files.Store(held, &LocalFile{fullPath: held})
+	assert.Same(t, outer, rolodex.localFSForFile(held))
+}
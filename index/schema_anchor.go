@@ -0,0 +1,117 @@
+// Copyright 2022-2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// SchemaAnchorEntry represents a schema registered by a JSON Schema 2020-12 $anchor or $dynamicAnchor.
+// Anchors are plain-name fragments scoped to the schema resource (the nearest $id, or the document) that
+// declares them, so the same name can legally appear in different resources.
+type SchemaAnchorEntry struct {
+	Name           string     // The anchor name as declared (without the leading '#')
+	ResourceUri    string     // URI of the schema resource that owns this anchor
+	Dynamic        bool       // true if declared with $dynamicAnchor, false for a plain $anchor
+	SchemaNode     *yaml.Node // The YAML node containing the schema that declares the anchor
+	Index          *SpecIndex // Reference to the SpecIndex containing this schema
+	DefinitionPath string     // JSON pointer path to this schema (e.g., #/components/schemas/Tree)
+	Line           int        // Line number where the anchor was declared (for error reporting)
+	Column         int        // Column number where the anchor was declared (for error reporting)
+}
+
+// GetKey returns the registry key for this entry, which is the owning resource URI joined
+// with the anchor name as a fragment (e.g. https://example.com/tree#node).
+func (e *SchemaAnchorEntry) GetKey() string {
+	return schemaAnchorKey(e.ResourceUri, e.Name)
+}
+
+func schemaAnchorKey(resourceUri, name string) string {
+	return resourceUri + "#" + name
+}
+
+// ValidateSchemaAnchor checks if an anchor name is valid per JSON Schema 2020-12.
+// Anchors must start with a letter or underscore, followed by letters, digits, '-', '_', '.' or ':'.
+func ValidateSchemaAnchor(name string) error {
+	if name == "" {
+		return fmt.Errorf("anchor cannot be empty")
+	}
+	for i, c := range name {
+		letter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+		if i == 0 {
+			if !letter {
+				return fmt.Errorf("anchor must start with a letter or underscore: %s", name)
+			}
+			continue
+		}
+		if !letter && (c < '0' || c > '9') && c != '-' && c != '.' && c != ':' {
+			return fmt.Errorf("anchor contains invalid character '%c': %s", c, name)
+		}
+	}
+	return nil
+}
+
+// isAnchorFragment reports whether a fragment is a plain-name anchor ("#node") rather than
+// a JSON pointer ("#/components/schemas/Node") or an empty fragment.
+func isAnchorFragment(fragment string) bool {
+	name := strings.TrimPrefix(fragment, "#")
+	return name != "" && !strings.HasPrefix(name, "/")
+}
+
+// registerSchemaAnchorToRegistry is the common anchor registration logic for both SpecIndex and Rolodex.
+// Duplicates follow the same first-wins policy as $id registration.
+func registerSchemaAnchorToRegistry(
+	registry map[string]*SchemaAnchorEntry,
+	entry *SchemaAnchorEntry,
+	logger *slog.Logger,
+	registryName string,
+) error {
+	if entry == nil {
+		return fmt.Errorf("cannot register nil SchemaAnchorEntry")
+	}
+	if err := ValidateSchemaAnchor(entry.Name); err != nil {
+		if logger != nil {
+			logger.Warn("invalid anchor value, skipping registration",
+				"registry", registryName,
+				"anchor", entry.Name,
+				"error", err.Error(),
+				"line", entry.Line,
+				"column", entry.Column)
+		}
+		return err
+	}
+
+	key := entry.GetKey()
+	if existing, ok := registry[key]; ok {
+		// a $dynamicAnchor also behaves as a plain anchor, so when both keywords declare the same
+		// name in the same resource, the dynamic declaration is the one that matters.
+		if entry.Dynamic && !existing.Dynamic && existing.SchemaNode == entry.SchemaNode {
+			registry[key] = entry
+			return nil
+		}
+		if logger != nil && existing.SchemaNode != entry.SchemaNode {
+			logger.Warn("duplicate anchor detected, keeping first registration",
+				"registry", registryName,
+				"anchor", key,
+				"first_line", existing.Line,
+				"duplicate_line", entry.Line)
+		}
+		return nil
+	}
+	registry[key] = entry
+	return nil
+}
+
+// copySchemaAnchorRegistry creates a defensive copy of a schema anchor registry.
+func copySchemaAnchorRegistry(registry map[string]*SchemaAnchorEntry) map[string]*SchemaAnchorEntry {
+	result := make(map[string]*SchemaAnchorEntry, len(registry))
+	for k, v := range registry {
+		result[k] = v
+	}
+	return result
+}
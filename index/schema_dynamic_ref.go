@@ -0,0 +1,151 @@
+// Copyright 2022-2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// ResolveDynamicRef resolves a $dynamicRef value per JSON Schema 2020-12.
+//
+// The reference is first resolved like a $ref against baseUri (the current $id scope, or the document).
+// If that initial target declares a matching $dynamicAnchor, the dynamic scope is searched from the
+// outermost schema resource inwards and the first resource declaring the same $dynamicAnchor wins.
+// Otherwise (a JSON pointer fragment, or a plain $anchor) the initial target is used as-is.
+//
+// dynamicScope lists the schema resource URIs entered to reach the $dynamicRef, outermost first. It is
+// typically built from a SchemaIdScope via DynamicScope(). Returns nil if the reference cannot be resolved.
+func (index *SpecIndex) ResolveDynamicRef(ref, baseUri string, dynamicScope []string) *Reference {
+	if ref == "" {
+		return nil
+	}
+	if baseUri == "" {
+		baseUri = index.specAbsolutePath
+	}
+
+	resource, fragment := SplitRefFragment(resolveRefWithSchemaBase(ref, baseUri))
+	if resource == "" {
+		resource = baseUri
+	}
+
+	if !isAnchorFragment(fragment) {
+		return index.resolveDynamicRefByPointer(ref, resource, fragment)
+	}
+
+	name := strings.TrimPrefix(fragment, "#")
+	entry := index.lookupSchemaAnchor(resource, name)
+	if entry == nil {
+		return nil
+	}
+
+	// only a $dynamicAnchor 'bookends' the dynamic behavior, a plain $anchor is a static target.
+	if entry.Dynamic {
+		for _, scoped := range dynamicScope {
+			if candidate := index.lookupSchemaAnchor(scoped, name); candidate != nil && candidate.Dynamic {
+				entry = candidate
+				break
+			}
+		}
+	}
+	resolved := buildSchemaAnchorResolvedReference(index, entry, ref)
+	resolved.IsDynamic = true
+	return resolved
+}
+
+// lookupSchemaAnchor checks the local anchor registry first, then the rolodex global registry.
+func (index *SpecIndex) lookupSchemaAnchor(resourceUri, name string) *SchemaAnchorEntry {
+	if entry := index.GetSchemaAnchor(resourceUri, name); entry != nil {
+		return entry
+	}
+	if index.rolodex != nil {
+		return index.rolodex.LookupSchemaAnchor(resourceUri, name)
+	}
+	return nil
+}
+
+// resolveDynamicRefByPointer handles a $dynamicRef without an anchor fragment, which behaves exactly like $ref.
+func (index *SpecIndex) resolveDynamicRefByPointer(ref, resource, fragment string) *Reference {
+	if found := index.ResolveRefViaSchemaId(resource + fragment); found != nil {
+		found.RawRef = ref
+		found.IsDynamic = true
+		return found
+	}
+
+	target := index.findIndexForResource(resource)
+	if target == nil {
+		return nil
+	}
+	node := navigateToFragment(target.root, fragment)
+	if node == nil {
+		return nil
+	}
+
+	definition := fragment
+	if definition == "" {
+		definition = "#"
+	}
+	_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(definition)
+	return &Reference{
+		FullDefinition: target.specAbsolutePath + definition,
+		Definition:     definition,
+		Name:           definition[strings.LastIndexByte(definition, '/')+1:],
+		RawRef:         ref,
+		Node:           node,
+		Path:           path,
+		IsRemote:       target != index,
+		RemoteLocation: target.specAbsolutePath,
+		Index:          target,
+		IsDynamic:      true,
+	}
+}
+
+// findIndexForResource locates the index whose document is the given resource, checking this index first
+// and then every index known to the rolodex.
+func (index *SpecIndex) findIndexForResource(resource string) *SpecIndex {
+	if resource == "" || resource == index.specAbsolutePath {
+		return index
+	}
+	if index.rolodex == nil {
+		return nil
+	}
+	if root := index.rolodex.GetRootIndex(); root != nil && root.specAbsolutePath == resource {
+		return root
+	}
+	for _, idx := range index.rolodex.GetIndexes() {
+		if idx != nil && idx.specAbsolutePath == resource {
+			return idx
+		}
+	}
+	return nil
+}
+
+func buildSchemaAnchorResolvedReference(index *SpecIndex, entry *SchemaAnchorEntry, originalRef string) *Reference {
+	definition := entry.DefinitionPath
+	fullDefinition := definition
+	remoteLocation := ""
+	schemaIdBase := ""
+	if entry.Index != nil {
+		remoteLocation = entry.Index.GetSpecAbsolutePath()
+		fullDefinition = remoteLocation + definition
+		if entry.ResourceUri != remoteLocation {
+			schemaIdBase = entry.ResourceUri
+		}
+	}
+	_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(definition)
+
+	return &Reference{
+		FullDefinition: fullDefinition,
+		Definition:     definition,
+		Name:           definition[strings.LastIndexByte(definition, '/')+1:],
+		RawRef:         originalRef,
+		SchemaIdBase:   schemaIdBase,
+		Node:           entry.SchemaNode,
+		Path:           path,
+		IsRemote:       entry.Index != index,
+		RemoteLocation: remoteLocation,
+		Index:          entry.Index,
+	}
+}
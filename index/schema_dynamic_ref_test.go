@@ -0,0 +1,309 @@
+// Copyright 2022-2026 Princess Beef Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+const dynamicRefGenericListSpec = `openapi: "3.1.0"
+info:
+  title: Generic containers
+  version: 1.0.0
+components:
+  schemas:
+    List:
+      $id: https://example.com/list
+      $defs:
+        itemType:
+          $dynamicAnchor: itemType
+          type: "null"
+      type: array
+      items:
+        $dynamicRef: "#itemType"
+    PetList:
+      $id: https://example.com/petlist
+      $ref: https://example.com/list
+      $defs:
+        pet:
+          $dynamicAnchor: itemType
+          $ref: https://example.com/pet
+    Pet:
+      $id: https://example.com/pet
+      $anchor: pet
+      type: object
+      properties:
+        name:
+          type: string
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: "#node"
+`
+
+func buildDynamicRefTestIndex(t *testing.T, spec string) *SpecIndex {
+	var rootNode yaml.Node
+	err := yaml.Unmarshal([]byte(spec), &rootNode)
+	assert.NoError(t, err)
+
+	config := CreateClosedAPIIndexConfig()
+	config.SpecAbsolutePath = "/specs/openapi.yaml"
+	return NewSpecIndexWithConfig(&rootNode, config)
+}
+
+func TestValidateSchemaAnchor(t *testing.T) {
+	assert.NoError(t, ValidateSchemaAnchor("node"))
+	assert.NoError(t, ValidateSchemaAnchor("_tree-node.v1:a"))
+	assert.Error(t, ValidateSchemaAnchor(""))
+	assert.Error(t, ValidateSchemaAnchor("1node"))
+	assert.Error(t, ValidateSchemaAnchor("no/de"))
+}
+
+func TestSchemaAnchor_Registration(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	anchors := idx.GetAllSchemaAnchors()
+	assert.Len(t, anchors, 4)
+
+	list := idx.GetSchemaAnchor("https://example.com/list", "itemType")
+	assert.NotNil(t, list)
+	assert.True(t, list.Dynamic)
+	assert.Equal(t, "#/components/schemas/List/$defs/itemType", list.DefinitionPath)
+	assert.Equal(t, "https://example.com/list#itemType", list.GetKey())
+
+	pet := idx.GetSchemaAnchor("https://example.com/pet", "pet")
+	assert.NotNil(t, pet)
+	assert.False(t, pet.Dynamic)
+
+	// anchors outside any $id belong to the document resource.
+	tree := idx.GetSchemaAnchor("/specs/openapi.yaml", "node")
+	assert.NotNil(t, tree)
+	assert.Equal(t, "#/components/schemas/Tree", tree.DefinitionPath)
+}
+
+func TestSchemaAnchor_InvalidAnchorIsAnIndexingError(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, `openapi: "3.1.0"
+components:
+  schemas:
+    Bad:
+      $anchor: "9lives"
+      type: string
+`)
+	assert.Empty(t, idx.GetAllSchemaAnchors())
+	assert.Len(t, idx.GetReferenceIndexErrors(), 1)
+	assert.Contains(t, idx.GetReferenceIndexErrors()[0].Error(), "invalid $anchor value '9lives'")
+}
+
+func TestGetAllDynamicReferences(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	dynamicRefs := idx.GetAllDynamicReferences()
+	assert.Len(t, dynamicRefs, 2)
+
+	assert.True(t, dynamicRefs[0].IsDynamic)
+	assert.Equal(t, "#itemType", dynamicRefs[0].RawRef)
+	assert.Equal(t, "itemType", dynamicRefs[0].Name)
+	assert.Equal(t, "https://example.com/list#itemType", dynamicRefs[0].FullDefinition)
+	assert.Equal(t, "https://example.com/list", dynamicRefs[0].SchemaIdBase)
+
+	assert.Equal(t, "/specs/openapi.yaml#node", dynamicRefs[1].FullDefinition)
+	assert.Empty(t, dynamicRefs[1].SchemaIdBase)
+
+	// dynamic refs never leak into the static reference maps.
+	for _, ref := range idx.GetRawReferencesSequenced() {
+		assert.False(t, ref.IsDynamic)
+	}
+}
+
+func TestGetAllDynamicReferences_EmptyIsAnIndexingError(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, `openapi: "3.1.0"
+components:
+  schemas:
+    Empty:
+      $dynamicRef: ""
+`)
+	assert.Empty(t, idx.GetAllDynamicReferences())
+	assert.Len(t, idx.GetReferenceIndexErrors(), 1)
+}
+
+func TestResolveDynamicRef_StaticWithoutDynamicScope(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	resolved := idx.ResolveDynamicRef("#itemType", "https://example.com/list", nil)
+	assert.NotNil(t, resolved)
+	assert.True(t, resolved.IsDynamic)
+	assert.Equal(t, "/specs/openapi.yaml#/components/schemas/List/$defs/itemType", resolved.FullDefinition)
+	assert.Equal(t, "https://example.com/list", resolved.SchemaIdBase)
+}
+
+func TestResolveDynamicRef_OutermostDynamicAnchorWins(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	scope := []string{"/specs/openapi.yaml", "https://example.com/petlist", "https://example.com/list"}
+	resolved := idx.ResolveDynamicRef("#itemType", "https://example.com/list", scope)
+	assert.NotNil(t, resolved)
+	assert.Equal(t, "#/components/schemas/PetList/$defs/pet", resolved.Definition)
+	assert.Equal(t, "pet", resolved.Name)
+}
+
+func TestResolveDynamicRef_PlainAnchorIsNotDynamic(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, `openapi: "3.1.0"
+components:
+  schemas:
+    Inner:
+      $id: https://example.com/inner
+      $anchor: thing
+      type: string
+    Outer:
+      $id: https://example.com/outer
+      $dynamicAnchor: thing
+      type: integer
+`)
+	scope := []string{"https://example.com/outer", "https://example.com/inner"}
+	resolved := idx.ResolveDynamicRef("#thing", "https://example.com/inner", scope)
+	assert.NotNil(t, resolved)
+	assert.Equal(t, "#/components/schemas/Inner", resolved.Definition)
+}
+
+func TestResolveDynamicRef_PointerBehavesLikeRef(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	resolved := idx.ResolveDynamicRef("#/components/schemas/Pet", "", nil)
+	assert.NotNil(t, resolved)
+	assert.True(t, resolved.IsDynamic)
+	assert.Equal(t, "/specs/openapi.yaml#/components/schemas/Pet", resolved.FullDefinition)
+	assert.Equal(t, "Pet", resolved.Name)
+
+	viaId := idx.ResolveDynamicRef("https://example.com/pet#/properties/name", "", nil)
+	assert.NotNil(t, viaId)
+	assert.Equal(t, "#/components/schemas/Pet/properties/name", viaId.Definition)
+
+	assert.Nil(t, idx.ResolveDynamicRef("#/components/schemas/Missing", "", nil))
+	assert.Nil(t, idx.ResolveDynamicRef("#missing", "", nil))
+	assert.Nil(t, idx.ResolveDynamicRef("", "", nil))
+	assert.Nil(t, idx.ResolveDynamicRef("other.yaml#/nope", "", nil))
+}
+
+func TestResolveRefViaSchemaId_AnchorFragment(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	resolved := idx.ResolveRefViaSchemaId("https://example.com/pet#pet")
+	assert.NotNil(t, resolved)
+	assert.False(t, resolved.IsDynamic)
+	assert.Equal(t, "#/components/schemas/Pet", resolved.Definition)
+
+	assert.Nil(t, idx.ResolveRefViaSchemaId("https://example.com/pet#nope"))
+}
+
+func TestResolveDynamicRef_ViaRolodexGlobalRegistry(t *testing.T) {
+	config := CreateClosedAPIIndexConfig()
+	rolodex := NewRolodex(config)
+
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+	rolodex.AddIndex(idx)
+
+	var other yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: "3.1.0"`), &other)
+	otherConfig := CreateClosedAPIIndexConfig()
+	otherConfig.SpecAbsolutePath = "/specs/other.yaml"
+	otherIdx := NewSpecIndexWithConfig(&other, otherConfig)
+	otherIdx.rolodex = rolodex
+
+	assert.NotNil(t, rolodex.LookupSchemaAnchor("https://example.com/list", "itemType"))
+	assert.Nil(t, rolodex.LookupSchemaAnchor("https://example.com/list", "nope"))
+
+	resolved := otherIdx.ResolveDynamicRef("https://example.com/list#itemType", "", nil)
+	assert.NotNil(t, resolved)
+	assert.True(t, resolved.IsRemote)
+	assert.Equal(t, "/specs/openapi.yaml", resolved.RemoteLocation)
+
+	byPointer := otherIdx.ResolveDynamicRef("/specs/openapi.yaml#/components/schemas/Tree", "", nil)
+	assert.NotNil(t, byPointer)
+	assert.Equal(t, idx, byPointer.Index)
+}
+
+func TestSchemaIdScope_DynamicScope(t *testing.T) {
+	scope := NewSchemaIdScope("/specs/openapi.yaml")
+	scope.PushId("https://example.com/petlist")
+	scope.PushId("https://example.com/petlist")
+	scope.PushId("https://example.com/list")
+
+	assert.Equal(t, []string{
+		"/specs/openapi.yaml",
+		"https://example.com/petlist",
+		"https://example.com/list",
+	}, scope.Copy().DynamicScope())
+
+	var nilScope *SchemaIdScope
+	assert.Nil(t, nilScope.DynamicScope())
+}
+
+func TestResolver_DynamicRefCircularReferences(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, dynamicRefGenericListSpec)
+
+	resolver := NewResolver(idx)
+	assert.Empty(t, resolver.CheckForCircularReferences())
+
+	circular := resolver.GetCircularReferences()
+	assert.Len(t, circular, 1)
+	assert.Equal(t, "Tree -> Tree", circular[0].GenerateJourneyPath())
+	assert.True(t, circular[0].IsArrayResult)
+	assert.False(t, circular[0].IsInfiniteLoop)
+	assert.True(t, circular[0].LoopPoint.IsDynamic)
+}
+
+func TestResolver_DynamicRefFollowsDynamicScope(t *testing.T) {
+	// PetList re-binds itemType to a schema that loops back to PetList, which is only visible
+	// when the $dynamicRef in List is resolved through the PetList dynamic scope.
+	idx := buildDynamicRefTestIndex(t, `openapi: "3.1.0"
+components:
+  schemas:
+    List:
+      $id: https://example.com/list
+      $defs:
+        itemType:
+          $dynamicAnchor: itemType
+          type: "null"
+      type: object
+      required: [item]
+      properties:
+        item:
+          $dynamicRef: "#itemType"
+    PetList:
+      $id: https://example.com/petlist
+      $ref: https://example.com/list
+      $defs:
+        pet:
+          $dynamicAnchor: itemType
+          $ref: "https://example.com/petlist"
+`)
+	resolver := NewResolver(idx)
+	_ = resolver.CheckForCircularReferences()
+
+	circular := resolver.GetCircularReferences()
+	assert.Len(t, circular, 1)
+	assert.Equal(t, "#/components/schemas/PetList", circular[0].LoopPoint.Definition)
+}
+
+func TestResolver_UnresolvableDynamicRefIsNotAnError(t *testing.T) {
+	idx := buildDynamicRefTestIndex(t, `openapi: "3.1.0"
+components:
+  schemas:
+    Holder:
+      type: object
+      properties:
+        thing:
+          $dynamicRef: "#nowhere"
+`)
+	resolver := NewResolver(idx)
+	assert.Empty(t, resolver.CheckForCircularReferences())
+	assert.Empty(t, resolver.GetCircularReferences())
+}
@@ -212,6 +212,15 @@ func (index *SpecIndex) ResolveRefViaSchemaId(ref string) *Reference {
 		return nil
 	}
 
+	// plain-name fragments address a $anchor (or $dynamicAnchor) inside the resource, not a JSON pointer.
+	if isAnchorFragment(fragment) {
+		anchor := index.lookupSchemaAnchor(entry.GetKey(), strings.TrimPrefix(fragment, "#"))
+		if anchor == nil {
+			return nil
+		}
+		return buildSchemaAnchorResolvedReference(index, anchor, ref)
+	}
+
 	return buildSchemaIdResolvedReference(index, entry, ref, baseUri, fragment)
 }
 
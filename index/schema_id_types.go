@@ -34,6 +34,7 @@ func (e *SchemaIdEntry) GetKey() string {
 type SchemaIdScope struct {
 	BaseUri string   // Current base URI for relative $id and $ref resolution
 	Chain   []string // Stack of $id URIs from root to current location
+	root    string   // Base URI the scope was created with (the document resource)
 }
 
 // NewSchemaIdScope initializes scope tracking for base URI resolution during schema tree traversal.
@@ -41,6 +42,7 @@ func NewSchemaIdScope(baseUri string) *SchemaIdScope {
 	return &SchemaIdScope{
 		BaseUri: baseUri,
 		Chain:   make([]string, 0),
+		root:    baseUri,
 	}
 }
 
@@ -69,5 +71,26 @@ func (s *SchemaIdScope) Copy() *SchemaIdScope {
 	return &SchemaIdScope{
 		BaseUri: s.BaseUri,
 		Chain:   chainCopy,
+		root:    s.root,
 	}
 }
+
+// DynamicScope returns the schema resources entered to reach the current location, outermost first.
+// The document resource the scope was created with leads the list, followed by every pushed $id.
+// This is the dynamic scope JSON Schema 2020-12 searches when resolving a $dynamicRef.
+func (s *SchemaIdScope) DynamicScope() []string {
+	if s == nil {
+		return nil
+	}
+	resources := make([]string, 0, len(s.Chain)+1)
+	if s.root != "" {
+		resources = append(resources, s.root)
+	}
+	for _, id := range s.Chain {
+		if len(resources) > 0 && resources[len(resources)-1] == id {
+			continue
+		}
+		resources = append(resources, id)
+	}
+	return resources
+}
@@ -404,3 +404,35 @@ func (index *SpecIndex) GetAllSchemaIds() map[string]*SchemaIdEntry {
 	defer index.schemaIdRegistryLock.RUnlock()
 	return copySchemaIdRegistry(index.schemaIdRegistry)
 }
+
+// RegisterSchemaAnchor registers a JSON Schema $anchor or $dynamicAnchor entry in this index's local registry.
+func (index *SpecIndex) RegisterSchemaAnchor(entry *SchemaAnchorEntry) error {
+	index.schemaAnchorRegistryLock.Lock()
+	defer index.schemaAnchorRegistryLock.Unlock()
+	if index.schemaAnchorRegistry == nil {
+		index.schemaAnchorRegistry = make(map[string]*SchemaAnchorEntry)
+	}
+	return registerSchemaAnchorToRegistry(index.schemaAnchorRegistry, entry, index.logger, "local index")
+}
+
+// GetSchemaAnchor looks up an anchor declared in the schema resource identified by resourceUri.
+func (index *SpecIndex) GetSchemaAnchor(resourceUri, name string) *SchemaAnchorEntry {
+	index.schemaAnchorRegistryLock.RLock()
+	defer index.schemaAnchorRegistryLock.RUnlock()
+	if index.schemaAnchorRegistry == nil {
+		return nil
+	}
+	return index.schemaAnchorRegistry[schemaAnchorKey(resourceUri, name)]
+}
+
+// GetAllSchemaAnchors returns a copy of all $anchor and $dynamicAnchor entries registered in this index.
+func (index *SpecIndex) GetAllSchemaAnchors() map[string]*SchemaAnchorEntry {
+	index.schemaAnchorRegistryLock.RLock()
+	defer index.schemaAnchorRegistryLock.RUnlock()
+	return copySchemaAnchorRegistry(index.schemaAnchorRegistry)
+}
+
+// GetAllDynamicReferences returns every $dynamicRef found in the spec, in the order they were discovered.
+func (index *SpecIndex) GetAllDynamicReferences() []*Reference {
+	return index.dynamicRefs
+}
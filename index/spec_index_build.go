@@ -38,6 +38,11 @@ func NewSpecIndexWithConfigAndContext(ctx context.Context, rootNode *yaml.Node,
 		return index
 	}
 	index.root = rootNode
+	if err := index.rolodex.resourceBudget().chargeDocument(config.SpecAbsolutePath, rootNode); err != nil {
+		index.logger.Warn("[index] resource budget exceeded, document will not be indexed",
+			"location", config.SpecAbsolutePath, "error", err.Error())
+		return index
+	}
 	return createNewIndex(ctx, rootNode, index, config.AvoidBuildIndex)
 }
 
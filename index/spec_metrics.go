@@ -0,0 +1,527 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// SpecMetrics is a report on the size, complexity and documentation quality of a specification. It serializes to
+// JSON, so reports from many specifications can be collected into a dashboard.
+type SpecMetrics struct {
+	// Counts are the raw counts collected by the index.
+	Counts *SpecCounts `json:"counts"`
+
+	// SchemaDepth is the nesting depth of the component schemas, following inline schemas (properties, items,
+	// allOf and so on) but not references. A schema without inline schemas has a depth of 1.
+	SchemaDepth *MetricDistribution `json:"schemaDepth"`
+
+	// ParametersPerOperation counts the parameters of each operation, including path level parameters.
+	ParametersPerOperation *MetricDistribution `json:"parametersPerOperation"`
+
+	// FanIn and FanOut summarise the fan-in and fan-out of the components.
+	FanIn  *MetricDistribution `json:"fanIn"`
+	FanOut *MetricDistribution `json:"fanOut"`
+
+	// Components are the fan-in and fan-out of each component, sorted by Id. Every component schema is included,
+	// other components are included when they reference, or are referenced by, something.
+	Components []*ComponentMetrics `json:"components"`
+
+	// InlineSchemas and ReferencedSchemas are the number of schemas outside of the components that are declared
+	// inline, and that are a $ref. InlineSchemaRatio is the share of inline schemas, between 0 and 1.
+	InlineSchemas     int     `json:"inlineSchemas"`
+	ReferencedSchemas int     `json:"referencedSchemas"`
+	InlineSchemaRatio float64 `json:"inlineSchemaRatio"`
+
+	// Descriptions is how much of the specification is described.
+	Descriptions *DescriptionCoverage `json:"descriptions"`
+
+	// Examples is how much of the specification has examples.
+	Examples *ExampleCoverage `json:"examples"`
+
+	// CircularReferences counts the circular references.
+	CircularReferences *CircularReferenceMetrics `json:"circularReferences"`
+}
+
+// SpecCounts are the raw counts collected by the index.
+type SpecCounts struct {
+	Paths               int `json:"paths"`
+	Operations          int `json:"operations"`
+	ComponentSchemas    int `json:"componentSchemas"`
+	ComponentParameters int `json:"componentParameters"`
+	OperationParameters int `json:"operationParameters"`
+	Tags                int `json:"tags"`
+	Callbacks           int `json:"callbacks"`
+	Links               int `json:"links"`
+	References          int `json:"references"`
+	UniqueReferences    int `json:"uniqueReferences"`
+	Descriptions        int `json:"descriptions"`
+	Summaries           int `json:"summaries"`
+	Enums               int `json:"enums"`
+}
+
+// MetricDistribution summarises a value measured over a number of items.
+type MetricDistribution struct {
+	Count   int     `json:"count"`
+	Max     int     `json:"max"`
+	Average float64 `json:"average"`
+}
+
+// ComponentMetrics are the metrics of a single component.
+type ComponentMetrics struct {
+	// Id is the full definition of the component.
+	Id string `json:"id"`
+
+	// Name is the name of the component.
+	Name string `json:"name"`
+
+	// FanIn is the number of components, operations and files referencing the component.
+	FanIn int `json:"fanIn"`
+
+	// FanOut is the number of components and files the component references.
+	FanOut int `json:"fanOut"`
+
+	// Depth is the nesting depth of a component schema, zero for other components.
+	Depth int `json:"depth,omitempty"`
+}
+
+// CoverageMetric is the share of items that have something, for example a description.
+type CoverageMetric struct {
+	Total   int     `json:"total"`
+	Covered int     `json:"covered"`
+	Ratio   float64 `json:"ratio"`
+
+	// Missing identifies the items that are not covered, sorted.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// DescriptionCoverage is how much of the specification is described. Operations with a summary but no
+// description count as described.
+type DescriptionCoverage struct {
+	Operations *CoverageMetric `json:"operations"`
+	Parameters *CoverageMetric `json:"parameters"`
+
+	// Properties are the properties of the component schemas, at every depth. Properties that are only a $ref are
+	// described by the referenced schema and are not counted.
+	Properties *CoverageMetric `json:"properties"`
+}
+
+// ExampleCoverage is how much of the specification has examples. An example on the schema of a media type or
+// parameter counts.
+type ExampleCoverage struct {
+	MediaTypes *CoverageMetric `json:"mediaTypes"`
+	Parameters *CoverageMetric `json:"parameters"`
+	Schemas    *CoverageMetric `json:"schemas"`
+}
+
+// CircularReferenceMetrics counts the circular references found by the resolver, and the schema cycles found by
+// AnalyzeSchemaCycles.
+type CircularReferenceMetrics struct {
+	Total                int `json:"total"`
+	Infinite             int `json:"infinite"`
+	IgnoredPolymorphic   int `json:"ignoredPolymorphic"`
+	IgnoredArray         int `json:"ignoredArray"`
+	SchemaCycles         int `json:"schemaCycles"`
+	InfiniteSchemaCycles int `json:"infiniteSchemaCycles"`
+}
+
+// BuildSpecMetrics builds a metrics report for the document held by this index.
+func (index *SpecIndex) BuildSpecMetrics() *SpecMetrics {
+	indexes := []*SpecIndex{index}
+	g := buildReferenceGraph(indexes, nil, nil)
+	return buildSpecMetrics(index, indexes, g, analyzeSchemaCycles(g, indexes))
+}
+
+// BuildSpecMetrics builds a metrics report for the root document of the rolodex. Fan-in, fan-out, circular
+// references and schema cycles take every file in the rolodex into account. Returns nil if the rolodex has not
+// been indexed.
+func (r *Rolodex) BuildSpecMetrics() *SpecMetrics {
+	root := r.GetRootIndex()
+	if root == nil {
+		return nil
+	}
+	indexes := []*SpecIndex{root}
+	for _, idx := range r.GetIndexes() {
+		if idx != nil && idx != root {
+			indexes = append(indexes, idx)
+		}
+	}
+	g := buildReferenceGraph(indexes, nil, nil)
+	return buildSpecMetrics(root, indexes, g, analyzeSchemaCycles(g, indexes))
+}
+
+func buildSpecMetrics(index *SpecIndex, indexes []*SpecIndex, g *ReferenceGraph,
+	cycles *SchemaCycleAnalysis,
+) *SpecMetrics {
+	m := &SpecMetrics{
+		Counts: &SpecCounts{
+			Paths:               index.GetPathCount(),
+			Operations:          index.GetOperationCount(),
+			ComponentSchemas:    len(index.GetAllComponentSchemas()),
+			ComponentParameters: index.GetComponentParameterCount(),
+			OperationParameters: index.GetOperationsParameterCount(),
+			Tags:                index.GetTotalTagsCount(),
+			Callbacks:           index.GetGlobalCallbacksCount(),
+			Links:               index.GetGlobalLinksCount(),
+			References:          index.GetRawReferenceCount(),
+			UniqueReferences:    len(index.GetAllReferences()),
+			Descriptions:        index.GetAllDescriptionsCount(),
+			Summaries:           index.GetAllSummariesCount(),
+			Enums:               len(index.GetAllEnums()),
+		},
+		InlineSchemas:     len(index.GetAllInlineSchemas()),
+		ReferencedSchemas: len(index.GetAllReferenceSchemas()),
+		Descriptions: &DescriptionCoverage{
+			Operations: &CoverageMetric{},
+			Parameters: &CoverageMetric{},
+			Properties: &CoverageMetric{},
+		},
+		Examples: &ExampleCoverage{
+			MediaTypes: &CoverageMetric{},
+			Parameters: &CoverageMetric{},
+			Schemas:    &CoverageMetric{},
+		},
+	}
+	if total := m.InlineSchemas + m.ReferencedSchemas; total > 0 {
+		m.InlineSchemaRatio = float64(m.InlineSchemas) / float64(total)
+	}
+
+	index.collectComponentMetrics(m, g)
+	index.collectOperationMetrics(m)
+
+	circular := uniqueCircularReferences(indexes, (*SpecIndex).GetCircularReferences)
+	m.CircularReferences = &CircularReferenceMetrics{
+		Total: len(circular),
+		IgnoredPolymorphic: len(uniqueCircularReferences(indexes,
+			(*SpecIndex).GetIgnoredPolymorphicCircularReferences)),
+		IgnoredArray: len(uniqueCircularReferences(indexes, (*SpecIndex).GetIgnoredArrayCircularReferences)),
+		SchemaCycles: len(cycles.Cycles),
+	}
+	for _, c := range circular {
+		if c.IsInfiniteLoop {
+			m.CircularReferences.Infinite++
+		}
+	}
+	m.CircularReferences.InfiniteSchemaCycles = cycles.Infinite
+
+	for _, c := range []*CoverageMetric{
+		m.Descriptions.Operations, m.Descriptions.Parameters, m.Descriptions.Properties,
+		m.Examples.MediaTypes, m.Examples.Parameters, m.Examples.Schemas,
+	} {
+		if c.Total > 0 {
+			c.Ratio = float64(c.Covered) / float64(c.Total)
+		}
+		sort.Strings(c.Missing)
+	}
+	return m
+}
+
+// uniqueCircularReferences collects the circular references of every index, once each by the definition they loop
+// on, as the same loop is found by each index it passes through.
+func uniqueCircularReferences(indexes []*SpecIndex,
+	get func(*SpecIndex) []*CircularReferenceResult,
+) []*CircularReferenceResult {
+	var unique []*CircularReferenceResult
+	seen := make(map[string]bool)
+	for _, idx := range indexes {
+		for _, c := range get(idx) {
+			if c == nil {
+				continue
+			}
+			if c.LoopPoint != nil {
+				if seen[c.LoopPoint.FullDefinition] {
+					continue
+				}
+				seen[c.LoopPoint.FullDefinition] = true
+			}
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+// collectComponentMetrics measures the depth of every component schema, the fan-in and fan-out of every component,
+// and the description and example coverage of the component schemas.
+func (index *SpecIndex) collectComponentMetrics(m *SpecMetrics, g *ReferenceGraph) {
+	components := make(map[string]*ComponentMetrics)
+	var depths []int
+	for def, ref := range index.GetAllComponentSchemas() {
+		if ref == nil {
+			continue
+		}
+		depth := schemaDepth(ref.Node)
+		depths = append(depths, depth)
+		components[ref.FullDefinition] = &ComponentMetrics{Id: ref.FullDefinition, Name: ref.Name, Depth: depth}
+		coverage(m.Examples.Schemas, def, hasExample(ref.Node))
+		collectPropertyDescriptions(m.Descriptions.Properties, def, ref.Node)
+	}
+	m.SchemaDepth = newMetricDistribution(depths)
+
+	fanIn := make(map[string]map[string]bool)
+	fanOut := make(map[string]map[string]bool)
+	for _, e := range g.Edges {
+		if e.From == e.To {
+			continue
+		}
+		if fanIn[e.To] == nil {
+			fanIn[e.To] = make(map[string]bool)
+		}
+		if fanOut[e.From] == nil {
+			fanOut[e.From] = make(map[string]bool)
+		}
+		fanIn[e.To][e.From] = true
+		fanOut[e.From][e.To] = true
+	}
+	for _, n := range g.Nodes {
+		if n.Kind == ReferenceGraphComponent && components[n.Id] == nil {
+			components[n.Id] = &ComponentMetrics{Id: n.Id, Name: n.Label}
+		}
+	}
+	var ins, outs []int
+	for _, id := range sortedKeys(components) {
+		c := components[id]
+		c.FanIn, c.FanOut = len(fanIn[id]), len(fanOut[id])
+		ins, outs = append(ins, c.FanIn), append(outs, c.FanOut)
+		m.Components = append(m.Components, c)
+	}
+	if m.Components == nil {
+		m.Components = []*ComponentMetrics{}
+	}
+	m.FanIn, m.FanOut = newMetricDistribution(ins), newMetricDistribution(outs)
+}
+
+// collectOperationMetrics measures the parameters of every operation, along with the description and example
+// coverage of operations, parameters and media types.
+func (index *SpecIndex) collectOperationMetrics(m *SpecMetrics) {
+	params := index.GetAllParametersFromOperations()
+	seenParams := make(map[*yaml.Node]bool)
+	var perOperation []int
+	paths := index.GetAllPaths()
+	for _, path := range sortedKeys(paths) {
+		for _, method := range sortedKeys(paths[path]) {
+			op := paths[path][method]
+			if op == nil || op.Node == nil {
+				continue
+			}
+			name := strings.ToUpper(method) + " " + path
+			coverage(m.Descriptions.Operations, name, hasDescription(op.Node))
+
+			names := make(map[string]bool)
+			for _, level := range []string{method, "top"} {
+				for paramName, refs := range params[path][level] {
+					for _, ref := range refs {
+						key := paramName + "\x00" + ref.In
+						if names[key] {
+							continue
+						}
+						names[key] = true
+						if ref.Node == nil || seenParams[ref.Node] {
+							continue
+						}
+						seenParams[ref.Node] = true
+						id := ref.Path
+						if id == "" {
+							id = ref.Definition
+						}
+						coverage(m.Descriptions.Parameters, id, hasDescription(ref.Node))
+						coverage(m.Examples.Parameters, id, index.hasExampleOrSchemaExample(ref.Node))
+					}
+				}
+			}
+			perOperation = append(perOperation, len(names))
+
+			for _, media := range index.operationMediaTypes(op.Node, name) {
+				coverage(m.Examples.MediaTypes, media.id, index.hasExampleOrSchemaExample(media.node))
+			}
+		}
+	}
+	m.ParametersPerOperation = newMetricDistribution(perOperation)
+}
+
+type metricsMediaType struct {
+	id   string
+	node *yaml.Node
+}
+
+// operationMediaTypes returns the media types of the request body and responses of an operation.
+func (index *SpecIndex) operationMediaTypes(op *yaml.Node, name string) []metricsMediaType {
+	var found []metricsMediaType
+	addContent := func(owner string, container *yaml.Node) {
+		container = index.localMetricsNode(container)
+		if container == nil {
+			return
+		}
+		_, content := utils.FindKeyNodeTop("content", container.Content)
+		if content == nil || !utils.IsNodeMap(content) {
+			return
+		}
+		for i := 0; i+1 < len(content.Content); i += 2 {
+			found = append(found, metricsMediaType{
+				id:   name + " " + owner + " " + content.Content[i].Value,
+				node: index.localMetricsNode(content.Content[i+1]),
+			})
+		}
+	}
+	if _, body := utils.FindKeyNodeTop("requestBody", op.Content); body != nil {
+		addContent("requestBody", body)
+	}
+	if _, responses := utils.FindKeyNodeTop("responses", op.Content); responses != nil && utils.IsNodeMap(responses) {
+		for i := 0; i+1 < len(responses.Content); i += 2 {
+			addContent(responses.Content[i].Value, responses.Content[i+1])
+		}
+	}
+	return found
+}
+
+// localMetricsNode follows a local $ref to the node it points at. References to other files are not followed.
+func (index *SpecIndex) localMetricsNode(node *yaml.Node) *yaml.Node {
+	for range 10 {
+		isRef, _, value := utils.IsNodeRefValue(node)
+		if !isRef {
+			return node
+		}
+		if !strings.HasPrefix(value, "#/") {
+			return nil
+		}
+		node = nodeAtPointer(index.GetRootNode(), value[1:])
+	}
+	return nil
+}
+
+func (index *SpecIndex) hasExampleOrSchemaExample(node *yaml.Node) bool {
+	if node == nil || !utils.IsNodeMap(node) {
+		return false
+	}
+	if hasExample(node) {
+		return true
+	}
+	_, schema := utils.FindKeyNodeTop("schema", node.Content)
+	return hasExample(index.localMetricsNode(schema))
+}
+
+func hasExample(node *yaml.Node) bool {
+	if node == nil || !utils.IsNodeMap(node) {
+		return false
+	}
+	for _, key := range []string{"example", "examples"} {
+		if k, _ := utils.FindKeyNodeTop(key, node.Content); k != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDescription(node *yaml.Node) bool {
+	if node == nil || !utils.IsNodeMap(node) {
+		return false
+	}
+	for _, key := range []string{"description", "summary"} {
+		if _, v := utils.FindKeyNodeTop(key, node.Content); v != nil && strings.TrimSpace(v.Value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// collectPropertyDescriptions records whether every property of a schema, and of its inline schemas, is described.
+func collectPropertyDescriptions(c *CoverageMetric, pointer string, schema *yaml.Node) {
+	walkInlineSchemas(schema, pointer, func(s *yaml.Node, p string) {
+		_, props := utils.FindKeyNodeTop("properties", s.Content)
+		if props == nil || !utils.IsNodeMap(props) {
+			return
+		}
+		for i := 0; i+1 < len(props.Content); i += 2 {
+			prop := props.Content[i+1]
+			if isRef, _, _ := utils.IsNodeRefValue(prop); isRef || !utils.IsNodeMap(prop) {
+				continue
+			}
+			id := p + "/properties/" + strings.ReplaceAll(props.Content[i].Value, "/", "~1")
+			_, d := utils.FindKeyNodeTop("description", prop.Content)
+			coverage(c, id, d != nil && strings.TrimSpace(d.Value) != "")
+		}
+	})
+}
+
+// schemaDepth returns the nesting depth of a schema, following inline schemas but not references.
+func schemaDepth(schema *yaml.Node) int {
+	if schema == nil || !utils.IsNodeMap(schema) {
+		return 0
+	}
+	deepest := 0
+	forEachInlineSchema(schema, "", func(child *yaml.Node, _ string) {
+		deepest = max(deepest, schemaDepth(child))
+	})
+	return deepest + 1
+}
+
+// walkInlineSchemas calls fn for a schema and every inline schema nested within it.
+func walkInlineSchemas(schema *yaml.Node, pointer string, fn func(*yaml.Node, string)) {
+	if schema == nil || !utils.IsNodeMap(schema) {
+		return
+	}
+	if isRef, _, _ := utils.IsNodeRefValue(schema); isRef {
+		return
+	}
+	fn(schema, pointer)
+	forEachInlineSchema(schema, pointer, func(child *yaml.Node, p string) {
+		walkInlineSchemas(child, p, fn)
+	})
+}
+
+// forEachInlineSchema calls fn for every schema directly nested within a schema.
+func forEachInlineSchema(schema *yaml.Node, pointer string, fn func(*yaml.Node, string)) {
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		key, value := schema.Content[i].Value, schema.Content[i+1]
+		p := pointer + "/" + key
+		switch key {
+		case "properties", "patternProperties", "dependentSchemas", "$defs", "definitions":
+			if !utils.IsNodeMap(value) {
+				continue
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				if utils.IsNodeMap(value.Content[j+1]) {
+					fn(value.Content[j+1], p+"/"+strings.ReplaceAll(value.Content[j].Value, "/", "~1"))
+				}
+			}
+		case "allOf", "oneOf", "anyOf", "prefixItems":
+			for j, item := range value.Content {
+				if utils.IsNodeMap(item) {
+					fn(item, p+"/"+strconv.Itoa(j))
+				}
+			}
+		case "items", "additionalProperties", "not", "if", "then", "else", "contains", "unevaluatedItems",
+			"unevaluatedProperties", "propertyNames", "additionalItems":
+			if utils.IsNodeMap(value) {
+				fn(value, p)
+			}
+		}
+	}
+}
+
+func coverage(c *CoverageMetric, id string, covered bool) {
+	c.Total++
+	if covered {
+		c.Covered++
+	} else {
+		c.Missing = append(c.Missing, id)
+	}
+}
+
+func newMetricDistribution(values []int) *MetricDistribution {
+	d := &MetricDistribution{Count: len(values)}
+	total := 0
+	for _, v := range values {
+		total += v
+		d.Max = max(d.Max, v)
+	}
+	if len(values) > 0 {
+		d.Average = float64(total) / float64(len(values))
+	}
+	return d
+}
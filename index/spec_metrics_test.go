@@ -0,0 +1,194 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+const metricsSpec = `openapi: 3.1.0
+info:
+  title: metrics
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Limit'
+    get:
+      summary: list pets
+      parameters:
+        - name: offset
+          in: query
+          schema:
+            type: integer
+            example: 10
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        '201':
+          description: created
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      description: page size
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          example:
+            name: fido
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      example:
+        name: fido
+      properties:
+        name:
+          type: string
+          description: the name
+        owner:
+          $ref: '#/components/schemas/Owner'
+        tags:
+          type: array
+          items:
+            type: object
+            properties:
+              label:
+                type: string
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+func TestSpecIndex_BuildSpecMetrics(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(metricsSpec), &root))
+	idx := NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+	NewResolver(idx).CheckForCircularReferences()
+
+	m := idx.BuildSpecMetrics()
+	assert.Equal(t, 1, m.Counts.Paths)
+	assert.Equal(t, 2, m.Counts.Operations)
+	assert.Equal(t, 2, m.Counts.ComponentSchemas)
+
+	assert.Equal(t, &MetricDistribution{Count: 2, Max: 4, Average: 3.5}, m.SchemaDepth)
+	assert.Equal(t, &MetricDistribution{Count: 2, Max: 2, Average: 1.5}, m.ParametersPerOperation)
+
+	byName := make(map[string]*ComponentMetrics)
+	for _, c := range m.Components {
+		byName[c.Name] = c
+	}
+	assert.Equal(t, 3, byName["Pet"].FanIn) // Owner, GET /pets and the NewPet request body
+	assert.Equal(t, 1, byName["Pet"].FanOut)
+	assert.Equal(t, 4, byName["Pet"].Depth)
+	assert.Equal(t, 1, byName["Limit"].FanIn)
+	assert.Equal(t, 0, byName["Limit"].FanOut)
+	assert.Equal(t, 3, m.FanIn.Max)
+
+	assert.Equal(t, 2, m.Descriptions.Operations.Total)
+	assert.Equal(t, 1, m.Descriptions.Operations.Covered)
+	assert.Equal(t, []string{"POST /pets"}, m.Descriptions.Operations.Missing)
+	assert.Equal(t, 0.5, m.Descriptions.Operations.Ratio)
+
+	assert.Equal(t, 2, m.Descriptions.Parameters.Total)
+	assert.Equal(t, 1, m.Descriptions.Parameters.Covered)
+
+	assert.Equal(t, []string{
+		"#/components/schemas/Owner/properties/pets",
+		"#/components/schemas/Pet/properties/tags",
+		"#/components/schemas/Pet/properties/tags/items/properties/label",
+	}, m.Descriptions.Properties.Missing)
+	assert.Equal(t, 4, m.Descriptions.Properties.Total)
+
+	assert.Equal(t, 2, m.Examples.MediaTypes.Total)
+	assert.Equal(t, []string{"GET /pets 200 application/json"}, m.Examples.MediaTypes.Missing)
+	assert.Equal(t, 1, m.Examples.Parameters.Covered)
+	assert.Equal(t, []string{"#/components/schemas/Owner"}, m.Examples.Schemas.Missing)
+
+	assert.Equal(t, 1, m.CircularReferences.SchemaCycles)
+	assert.Equal(t, 0, m.CircularReferences.InfiniteSchemaCycles)
+
+	b, err := json.Marshal(m)
+	assert.NoError(t, err)
+	var decoded SpecMetrics
+	assert.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, m.Counts, decoded.Counts)
+}
+
+func TestSpecIndex_BuildSpecMetrics_Burgershop(t *testing.T) {
+	spec, err := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	assert.NoError(t, err)
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal(spec, &root))
+	idx := NewSpecIndexWithConfig(&root, CreateOpenAPIIndexConfig())
+
+	m := idx.BuildSpecMetrics()
+	assert.Equal(t, idx.GetOperationCount(), m.ParametersPerOperation.Count)
+	assert.Equal(t, idx.GetOperationCount(), m.Descriptions.Operations.Total)
+	assert.Equal(t, len(idx.GetAllComponentSchemas()), m.SchemaDepth.Count)
+	assert.Greater(t, m.SchemaDepth.Max, 1)
+	assert.Greater(t, m.FanIn.Max, 1)
+	assert.Greater(t, m.InlineSchemaRatio, 0.0)
+	assert.Less(t, m.InlineSchemaRatio, 1.0)
+}
+
+func TestRolodex_BuildSpecMetrics_CircularReferences(t *testing.T) {
+	root := `openapi: 3.1.0
+components:
+  schemas:
+    Leaf:
+      $ref: 'tree.yaml#/components/schemas/Leaf'
+    Node:
+      $ref: 'tree.yaml#/components/schemas/Node'`
+	tree := `components:
+  schemas:
+    Leaf:
+      type: string
+    Node:
+      type: object
+      required: [next]
+      properties:
+        next:
+          $ref: '#/components/schemas/Node'
+    Orphan:
+      type: object
+      required: [self]
+      properties:
+        self:
+          $ref: '#/components/schemas/Orphan'`
+	rolodex, _ := indexWithBudget(t, nil, root, map[string]string{"tree.yaml": tree})
+	rolodex.Resolve()
+
+	// Node loops in both indexes and is counted once, Orphan only loops in tree.yaml.
+	m := rolodex.BuildSpecMetrics()
+	assert.Equal(t, 2, m.CircularReferences.Total)
+	assert.Equal(t, 2, m.CircularReferences.Infinite)
+}
+
+func TestRolodex_BuildSpecMetrics_NotIndexed(t *testing.T) {
+	assert.Nil(t, NewRolodex(CreateOpenAPIIndexConfig()).BuildSpecMetrics())
+}
@@ -0,0 +1,499 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// SearchResultType is the kind of thing a search result points to.
+type SearchResultType string
+
+const (
+	SearchOperation   SearchResultType = "operation"
+	SearchPath        SearchResultType = "path"
+	SearchSchema      SearchResultType = "schema"
+	SearchParameter   SearchResultType = "parameter"
+	SearchProperty    SearchResultType = "property"
+	SearchTag         SearchResultType = "tag"
+	SearchSummary     SearchResultType = "summary"
+	SearchEnum        SearchResultType = "enum"
+	SearchDescription SearchResultType = "description"
+)
+
+// searchTypeWeights rank names above prose, a query matching an operationId is more likely to be looking for that
+// operation than for a description that happens to mention it.
+var searchTypeWeights = map[SearchResultType]float64{
+	SearchOperation:   3,
+	SearchPath:        3,
+	SearchSchema:      3,
+	SearchParameter:   2,
+	SearchProperty:    2,
+	SearchTag:         2,
+	SearchSummary:     1.5,
+	SearchEnum:        1,
+	SearchDescription: 1,
+}
+
+var searchTypeOrder = map[SearchResultType]int{
+	SearchOperation: 0, SearchPath: 1, SearchSchema: 2, SearchParameter: 3, SearchProperty: 4,
+	SearchTag: 5, SearchSummary: 6, SearchEnum: 7, SearchDescription: 8,
+}
+
+// SearchResult is a single match returned by SearchIndex.Search.
+type SearchResult struct {
+	// Type is the kind of thing that matched.
+	Type SearchResultType `json:"type"`
+
+	// Text is the text that matched: an operationId, a path, a name, or the content of a description.
+	Text string `json:"text"`
+
+	// Pointer is the JSON pointer of the match within File. For operations, schemas, parameters and properties it
+	// points to the object itself, for everything else it points to the matching value.
+	Pointer string `json:"pointer"`
+
+	// File is the absolute path of the file holding the match, empty for an in-memory document.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+
+	// Score ranks the result, higher is better.
+	Score float64 `json:"score"`
+
+	// Node is the node that matched.
+	Node *yaml.Node `json:"-"`
+}
+
+// SearchOptions narrow down a search.
+type SearchOptions struct {
+	// Types limits the results to these types, all types are returned when empty.
+	Types []SearchResultType
+
+	// Limit is the maximum number of results, zero returns every result.
+	Limit int
+
+	// Exact disables prefix and fuzzy matching, every query token has to match a token of the result exactly.
+	Exact bool
+}
+
+// SearchIndex is an in-memory, tokenized search index over the operations, paths, schemas, parameters, schema
+// properties, tags, summaries, enums and descriptions of a specification. Create one with
+// SpecIndex.BuildSearchIndex or Rolodex.BuildSearchIndex; once built it is safe to search concurrently.
+type SearchIndex struct {
+	entries  []*SearchResult
+	lengths  []int
+	postings map[string][]int
+	terms    []string
+}
+
+// BuildSearchIndex builds a search index over the document held by this index.
+func (index *SpecIndex) BuildSearchIndex() *SearchIndex {
+	return buildSearchIndex([]*SpecIndex{index})
+}
+
+// BuildSearchIndex builds a search index over every file in the rolodex.
+func (r *Rolodex) BuildSearchIndex() *SearchIndex {
+	return buildSearchIndex(append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...))
+}
+
+// Len returns the number of searchable entries in the index.
+func (s *SearchIndex) Len() int {
+	return len(s.entries)
+}
+
+// Search returns the entries matching query, best match first. The query is split into case-insensitive tokens
+// the same way as the indexed text, including camelCase and snake_case identifiers, so "pet id" finds petId. Unless
+// options.Exact is set, a query token also matches tokens it is a prefix of, and tokens within a small edit
+// distance of it, at a lower score. Options may be nil.
+func (s *SearchIndex) Search(query string, options *SearchOptions) []*SearchResult {
+	if options == nil {
+		options = &SearchOptions{}
+	}
+	tokens := uniqueStrings(tokenizeSearchText(query))
+	if len(tokens) == 0 {
+		return []*SearchResult{}
+	}
+	types := make(map[SearchResultType]bool, len(options.Types))
+	for _, t := range options.Types {
+		types[t] = true
+	}
+
+	scores := make(map[int]float64)
+	for _, token := range tokens {
+		best := make(map[int]float64)
+		for _, term := range s.matchingTerms(token, options.Exact) {
+			score := searchTermScore(token, term)
+			for _, id := range s.postings[term] {
+				best[id] = max(best[id], score)
+			}
+		}
+		for id, score := range best {
+			scores[id] += score
+		}
+	}
+
+	results := make([]*SearchResult, 0, len(scores))
+	for id, score := range scores {
+		entry := s.entries[id]
+		if len(types) > 0 && !types[entry.Type] {
+			continue
+		}
+		// an entry made up entirely of the query outranks a longer one that merely contains it.
+		coverage := min(1, float64(len(tokens))/float64(s.lengths[id]))
+		result := *entry
+		result.Score = score / float64(len(tokens)) * (0.5 + 0.5*coverage) * searchTypeWeights[entry.Type]
+		results = append(results, &result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Type != b.Type {
+			return searchTypeOrder[a.Type] < searchTypeOrder[b.Type]
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	if options.Limit > 0 && len(results) > options.Limit {
+		results = results[:options.Limit]
+	}
+	return results
+}
+
+// matchingTerms returns the indexed terms matching a query token exactly, by prefix, or within the allowed edit
+// distance.
+func (s *SearchIndex) matchingTerms(token string, exact bool) []string {
+	if exact {
+		if _, ok := s.postings[token]; ok {
+			return []string{token}
+		}
+		return nil
+	}
+	var terms []string
+	i := sort.SearchStrings(s.terms, token)
+	for ; i < len(s.terms) && strings.HasPrefix(s.terms[i], token); i++ {
+		terms = append(terms, s.terms[i])
+	}
+	if edits := searchMaxEdits(token); edits > 0 {
+		for _, term := range s.terms {
+			if !strings.HasPrefix(term, token) && boundedEditDistance(token, term, edits) <= edits {
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// searchTermScore scores how well an indexed term matches a query token: 1 for an exact match, a little less for a
+// prefix match, less again for a fuzzy match.
+func searchTermScore(token, term string) float64 {
+	switch {
+	case token == term:
+		return 1
+	case strings.HasPrefix(term, token):
+		return 0.5 + 0.4*float64(len(token))/float64(len(term))
+	default:
+		d := boundedEditDistance(token, term, searchMaxEdits(token))
+		return 0.6 * (1 - float64(d)/float64(len(token)+1))
+	}
+}
+
+// searchMaxEdits is the edit distance a query token may be from an indexed term, short tokens have to be exact.
+func searchMaxEdits(token string) int {
+	switch n := len(token); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// boundedEditDistance returns the Levenshtein distance between a and b, or limit+1 once it exceeds limit.
+func boundedEditDistance(a, b string, limit int) int {
+	if diff := len(a) - len(b); diff > limit || -diff > limit {
+		return limit + 1
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		lowest := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			lowest = min(lowest, curr[j])
+		}
+		if lowest > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// tokenizeSearchText splits text into lower case tokens on anything that is not a letter or a digit, and splits
+// camelCase words. A split identifier is also kept whole, so listPets is indexed as list, pets and listpets.
+func tokenizeSearchText(text string) []string {
+	var tokens []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		parts := splitCamelCase(word)
+		for _, p := range parts {
+			tokens = append(tokens, strings.ToLower(p))
+		}
+		if len(parts) > 1 {
+			tokens = append(tokens, strings.ToLower(word))
+		}
+	}
+	return tokens
+}
+
+// splitCamelCase splits a word on lower to upper case transitions, and before the last capital of a run of
+// capitals followed by a lower case letter, so HTTPServer becomes HTTP and Server.
+func splitCamelCase(word string) []string {
+	runes := []rune(word)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		if unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])) {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// searchIndexBuilder collects the entries of a search index, one file at a time.
+type searchIndexBuilder struct {
+	search   *SearchIndex
+	file     string
+	pointers map[*yaml.Node]string
+	seen     map[*yaml.Node]map[SearchResultType]bool
+}
+
+func buildSearchIndex(indexes []*SpecIndex) *SearchIndex {
+	b := &searchIndexBuilder{
+		search: &SearchIndex{postings: make(map[string][]int)},
+		seen:   make(map[*yaml.Node]map[SearchResultType]bool),
+	}
+	visited := make(map[*SpecIndex]bool)
+	for _, idx := range indexes {
+		if idx == nil || visited[idx] || idx.GetRootNode() == nil {
+			continue
+		}
+		visited[idx] = true
+		b.file = idx.GetSpecAbsolutePath()
+		b.pointers = jsonPointers(idx.GetRootNode())
+		b.collect(idx)
+	}
+	b.search.terms = make([]string, 0, len(b.search.postings))
+	for term := range b.search.postings {
+		b.search.terms = append(b.search.terms, term)
+	}
+	sort.Strings(b.search.terms)
+	return b.search
+}
+
+func (b *searchIndexBuilder) collect(idx *SpecIndex) {
+	paths := idx.GetAllPaths()
+	for _, path := range sortedKeys(paths) {
+		for _, method := range sortedKeys(paths[path]) {
+			op := paths[path][method].Node
+			if _, id := utils.FindKeyNodeTop("operationId", contentOf(op)); id != nil {
+				b.add(SearchOperation, id.Value, op, id)
+			}
+		}
+	}
+	for _, key := range []string{"paths", "webhooks"} {
+		if _, items := utils.FindKeyNodeTop(key, contentOf(rootMapNode(idx.GetRootNode()))); items != nil {
+			for i := 0; i+1 < len(items.Content); i += 2 {
+				b.add(SearchPath, items.Content[i].Value, items.Content[i+1], items.Content[i])
+			}
+		}
+	}
+	schemas := idx.GetAllComponentSchemas()
+	for _, key := range sortedKeys(schemas) {
+		if ref := schemas[key]; ref != nil {
+			b.add(SearchSchema, ref.Name, ref.Node, ref.KeyNode)
+		}
+	}
+
+	params := idx.GetAllParameters()
+	for _, key := range sortedKeys(params) {
+		b.addParameter(params[key].Node)
+	}
+	opParams := idx.GetAllParametersFromOperations()
+	for _, path := range sortedKeys(opParams) {
+		for _, method := range sortedKeys(opParams[path]) {
+			for _, name := range sortedKeys(opParams[path][method]) {
+				for _, ref := range opParams[path][method][name] {
+					b.addParameter(ref.Node)
+				}
+			}
+		}
+	}
+
+	for _, obj := range idx.GetAllObjectsWithProperties() {
+		if _, props := utils.FindKeyNodeTop("properties", contentOf(obj.Node)); props != nil && utils.IsNodeMap(props) {
+			for i := 0; i+1 < len(props.Content); i += 2 {
+				b.add(SearchProperty, props.Content[i].Value, props.Content[i+1], props.Content[i])
+			}
+		}
+	}
+
+	tags := idx.GetOperationTags()
+	for _, path := range sortedKeys(tags) {
+		for _, method := range sortedKeys(tags[path]) {
+			for _, tag := range tags[path][method] {
+				b.add(SearchTag, tag.Name, tag.Node, nil)
+			}
+		}
+	}
+	if global := idx.GetGlobalTagsNode(); global != nil {
+		for _, tag := range global.Content {
+			if _, name := utils.FindKeyNodeTop("name", contentOf(tag)); name != nil {
+				b.add(SearchTag, name.Value, tag, name)
+			}
+		}
+	}
+
+	for _, s := range idx.GetAllSummaries() {
+		b.add(SearchSummary, s.Content, s.Node, nil)
+	}
+	for _, e := range idx.GetAllEnums() {
+		var values []string
+		for _, v := range e.Node.Content {
+			if v.Kind == yaml.ScalarNode {
+				values = append(values, v.Value)
+			}
+		}
+		b.add(SearchEnum, strings.Join(values, " "), e.Node, nil)
+	}
+	for _, d := range idx.GetAllDescriptions() {
+		b.add(SearchDescription, d.Content, d.Node, nil)
+	}
+}
+
+func (b *searchIndexBuilder) addParameter(param *yaml.Node) {
+	if _, name := utils.FindKeyNodeTop("name", contentOf(param)); name != nil {
+		b.add(SearchParameter, name.Value, param, name)
+	}
+}
+
+// add indexes text found at node, positioned at the location node, or at node itself when location is nil. Nodes
+// that do not belong to the current file are skipped, they are indexed with the file they belong to.
+func (b *searchIndexBuilder) add(kind SearchResultType, text string, node, location *yaml.Node) {
+	if node == nil || text == "" {
+		return
+	}
+	pointer, ok := b.pointers[node]
+	if !ok || b.seen[node][kind] {
+		return
+	}
+	if b.seen[node] == nil {
+		b.seen[node] = make(map[SearchResultType]bool)
+	}
+	b.seen[node][kind] = true
+	if location == nil {
+		location = node
+	}
+
+	id := len(b.search.entries)
+	b.search.entries = append(b.search.entries, &SearchResult{
+		Type:    kind,
+		Text:    text,
+		Pointer: pointer,
+		File:    b.file,
+		Line:    location.Line,
+		Column:  location.Column,
+		Node:    node,
+	})
+	tokens := uniqueStrings(tokenizeSearchText(text))
+	for _, token := range tokens {
+		b.search.postings[token] = append(b.search.postings[token], id)
+	}
+	b.search.lengths = append(b.search.lengths, max(1, len(tokens)))
+}
+
+// jsonPointers maps every node of a document to its JSON pointer.
+func jsonPointers(root *yaml.Node) map[*yaml.Node]string {
+	pointers := make(map[*yaml.Node]string)
+	var walk func(node *yaml.Node, pointer string)
+	walk = func(node *yaml.Node, pointer string) {
+		if node == nil {
+			return
+		}
+		if _, ok := pointers[node]; ok {
+			return
+		}
+		pointers[node] = pointer
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i+1], pointer+"/"+escapePointerSegment(node.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, pointer+"/"+strconv.Itoa(i))
+			}
+		}
+	}
+	walk(rootMapNode(root), "")
+	return pointers
+}
+
+func escapePointerSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
+
+func rootMapNode(root *yaml.Node) *yaml.Node {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
+
+func contentOf(node *yaml.Node) []*yaml.Node {
+	if node == nil {
+		return nil
+	}
+	return node.Content
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+const searchSpec = `openapi: 3.1.0
+info:
+  title: search
+  version: 1.0.0
+tags:
+  - name: pets
+    description: Everything about your pets
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+    get:
+      operationId: getPetById
+      summary: Find a pet by its identifier
+      tags: [pets]
+      responses:
+        '200':
+          description: the pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      description: maximum number of results
+  schemas:
+    Pet:
+      type: object
+      properties:
+        nickname:
+          type: string
+          description: what the owner calls the animal
+        status:
+          type: string
+          enum: [available, adopted]
+`
+
+func buildSearchTestIndex(t *testing.T) *SpecIndex {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(searchSpec), &root))
+	return NewSpecIndexWithConfig(&root, CreateClosedAPIIndexConfig())
+}
+
+func TestSpecIndex_BuildSearchIndex(t *testing.T) {
+	search := buildSearchTestIndex(t).BuildSearchIndex()
+	assert.Equal(t, 15, search.Len())
+
+	results := search.Search("getPetById", nil)
+	assert.NotEmpty(t, results)
+	assert.Equal(t, SearchOperation, results[0].Type)
+	assert.Equal(t, "/paths/~1pets~1{petId}/get", results[0].Pointer)
+	assert.Equal(t, 15, results[0].Line)
+
+	// camel case identifiers are split, so natural language queries find them.
+	results = search.Search("pet id", &SearchOptions{Types: []SearchResultType{SearchParameter}})
+	assert.Len(t, results, 1)
+	assert.Equal(t, "petId", results[0].Text)
+	assert.Equal(t, "/paths/~1pets~1{petId}/parameters/0", results[0].Pointer)
+
+	results = search.Search("NICKNAME", nil)
+	assert.Len(t, results, 1)
+	assert.Equal(t, SearchProperty, results[0].Type)
+	assert.Equal(t, "/components/schemas/Pet/properties/nickname", results[0].Pointer)
+	assert.Equal(t, 35, results[0].Line)
+
+	results = search.Search("adopted", nil)
+	assert.Len(t, results, 1)
+	assert.Equal(t, SearchEnum, results[0].Type)
+	assert.Equal(t, "available adopted", results[0].Text)
+}
+
+func TestSearchIndex_Search_Ranking(t *testing.T) {
+	search := buildSearchTestIndex(t).BuildSearchIndex()
+
+	// names rank above prose mentioning the same word.
+	results := search.Search("pet", nil)
+	assert.Equal(t, SearchSchema, results[0].Type)
+	assert.Equal(t, "Pet", results[0].Text)
+	for i := 1; i < len(results); i++ {
+		assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+	}
+
+	// typos and prefixes still match, below exact matches.
+	results = search.Search("animl", nil)
+	assert.Len(t, results, 1)
+	assert.Equal(t, SearchDescription, results[0].Type)
+
+	results = search.Search("nick", nil)
+	assert.Len(t, results, 1)
+	assert.Less(t, results[0].Score, search.Search("nickname", nil)[0].Score)
+
+	assert.Empty(t, search.Search("animl", &SearchOptions{Exact: true}))
+	assert.Len(t, search.Search("pet", &SearchOptions{Limit: 2}), 2)
+	assert.Empty(t, search.Search("  ", nil))
+}
+
+func TestTokenizeSearchText(t *testing.T) {
+	assert.Equal(t, []string{"get", "pet", "by", "id", "getpetbyid"}, tokenizeSearchText("getPetById"))
+	assert.Equal(t, []string{"http", "server", "httpserver", "pet", "id"}, tokenizeSearchText("HTTPServer pet_id"))
+	assert.Equal(t, []string{"pets", "pet", "id", "petid"}, tokenizeSearchText("/pets/{petId}"))
+}
+
+func TestBoundedEditDistance(t *testing.T) {
+	assert.Equal(t, 0, boundedEditDistance("pet", "pet", 1))
+	assert.Equal(t, 1, boundedEditDistance("animl", "animal", 1))
+	assert.Equal(t, 2, boundedEditDistance("kitten", "sitting", 1))
+	assert.Equal(t, 3, boundedEditDistance("a", "abcd", 2))
+}
+
+func TestRolodex_BuildSearchIndex(t *testing.T) {
+	rolodex, dir := buildWatchRolodex(t)
+
+	results := rolodex.BuildSearchIndex().Search("owner", &SearchOptions{Types: []SearchResultType{SearchSchema}})
+	assert.Len(t, results, 1)
+	assert.Equal(t, filepath.Join(dir, "shared.yaml"), results[0].File)
+	assert.Equal(t, "/components/schemas/Owner", results[0].Pointer)
+
+	_, err := os.Stat(results[0].File)
+	assert.NoError(t, err)
+}
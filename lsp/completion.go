@@ -0,0 +1,341 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// refValuePattern matches the text of a line before a position within the value of a $ref.
+var refValuePattern = regexp.MustCompile(`["']?\$ref["']?\s*:\s*["']?([^"']*)$`)
+
+// keyPattern matches the text of a line before a position where a key is being written.
+var keyPattern = regexp.MustCompile(`^(\s*)(- +)?([\w$.-]*)$`)
+
+var schemaKeys = []string{
+	"$ref", "type", "format", "title", "description", "properties", "required", "items", "additionalProperties",
+	"allOf", "oneOf", "anyOf", "not", "enum", "const", "default", "example", "examples", "discriminator",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "minLength", "maxLength", "pattern",
+	"minItems", "maxItems", "uniqueItems", "minProperties", "maxProperties", "readOnly", "writeOnly",
+	"deprecated", "nullable", "externalDocs", "xml",
+}
+
+// objectKeys are the keys of the OpenAPI objects classified by the index.
+var objectKeys = map[index.PositionObjectKind][]string{
+	index.PositionDocument: {
+		"openapi", "info", "jsonSchemaDialect", "servers", "paths", "webhooks", "components", "security", "tags",
+		"externalDocs",
+	},
+	index.PositionPathItem: {
+		"$ref", "summary", "description", "get", "put", "post", "delete", "options", "head", "patch", "trace",
+		"servers", "parameters",
+	},
+	index.PositionOperation: {
+		"tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody", "responses",
+		"callbacks", "deprecated", "security", "servers",
+	},
+	index.PositionParameter: {
+		"$ref", "name", "in", "description", "required", "deprecated", "allowEmptyValue", "style", "explode",
+		"allowReserved", "schema", "example", "examples", "content",
+	},
+	index.PositionRequestBody:    {"$ref", "description", "content", "required"},
+	index.PositionResponse:       {"$ref", "description", "headers", "content", "links"},
+	index.PositionMediaType:      {"schema", "example", "examples", "encoding"},
+	index.PositionHeader:         {"$ref", "description", "required", "deprecated", "style", "explode", "schema", "example", "examples", "content"},
+	index.PositionEncoding:       {"contentType", "headers", "style", "explode", "allowReserved"},
+	index.PositionSchema:         schemaKeys,
+	index.PositionProperty:       schemaKeys,
+	index.PositionExample:        {"$ref", "summary", "description", "value", "externalValue"},
+	index.PositionLink:           {"$ref", "operationRef", "operationId", "parameters", "requestBody", "description", "server"},
+	index.PositionSecurityScheme: {"$ref", "type", "description", "name", "in", "scheme", "bearerFormat", "flows", "openIdConnectUrl"},
+}
+
+// patternKeys are the keys of objects found by their JSON pointer, where * matches any segment. They cover the
+// parts of an OpenAPI document that are not classified by the index, and Arazzo and Overlay documents.
+var patternKeys = map[DocumentKind]map[string][]string{
+	KindOpenAPI: {
+		"/info":         {"title", "summary", "description", "termsOfService", "contact", "license", "version"},
+		"/info/contact": {"name", "url", "email"},
+		"/info/license": {"name", "identifier", "url"},
+		"/servers/*":    {"url", "description", "variables"},
+		"/tags/*":       {"name", "description", "externalDocs"},
+		"/externalDocs": {"description", "url"},
+		"/components":   {"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links", "callbacks", "pathItems"},
+	},
+	KindArazzo: {
+		"":                                  {"arazzo", "info", "sourceDescriptions", "workflows", "components"},
+		"/info":                             {"title", "summary", "description", "version"},
+		"/sourceDescriptions/*":             {"name", "url", "type"},
+		"/workflows/*":                      {"workflowId", "summary", "description", "inputs", "dependsOn", "steps", "successActions", "failureActions", "outputs", "parameters"},
+		"/workflows/*/steps/*":              {"stepId", "description", "operationId", "operationPath", "workflowId", "parameters", "requestBody", "successCriteria", "onSuccess", "onFailure", "outputs"},
+		"/workflows/*/steps/*/parameters/*": {"name", "in", "value", "reference"},
+		"/components":                       {"inputs", "parameters", "successActions", "failureActions"},
+	},
+	KindOverlay: {
+		"":           {"overlay", "info", "extends", "actions"},
+		"/info":      {"title", "version"},
+		"/actions/*": {"target", "description", "update", "remove", "copy"},
+	},
+}
+
+// itemKinds are the kinds of the items of sequences, used for an item that is still being written and so is not
+// classified by the index.
+var itemKinds = map[string]index.PositionObjectKind{
+	"parameters":  index.PositionParameter,
+	"allOf":       index.PositionSchema,
+	"oneOf":       index.PositionSchema,
+	"anyOf":       index.PositionSchema,
+	"prefixItems": index.PositionSchema,
+}
+
+// completion completes the value of a $ref with the components of the document and the files it references, or
+// a key with the keys known for the object it is written in.
+func (s *Server) completion(params TextDocumentPositionParams) *CompletionList {
+	list := &CompletionList{Items: []CompletionItem{}}
+	d := s.documents[params.TextDocument.URI]
+	if d == nil || d.rolodex == nil || d.rolodex.GetRootIndex() == nil {
+		return list
+	}
+	before := textBefore(d.lines, params.Position)
+	if m := refValuePattern.FindStringSubmatch(before); m != nil {
+		start := params.Position
+		start.Character -= utf16Length(m[1])
+		list.Items = s.refCompletions(d, Range{Start: start, End: params.Position})
+		return list
+	}
+	if m := keyPattern.FindStringSubmatch(before); m != nil && !isFlowDocument(d.root) {
+		start := params.Position
+		start.Character -= utf16Length(m[3])
+		indent := len(m[1]) + len(m[2])
+		list.Items = s.keyCompletions(d, params.Position.Line, indent, m[2] != "", Range{Start: start, End: params.Position})
+	}
+	return list
+}
+
+// refCompletions returns the components of the document, and the components and files it references.
+func (s *Server) refCompletions(d *document, replace Range) []CompletionItem {
+	var items []CompletionItem
+	add := func(ref, detail string) {
+		items = append(items, CompletionItem{
+			Label:    ref,
+			Kind:     CompletionReference,
+			Detail:   detail,
+			TextEdit: &TextEdit{Range: replace, NewText: ref},
+		})
+	}
+	for _, idx := range d.indexes() {
+		file := idx.GetSpecAbsolutePath()
+		prefix := ""
+		if !d.isRoot(file) {
+			prefix = file
+			if d.path != "" && filepath.IsAbs(file) {
+				if rel, err := filepath.Rel(filepath.Dir(d.path), file); err == nil {
+					prefix = filepath.ToSlash(rel)
+				}
+			}
+			items = append(items, CompletionItem{
+				Label:    prefix,
+				Kind:     CompletionFile,
+				TextEdit: &TextEdit{Range: replace, NewText: prefix},
+			})
+		}
+		for _, pointer := range componentPointers(idx.GetRootNode()) {
+			add(prefix+"#"+pointer, strings.TrimPrefix(filepath.Base(file), "."))
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+	return items
+}
+
+// componentPointers returns the JSON pointers of the components of a file. The components of a file without
+// components (or definitions) are its top level keys, as in a file of shared schemas.
+func componentPointers(root *yaml.Node) []string {
+	mapping := rootMapping(root)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	var pointers []string
+	sections := map[string]int{"components": 2, "definitions": 1, "parameters": 1, "responses": 1,
+		"securityDefinitions": 1, "$defs": 1}
+	found := false
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i].Value, mapping.Content[i+1]
+		depth, ok := sections[key]
+		if !ok || value.Kind != yaml.MappingNode {
+			continue
+		}
+		found = true
+		pointers = append(pointers, childPointers("/"+escapePointer(key), value, depth)...)
+	}
+	if !found && detectKind(mapping) == KindFragment {
+		pointers = childPointers("", mapping, 1)
+	}
+	return pointers
+}
+
+// childPointers returns the pointers of the keys of a mapping, depth levels down.
+func childPointers(prefix string, mapping *yaml.Node, depth int) []string {
+	var pointers []string
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		pointer := prefix + "/" + escapePointer(mapping.Content[i].Value)
+		if depth == 1 {
+			pointers = append(pointers, pointer)
+		} else if mapping.Content[i+1].Kind == yaml.MappingNode {
+			pointers = append(pointers, childPointers(pointer, mapping.Content[i+1], depth-1)...)
+		}
+	}
+	return pointers
+}
+
+func escapePointer(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
+
+// isFlowDocument returns true if a document is written in flow style, as JSON is.
+func isFlowDocument(root *yaml.Node) bool {
+	mapping := rootMapping(root)
+	return mapping != nil && mapping.Style&yaml.FlowStyle != 0
+}
+
+// keyCompletions returns the keys known for the object a key is being written in, without those already set. The
+// object is found from the indentation of the lines above, as the text being written rarely parses.
+func (s *Server) keyCompletions(d *document, line, indent int, item bool, replace Range) []CompletionItem {
+	pointer, node, kind, ok := parentObject(d, line, indent, item)
+	if !ok {
+		return nil
+	}
+	var keys []string
+	if kind != "" && (kind != index.PositionDocument || d.kind == KindOpenAPI) &&
+		(d.kind == KindOpenAPI || d.kind == KindFragment) {
+		keys = objectKeys[kind]
+	}
+	if keys == nil {
+		kind := d.kind
+		if kind == KindFragment {
+			kind = KindOpenAPI
+		}
+		keys = matchPatternKeys(patternKeys[kind], pointer)
+	}
+
+	existing := make(map[string]bool)
+	if node != nil && node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			existing[node.Content[i].Value] = true
+		}
+	}
+	var items []CompletionItem
+	for _, key := range keys {
+		if existing[key] {
+			continue
+		}
+		items = append(items, CompletionItem{
+			Label:    key,
+			Kind:     CompletionProperty,
+			TextEdit: &TextEdit{Range: replace, NewText: key + ": "},
+		})
+	}
+	return items
+}
+
+// parentObject finds the object a key written at a line and indentation belongs to, from the last build of the
+// document. It returns the JSON pointer and node of the object, and its kind when the index classifies it.
+func parentObject(d *document, line, indent int, item bool) (string, *yaml.Node, index.PositionObjectKind, bool) {
+	idx := d.rolodex.GetRootIndex()
+	if indent == 0 {
+		return "", rootMapping(d.root), index.PositionDocument, true
+	}
+	for l := line - 1; l >= 0; l-- {
+		text := lineText(d.lines, l)
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		lineIndent := len(text) - len(trimmed)
+		dash := strings.HasPrefix(trimmed, "- ")
+		content := lineIndent
+		if dash {
+			content = len(text) - len(strings.TrimLeft(trimmed[1:], " "))
+		}
+		sameItem := dash && content == indent && !item
+		if !sameItem && content >= indent {
+			// a sibling, or something nested in a sibling.
+			continue
+		}
+		if !sameItem && !strings.HasSuffix(strings.TrimSpace(stripComment(trimmed)), ":") {
+			return "", nil, "", false
+		}
+
+		lookup := idx.LookupPosition(l+1, content+1)
+		if lookup == nil || !lookup.OnKey {
+			return "", nil, "", false
+		}
+		switch {
+		case sameItem:
+			// a key of the same item of a sequence, the object is the item.
+			pointer := lookup.Pointer[:strings.LastIndex(lookup.Pointer, "/")]
+			_, node := nodeAtPointer(idx.GetRootNode(), pointer)
+			return pointer, node, objectKind(lookup, pointer), true
+		case item:
+			// the first key of a new item of a sequence.
+			n := 0
+			if lookup.Node != nil && lookup.Node.Kind == yaml.SequenceNode {
+				n = len(lookup.Node.Content)
+			}
+			segments := strings.Split(lookup.Pointer, "/")
+			return lookup.Pointer + "/" + strconv.Itoa(n), nil, itemKinds[segments[len(segments)-1]], true
+		}
+		return lookup.Pointer, lookup.Node, objectKind(lookup, lookup.Pointer), true
+	}
+	return "", nil, "", false
+}
+
+// objectKind returns the kind of the object of a lookup at a pointer, or an empty kind.
+func objectKind(lookup *index.PositionLookup, pointer string) index.PositionObjectKind {
+	for _, o := range lookup.Objects {
+		if o.Pointer == pointer {
+			return o.Kind
+		}
+	}
+	return ""
+}
+
+// stripComment removes a trailing comment from a line of YAML.
+func stripComment(text string) string {
+	if i := strings.Index(text, " #"); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
+
+// matchPatternKeys returns the keys of the pattern matching a pointer.
+func matchPatternKeys(patterns map[string][]string, pointer string) []string {
+	if keys, ok := patterns[pointer]; ok {
+		return keys
+	}
+	segments := strings.Split(pointer, "/")
+	for pattern, keys := range patterns {
+		patternSegments := strings.Split(pattern, "/")
+		if len(patternSegments) != len(segments) {
+			continue
+		}
+		match := true
+		for i, p := range patternSegments {
+			if p != "*" && p != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return keys
+		}
+	}
+	return nil
+}
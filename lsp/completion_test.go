@@ -0,0 +1,106 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func completionLabels(list *CompletionList) []string {
+	var labels []string
+	for _, item := range list.Items {
+		labels = append(labels, item.Label)
+	}
+	return labels
+}
+
+func TestServer_Completion_Ref(t *testing.T) {
+	c, uri := newSpecClient(t)
+
+	// an unfinished $ref does not parse, completion works with the last build.
+	text := strings.Replace(lspSpec, "$ref: 'shared.yaml#/Pet'\ncomponents", "$ref: '#/comp\ncomponents", 1)
+	c.change(uri, 2, text)
+
+	var list CompletionList
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 16, 29), &list))
+	assert.Equal(t, []string{
+		"#/components/parameters/Limit",
+		"#/components/schemas/Pets",
+		"shared.yaml",
+		"shared.yaml#/Pet",
+	}, completionLabels(&list))
+	item := list.Items[0]
+	assert.Equal(t, CompletionReference, item.Kind)
+	require.NotNil(t, item.TextEdit)
+	assert.Equal(t, Range{Start: Position{16, 23}, End: Position{16, 29}}, item.TextEdit.Range)
+	assert.Equal(t, "#/components/parameters/Limit", item.TextEdit.NewText)
+	assert.Equal(t, CompletionFile, list.Items[2].Kind)
+}
+
+func TestServer_Completion_Keys(t *testing.T) {
+	c, uri := newSpecClient(t)
+
+	// a new key of the operation, those already set are left out.
+	text := strings.Replace(lspSpec, "      operationId: listPets\n", "      operationId: listPets\n      su\n", 1)
+	c.change(uri, 2, text)
+	var list CompletionList
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 8, 8), &list))
+	labels := completionLabels(&list)
+	assert.Contains(t, labels, "summary")
+	assert.Contains(t, labels, "requestBody")
+	assert.NotContains(t, labels, "operationId")
+	assert.Equal(t, Range{Start: Position{8, 6}, End: Position{8, 8}}, list.Items[0].TextEdit.Range)
+	assert.Equal(t, list.Items[0].Label+": ", list.Items[0].TextEdit.NewText)
+
+	// a new key of a parameter, within the item of the sequence.
+	text = strings.Replace(lspSpec, "      name: limit\n", "      name: limit\n      \n", 1)
+	c.change(uri, 3, strings.Replace(text, "        - $ref: '#/components/parameters/Limit'\n",
+		"        - name: offset\n          \n", 1))
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 10, 10), &list))
+	labels = completionLabels(&list)
+	assert.Contains(t, labels, "in")
+	assert.NotContains(t, labels, "name")
+	assert.NotContains(t, labels, "summary")
+
+	// a key of a structural object, found by its pointer.
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 3, 2), &list))
+	labels = completionLabels(&list)
+	assert.Contains(t, labels, "description")
+	assert.NotContains(t, labels, "title")
+
+	// a top level key.
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 5, 0), &list))
+	assert.Contains(t, completionLabels(&list), "webhooks")
+	assert.NotContains(t, completionLabels(&list), "paths")
+}
+
+func TestServer_Completion_Arazzo(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize()
+	uri := c.openText("flow.arazzo.yaml", `arazzo: 1.0.1
+info:
+  title: flow
+  version: 1.0.0
+workflows:
+  - workflowId: adopt
+    steps:
+      - stepId: list
+        operationId: listPets
+      - st
+`)
+	var list CompletionList
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 9, 10), &list))
+	labels := completionLabels(&list)
+	assert.Contains(t, labels, "stepId")
+	assert.Contains(t, labels, "successCriteria")
+
+	require.Nil(t, c.call("textDocument/completion", c.position(uri, 9, 0), &list))
+	labels = completionLabels(&list)
+	assert.Contains(t, labels, "sourceDescriptions")
+	assert.NotContains(t, labels, "workflows")
+}
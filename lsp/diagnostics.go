@@ -0,0 +1,196 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/jsonpath/pkg/jsonpath"
+	"github.com/pb33f/jsonpath/pkg/jsonpath/config"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/arazzo"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/overlay"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// diagnosticSource is the source of every diagnostic published by the server.
+const diagnosticSource = "libopenapi"
+
+// lineDiagnostic returns a diagnostic from a 1-based line and column to the end of the line. A line of zero is
+// the start of the document.
+func lineDiagnostic(lines []string, line, column int, severity DiagnosticSeverity, message string) Diagnostic {
+	start := textPosition{lines: lines}.position(line, column)
+	end := Position{Line: start.Line, Character: utf16Length(lineText(lines, start.Line))}
+	if end.Character < start.Character {
+		end.Character = start.Character
+	}
+	return Diagnostic{
+		Range:    Range{Start: start, End: end},
+		Severity: severity,
+		Source:   diagnosticSource,
+		Message:  message,
+	}
+}
+
+// syntaxDiagnostics returns the diagnostics of a document that cannot be parsed.
+func syntaxDiagnostics(err error, lines []string) []Diagnostic {
+	var loadErrors *yaml.LoadErrors
+	if errors.As(err, &loadErrors) && len(loadErrors.Errors) > 0 {
+		var diagnostics []Diagnostic
+		for _, e := range loadErrors.Errors {
+			diagnostics = append(diagnostics, lineDiagnostic(lines, e.Mark.Line, e.Mark.Column, SeverityError, e.Message))
+		}
+		return diagnostics
+	}
+	var loadError *yaml.LoadError
+	if errors.As(err, &loadError) {
+		return []Diagnostic{lineDiagnostic(lines, loadError.Mark.Line, loadError.Mark.Column, SeverityError,
+			loadError.Message)}
+	}
+	return []Diagnostic{lineDiagnostic(lines, 0, 0, SeverityError, err.Error())}
+}
+
+// indexDiagnostics adds the errors caught by the rolodex while indexing the document and resolving its
+// references. Errors are reported in the file holding the node they were found at.
+func (d *document) indexDiagnostics(s *Server) {
+	var files map[*yaml.Node]string
+	fileOf := func(node *yaml.Node) string {
+		if files == nil {
+			files = make(map[*yaml.Node]string)
+			for _, idx := range d.indexes() {
+				mapNodes(idx.GetRootNode(), idx.GetSpecAbsolutePath(), files)
+			}
+		}
+		return files[node]
+	}
+	for _, caught := range d.rolodex.GetCaughtErrors() {
+		for _, err := range utils.UnwrapErrors(caught) {
+			var node *yaml.Node
+			message := err.Error()
+			var resolvingError *index.ResolvingError
+			var indexingError *index.IndexingError
+			switch {
+			case errors.As(err, &resolvingError):
+				node = refValueNode(resolvingError.Node)
+			case errors.As(err, &indexingError):
+				node = indexingError.KeyNode
+				if node == nil {
+					node = refValueNode(indexingError.Node)
+				}
+				if indexingError.Err != nil {
+					message = indexingError.Err.Error()
+				}
+			}
+			if node == nil {
+				d.addDiagnostics("", []Diagnostic{lineDiagnostic(d.builtLines, 0, 0, SeverityError, message)})
+				continue
+			}
+			file := fileOf(node)
+			d.addDiagnostics(file, []Diagnostic{{
+				Range:    s.filePositions(d, file).nodeRange(node),
+				Severity: SeverityError,
+				Source:   diagnosticSource,
+				Message:  message,
+			}})
+		}
+	}
+}
+
+// mapNodes records the file of every node under root.
+func mapNodes(root *yaml.Node, file string, files map[*yaml.Node]string) {
+	if root == nil {
+		return
+	}
+	if _, seen := files[root]; seen {
+		return
+	}
+	files[root] = file
+	for _, child := range root.Content {
+		mapNodes(child, file, files)
+	}
+}
+
+// refValueNode returns the value of the $ref of a node, or the node itself when it is not a $ref.
+func refValueNode(node *yaml.Node) *yaml.Node {
+	if isRef, _, _ := utils.IsNodeRefValue(node); isRef {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" {
+				return node.Content[i+1]
+			}
+		}
+	}
+	return node
+}
+
+// arazzoDiagnostics returns the errors and warnings of the validation of an Arazzo document.
+func arazzoDiagnostics(text []byte, lines []string) []Diagnostic {
+	doc, err := libopenapi.NewArazzoDocument(text)
+	if err != nil {
+		return []Diagnostic{lineDiagnostic(lines, 0, 0, SeverityError, err.Error())}
+	}
+	result := arazzo.Validate(doc)
+	if result == nil {
+		return nil
+	}
+	var diagnostics []Diagnostic
+	for _, e := range result.Errors {
+		diagnostics = append(diagnostics, lineDiagnostic(lines, e.Line, e.Column, SeverityError,
+			fmt.Sprintf("%s: %s", e.Path, e.Cause)))
+	}
+	for _, w := range result.Warnings {
+		diagnostics = append(diagnostics, lineDiagnostic(lines, w.Line, w.Column, SeverityWarning,
+			fmt.Sprintf("%s: %s", w.Path, w.Message)))
+	}
+	return diagnostics
+}
+
+// overlayDiagnostics returns the problems of an Overlay document: missing required fields, and action targets
+// that are not valid JSONPath expressions.
+func overlayDiagnostics(text []byte, lines []string) []Diagnostic {
+	doc, err := libopenapi.NewOverlayDocument(text)
+	if err != nil {
+		return []Diagnostic{lineDiagnostic(lines, 0, 0, SeverityError, err.Error())}
+	}
+	var diagnostics []Diagnostic
+	add := func(node *yaml.Node, message string) {
+		line, column := 0, 0
+		if node != nil {
+			line, column = node.Line, node.Column
+		}
+		diagnostics = append(diagnostics, lineDiagnostic(lines, line, column, SeverityError, message))
+	}
+	low := doc.GoLow()
+	if doc.Overlay == "" {
+		add(low.Overlay.KeyNode, overlay.ErrMissingOverlayField.Error())
+	}
+	if doc.Info == nil {
+		add(nil, overlay.ErrMissingInfo.Error())
+	}
+	if len(doc.Actions) == 0 {
+		add(low.Actions.KeyNode, overlay.ErrEmptyActions.Error())
+	}
+	for i, action := range low.Actions.Value {
+		a := action.Value
+		if a == nil {
+			continue
+		}
+		if a.Target.Value == "" {
+			node := a.Target.ValueNode
+			if node == nil {
+				node = action.ValueNode
+			}
+			add(node, fmt.Sprintf("actions[%d]: missing required 'target' field", i))
+			continue
+		}
+		_, pathErr := jsonpath.NewPath(a.Target.Value, config.WithPropertyNameExtension(),
+			config.WithLazyContextTracking())
+		if pathErr != nil {
+			add(a.Target.ValueNode, fmt.Sprintf("actions[%d]: %s: %s", i, overlay.ErrInvalidJSONPath, pathErr))
+		}
+	}
+	return diagnostics
+}
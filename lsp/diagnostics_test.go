@@ -0,0 +1,130 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestServer_Diagnostics(t *testing.T) {
+	c := newTestClient(t, map[string]string{
+		"shared.yaml": "Pet:\n  type: object\n  properties:\n    owner:\n      $ref: '#/Owner'\n",
+	})
+	c.initialize()
+	uri := c.openText("openapi.yaml", `openapi: 3.1.0
+info:
+  title: diagnostics
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Missing'
+        '400':
+          description: nope
+          content:
+            application/json:
+              schema:
+                $ref: 'shared.yaml#/Pet'
+`)
+
+	// the missing local component is reported on the $ref.
+	diagnostics := c.publish[uri]
+	require.NotEmpty(t, diagnostics)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Equal(t, diagnosticSource, diagnostics[0].Source)
+	assert.Equal(t, Range{Start: Position{13, 22}, End: Position{13, 52}}, diagnostics[0].Range)
+	assert.Contains(t, diagnostics[0].Message, "#/components/schemas/Missing")
+
+	// the missing component of the referenced file is reported in that file.
+	shared := c.publish[c.uri("shared.yaml")]
+	require.Len(t, shared, 1)
+	assert.Equal(t, 4, shared[0].Range.Start.Line)
+	assert.Contains(t, shared[0].Message, "Owner")
+
+	// a syntax error, the last build is kept for other features.
+	c.change(uri, 2, "openapi: 3.1.0\npaths: [\n")
+	diagnostics = c.publish[uri]
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	var symbols []DocumentSymbol
+	require.Nil(t, c.call("textDocument/documentSymbol", DocumentSymbolParams{TextDocument: TextDocumentIdentifier{URI: uri}}, &symbols))
+	assert.Equal(t, "paths", symbols[0].Name)
+
+	// fixed, every diagnostic is cleared, including those of the referenced file.
+	c.change(uri, 3, "openapi: 3.1.0\ninfo:\n  title: fixed\n  version: 1.0.0\npaths: {}\n")
+	assert.Empty(t, c.publish[uri])
+	assert.Empty(t, c.publish[c.uri("shared.yaml")])
+
+	// closing does not leave anything behind.
+	c.change(uri, 4, "openapi: 3.1.0\npaths: [\n")
+	c.notify("textDocument/didClose", DidCloseTextDocumentParams{TextDocument: TextDocumentIdentifier{URI: uri}})
+	c.sync()
+	assert.Empty(t, c.publish[uri])
+}
+
+func TestServer_Diagnostics_Arazzo(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize()
+	uri := c.openText("flow.arazzo.yaml", `arazzo: 1.0.1
+info:
+  title: flow
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./openapi.yaml
+    type: openapi
+workflows:
+  - workflowId: adopt
+    steps:
+      - stepId: list
+        operationId: listPets
+      - stepId: list
+        operationId: adoptPet
+`)
+	diagnostics := c.publish[uri]
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Equal(t, Range{Start: Position{13, 8}, End: Position{13, 20}}, diagnostics[0].Range)
+	assert.Equal(t, `workflows[0].steps[1].stepId: duplicate stepId within workflow: "list"`, diagnostics[0].Message)
+}
+
+func TestServer_Diagnostics_Overlay(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize()
+	uri := c.openText("fix.overlay.yaml", `overlay: 1.0.0
+info:
+  title: fix
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      description: updated
+  - target: $.paths[?(@.x ==
+    remove: true
+  - description: no target
+    remove: true
+`)
+	diagnostics := c.publish[uri]
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, 8, diagnostics[0].Range.Start.Line)
+	assert.Equal(t, 12, diagnostics[0].Range.Start.Character)
+	assert.Contains(t, diagnostics[0].Message, "invalid JSONPath expression")
+	assert.Equal(t, 10, diagnostics[1].Range.Start.Line)
+	assert.Contains(t, diagnostics[1].Message, "missing required 'target' field")
+
+	c.change(uri, 2, "overlay: 1.0.0\nactions: []\n")
+	diagnostics = c.publish[uri]
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "missing required 'info' field", diagnostics[0].Message)
+	assert.Equal(t, Position{1, 0}, diagnostics[1].Range.Start)
+}
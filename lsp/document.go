@@ -0,0 +1,166 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// DocumentKind is the kind of document held by an open file, detected from its top level keys.
+type DocumentKind string
+
+const (
+	KindOpenAPI  DocumentKind = "openapi"
+	KindArazzo   DocumentKind = "arazzo"
+	KindOverlay  DocumentKind = "overlay"
+	KindFragment DocumentKind = "fragment" // anything else, such as a file of schemas referenced by a document.
+)
+
+// document is a file opened by the client. Every document is the root of its own rolodex, so features work the
+// same way for an OpenAPI document and for a file of components referenced by one. Other files are read from
+// disk, unsaved changes to them are seen once they are saved.
+type document struct {
+	uri     string
+	path    string // empty when the URI is not a file URI.
+	version int
+	text    string
+	lines   []string
+
+	// the last build of the document that could be parsed, features keep working with it while the text is
+	// broken.
+	kind       DocumentKind
+	root       *yaml.Node
+	builtLines []string // the lines of the text the root was parsed from.
+	rolodex    *index.Rolodex
+
+	// diagnostics are keyed by file, as a document reports problems in the files it references. The document
+	// itself is the empty file.
+	diagnostics map[string][]Diagnostic
+}
+
+func newDocument(uri string, version int, text string) *document {
+	d := &document{uri: uri, path: uriToPath(uri), version: version}
+	d.setText(text)
+	return d
+}
+
+func (d *document) setText(text string) {
+	d.text = text
+	d.lines = splitLines(text)
+}
+
+// isRoot returns true if a file of the rolodex is the document itself.
+func (d *document) isRoot(file string) bool {
+	if file == "" || (d.path != "" && file == d.path) {
+		return true
+	}
+	return d.rolodex != nil && d.rolodex.GetRootIndex() != nil && file == d.rolodex.GetRootIndex().GetSpecAbsolutePath()
+}
+
+// detectKind returns the kind of document held by a mapping node.
+func detectKind(mapping *yaml.Node) DocumentKind {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return KindFragment
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		switch mapping.Content[i].Value {
+		case "openapi", "swagger":
+			return KindOpenAPI
+		case "arazzo":
+			return KindArazzo
+		case "overlay":
+			return KindOverlay
+		}
+	}
+	return KindFragment
+}
+
+// rootMapping returns the top level node of a parsed document.
+func rootMapping(root *yaml.Node) *yaml.Node {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		return root.Content[0]
+	}
+	return root
+}
+
+// build parses the text of the document and indexes it, with the files it references. The diagnostics of the
+// document are replaced. When the text cannot be parsed, the previous build is kept and a syntax diagnostic is
+// reported.
+func (d *document) build(ctx context.Context, s *Server) {
+	d.diagnostics = make(map[string][]Diagnostic)
+
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(d.text), &root); err != nil {
+		d.addDiagnostics("", syntaxDiagnostics(err, d.lines))
+		return
+	}
+	d.root, d.builtLines = &root, d.lines
+	d.kind = detectKind(rootMapping(&root))
+
+	cfg := index.CreateClosedAPIIndexConfig()
+	cfg.Logger = s.logger
+	cfg.AvoidCircularReferenceCheck = true
+	if d.kind == KindOpenAPI {
+		if info, err := datamodel.ExtractSpecInfo([]byte(d.text)); err == nil {
+			cfg.SpecInfo = info
+		}
+	}
+	if d.path != "" {
+		cfg.BasePath = filepath.Dir(d.path)
+		cfg.SpecFilePath = d.path
+	}
+	rolodex := index.NewRolodex(cfg)
+	rolodex.SetRootNode(&root)
+	if d.path != "" {
+		localFS, err := index.NewLocalFSWithConfig(&index.LocalFSConfig{
+			BaseDirectory: cfg.BasePath,
+			IndexConfig:   cfg,
+			Logger:        s.logger,
+		})
+		if err == nil {
+			cfg.AllowFileLookup = true
+			rolodex.AddLocalFS(cfg.BasePath, localFS)
+		}
+	}
+	if s.options.AllowRemoteReferences {
+		remoteFS, err := index.NewRemoteFSWithConfig(cfg)
+		if err == nil {
+			cfg.AllowRemoteLookup = true
+			rolodex.AddRemoteFS("default", remoteFS)
+		}
+	}
+	_ = rolodex.IndexTheRolodex(ctx)
+	rolodex.CheckForCircularReferences()
+	d.rolodex = rolodex
+
+	// the document always has an entry, so previously published diagnostics are cleared.
+	d.addDiagnostics("", nil)
+	d.indexDiagnostics(s)
+	switch d.kind {
+	case KindArazzo:
+		d.addDiagnostics("", arazzoDiagnostics([]byte(d.text), d.lines))
+	case KindOverlay:
+		d.addDiagnostics("", overlayDiagnostics([]byte(d.text), d.lines))
+	}
+}
+
+func (d *document) addDiagnostics(file string, diagnostics []Diagnostic) {
+	if d.isRoot(file) {
+		file = ""
+	}
+	d.diagnostics[file] = append(d.diagnostics[file], diagnostics...)
+}
+
+// indexes returns the index of the document, followed by the index of every file it references.
+func (d *document) indexes() []*index.SpecIndex {
+	if d.rolodex == nil || d.rolodex.GetRootIndex() == nil {
+		return nil
+	}
+	return append([]*index.SpecIndex{d.rolodex.GetRootIndex()}, d.rolodex.GetIndexes()...)
+}
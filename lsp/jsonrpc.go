@@ -0,0 +1,138 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// JSON-RPC error codes used by the server.
+const (
+	CodeParseError           = -32700
+	CodeInvalidRequest       = -32600
+	CodeMethodNotFound       = -32601
+	CodeInvalidParams        = -32602
+	CodeInternalError        = -32603
+	CodeServerNotInitialized = -32002
+)
+
+// maxContentLength is the largest message body the server reads. A larger Content-Length fails the stream instead of
+// being allocated.
+const maxContentLength = 64 << 20
+
+// ResponseError is the error of a JSON-RPC response.
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// message is a JSON-RPC 2.0 message. A request has a Method and an ID, a notification has a Method and no ID,
+// and a response has an ID and either a Result or an Error.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *ResponseError   `json:"error,omitempty"`
+}
+
+// isNotification returns true if the message is a notification, which must not be answered.
+func (m *message) isNotification() bool {
+	return m.ID == nil
+}
+
+// conn reads and writes JSON-RPC messages framed with a Content-Length header, as the LSP base protocol does.
+type conn struct {
+	reader *textproto.Reader
+	in     *bufio.Reader
+	out    io.Writer
+	mu     sync.Mutex
+}
+
+func newConn(in io.Reader, out io.Writer) *conn {
+	r := bufio.NewReader(in)
+	return &conn{reader: textproto.NewReader(r), in: r, out: out}
+}
+
+// read reads the next message. io.EOF is returned once the input is closed between two messages.
+func (c *conn) read() (*message, []byte, error) {
+	header, err := c.reader.ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF && len(header) == 0 {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, fmt.Errorf("unable to read message header: %w", err)
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(header.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, nil, fmt.Errorf("invalid Content-Length header: '%s'", header.Get("Content-Length"))
+	}
+	if length > maxContentLength {
+		return nil, nil, fmt.Errorf("message of %d bytes exceeds the limit of %d bytes", length, maxContentLength)
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(c.in, body); err != nil {
+		return nil, nil, fmt.Errorf("unable to read message body: %w", err)
+	}
+	var msg message
+	if err = json.Unmarshal(body, &msg); err != nil {
+		return nil, body, err
+	}
+	return &msg, body, nil
+}
+
+// write frames and writes a message, it is safe to call from several goroutines.
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err = fmt.Fprintf(c.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.out.Write(body)
+	return err
+}
+
+// reply answers a request with a result, or an error. A nil id is sent as null, as JSON-RPC requires for errors
+// answering a request whose id could not be read.
+func (c *conn) reply(id *json.RawMessage, result any, rpcErr *ResponseError) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	msg := &message{ID: id, Error: rpcErr}
+	if rpcErr == nil {
+		raw, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		msg.Result = raw
+	}
+	return c.write(msg)
+}
+
+// notify sends a notification.
+func (c *conn) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: raw})
+}
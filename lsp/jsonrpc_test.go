@@ -0,0 +1,55 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func TestConn_ReadTooLarge(t *testing.T) {
+	c := newConn(strings.NewReader(fmt.Sprintf("Content-Length: %d\r\n\r\n{}", maxContentLength+1)), io.Discard)
+	msg, body, err := c.read()
+	assert.ErrorContains(t, err, "exceeds the limit")
+	assert.Nil(t, msg)
+	assert.Nil(t, body)
+}
+
+func TestServer_ParseError(t *testing.T) {
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- NewServer(&ServerOptions{Logger: slog.New(slog.DiscardHandler)}).Serve(context.Background(), serverIn, serverOut)
+		_ = serverOut.Close()
+	}()
+
+	go func() {
+		_, _ = fmt.Fprintf(clientOut, "Content-Length: 6\r\n\r\n{nope}")
+	}()
+	_, body, err := newConn(clientIn, io.Discard).read()
+	require.NoError(t, err)
+	assert.True(t, bytes.Contains(body, []byte(`"id":null`)), string(body))
+	assert.Contains(t, string(body), fmt.Sprintf(`"code":%d`, CodeParseError))
+
+	// an oversized message cannot be skipped, so the stream fails.
+	go func() {
+		_, _ = fmt.Fprintf(clientOut, "Content-Length: %d\r\n\r\n", maxContentLength+1)
+	}()
+	select {
+	case err = <-served:
+		assert.ErrorContains(t, err, "exceeds the limit")
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the server to stop")
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// maxHoverLines is the number of lines of a resolved object shown by a hover.
+const maxHoverLines = 40
+
+// lookup returns what is under a position of an open document, or nil.
+func (s *Server) lookup(uri string, pos Position) (*document, *index.PositionLookup) {
+	d := s.documents[uri]
+	if d == nil || d.rolodex == nil || d.rolodex.GetRootIndex() == nil {
+		return nil, nil
+	}
+	line, column := yamlPosition(d.lines, pos)
+	return d, d.rolodex.GetRootIndex().LookupPosition(line, column)
+}
+
+// definition returns where the $ref under a position resolves to.
+func (s *Server) definition(params TextDocumentPositionParams) []Location {
+	d, lookup := s.lookup(params.TextDocument.URI, params.Position)
+	if lookup == nil || lookup.Target == nil {
+		return nil
+	}
+	return []Location{s.location(d, lookup.Target.File, lookup.Target.Node)}
+}
+
+// references returns every $ref of the open documents, and the files they reference, pointing at what is under a
+// position: the target of a $ref, or the innermost object that is referenced.
+func (s *Server) references(params ReferenceParams) []Location {
+	d, lookup := s.lookup(params.TextDocument.URI, params.Position)
+	if lookup == nil {
+		return nil
+	}
+	file, pointer := d.rolodex.GetRootIndex().GetSpecAbsolutePath(), lookup.Pointer
+	if lookup.Target != nil {
+		file, pointer = lookup.Target.File, lookup.Target.Pointer
+	}
+
+	refs := s.referencesByDefinition()
+	segments := strings.Split(pointer, "/")
+	for n := len(segments); n > 0; n-- {
+		candidate := strings.Join(segments[:n], "/")
+		sites := refs[file+"#"+candidate]
+		if len(sites) == 0 {
+			continue
+		}
+		var locations []Location
+		if params.Context.IncludeDeclaration {
+			if declaration := s.declaration(d, lookup, file, candidate); declaration != nil {
+				locations = append(locations, *declaration)
+			}
+		}
+		return append(locations, sites...)
+	}
+	return nil
+}
+
+// referencesByDefinition returns the location of every $ref known to the open documents, keyed by the file and
+// pointer they point at.
+func (s *Server) referencesByDefinition() map[string][]Location {
+	refs := make(map[string][]Location)
+	seen := make(map[string]bool)
+	for _, d := range s.sortedDocuments() {
+		for _, idx := range d.indexes() {
+			for _, ref := range idx.GetRawReferencesSequenced() {
+				if ref.KeyNode == nil {
+					continue
+				}
+				from := idx.GetSpecAbsolutePath()
+				site := fmt.Sprintf("%s:%d:%d", from, ref.KeyNode.Line, ref.KeyNode.Column)
+				if seen[site] {
+					continue
+				}
+				seen[site] = true
+				file, pointer, _ := strings.Cut(ref.FullDefinition, "#")
+				if file == "" {
+					file = from
+				}
+				key := file + "#" + pointer
+				refs[key] = append(refs[key], s.location(d, from, ref.KeyNode))
+			}
+		}
+	}
+	for _, locations := range refs {
+		sort.SliceStable(locations, func(i, j int) bool {
+			a, b := locations[i], locations[j]
+			if a.URI != b.URI {
+				return a.URI < b.URI
+			}
+			return a.Range.Start.Line < b.Range.Start.Line ||
+				(a.Range.Start.Line == b.Range.Start.Line && a.Range.Start.Character < b.Range.Start.Character)
+		})
+	}
+	return refs
+}
+
+// declaration returns the location of the key declaring the object at a pointer of a file, or of the file itself.
+func (s *Server) declaration(d *document, lookup *index.PositionLookup, file, pointer string) *Location {
+	root := d.rolodex.GetRootIndex().GetRootNode()
+	if lookup.Target != nil && lookup.Target.Index != nil {
+		root = lookup.Target.Index.GetRootNode()
+	}
+	key, value := nodeAtPointer(root, pointer)
+	if key == nil {
+		key = value
+	}
+	if key == nil {
+		return nil
+	}
+	location := s.location(d, file, key)
+	return &location
+}
+
+// nodeAtPointer returns the key and value of the node at a JSON pointer. The key is nil for array items and the
+// document.
+func nodeAtPointer(root *yaml.Node, pointer string) (*yaml.Node, *yaml.Node) {
+	node := rootMapping(root)
+	var key *yaml.Node
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if segment == "" || node == nil {
+			continue
+		}
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		next, nextKey := (*yaml.Node)(nil), (*yaml.Node)(nil)
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					nextKey, next = node.Content[i], node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			var i int
+			if _, err := fmt.Sscan(segment, &i); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		node, key = next, nextKey
+	}
+	return key, node
+}
+
+// location returns the location of a node of a file of the rolodex of a document.
+func (s *Server) location(d *document, file string, node *yaml.Node) Location {
+	return Location{URI: s.fileURI(d, file), Range: s.filePositions(d, file).nodeRange(node)}
+}
+
+// hover shows what the $ref under a position resolves to.
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	d, lookup := s.lookup(params.TextDocument.URI, params.Position)
+	if lookup == nil || lookup.Target == nil || lookup.Target.Node == nil {
+		return nil
+	}
+	rendered, err := yaml.Marshal(lookup.Target.Node)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(string(rendered), "\n"), "\n")
+	if len(lines) > maxHoverLines {
+		lines = append(lines[:maxHoverLines], "# ...")
+	}
+
+	target := lookup.Target.File
+	if d.isRoot(target) {
+		target = ""
+	} else if d.path != "" && filepath.IsAbs(target) {
+		if rel, relErr := filepath.Rel(filepath.Dir(d.path), target); relErr == nil {
+			target = filepath.ToSlash(rel)
+		}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "`%s#%s`\n\n```yaml\n%s\n```", target, lookup.Target.Pointer, strings.Join(lines, "\n"))
+
+	hover := &Hover{Contents: MarkupContent{Kind: "markdown", Value: b.String()}}
+	if lookup.Reference != nil && lookup.Reference.KeyNode != nil {
+		r := s.filePositions(d, "").nodeRange(lookup.Reference.KeyNode)
+		hover.Range = &r
+	}
+	return hover
+}
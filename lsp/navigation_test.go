@@ -0,0 +1,136 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+const lspSpec = `openapi: 3.1.0
+info:
+  title: lsp
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'shared.yaml#/Pet'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: 'shared.yaml#/Pet'
+`
+
+const lspShared = `Pet:
+  type: object
+  properties:
+    name:
+      type: string
+`
+
+func newSpecClient(t *testing.T) (*testClient, string) {
+	c := newTestClient(t, map[string]string{"openapi.yaml": lspSpec, "shared.yaml": lspShared})
+	c.initialize()
+	return c, c.open("openapi.yaml")
+}
+
+func TestServer_Definition(t *testing.T) {
+	c, uri := newSpecClient(t)
+
+	// a local $ref, to the parameter component.
+	var locations []Location
+	require.Nil(t, c.call("textDocument/definition", c.position(uri, 9, 20), &locations))
+	require.Len(t, locations, 1)
+	assert.Equal(t, uri, locations[0].URI)
+	assert.Equal(t, Range{Start: Position{20, 6}, End: Position{23, 21}}, locations[0].Range)
+
+	// a $ref to another file.
+	require.Nil(t, c.call("textDocument/definition", c.position(uri, 16, 26), &locations))
+	require.Len(t, locations, 1)
+	assert.Equal(t, c.uri("shared.yaml"), locations[0].URI)
+	assert.Equal(t, Position{1, 2}, locations[0].Range.Start)
+
+	// not on a $ref.
+	locations = nil
+	require.Nil(t, c.call("textDocument/definition", c.position(uri, 7, 20), &locations))
+	assert.Empty(t, locations)
+}
+
+func TestServer_References(t *testing.T) {
+	c, uri := newSpecClient(t)
+
+	// from a $ref, every $ref to the same target, and its declaration.
+	var locations []Location
+	params := ReferenceParams{TextDocumentPositionParams: c.position(uri, 16, 26)}
+	params.Context.IncludeDeclaration = true
+	require.Nil(t, c.call("textDocument/references", params, &locations))
+	require.Len(t, locations, 3)
+	assert.Equal(t, Location{URI: c.uri("shared.yaml"), Range: Range{Start: Position{0, 0}, End: Position{0, 3}}},
+		locations[0])
+	assert.Equal(t, uri, locations[1].URI)
+	assert.Equal(t, Range{Start: Position{16, 22}, End: Position{16, 40}}, locations[1].Range)
+	assert.Equal(t, 28, locations[2].Range.Start.Line)
+
+	// from within a component, the references to the component.
+	params = ReferenceParams{TextDocumentPositionParams: c.position(uri, 21, 12)}
+	require.Nil(t, c.call("textDocument/references", params, &locations))
+	require.Len(t, locations, 1)
+	assert.Equal(t, Position{9, 16}, locations[0].Range.Start)
+
+	// from the file referenced, once it is open too.
+	shared := c.open("shared.yaml")
+	params = ReferenceParams{TextDocumentPositionParams: c.position(shared, 1, 4)}
+	require.Nil(t, c.call("textDocument/references", params, &locations))
+	require.Len(t, locations, 2)
+	assert.Equal(t, uri, locations[0].URI)
+	assert.Equal(t, 16, locations[0].Range.Start.Line)
+
+	// nothing references the info object.
+	locations = nil
+	params = ReferenceParams{TextDocumentPositionParams: c.position(uri, 2, 4)}
+	require.Nil(t, c.call("textDocument/references", params, &locations))
+	assert.Empty(t, locations)
+}
+
+func TestServer_Hover(t *testing.T) {
+	c, uri := newSpecClient(t)
+
+	var hover Hover
+	require.Nil(t, c.call("textDocument/hover", c.position(uri, 16, 26), &hover))
+	assert.Equal(t, "markdown", hover.Contents.Kind)
+	assert.Contains(t, hover.Contents.Value, "`shared.yaml#/Pet`")
+	assert.Contains(t, hover.Contents.Value, "```yaml\ntype: object\nproperties:\n    name:\n        type: string\n```")
+	require.NotNil(t, hover.Range)
+	assert.Equal(t, Range{Start: Position{16, 22}, End: Position{16, 40}}, *hover.Range)
+
+	var local *Hover
+	require.Nil(t, c.call("textDocument/hover", c.position(uri, 9, 20), &local))
+	require.NotNil(t, local)
+	assert.Contains(t, local.Contents.Value, "`#/components/parameters/Limit`")
+	assert.Contains(t, local.Contents.Value, "name: limit")
+
+	// no hover away from a $ref.
+	local = nil
+	require.Nil(t, c.call("textDocument/hover", c.position(uri, 0, 2), &local))
+	assert.Nil(t, local)
+}
@@ -0,0 +1,371 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package lsp is a Language Server Protocol server for OpenAPI, Arazzo and Overlay documents, built on the
+// Rolodex and SpecIndex. It publishes diagnostics from indexing and resolving errors, and supports go to
+// definition and find references for $ref, hovers showing what a $ref resolves to, completion of component
+// references and known keys, and document symbols.
+//
+// The server speaks JSON-RPC over any reader and writer, ServeStdio serves it over standard input and output for
+// an editor. Documents are synced in full.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+)
+
+// ErrExitWithoutShutdown is returned by Serve when the client sends exit before shutdown.
+var ErrExitWithoutShutdown = errors.New("exit notification received before shutdown")
+
+// ServerOptions configure a Server.
+type ServerOptions struct {
+	// AllowRemoteReferences allows references to remote documents to be fetched. Off by default, as documents
+	// opened in an editor are not always trusted.
+	AllowRemoteReferences bool
+
+	// Logger receives the logs of the server and the rolodex. It must not write to the output of the server,
+	// defaults to errors written to stderr.
+	Logger *slog.Logger
+}
+
+// Server is a language server. A Server serves a single client, requests are handled in order.
+type Server struct {
+	options   ServerOptions
+	logger    *slog.Logger
+	conn      *conn
+	documents map[string]*document
+
+	// published are the URIs that diagnostics have been published for, so they can be cleared.
+	published map[string]bool
+
+	initialized bool
+	shutdown    bool
+}
+
+// NewServer creates a new Server, options may be nil.
+func NewServer(options *ServerOptions) *Server {
+	s := &Server{documents: make(map[string]*document), published: make(map[string]bool)}
+	if options != nil {
+		s.options = *options
+	}
+	s.logger = s.options.Logger
+	if s.logger == nil {
+		s.logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	}
+	return s
+}
+
+// ServeStdio serves a client over standard input and output, until the client exits.
+func ServeStdio(ctx context.Context, options *ServerOptions) error {
+	return NewServer(options).Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// Serve reads requests and notifications from in and writes responses and notifications to out, until the client
+// sends exit, in is closed or ctx is done. Returns nil when the client exits after a shutdown, or when in is closed.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.conn = newConn(in, out)
+
+	type incoming struct {
+		msg  *message
+		body []byte
+		err  error
+	}
+	messages := make(chan incoming)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			msg, body, err := s.conn.read()
+			select {
+			case messages <- incoming{msg, body, err}:
+			case <-done:
+				return
+			}
+			if err != nil && body == nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case m := <-messages:
+			if m.err != nil {
+				if errors.Is(m.err, io.EOF) {
+					return nil
+				}
+				if m.body == nil {
+					// the stream cannot be recovered once its framing is broken.
+					return m.err
+				}
+				_ = s.conn.reply(nil, nil, &ResponseError{Code: CodeParseError, Message: m.err.Error()})
+				continue
+			}
+			if m.msg.Method == "exit" {
+				if !s.shutdown {
+					return ErrExitWithoutShutdown
+				}
+				return nil
+			}
+			s.handle(ctx, m.msg)
+		}
+	}
+}
+
+// handle handles a request or a notification, answering requests.
+func (s *Server) handle(ctx context.Context, msg *message) {
+	result, err := s.dispatch(ctx, msg)
+	if msg.isNotification() {
+		if err != nil {
+			s.logger.Error("[lsp] notification failed", "method", msg.Method, "error", err)
+		}
+		return
+	}
+	var rpcErr *ResponseError
+	if err != nil && !errors.As(err, &rpcErr) {
+		rpcErr = &ResponseError{Code: CodeInternalError, Message: err.Error()}
+	}
+	if writeErr := s.conn.reply(msg.ID, result, rpcErr); writeErr != nil {
+		s.logger.Error("[lsp] unable to write response", "method", msg.Method, "error", writeErr)
+	}
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *message) (any, error) {
+	switch {
+	case msg.Method == "initialize":
+		return s.initialize(msg.Params)
+	case !s.initialized:
+		return nil, &ResponseError{Code: CodeServerNotInitialized, Message: "server not initialized"}
+	case msg.Method == "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case s.shutdown:
+		return nil, &ResponseError{Code: CodeInvalidRequest, Message: "server is shutting down"}
+	}
+
+	switch msg.Method {
+	case "initialized":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d := newDocument(params.TextDocument.URI, params.TextDocument.Version, params.TextDocument.Text)
+		s.documents[d.uri] = d
+		s.rebuild(ctx, d)
+		return nil, nil
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d := s.documents[params.TextDocument.URI]
+		if d == nil {
+			return nil, fmt.Errorf("document '%s' is not open", params.TextDocument.URI)
+		}
+		text := d.text
+		for _, change := range params.ContentChanges {
+			text = applyChange(text, change)
+		}
+		d.setText(text)
+		d.version = params.TextDocument.Version
+		s.rebuild(ctx, d)
+		return nil, nil
+	case "textDocument/didSave":
+		var params DidSaveTextDocumentParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		if d := s.documents[params.TextDocument.URI]; d != nil && params.Text != nil {
+			d.setText(*params.Text)
+		}
+		// other documents may reference the saved file, which they read from disk.
+		s.rebuild(ctx, s.sortedDocuments()...)
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		s.publishDiagnostics()
+		return nil, nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.definition(params), nil
+	case "textDocument/references":
+		var params ReferenceParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.references(params), nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params), nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params), nil
+	case "textDocument/documentSymbol":
+		var params DocumentSymbolParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.documentSymbols(params), nil
+	}
+	if !msg.isNotification() {
+		return nil, &ResponseError{Code: CodeMethodNotFound, Message: fmt.Sprintf("method '%s' not found", msg.Method)}
+	}
+	// unknown notifications, such as $/cancelRequest, are ignored.
+	return nil, nil
+}
+
+func (s *Server) initialize(raw json.RawMessage) (any, error) {
+	var params InitializeParams
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	s.initialized = true
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync: TextDocumentSyncOptions{
+				OpenClose: true,
+				Change:    SyncFull,
+				Save:      &SaveOptions{IncludeText: true},
+			},
+			DefinitionProvider:     true,
+			ReferencesProvider:     true,
+			HoverProvider:          true,
+			CompletionProvider:     &CompletionOptions{TriggerCharacters: []string{"#", "/", "'", "\""}},
+			DocumentSymbolProvider: true,
+		},
+		ServerInfo: &ServerInfo{Name: "libopenapi"},
+	}, nil
+}
+
+func decodeParams(raw json.RawMessage, params any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, params); err != nil {
+		return &ResponseError{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// rebuild builds documents and publishes the diagnostics of every open document.
+func (s *Server) rebuild(ctx context.Context, documents ...*document) {
+	for _, d := range documents {
+		d.build(ctx, s)
+	}
+	s.publishDiagnostics()
+}
+
+// publishDiagnostics publishes the diagnostics of every open document, merged per URI, and clears the
+// diagnostics of URIs no document reports on anymore.
+func (s *Server) publishDiagnostics() {
+	merged := make(map[string][]Diagnostic)
+	for _, d := range s.sortedDocuments() {
+		for file, diagnostics := range d.diagnostics {
+			uri := s.fileURI(d, file)
+			for _, diagnostic := range diagnostics {
+				if !slices.Contains(merged[uri], diagnostic) {
+					merged[uri] = append(merged[uri], diagnostic)
+				}
+			}
+			if merged[uri] == nil {
+				merged[uri] = []Diagnostic{}
+			}
+		}
+	}
+	for uri := range s.published {
+		if _, ok := merged[uri]; !ok {
+			merged[uri] = []Diagnostic{}
+		}
+	}
+	uris := make([]string, 0, len(merged))
+	for uri := range merged {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		params := PublishDiagnosticsParams{URI: uri, Diagnostics: merged[uri]}
+		if d := s.documents[uri]; d != nil {
+			version := d.version
+			params.Version = &version
+		}
+		if err := s.conn.notify("textDocument/publishDiagnostics", params); err != nil {
+			s.logger.Error("[lsp] unable to publish diagnostics", "uri", uri, "error", err)
+		}
+		if len(merged[uri]) > 0 {
+			s.published[uri] = true
+		} else {
+			delete(s.published, uri)
+		}
+	}
+}
+
+// sortedDocuments returns the open documents, ordered by URI.
+func (s *Server) sortedDocuments() []*document {
+	uris := make([]string, 0, len(s.documents))
+	for uri := range s.documents {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	documents := make([]*document, len(uris))
+	for i, uri := range uris {
+		documents[i] = s.documents[uri]
+	}
+	return documents
+}
+
+// openDocument returns the open document of a file, or nil.
+func (s *Server) openDocument(file string) *document {
+	for _, d := range s.documents {
+		if d.path != "" && d.path == file {
+			return d
+		}
+	}
+	return nil
+}
+
+// fileURI returns the URI of a file of the rolodex of a document. Open files keep the URI used by the client.
+func (s *Server) fileURI(d *document, file string) string {
+	if d.isRoot(file) {
+		return d.uri
+	}
+	if o := s.openDocument(file); o != nil {
+		return o.uri
+	}
+	return pathToURI(file)
+}
+
+// filePositions returns the position converter of a file of the rolodex of a document.
+func (s *Server) filePositions(d *document, file string) textPosition {
+	if d.isRoot(file) {
+		return textPosition{lines: d.builtLines}
+	}
+	if o := s.openDocument(file); o != nil {
+		return textPosition{lines: o.lines}
+	}
+	return textPosition{}
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+// testClient is an in-process client of a Server, connected with pipes.
+type testClient struct {
+	t       *testing.T
+	conn    *conn
+	inbox   chan *message
+	served  chan error
+	nextID  int
+	dir     string
+	publish map[string][]Diagnostic
+}
+
+func newTestClient(t *testing.T, files map[string]string) *testClient {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	serverIn, clientOut := io.Pipe()
+	clientIn, serverOut := io.Pipe()
+	c := &testClient{
+		t:       t,
+		conn:    newConn(clientIn, clientOut),
+		inbox:   make(chan *message, 100),
+		served:  make(chan error, 1),
+		dir:     dir,
+		publish: make(map[string][]Diagnostic),
+	}
+	server := NewServer(&ServerOptions{Logger: slog.New(slog.DiscardHandler)})
+	go func() {
+		err := server.Serve(context.Background(), serverIn, serverOut)
+		_ = serverOut.Close()
+		c.served <- err
+	}()
+	go func() {
+		for {
+			msg, _, err := c.conn.read()
+			if err != nil {
+				close(c.inbox)
+				return
+			}
+			c.inbox <- msg
+		}
+	}()
+	t.Cleanup(func() { _ = clientOut.Close() })
+	return c
+}
+
+// uri returns the URI of a file of the client directory.
+func (c *testClient) uri(name string) string {
+	return pathToURI(filepath.Join(c.dir, name))
+}
+
+// call sends a request and waits for its response, collecting the notifications received meanwhile.
+func (c *testClient) call(method string, params, result any) *ResponseError {
+	c.t.Helper()
+	c.nextID++
+	id := json.RawMessage(fmtID(c.nextID))
+	raw, err := json.Marshal(params)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.conn.write(&message{ID: &id, Method: method, Params: raw}))
+	for {
+		select {
+		case msg, ok := <-c.inbox:
+			require.True(c.t, ok, "connection closed waiting for %s", method)
+			if msg.ID == nil {
+				c.received(msg)
+				continue
+			}
+			if string(*msg.ID) != string(id) {
+				continue
+			}
+			if msg.Error != nil {
+				return msg.Error
+			}
+			if result != nil {
+				require.NoError(c.t, json.Unmarshal(msg.Result, result))
+			}
+			return nil
+		case <-time.After(10 * time.Second):
+			c.t.Fatalf("timed out waiting for %s", method)
+		}
+	}
+}
+
+func fmtID(id int) string {
+	b, _ := json.Marshal(id)
+	return string(b)
+}
+
+// notify sends a notification.
+func (c *testClient) notify(method string, params any) {
+	c.t.Helper()
+	require.NoError(c.t, c.conn.notify(method, params))
+}
+
+func (c *testClient) received(msg *message) {
+	if msg.Method == "textDocument/publishDiagnostics" {
+		var params PublishDiagnosticsParams
+		require.NoError(c.t, json.Unmarshal(msg.Params, &params))
+		c.publish[params.URI] = params.Diagnostics
+	}
+}
+
+// initialize initializes the server.
+func (c *testClient) initialize() *InitializeResult {
+	c.t.Helper()
+	var result InitializeResult
+	require.Nil(c.t, c.call("initialize", InitializeParams{RootURI: pathToURI(c.dir)}, &result))
+	c.notify("initialized", struct{}{})
+	return &result
+}
+
+// open opens a file of the client directory, with its content on disk, and waits for it to be built.
+func (c *testClient) open(name string) string {
+	c.t.Helper()
+	content, err := os.ReadFile(filepath.Join(c.dir, name))
+	require.NoError(c.t, err)
+	return c.openText(name, string(content))
+}
+
+// openText opens a file of the client directory with a text, and waits for it to be built.
+func (c *testClient) openText(name, text string) string {
+	c.t.Helper()
+	uri := c.uri(name)
+	c.notify("textDocument/didOpen", DidOpenTextDocumentParams{
+		TextDocument: TextDocumentItem{URI: uri, LanguageID: "yaml", Version: 1, Text: text},
+	})
+	c.sync()
+	return uri
+}
+
+// change replaces the text of an open document, and waits for it to be built.
+func (c *testClient) change(uri string, version int, text string) {
+	c.t.Helper()
+	c.notify("textDocument/didChange", DidChangeTextDocumentParams{
+		TextDocument:   VersionedTextDocumentIdentifier{URI: uri, Version: version},
+		ContentChanges: []TextDocumentContentChangeEvent{{Text: text}},
+	})
+	c.sync()
+}
+
+// sync waits for the notifications sent before it to be handled, as requests are handled in order.
+func (c *testClient) sync() {
+	c.t.Helper()
+	_ = c.call("workspace/sync", struct{}{}, nil)
+}
+
+func (c *testClient) position(uri string, line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: uri}, Position: Position{line, character}}
+}
+
+// shutdown shuts the server down, and returns what Serve returned.
+func (c *testClient) shutdown() error {
+	c.t.Helper()
+	require.Nil(c.t, c.call("shutdown", nil, nil))
+	c.notify("exit", nil)
+	select {
+	case err := <-c.served:
+		return err
+	case <-time.After(10 * time.Second):
+		c.t.Fatal("timed out waiting for the server to exit")
+	}
+	return nil
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	c := newTestClient(t, nil)
+
+	rpcErr := c.call("textDocument/hover", c.position("file:///nope.yaml", 0, 0), nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, CodeServerNotInitialized, rpcErr.Code)
+
+	result := c.initialize()
+	assert.True(t, result.Capabilities.DefinitionProvider)
+	assert.True(t, result.Capabilities.ReferencesProvider)
+	assert.True(t, result.Capabilities.HoverProvider)
+	assert.True(t, result.Capabilities.DocumentSymbolProvider)
+	assert.Equal(t, SyncFull, result.Capabilities.TextDocumentSync.Change)
+	assert.Contains(t, result.Capabilities.CompletionProvider.TriggerCharacters, "#")
+
+	rpcErr = c.call("textDocument/formatting", struct{}{}, nil)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, CodeMethodNotFound, rpcErr.Code)
+
+	// an unknown document has nothing to offer.
+	var locations []Location
+	assert.Nil(t, c.call("textDocument/definition", c.position("file:///nope.yaml", 0, 0), &locations))
+	assert.Empty(t, locations)
+
+	assert.NoError(t, c.shutdown())
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize()
+	c.notify("exit", nil)
+	select {
+	case err := <-c.served:
+		assert.ErrorIs(t, err, ErrExitWithoutShutdown)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the server to exit")
+	}
+}
+
+func TestServer_ServeContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in, _ := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- NewServer(&ServerOptions{Logger: slog.New(slog.DiscardHandler)}).Serve(ctx, in, io.Discard)
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the server to stop")
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// componentSections are the top level keys of a Swagger document holding named components, and the kind of their
+// symbols.
+var componentSections = map[string]SymbolKind{
+	"definitions":         SymbolClass,
+	"parameters":          SymbolField,
+	"responses":           SymbolObject,
+	"securityDefinitions": SymbolKey,
+}
+
+// documentSymbols returns the outline of a document: paths and their operations, webhooks and components for
+// OpenAPI; source descriptions, workflows and their steps for Arazzo; actions for Overlay.
+func (s *Server) documentSymbols(params DocumentSymbolParams) []DocumentSymbol {
+	d := s.documents[params.TextDocument.URI]
+	if d == nil || d.root == nil {
+		return []DocumentSymbol{}
+	}
+	b := symbolBuilder{positions: textPosition{lines: d.builtLines}}
+	mapping := rootMapping(d.root)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return []DocumentSymbol{}
+	}
+	symbols := []DocumentSymbol{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		var symbol *DocumentSymbol
+		switch d.kind {
+		case KindArazzo:
+			symbol = b.arazzo(key, value)
+		case KindOverlay:
+			symbol = b.overlay(key, value)
+		case KindOpenAPI:
+			symbol = b.openAPI(key, value)
+		default:
+			if symbol = b.openAPI(key, value); symbol == nil {
+				symbol = b.symbol(key.Value, "", SymbolObject, key, value)
+			}
+		}
+		if symbol != nil {
+			symbols = append(symbols, *symbol)
+		}
+	}
+	return symbols
+}
+
+// symbolBuilder builds the symbols of a document.
+type symbolBuilder struct {
+	positions textPosition
+}
+
+// symbol returns a symbol from the key declaring it (or the node naming it) to the end of its value.
+func (b symbolBuilder) symbol(name, detail string, kind SymbolKind, key, value *yaml.Node) *DocumentSymbol {
+	selection := b.positions.nodeRange(key)
+	full := Range{Start: selection.Start, End: b.positions.nodeRange(value).End}
+	if value == nil || full.End.Line < full.Start.Line ||
+		(full.End.Line == full.Start.Line && full.End.Character < full.Start.Character) {
+		full.End = selection.End
+	}
+	return &DocumentSymbol{Name: name, Detail: detail, Kind: kind, Range: full, SelectionRange: selection}
+}
+
+// children returns a symbol for every key of a mapping.
+func (b symbolBuilder) children(mapping *yaml.Node, build func(key, value *yaml.Node) *DocumentSymbol) []DocumentSymbol {
+	var symbols []DocumentSymbol
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return symbols
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if symbol := build(mapping.Content[i], mapping.Content[i+1]); symbol != nil {
+			symbols = append(symbols, *symbol)
+		}
+	}
+	return symbols
+}
+
+// items returns a symbol for every item of a sequence, named by one of its keys.
+func (b symbolBuilder) items(sequence *yaml.Node, build func(i int, item *yaml.Node) *DocumentSymbol) []DocumentSymbol {
+	var symbols []DocumentSymbol
+	if sequence == nil || sequence.Kind != yaml.SequenceNode {
+		return symbols
+	}
+	for i, item := range sequence.Content {
+		if symbol := build(i, item); symbol != nil {
+			symbols = append(symbols, *symbol)
+		}
+	}
+	return symbols
+}
+
+// namedItem returns the symbol of an item of a sequence, named by the value of one of its keys.
+func (b symbolBuilder) namedItem(i int, item *yaml.Node, nameKey, detailKey string, kind SymbolKind) *DocumentSymbol {
+	_, nameNode := utils.FindKeyNodeTop(nameKey, item.Content)
+	name := "[" + strconv.Itoa(i) + "]"
+	selection := item
+	if nameNode != nil && nameNode.Value != "" {
+		name, selection = nameNode.Value, nameNode
+	}
+	detail := ""
+	if _, detailNode := utils.FindKeyNodeTop(detailKey, item.Content); detailNode != nil {
+		detail = detailNode.Value
+	}
+	symbol := b.symbol(name, detail, kind, selection, item)
+	symbol.Range.Start = b.positions.position(item.Line, item.Column)
+	return symbol
+}
+
+func (b symbolBuilder) openAPI(key, value *yaml.Node) *DocumentSymbol {
+	switch key.Value {
+	case "paths", "webhooks":
+		symbol := b.symbol(key.Value, "", SymbolNamespace, key, value)
+		symbol.Children = b.children(value, func(pathKey, pathItem *yaml.Node) *DocumentSymbol {
+			path := b.symbol(pathKey.Value, "", SymbolNamespace, pathKey, pathItem)
+			path.Children = b.children(pathItem, func(method, operation *yaml.Node) *DocumentSymbol {
+				if !utils.IsHttpVerb(strings.ToLower(method.Value)) {
+					return nil
+				}
+				detail := ""
+				if _, id := utils.FindKeyNodeTop("operationId", operation.Content); id != nil {
+					detail = id.Value
+				}
+				return b.symbol(strings.ToUpper(method.Value)+" "+pathKey.Value, detail, SymbolMethod, method, operation)
+			})
+			return path
+		})
+		return symbol
+	case "components":
+		symbol := b.symbol(key.Value, "", SymbolModule, key, value)
+		symbol.Children = b.children(value, func(sectionKey, section *yaml.Node) *DocumentSymbol {
+			kind := SymbolObject
+			if sectionKey.Value == "schemas" {
+				kind = SymbolClass
+			}
+			sectionSymbol := b.symbol(sectionKey.Value, "", SymbolModule, sectionKey, section)
+			sectionSymbol.Children = b.children(section, func(name, component *yaml.Node) *DocumentSymbol {
+				return b.symbol(name.Value, sectionKey.Value, kind, name, component)
+			})
+			return sectionSymbol
+		})
+		return symbol
+	}
+	if kind, ok := componentSections[key.Value]; ok {
+		symbol := b.symbol(key.Value, "", SymbolModule, key, value)
+		symbol.Children = b.children(value, func(name, component *yaml.Node) *DocumentSymbol {
+			return b.symbol(name.Value, key.Value, kind, name, component)
+		})
+		return symbol
+	}
+	return nil
+}
+
+func (b symbolBuilder) arazzo(key, value *yaml.Node) *DocumentSymbol {
+	switch key.Value {
+	case "sourceDescriptions":
+		symbol := b.symbol(key.Value, "", SymbolNamespace, key, value)
+		symbol.Children = b.items(value, func(i int, item *yaml.Node) *DocumentSymbol {
+			return b.namedItem(i, item, "name", "url", SymbolModule)
+		})
+		return symbol
+	case "workflows":
+		symbol := b.symbol(key.Value, "", SymbolNamespace, key, value)
+		symbol.Children = b.items(value, func(i int, workflow *yaml.Node) *DocumentSymbol {
+			w := b.namedItem(i, workflow, "workflowId", "summary", SymbolFunction)
+			_, steps := utils.FindKeyNodeTop("steps", workflow.Content)
+			w.Children = b.items(steps, func(j int, step *yaml.Node) *DocumentSymbol {
+				return b.namedItem(j, step, "stepId", "operationId", SymbolEvent)
+			})
+			return w
+		})
+		return symbol
+	case "components":
+		symbol := b.symbol(key.Value, "", SymbolModule, key, value)
+		symbol.Children = b.children(value, func(sectionKey, section *yaml.Node) *DocumentSymbol {
+			sectionSymbol := b.symbol(sectionKey.Value, "", SymbolModule, sectionKey, section)
+			sectionSymbol.Children = b.children(section, func(name, component *yaml.Node) *DocumentSymbol {
+				return b.symbol(name.Value, sectionKey.Value, SymbolObject, name, component)
+			})
+			return sectionSymbol
+		})
+		return symbol
+	}
+	return nil
+}
+
+func (b symbolBuilder) overlay(key, value *yaml.Node) *DocumentSymbol {
+	if key.Value != "actions" {
+		return nil
+	}
+	symbol := b.symbol(key.Value, "", SymbolNamespace, key, value)
+	symbol.Children = b.items(value, func(i int, action *yaml.Node) *DocumentSymbol {
+		return b.namedItem(i, action, "target", "description", SymbolKey)
+	})
+	return symbol
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+	"github.com/pb33f/testify/require"
+)
+
+func symbolNames(symbols []DocumentSymbol) []string {
+	var names []string
+	for _, s := range symbols {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestServer_DocumentSymbols(t *testing.T) {
+	c, uri := newSpecClient(t)
+
+	var symbols []DocumentSymbol
+	require.Nil(t, c.call("textDocument/documentSymbol", DocumentSymbolParams{TextDocument: TextDocumentIdentifier{URI: uri}}, &symbols))
+	require.Equal(t, []string{"paths", "components"}, symbolNames(symbols))
+
+	paths := symbols[0]
+	assert.Equal(t, SymbolNamespace, paths.Kind)
+	require.Equal(t, []string{"/pets"}, symbolNames(paths.Children))
+	get := paths.Children[0].Children[0]
+	assert.Equal(t, "GET /pets", get.Name)
+	assert.Equal(t, "listPets", get.Detail)
+	assert.Equal(t, SymbolMethod, get.Kind)
+	assert.Equal(t, Range{Start: Position{6, 4}, End: Position{6, 7}}, get.SelectionRange)
+	assert.Equal(t, Range{Start: Position{6, 4}, End: Position{16, 40}}, get.Range)
+
+	components := symbols[1]
+	assert.Equal(t, []string{"parameters", "schemas"}, symbolNames(components.Children))
+	pets := components.Children[1].Children[0]
+	assert.Equal(t, "Pets", pets.Name)
+	assert.Equal(t, "schemas", pets.Detail)
+	assert.Equal(t, SymbolClass, pets.Kind)
+
+	// a file of shared components, its top level keys.
+	shared := c.open("shared.yaml")
+	require.Nil(t, c.call("textDocument/documentSymbol", DocumentSymbolParams{TextDocument: TextDocumentIdentifier{URI: shared}}, &symbols))
+	assert.Equal(t, []string{"Pet"}, symbolNames(symbols))
+}
+
+func TestServer_DocumentSymbols_ArazzoOverlay(t *testing.T) {
+	c := newTestClient(t, nil)
+	c.initialize()
+	arazzo := c.openText("flow.arazzo.yaml", `arazzo: 1.0.1
+info:
+  title: flow
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./openapi.yaml
+workflows:
+  - workflowId: adopt
+    summary: adopt a pet
+    steps:
+      - stepId: list
+        operationId: listPets
+      - stepId: adopt
+        operationId: adoptPet
+`)
+	var symbols []DocumentSymbol
+	require.Nil(t, c.call("textDocument/documentSymbol", DocumentSymbolParams{TextDocument: TextDocumentIdentifier{URI: arazzo}}, &symbols))
+	require.Equal(t, []string{"sourceDescriptions", "workflows"}, symbolNames(symbols))
+	assert.Equal(t, "./openapi.yaml", symbols[0].Children[0].Detail)
+	workflow := symbols[1].Children[0]
+	assert.Equal(t, "adopt", workflow.Name)
+	assert.Equal(t, "adopt a pet", workflow.Detail)
+	assert.Equal(t, SymbolFunction, workflow.Kind)
+	assert.Equal(t, []string{"list", "adopt"}, symbolNames(workflow.Children))
+	assert.Equal(t, Range{Start: Position{11, 8}, End: Position{12, 29}}, workflow.Children[0].Range)
+
+	overlay := c.openText("fix.overlay.yaml", `overlay: 1.0.0
+info:
+  title: fix
+  version: 1.0.0
+actions:
+  - target: $.info
+    description: describe
+    update:
+      description: updated
+  - remove: true
+`)
+	require.Nil(t, c.call("textDocument/documentSymbol", DocumentSymbolParams{TextDocument: TextDocumentIdentifier{URI: overlay}}, &symbols))
+	require.Equal(t, []string{"actions"}, symbolNames(symbols))
+	assert.Equal(t, []string{"$.info", "[1]"}, symbolNames(symbols[0].Children))
+	assert.Equal(t, "describe", symbols[0].Children[0].Detail)
+}
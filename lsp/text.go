@@ -0,0 +1,202 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// uriToPath returns the file path of a file URI, or an empty string if the URI is not a file URI.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	p := u.Path
+	// file:///C:/dir/file.yaml is C:\dir\file.yaml on windows.
+	if runtime.GOOS == "windows" && len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:]
+	}
+	return filepath.Clean(filepath.FromSlash(p))
+}
+
+// pathToURI returns the file URI of a file path. Anything that is already a URI (such as a remote reference) is
+// returned as it is.
+func pathToURI(p string) string {
+	if strings.Contains(p, "://") {
+		return p
+	}
+	p = filepath.ToSlash(p)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+// splitLines splits text into lines, without their line breaks.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	return lines
+}
+
+// lineText returns a line of text, zero based, or an empty string if there is no such line.
+func lineText(lines []string, line int) string {
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return lines[line]
+}
+
+// utf16Length returns the length of a string in UTF-16 code units.
+func utf16Length(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// runeColumnToUTF16 converts a zero based column counted in runes, as yaml.Node columns are, to UTF-16 code units.
+// Without the text of the line, runes are counted as single units, which holds for everything but the
+// supplementary planes.
+func runeColumnToUTF16(line string, column int) int {
+	if line == "" {
+		return column
+	}
+	units := 0
+	for _, r := range line {
+		if column == 0 {
+			break
+		}
+		units += utf16.RuneLen(r)
+		column--
+	}
+	return units + column
+}
+
+// utf16ToRuneColumn converts a zero based column counted in UTF-16 code units to runes.
+func utf16ToRuneColumn(line string, character int) int {
+	runes := 0
+	for _, r := range line {
+		if character <= 0 {
+			break
+		}
+		character -= utf16.RuneLen(r)
+		runes++
+	}
+	return runes
+}
+
+// byteOffset returns the offset in bytes of a position in lines, clamped to the text.
+func byteOffset(lines []string, pos Position) int {
+	offset := 0
+	for i := 0; i < pos.Line && i < len(lines); i++ {
+		offset += len(lines[i]) + 1
+	}
+	if pos.Line >= len(lines) {
+		return offset
+	}
+	line := lines[pos.Line]
+	col := utf16ToRuneColumn(line, pos.Character)
+	for i := range line {
+		if col == 0 {
+			return offset + i
+		}
+		col--
+	}
+	return offset + len(line)
+}
+
+// applyChange applies a content change to a text.
+func applyChange(text string, change TextDocumentContentChangeEvent) string {
+	if change.Range == nil {
+		return change.Text
+	}
+	lines := strings.Split(text, "\n")
+	start, end := byteOffset(lines, change.Range.Start), byteOffset(lines, change.Range.End)
+	if end < start {
+		start, end = end, start
+	}
+	start, end = min(start, len(text)), min(end, len(text))
+	return text[:start] + change.Text + text[end:]
+}
+
+// textPosition converts the positions of yaml.Node values in a file to protocol positions, using the lines of
+// the file. Without lines, columns are converted as if every rune was a single UTF-16 unit.
+type textPosition struct {
+	lines []string
+}
+
+// position converts a yaml.Node line and column to a protocol position.
+func (t textPosition) position(line, column int) Position {
+	line, column = max(line-1, 0), max(column-1, 0)
+	return Position{Line: line, Character: runeColumnToUTF16(lineText(t.lines, line), column)}
+}
+
+// nodeRange returns the range of a node. A scalar ends after its value, including quotes, and a mapping or
+// sequence ends where its last scalar does.
+func (t textPosition) nodeRange(node *yaml.Node) Range {
+	if node == nil {
+		return Range{}
+	}
+	start := t.position(node.Line, node.Column)
+	last := lastScalar(node)
+	if last == nil {
+		return Range{Start: start, End: start}
+	}
+	end := t.position(last.Line, last.Column)
+	if last.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 && !strings.Contains(last.Value, "\n") {
+		end.Character += utf16Length(last.Value)
+		if last.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			end.Character += 2
+		}
+	}
+	return Range{Start: start, End: end}
+}
+
+// lastScalar returns the last scalar of a node, in document order.
+func lastScalar(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch node.Kind {
+		case yaml.ScalarNode, yaml.AliasNode:
+			return node
+		case yaml.DocumentNode, yaml.MappingNode, yaml.SequenceNode:
+			if len(node.Content) == 0 {
+				return nil
+			}
+			node = node.Content[len(node.Content)-1]
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// yamlPosition converts a protocol position to a yaml.Node line and column.
+func yamlPosition(lines []string, pos Position) (int, int) {
+	return pos.Line + 1, utf16ToRuneColumn(lineText(lines, pos.Line), pos.Character) + 1
+}
+
+// textBefore returns the text of a line before a position, used to decide what to complete.
+func textBefore(lines []string, pos Position) string {
+	line := lineText(lines, pos.Line)
+	col := utf16ToRuneColumn(line, pos.Character)
+	i := 0
+	for col > 0 && i < len(line) {
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		col--
+	}
+	return line[:i]
+}
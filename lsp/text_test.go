@@ -0,0 +1,44 @@
+// Copyright 2026 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package lsp
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/pb33f/testify/assert"
+)
+
+func TestURIConversion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix paths")
+	}
+	assert.Equal(t, "file:///tmp/my%20specs/openapi.yaml", pathToURI("/tmp/my specs/openapi.yaml"))
+	assert.Equal(t, filepath.FromSlash("/tmp/my specs/openapi.yaml"), uriToPath("file:///tmp/my%20specs/openapi.yaml"))
+	assert.Equal(t, "", uriToPath("untitled:Untitled-1"))
+	assert.Equal(t, "https://example.com/a.yaml", pathToURI("https://example.com/a.yaml"))
+}
+
+func TestUTF16Columns(t *testing.T) {
+	// the emoji is two UTF-16 units and a single rune.
+	line := "  title: 🐶 pets"
+	assert.Equal(t, 12, runeColumnToUTF16(line, 11))
+	assert.Equal(t, 11, utf16ToRuneColumn(line, 12))
+	assert.Equal(t, "  title: 🐶", textBefore([]string{line}, Position{0, 11}))
+
+	row, column := yamlPosition([]string{line}, Position{0, 12})
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 12, column)
+}
+
+func TestApplyChange(t *testing.T) {
+	text := "openapi: 3.1.0\ninfo:\n  title: 🐶\n"
+	text = applyChange(text, TextDocumentContentChangeEvent{
+		Range: &Range{Start: Position{2, 9}, End: Position{2, 11}},
+		Text:  "pets",
+	})
+	assert.Equal(t, "openapi: 3.1.0\ninfo:\n  title: pets\n", text)
+	assert.Equal(t, "whole", applyChange(text, TextDocumentContentChangeEvent{Text: "whole"}))
+}